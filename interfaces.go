@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"time"
+
 	backupClientV1 "github.com/cloudogu/k8s-backup-lib/api/ecosystem"
 	"github.com/cloudogu/k8s-ces-control/packages/doguAdministration"
 	componentClientV1 "github.com/cloudogu/k8s-component-lib/client"
@@ -50,3 +53,15 @@ type clusterClient interface {
 	backupClientV1.BackupSchedulesGetter
 	componentClientV1.ComponentV1Alpha1Interface
 }
+
+type debugModeStatus interface {
+	// IsActive returns whether the debug mode is active and when it is disabled automatically.
+	IsActive(ctx context.Context) (bool, time.Time, error)
+}
+
+type stoppableServer interface {
+	// GracefulStop stops accepting new calls and blocks until all running calls are finished.
+	GracefulStop()
+	// Stop cancels all running calls and closes all connections.
+	Stop()
+}
@@ -2,23 +2,36 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	pbBackup "github.com/cloudogu/ces-control-api/generated/backup"
 	pbDoguAdministration "github.com/cloudogu/ces-control-api/generated/doguAdministration"
 	pgHealth "github.com/cloudogu/ces-control-api/generated/health"
 	pbLogging "github.com/cloudogu/ces-control-api/generated/logging"
 	pbMaintenance "github.com/cloudogu/ces-control-api/generated/maintenance"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
+	"github.com/cloudogu/k8s-ces-control/packages/auth"
 	"github.com/cloudogu/k8s-ces-control/packages/backup"
+	"github.com/cloudogu/k8s-ces-control/packages/certificate"
+	clientCommands "github.com/cloudogu/k8s-ces-control/packages/client"
 	"github.com/cloudogu/k8s-ces-control/packages/config"
 	pbDebug "github.com/cloudogu/k8s-ces-control/packages/debug"
 	"github.com/cloudogu/k8s-ces-control/packages/doguAdministration"
 	"github.com/cloudogu/k8s-ces-control/packages/doguHealth"
 	"github.com/cloudogu/k8s-ces-control/packages/doguinteraction"
+	"github.com/cloudogu/k8s-ces-control/packages/gateway"
+	"github.com/cloudogu/k8s-ces-control/packages/healthcheck"
 	"github.com/cloudogu/k8s-ces-control/packages/logging"
+	"github.com/cloudogu/k8s-ces-control/packages/lokiGateway"
+	"github.com/cloudogu/k8s-ces-control/packages/metrics"
 	"github.com/cloudogu/k8s-ces-control/packages/supportArchive"
 	"github.com/cloudogu/k8s-ces-control/packages/util"
 	"github.com/cloudogu/k8s-registry-lib/dogu"
@@ -28,13 +41,17 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 const (
-	port = ":50051"
+	// healthProbePort serves the grpc health service without tls because kubernetes grpc probes do not support tls.
+	healthProbePort = ":50052"
+	// metricsPort serves the prometheus metrics via http.
+	metricsPort = ":9090"
 )
 
 var (
@@ -55,11 +72,6 @@ func main() {
 }
 
 func startCesControl() error {
-	err := config.ConfigureApplication()
-	if err != nil {
-		return err
-	}
-
 	app := cli.NewApp()
 	app.Name = "k8s-ces-control"
 	app.Usage = "Control you EcoSystem with ease!"
@@ -67,13 +79,9 @@ func startCesControl() error {
 	app.Flags = createGlobalFlags()
 	app.Before = configureApplication
 	app.EnableBashCompletion = true
-	app.Commands = []*cli.Command{
-		startServerCommand(),
-	}
+	app.Commands = append([]*cli.Command{startServerCommand()}, clientCommands.Commands()...)
 
-	logrus.Infoln("Starting k8s-ces-control")
-	err = app.Run(os.Args)
-	return err
+	return app.Run(os.Args)
 }
 
 func createGlobalFlags() []cli.Flag {
@@ -94,12 +102,10 @@ func configureApplication(_ *cli.Context) error {
 	return nil
 }
 
-func registerServices(client clusterClient, grpcServer grpc.ServiceRegistrar) error {
-	lokiLogProvider := logging.NewLokiLogProvider(
-		config.CurrentLokiGatewayConfig.Url,
-		config.CurrentLokiGatewayConfig.Username,
-		config.CurrentLokiGatewayConfig.Password,
-	)
+// registerServices registers the grpc services and starts the expiry watcher of the debug mode. It returns the debug
+// mode service, so that the metrics report the same debug mode state as the service.
+func registerServices(ctx context.Context, client clusterClient, logBackend logBackendAccess, grpcServer grpc.ServiceRegistrar, healthServer grpc_health_v1.HealthServer) (debugModeStatus, error) {
+	logProvider := createLogProvider(client, logBackend)
 
 	configMapClient := client.CoreV1().ConfigMaps(config.CurrentNamespace)
 	doguDescriptorGetter := util.NewDoguGetter(
@@ -124,31 +130,126 @@ func registerServices(client clusterClient, grpcServer grpc.ServiceRegistrar) er
 
 	debugModeClient := client.DebugMode(config.CurrentNamespace)
 
+	auditLogger, err := createAuditLogger(client)
+	if err != nil {
+		return nil, err
+	}
+
 	loggingService := logging.NewLoggingService(
-		lokiLogProvider,
+		logProvider,
 		doguConfig,
 		doguInterActor,
 		doguDescriptorGetter,
 		client.Dogus(config.CurrentNamespace),
+		auditLogger,
 	)
 
-	doguAdministrationServer := doguAdministration.NewDoguAdministrationServer(client, doguDescriptorGetter, doguInterActor, loggingService)
+	doguAdministrationServer := doguAdministration.NewDoguAdministrationServer(client, doguDescriptorGetter, doguInterActor, loggingService, auditLogger)
 
 	pbLogging.RegisterDoguLogMessagesServer(grpcServer, loggingService)
 	pbDoguAdministration.RegisterDoguAdministrationServer(grpcServer, doguAdministrationServer)
 	pgHealth.RegisterDoguHealthServer(grpcServer, doguHealth.NewDoguHealthService(client.Dogus(config.CurrentNamespace)))
-	debugModeService := pbDebug.NewDebugModeService(debugModeClient, doguInterActor, doguConfig, doguDescriptorGetter, client, config.CurrentNamespace)
+	debugModeService := pbDebug.NewDebugModeService(debugModeClient, doguInterActor, doguConfig, doguDescriptorGetter, client, supportArchiveClient, config.CurrentNamespace, auditLogger)
 	pbMaintenance.RegisterDebugModeServer(grpcServer, debugModeService)
-	supportArchiveService := supportArchive.NewSupportArchiveService(supportArchiveClient, &http.Client{})
+	supportArchiveService := supportArchive.NewSupportArchiveService(supportArchiveClient, &http.Client{}, auditLogger)
 	pbMaintenance.RegisterSupportArchiveServer(grpcServer, supportArchiveService)
-	watcher := pbDebug.NewDefaultConfigMapRegistryWatcher(configMapClient, debugModeService)
-	watcher.StartWatch(context.Background())
-	backupService := backup.NewBackupService(backupClient, restoreClient, backupScheduleClient, componentClient, client, cronJobClient)
+	// the hostname is the name of the pod and identifies the replica in the leader election of the expiry watcher
+	identity, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hostname: %w", err)
+	}
+	watcher := pbDebug.NewExpiryWatcher(configMapClient, debugModeClient, client.Discovery(), client.CoordinationV1(), config.CurrentNamespace, identity, debugModeService)
+	watcher.StartWatch(ctx)
+	backupService := backup.NewBackupService(backupClient, restoreClient, backupScheduleClient, componentClient, client, cronJobClient, auditLogger)
 
 	pbBackup.RegisterBackupManagementServer(grpcServer, backupService)
 	// health endpoint used to determine the healthiness of the app
-	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
-	return nil
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	return debugModeService, nil
+}
+
+// createLogProvider creates the provider reading the dogu logs from the configured log backend.
+func createLogProvider(client clusterClient, logBackend logBackendAccess) logging.LogProvider {
+	switch config.CurrentLogBackend {
+	case config.LogBackendKubernetes:
+		return logging.NewKubernetesLogProvider(client.CoreV1().Pods(config.CurrentNamespace))
+	case config.LogBackendOpenSearch:
+		openSearchConfig := config.CurrentOpenSearchConfig
+		return logging.NewOpenSearchLogProvider(openSearchConfig.Url, openSearchConfig.Index, logBackend.credentials, logBackend.httpClient)
+	default:
+		return logging.NewLokiLogProvider(config.CurrentLokiGatewayConfig.Url, logBackend.credentials, logBackend.httpClient)
+	}
+}
+
+// createHealthChecks creates the checks of the dependencies of the grpc services. The kubernetes api server is
+// required by every service while the log backend and the local dogu registry are only required by some of them.
+// The kubernetes log backend is covered by the check of the kubernetes api server.
+func createHealthChecks(client clusterClient, logBackend logBackendAccess) []healthcheck.Check {
+	checks := []healthcheck.Check{
+		{
+			Name:   "kubernetes",
+			Prober: healthcheck.NewKubernetesProber(client.Discovery()),
+			Services: []string{
+				healthcheck.ReadinessService,
+				pbLogging.DoguLogMessages_ServiceDesc.ServiceName,
+				pbDoguAdministration.DoguAdministration_ServiceDesc.ServiceName,
+				pgHealth.DoguHealth_ServiceDesc.ServiceName,
+				pbMaintenance.DebugMode_ServiceDesc.ServiceName,
+				pbMaintenance.SupportArchive_ServiceDesc.ServiceName,
+				pbBackup.BackupManagement_ServiceDesc.ServiceName,
+			},
+		},
+		{
+			Name:   "dogu registry",
+			Prober: healthcheck.NewRegistryProber(client.CoreV1().ConfigMaps(config.CurrentNamespace)),
+			Services: []string{
+				pbLogging.DoguLogMessages_ServiceDesc.ServiceName,
+				pbDoguAdministration.DoguAdministration_ServiceDesc.ServiceName,
+				pbMaintenance.DebugMode_ServiceDesc.ServiceName,
+			},
+		},
+	}
+
+	switch config.CurrentLogBackend {
+	case config.LogBackendLoki:
+		checks = append(checks, healthcheck.Check{
+			Name:     "loki",
+			Prober:   healthcheck.NewLokiProber(config.CurrentLokiGatewayConfig.Url, logBackend.credentials, logBackend.httpClient),
+			Services: []string{pbLogging.DoguLogMessages_ServiceDesc.ServiceName},
+		})
+	case config.LogBackendOpenSearch:
+		checks = append(checks, healthcheck.Check{
+			Name:     "opensearch",
+			Prober:   healthcheck.NewOpenSearchProber(config.CurrentOpenSearchConfig.Url, logBackend.credentials, logBackend.httpClient),
+			Services: []string{pbLogging.DoguLogMessages_ServiceDesc.ServiceName},
+		})
+	}
+
+	return checks
+}
+
+// createAuditLogger creates the audit logger for mutating grpc calls. The records are written to stdout if no audit
+// log file is configured.
+func createAuditLogger(client clusterClient) (*audit.Logger, error) {
+	auditConfig := config.CurrentAuditConfig
+	if auditConfig == nil {
+		auditConfig = &config.AuditConfig{}
+	}
+
+	writer := os.Stdout
+	if auditConfig.LogFile != "" {
+		file, err := os.OpenFile(auditConfig.LogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit log file %s: %w", auditConfig.LogFile, err)
+		}
+		writer = file
+	}
+
+	if !auditConfig.KubernetesEvents {
+		return audit.NewLogger(writer, nil, config.CurrentNamespace), nil
+	}
+
+	return audit.NewLogger(writer, client.CoreV1().Events(config.CurrentNamespace), config.CurrentNamespace), nil
 }
 
 func registerServerForServiceDiscovery(grpcServer *grpc.Server) {
@@ -159,16 +260,26 @@ func startServerCommand() *cli.Command {
 	return &cli.Command{
 		Name:      "start",
 		Aliases:   []string{"s"},
-		Usage:     fmt.Sprintf("starts the service and listens on port %s", port),
+		Usage:     "starts the service and listens on the configured address (default :50051)",
 		ArgsUsage: "",
 		Flags:     []cli.Flag{},
-		Action:    startServerAction,
+		// only the server requires the cluster configuration, the client commands just connect to a server
+		Before: func(_ *cli.Context) error {
+			return config.ConfigureApplication()
+		},
+		Action: startServerAction,
 	}
 }
 
 func startServerAction(_ *cli.Context) error {
+	logrus.Infoln("Starting k8s-ces-control")
 	config.PrintCloudoguLogo()
-	lis, err := net.Listen("tcp", port)
+
+	// the root context is cancelled on termination so that all background watchers stop
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	lis, err := net.Listen("tcp", config.CurrentListenAddress)
 	if err != nil {
 		logrus.Fatalf("failed to listen: %v", err)
 	}
@@ -178,22 +289,376 @@ func startServerAction(_ *cli.Context) error {
 		return fmt.Errorf("failed to create cluster client")
 	}
 
-	grpcServer := grpc.NewServer()
-	err = registerServices(client, grpcServer)
+	tlsConfig, err := createTLSConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	authenticator, err := createAuthenticator(ctx)
+	if err != nil {
+		return err
+	}
+
+	logBackend, err := createLogBackendAccess(ctx)
+	if err != nil {
+		return err
+	}
+
+	applyTimeouts()
+	pbDebug.SetWatchInterval(config.CurrentDebugModeWatchInterval)
+	go config.Watch(ctx, applyTimeouts)
+
+	healthServer := health.NewServer()
+	grpcServer := grpc.NewServer(createServerOptions(tlsConfig, authenticator)...)
+	gw := createGateway(authenticator)
+	debugModeService, err := registerServices(ctx, client, logBackend, serviceRegistrars(grpcServer, gw), healthServer)
 	if err != nil {
 		logrus.Fatalf("failed to register services: %s", err.Error())
 		return err
 	}
 
+	healthManager := healthcheck.NewManager(healthServer, createHealthChecks(client, logBackend)...)
+	go healthManager.Run(ctx)
+
 	if config.IsDevelopmentStage() {
 		logrus.Debugln("Register k8s-ces-control to be used with the service discovery")
 		registerServerForServiceDiscovery(grpcServer)
 	}
 
-	logrus.Infof("server listening at %v", lis.Addr())
-	if err := grpcServer.Serve(lis); err != nil {
-		logrus.Fatalf("failed to serve: %v", err)
+	var probeServer *grpc.Server
+	if config.CurrentTLSConfig.Enabled() {
+		probeServer, err = startHealthProbeServer(healthServer)
+		if err != nil {
+			return err
+		}
+	}
+
+	metricsServer, err := startMetricsServer(client, debugModeService)
+	if err != nil {
+		return err
+	}
+
+	var gatewayServer *http.Server
+	if gw != nil {
+		gatewayServer, err = startGatewayServer(gw, tlsConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logrus.Infof("server listening at %v", lis.Addr())
+		serveErr <- grpcServer.Serve(lis)
+	}()
+
+	select {
+	case err = <-serveErr:
+		logrus.Errorf("failed to serve: %v", err)
 		return err
+	case <-ctx.Done():
 	}
+
+	shutdownTimeout := config.CurrentTimeouts().Shutdown
+	logrus.Infof("Received termination signal, shutting down within %s", shutdownTimeout)
+	// let clients and probes know that no new calls should be sent
+	healthServer.Shutdown()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	// the gateway is shut down concurrently so that the calls of both servers are drained within the same timeout
+	gatewayStopped := make(chan struct{})
+	go func() {
+		defer close(gatewayStopped)
+		if gatewayServer == nil {
+			return
+		}
+		err := gatewayServer.Shutdown(shutdownCtx)
+		if err != nil {
+			logrus.Errorf("failed to shut down gateway server: %v", err)
+		}
+	}()
+
+	gracefulStop(grpcServer, shutdownTimeout)
+	<-gatewayStopped
+
+	if probeServer != nil {
+		probeServer.Stop()
+	}
+
+	err = metricsServer.Shutdown(shutdownCtx)
+	if err != nil {
+		logrus.Errorf("failed to shut down metrics server: %v", err)
+	}
+
 	return nil
 }
+
+// applyTimeouts passes the configured timeouts on to the services. It is called again on every reload of the
+// configuration file.
+func applyTimeouts() {
+	timeouts := config.CurrentTimeouts()
+	logging.SetQueryTimeout(timeouts.LokiQuery)
+	doguinteraction.SetWaitTimeout(timeouts.DoguWait)
+}
+
+// gracefulStop stops accepting new calls and waits for running calls and streams to finish. Calls which are still
+// running after the timeout are cancelled.
+func gracefulStop(server stoppableServer, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+		logrus.Info("All running calls finished")
+	case <-timer.C:
+		logrus.Warnf("Running calls did not finish within %s, cancelling them", timeout)
+		server.Stop()
+		<-stopped
+	}
+}
+
+func createServerOptions(tlsConfig *tls.Config, authenticator *auth.Authenticator) []grpc.ServerOption {
+	unaryInterceptors, streamInterceptors := createInterceptors(authenticator)
+	serverOptions := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
+
+	if tlsConfig != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	return serverOptions
+}
+
+// createInterceptors creates the interceptors of the grpc server and the gateway, so that calls via the gateway are
+// authorized and measured like grpc calls.
+func createInterceptors(authenticator *auth.Authenticator) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor) {
+	// the metrics interceptors are chained first so that rejected calls are counted as well
+	unaryInterceptors := []grpc.UnaryServerInterceptor{metrics.UnaryServerInterceptor()}
+	streamInterceptors := []grpc.StreamServerInterceptor{metrics.StreamServerInterceptor()}
+
+	if authenticator != nil {
+		unaryInterceptors = append(unaryInterceptors, auth.UnaryServerInterceptor(authenticator))
+		streamInterceptors = append(streamInterceptors, auth.StreamServerInterceptor(authenticator))
+	}
+
+	return unaryInterceptors, streamInterceptors
+}
+
+// createTLSConfig creates the tls configuration of the grpc server and the gateway. It returns nil if tls is disabled.
+func createTLSConfig(ctx context.Context) (*tls.Config, error) {
+	if !config.CurrentTLSConfig.Enabled() {
+		return nil, nil
+	}
+
+	reloader, err := certificate.NewReloader(
+		config.CurrentTLSConfig.CertFile,
+		config.CurrentTLSConfig.KeyFile,
+		config.CurrentTLSConfig.ClientCAFile,
+		config.CurrentTLSConfig.RequireClientCert,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tls certificates: %w", err)
+	}
+
+	go reloader.Watch(ctx)
+
+	return reloader.TLSConfig(), nil
+}
+
+// logBackendAccess contains the credentials and the http client shared by all requests to the log backend. Both are
+// nil for the kubernetes log backend, which uses the cluster client.
+type logBackendAccess struct {
+	credentials *lokiGateway.Credentials
+	httpClient  *http.Client
+}
+
+// createLogBackendAccess reads the credentials and the CA bundle of the configured log backend. Credential files are
+// watched for changes until the context is done.
+func createLogBackendAccess(ctx context.Context) (logBackendAccess, error) {
+	switch config.CurrentLogBackend {
+	case config.LogBackendLoki:
+		return createLokiGatewayAccess(ctx)
+	case config.LogBackendOpenSearch:
+		return createOpenSearchAccess(ctx)
+	default:
+		return logBackendAccess{}, nil
+	}
+}
+
+// createLokiGatewayAccess reads the credentials and the CA bundle of the loki gateway.
+func createLokiGatewayAccess(ctx context.Context) (logBackendAccess, error) {
+	lokiConfig := config.CurrentLokiGatewayConfig
+	credentials, err := lokiGateway.NewCredentials(lokiGateway.CredentialsConfig{
+		Username:     lokiConfig.Username,
+		UsernameFile: lokiConfig.UsernameFile,
+		Password:     lokiConfig.Password,
+		PasswordFile: lokiConfig.PasswordFile,
+		TokenFile:    lokiConfig.TokenFile,
+	})
+	if err != nil {
+		return logBackendAccess{}, fmt.Errorf("failed to load loki gateway credentials: %w", err)
+	}
+
+	httpClient, err := lokiGateway.NewHTTPClient(lokiConfig.CAFile)
+	if err != nil {
+		return logBackendAccess{}, err
+	}
+
+	go credentials.Watch(ctx)
+
+	return logBackendAccess{credentials: credentials, httpClient: httpClient}, nil
+}
+
+// createOpenSearchAccess reads the credentials and the CA bundle of opensearch. They are handled like the ones of the
+// loki gateway.
+func createOpenSearchAccess(ctx context.Context) (logBackendAccess, error) {
+	openSearchConfig := config.CurrentOpenSearchConfig
+	credentials, err := lokiGateway.NewCredentials(lokiGateway.CredentialsConfig{
+		Username:     openSearchConfig.Username,
+		UsernameFile: openSearchConfig.UsernameFile,
+		Password:     openSearchConfig.Password,
+		PasswordFile: openSearchConfig.PasswordFile,
+	})
+	if err != nil {
+		return logBackendAccess{}, fmt.Errorf("failed to load opensearch credentials: %w", err)
+	}
+
+	httpClient, err := lokiGateway.NewHTTPClient(openSearchConfig.CAFile)
+	if err != nil {
+		return logBackendAccess{}, fmt.Errorf("failed to create opensearch http client: %w", err)
+	}
+
+	go credentials.Watch(ctx)
+
+	return logBackendAccess{credentials: credentials, httpClient: httpClient}, nil
+}
+
+// createAuthenticator creates the authenticator of the grpc server and the gateway. It returns nil if auth is disabled.
+func createAuthenticator(ctx context.Context) (*auth.Authenticator, error) {
+	if !config.CurrentAuthConfig.Enabled() {
+		return nil, nil
+	}
+
+	authenticator, err := auth.NewAuthenticator(config.CurrentAuthConfig.ConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auth configuration: %w", err)
+	}
+
+	go authenticator.Watch(ctx)
+
+	return authenticator, nil
+}
+
+// serviceRegistrars returns a registrar which registers the services at the grpc server and, if enabled, at the
+// gateway.
+func serviceRegistrars(grpcServer *grpc.Server, gw *gateway.Gateway) grpc.ServiceRegistrar {
+	if gw == nil {
+		return grpcServer
+	}
+
+	return multiServiceRegistrar{grpcServer, gw}
+}
+
+// multiServiceRegistrar registers services at all contained registrars.
+type multiServiceRegistrar []grpc.ServiceRegistrar
+
+// RegisterService registers the service at all contained registrars.
+func (m multiServiceRegistrar) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	for _, registrar := range m {
+		registrar.RegisterService(desc, impl)
+	}
+}
+
+// createGateway creates the http gateway to the grpc services. It returns nil if the gateway is disabled.
+func createGateway(authenticator *auth.Authenticator) *gateway.Gateway {
+	if !config.CurrentGatewayConfig.Enabled() {
+		return nil
+	}
+
+	return gateway.NewGateway(createInterceptors(authenticator))
+}
+
+func startGatewayServer(gw *gateway.Gateway, tlsConfig *tls.Config) (*http.Server, error) {
+	lis, err := net.Listen("tcp", config.CurrentGatewayConfig.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on gateway address %s: %w", config.CurrentGatewayConfig.Address, err)
+	}
+
+	gatewayServer := &http.Server{
+		Addr:              config.CurrentGatewayConfig.Address,
+		Handler:           gw,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		logrus.Infof("gateway server listening at %v", lis.Addr())
+		var serveErr error
+		if tlsConfig != nil {
+			serveErr = gatewayServer.ServeTLS(lis, "", "")
+		} else {
+			serveErr = gatewayServer.Serve(lis)
+		}
+		if serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			logrus.Errorf("failed to serve gateway: %v", serveErr)
+		}
+	}()
+
+	return gatewayServer, nil
+}
+
+func startHealthProbeServer(healthServer grpc_health_v1.HealthServer) (*grpc.Server, error) {
+	lis, err := net.Listen("tcp", healthProbePort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on health probe port %s: %w", healthProbePort, err)
+	}
+
+	probeServer := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(probeServer, healthServer)
+
+	go func() {
+		logrus.Infof("health probe server listening at %v", lis.Addr())
+		if err := probeServer.Serve(lis); err != nil {
+			logrus.Errorf("failed to serve health probes: %v", err)
+		}
+	}()
+
+	return probeServer, nil
+}
+
+func startMetricsServer(client clusterClient, debugMode debugModeStatus) (*http.Server, error) {
+	err := metrics.Register(metrics.NewDomainCollector(
+		client.Dogus(config.CurrentNamespace),
+		client.Backups(config.CurrentNamespace),
+		client.Restores(config.CurrentNamespace),
+		debugMode,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	lis, err := net.Listen("tcp", metricsPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on metrics port %s: %w", metricsPort, err)
+	}
+
+	metricsServer := metrics.NewServer(metricsPort)
+
+	go func() {
+		logrus.Infof("metrics server listening at %v", lis.Addr())
+		if err := metricsServer.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Errorf("failed to serve metrics: %v", err)
+		}
+	}()
+
+	return metricsServer, nil
+}
@@ -1,18 +1,28 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/cloudogu/k8s-ces-control/packages/auth"
 	"github.com/cloudogu/k8s-ces-control/packages/config"
+	"github.com/cloudogu/k8s-ces-control/packages/healthcheck"
+	"github.com/cloudogu/k8s-ces-control/packages/logging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func Test_startCesControl(tt *testing.T) {
 	tt.Run("Error on missing namespace environment variable", func(t *testing.T) {
 		// given
+		os.Args = []string{"k8s-ces-control", "start"}
 
 		// when
 		err := startCesControl()
@@ -22,6 +32,17 @@ func Test_startCesControl(tt *testing.T) {
 		require.Contains(t, err.Error(), "found invalid value for namespace []: namespace cannot be empty: set valid value with environment variable [NAMESPACE]")
 	})
 
+	tt.Run("Should not require server configuration for client commands", func(t *testing.T) {
+		// given
+		os.Args = []string{"k8s-ces-control", "dogu", "--help"}
+
+		// when
+		err := startCesControl()
+
+		// then
+		require.NoError(t, err)
+	})
+
 	tt.Run("Should succeed on help command", func(t *testing.T) {
 		// given
 		t.Setenv("NAMESPACE", "mynamespace")
@@ -60,15 +81,25 @@ func Test_registerServices(tt *testing.T) {
 		clientSetMock.EXPECT().Components(config.CurrentNamespace).Return(nil)
 		batchv1Mock.EXPECT().CronJobs(config.CurrentNamespace).Return(nil)
 		clientSetMock.EXPECT().BatchV1().Return(batchv1Mock)
+		appsV1Mock := newMockAppsV1Interface(t)
+		appsV1Mock.EXPECT().Deployments(config.CurrentNamespace).Return(nil)
+		clientSetMock.EXPECT().AppsV1().Return(appsV1Mock)
 
 		configMapInterfaceMock := newMockConfigMapInterface(t)
 		coreV1Mock.EXPECT().ConfigMaps(config.CurrentNamespace).Return(configMapInterfaceMock)
+		coreV1Mock.EXPECT().Pods(config.CurrentNamespace).Return(nil)
+		clientSetMock.EXPECT().Discovery().Return(fake.NewClientset().Discovery())
+		// the expiry watcher of the debug mode applies for the lease until the test is done
+		clientSetMock.EXPECT().CoordinationV1().Return(fake.NewClientset().CoordinationV1())
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 
 		// when
-		err := registerServices(clientSetMock, mockGrpcServerRegistrar)
+		debugModeService, err := registerServices(ctx, clientSetMock, logBackendAccess{}, mockGrpcServerRegistrar, health.NewServer())
 
 		// then
 		require.NoError(t, err)
+		assert.NotNil(t, debugModeService)
 		assert.Equal(t, 7, len(mockGrpcServerRegistrar.registeredServices))
 		assert.Contains(t, mockGrpcServerRegistrar.registeredServices, "logging.DoguLogMessages")
 		assert.Contains(t, mockGrpcServerRegistrar.registeredServices, "doguAdministration.DoguAdministration")
@@ -79,6 +110,242 @@ func Test_registerServices(tt *testing.T) {
 	})
 }
 
+func Test_createAuditLogger(tt *testing.T) {
+	tt.Run("Should fail to open audit log file", func(t *testing.T) {
+		// given
+		setConfig(t, &config.CurrentAuditConfig, &config.AuditConfig{LogFile: filepath.Join(t.TempDir(), "missing", "audit.log")})
+
+		// when
+		_, err := createAuditLogger(newMockClusterClient(t))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to open audit log file")
+	})
+	tt.Run("Should create events in the current namespace", func(t *testing.T) {
+		// given
+		setConfig(t, &config.CurrentAuditConfig, &config.AuditConfig{LogFile: filepath.Join(t.TempDir(), "audit.log"), KubernetesEvents: true})
+		config.CurrentNamespace = "ecosystem"
+
+		clientSetMock := newMockClusterClient(t)
+		coreV1Mock := newMockCoreV1Interface(t)
+		clientSetMock.EXPECT().CoreV1().Return(coreV1Mock)
+		coreV1Mock.EXPECT().Events("ecosystem").Return(nil)
+
+		// when
+		auditLogger, err := createAuditLogger(clientSetMock)
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, auditLogger)
+	})
+}
+
+func Test_createHealthChecks(tt *testing.T) {
+	tests := []struct {
+		name       string
+		logBackend config.LogBackend
+		wantChecks []string
+	}{
+		{
+			name:       "Should create checks for kubernetes, the dogu registry and loki",
+			logBackend: config.LogBackendLoki,
+			wantChecks: []string{"kubernetes", "dogu registry", "loki"},
+		},
+		{
+			name:       "Should check opensearch for the opensearch log backend",
+			logBackend: config.LogBackendOpenSearch,
+			wantChecks: []string{"kubernetes", "dogu registry", "opensearch"},
+		},
+		{
+			name:       "Should not check a log stack for the kubernetes log backend",
+			logBackend: config.LogBackendKubernetes,
+			wantChecks: []string{"kubernetes", "dogu registry"},
+		},
+	}
+	for _, test := range tests {
+		tt.Run(test.name, func(t *testing.T) {
+			// given
+			setConfig(t, &config.CurrentLogBackend, test.logBackend)
+			setConfig(t, &config.CurrentLokiGatewayConfig, &config.LokiGatewayConfig{Url: "http://loki", Username: "test", Password: "password"})
+			setConfig(t, &config.CurrentOpenSearchConfig, &config.OpenSearchConfig{Url: "https://opensearch:9200", Index: "fluent-bit", Username: "test", Password: "password"})
+			config.CurrentNamespace = "ecosystem"
+			clientSetMock := newMockClusterClient(t)
+			coreV1Mock := newMockCoreV1Interface(t)
+			clientSetMock.EXPECT().Discovery().Return(nil)
+			clientSetMock.EXPECT().CoreV1().Return(coreV1Mock)
+			coreV1Mock.EXPECT().ConfigMaps(config.CurrentNamespace).Return(newMockConfigMapInterface(t))
+
+			// when
+			checks := createHealthChecks(clientSetMock, logBackendAccess{})
+
+			// then
+			var names []string
+			for _, check := range checks {
+				names = append(names, check.Name)
+			}
+			require.Equal(t, test.wantChecks, names)
+			assert.Len(t, checks[0].Services, 7)
+			assert.Contains(t, checks[0].Services, healthcheck.ReadinessService)
+			assert.Contains(t, checks[0].Services, "logging.DoguLogMessages")
+			assert.Contains(t, checks[1].Services, "doguAdministration.DoguAdministration")
+			if len(checks) == 3 {
+				assert.Equal(t, []string{"logging.DoguLogMessages"}, checks[2].Services)
+			}
+		})
+	}
+}
+
+func Test_createLogProvider(tt *testing.T) {
+	tests := []struct {
+		name       string
+		logBackend config.LogBackend
+		want       any
+	}{
+		{name: "Should create loki log provider by default", logBackend: config.LogBackendLoki, want: &logging.LokiLogProvider{}},
+		{name: "Should create kubernetes log provider for the pods of the namespace", logBackend: config.LogBackendKubernetes, want: &logging.KubernetesLogProvider{}},
+		{name: "Should create opensearch log provider", logBackend: config.LogBackendOpenSearch, want: &logging.OpenSearchLogProvider{}},
+	}
+	for _, test := range tests {
+		tt.Run(test.name, func(t *testing.T) {
+			// given
+			setConfig(t, &config.CurrentLogBackend, test.logBackend)
+			setConfig(t, &config.CurrentLokiGatewayConfig, &config.LokiGatewayConfig{Url: "http://loki", Username: "test", Password: "password"})
+			setConfig(t, &config.CurrentOpenSearchConfig, &config.OpenSearchConfig{Url: "https://opensearch:9200", Index: "fluent-bit", Username: "test", Password: "password"})
+			config.CurrentNamespace = "ecosystem"
+			clientSetMock := newMockClusterClient(t)
+			if test.logBackend == config.LogBackendKubernetes {
+				coreV1Mock := newMockCoreV1Interface(t)
+				clientSetMock.EXPECT().CoreV1().Return(coreV1Mock)
+				coreV1Mock.EXPECT().Pods("ecosystem").Return(nil)
+			}
+
+			// when
+			actual := createLogProvider(clientSetMock, logBackendAccess{})
+
+			// then
+			assert.IsType(t, test.want, actual)
+		})
+	}
+}
+
+func Test_createLokiGatewayAccess(tt *testing.T) {
+	tests := []struct {
+		name    string
+		config  func(t *testing.T) *config.LokiGatewayConfig
+		wantErr string
+	}{
+		{
+			name: "Should create basic auth credentials and http client",
+			config: func(t *testing.T) *config.LokiGatewayConfig {
+				passwordFile := filepath.Join(t.TempDir(), "password")
+				require.NoError(t, os.WriteFile(passwordFile, []byte("password"), 0600))
+				return &config.LokiGatewayConfig{Url: "http://loki", Username: "test", PasswordFile: passwordFile}
+			},
+		},
+		{
+			name: "Should fail for missing credential file",
+			config: func(t *testing.T) *config.LokiGatewayConfig {
+				return &config.LokiGatewayConfig{Url: "http://loki", TokenFile: filepath.Join(t.TempDir(), "token")}
+			},
+			wantErr: "failed to load loki gateway credentials",
+		},
+		{
+			name: "Should fail for missing CA file",
+			config: func(t *testing.T) *config.LokiGatewayConfig {
+				return &config.LokiGatewayConfig{Url: "https://loki", Username: "test", Password: "password", CAFile: filepath.Join(t.TempDir(), "ca.crt")}
+			},
+			wantErr: "failed to read loki gateway CA file",
+		},
+	}
+	for _, test := range tests {
+		tt.Run(test.name, func(t *testing.T) {
+			// given
+			setConfig(t, &config.CurrentLokiGatewayConfig, test.config(t))
+
+			// when
+			loki, err := createLokiGatewayAccess(context.Background())
+
+			// then
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, loki.credentials)
+			assert.NotNil(t, loki.httpClient)
+		})
+	}
+}
+
+func Test_createLogBackendAccess(tt *testing.T) {
+	tests := []struct {
+		name       string
+		logBackend config.LogBackend
+		config     func(t *testing.T) *config.OpenSearchConfig
+		wantAccess bool
+		wantErr    string
+	}{
+		{
+			name:       "Should not require access for the kubernetes log backend",
+			logBackend: config.LogBackendKubernetes,
+			config: func(*testing.T) *config.OpenSearchConfig {
+				return nil
+			},
+		},
+		{
+			name:       "Should create opensearch credentials and http client",
+			logBackend: config.LogBackendOpenSearch,
+			config: func(t *testing.T) *config.OpenSearchConfig {
+				usernameFile := filepath.Join(t.TempDir(), "username")
+				require.NoError(t, os.WriteFile(usernameFile, []byte("admin"), 0600))
+				return &config.OpenSearchConfig{Url: "https://opensearch:9200", UsernameFile: usernameFile, Password: "password"}
+			},
+			wantAccess: true,
+		},
+		{
+			name:       "Should fail for missing opensearch CA file",
+			logBackend: config.LogBackendOpenSearch,
+			config: func(t *testing.T) *config.OpenSearchConfig {
+				return &config.OpenSearchConfig{Url: "https://opensearch:9200", Username: "test", Password: "password", CAFile: filepath.Join(t.TempDir(), "ca.crt")}
+			},
+			wantErr: "failed to create opensearch http client",
+		},
+	}
+	for _, test := range tests {
+		tt.Run(test.name, func(t *testing.T) {
+			// given
+			setConfig(t, &config.CurrentLogBackend, test.logBackend)
+			setConfig(t, &config.CurrentOpenSearchConfig, test.config(t))
+
+			// when
+			actual, err := createLogBackendAccess(context.Background())
+
+			// then
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if test.wantAccess {
+				assert.NotNil(t, actual.credentials)
+				assert.NotNil(t, actual.httpClient)
+			} else {
+				assert.Equal(t, logBackendAccess{}, actual)
+			}
+		})
+	}
+}
+
+// setConfig sets a global configuration for the test and restores the previous one afterwards.
+func setConfig[T any](t *testing.T, current *T, value T) {
+	previous := *current
+	t.Cleanup(func() { *current = previous })
+	*current = value
+}
+
 type mockServiceRegistrar struct {
 	registeredServices []string
 }
@@ -86,3 +353,188 @@ type mockServiceRegistrar struct {
 func (sr *mockServiceRegistrar) RegisterService(desc *grpc.ServiceDesc, _ interface{}) {
 	sr.registeredServices = append(sr.registeredServices, desc.ServiceName)
 }
+
+func Test_createServerOptions(tt *testing.T) {
+	tt.Run("Should return only metrics interceptors without tls and auth", func(t *testing.T) {
+		// when
+		options := createServerOptions(nil, nil)
+
+		// then
+		assert.Len(t, options, 2)
+	})
+
+	tt.Run("Should add tls credentials", func(t *testing.T) {
+		// when
+		options := createServerOptions(&tls.Config{}, &auth.Authenticator{})
+
+		// then
+		assert.Len(t, options, 3)
+	})
+}
+
+func Test_createInterceptors(tt *testing.T) {
+	tt.Run("Should return only metrics interceptors without auth", func(t *testing.T) {
+		// when
+		unaryInterceptors, streamInterceptors := createInterceptors(nil)
+
+		// then
+		assert.Len(t, unaryInterceptors, 1)
+		assert.Len(t, streamInterceptors, 1)
+	})
+
+	tt.Run("Should add auth interceptors", func(t *testing.T) {
+		// when
+		unaryInterceptors, streamInterceptors := createInterceptors(&auth.Authenticator{})
+
+		// then
+		assert.Len(t, unaryInterceptors, 2)
+		assert.Len(t, streamInterceptors, 2)
+	})
+}
+
+func Test_createTLSConfig(tt *testing.T) {
+	tt.Run("Should return nil without tls", func(t *testing.T) {
+		// given
+		setConfig(t, &config.CurrentTLSConfig, &config.TLSConfig{})
+
+		// when
+		tlsConfig, err := createTLSConfig(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, tlsConfig)
+	})
+
+	tt.Run("Should fail if certificates cannot be loaded", func(t *testing.T) {
+		// given
+		setConfig(t, &config.CurrentTLSConfig, &config.TLSConfig{
+			CertFile: "/does/not/exist/tls.crt",
+			KeyFile:  "/does/not/exist/tls.key",
+		})
+
+		// when
+		_, err := createTLSConfig(context.Background())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to load tls certificates")
+	})
+}
+
+func Test_createAuthenticator(tt *testing.T) {
+	tt.Run("Should return nil without auth", func(t *testing.T) {
+		// given
+		setConfig(t, &config.CurrentAuthConfig, &config.AuthConfig{})
+
+		// when
+		authenticator, err := createAuthenticator(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, authenticator)
+	})
+
+	tt.Run("Should return authenticator", func(t *testing.T) {
+		// given
+		authFile := filepath.Join(t.TempDir(), "auth.yaml")
+		require.NoError(t, os.WriteFile(authFile, []byte("tokens: []"), 0600))
+		setConfig(t, &config.CurrentAuthConfig, &config.AuthConfig{ConfigFile: authFile})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// when
+		authenticator, err := createAuthenticator(ctx)
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, authenticator)
+	})
+
+	tt.Run("Should fail if auth configuration cannot be loaded", func(t *testing.T) {
+		// given
+		setConfig(t, &config.CurrentAuthConfig, &config.AuthConfig{ConfigFile: "/does/not/exist/auth.yaml"})
+
+		// when
+		_, err := createAuthenticator(context.Background())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to load auth configuration")
+	})
+}
+
+func Test_createGateway(tt *testing.T) {
+	tests := []struct {
+		name        string
+		config      *config.GatewayConfig
+		wantGateway bool
+	}{
+		{name: "Should return nil if gateway is disabled", config: &config.GatewayConfig{}},
+		{name: "Should create gateway", config: &config.GatewayConfig{Address: ":8080"}, wantGateway: true},
+	}
+	for _, test := range tests {
+		tt.Run(test.name, func(t *testing.T) {
+			// given
+			setConfig(t, &config.CurrentGatewayConfig, test.config)
+
+			// when
+			gw := createGateway(nil)
+
+			// then
+			assert.Equal(t, test.wantGateway, gw != nil)
+		})
+	}
+}
+
+func Test_serviceRegistrars(tt *testing.T) {
+	tt.Run("Should register services at all registrars", func(t *testing.T) {
+		// given
+		first := &mockServiceRegistrar{}
+		second := &mockServiceRegistrar{}
+
+		// when
+		multiServiceRegistrar{first, second}.RegisterService(&grpc.ServiceDesc{ServiceName: "backup.BackupManagement"}, nil)
+
+		// then
+		assert.Equal(t, []string{"backup.BackupManagement"}, first.registeredServices)
+		assert.Equal(t, []string{"backup.BackupManagement"}, second.registeredServices)
+	})
+
+	tt.Run("Should only use grpc server without gateway", func(t *testing.T) {
+		// given
+		grpcServer := grpc.NewServer()
+
+		// when
+		registrar := serviceRegistrars(grpcServer, nil)
+
+		// then
+		assert.Same(t, grpcServer, registrar)
+	})
+}
+
+func Test_gracefulStop(tt *testing.T) {
+	tt.Run("Should wait for running calls", func(t *testing.T) {
+		// given
+		serverMock := newMockStoppableServer(t)
+		serverMock.EXPECT().GracefulStop().Return()
+
+		// when
+		gracefulStop(serverMock, time.Second)
+
+		// then
+		serverMock.AssertNotCalled(t, "Stop")
+	})
+	tt.Run("Should cancel running calls after timeout", func(t *testing.T) {
+		// given
+		stopped := make(chan struct{})
+		serverMock := newMockStoppableServer(t)
+		serverMock.EXPECT().GracefulStop().Run(func() { <-stopped }).Return()
+		serverMock.EXPECT().Stop().Run(func() { close(stopped) }).Return()
+
+		// when
+		gracefulStop(serverMock, time.Millisecond)
+
+		// then
+		serverMock.AssertCalled(t, "Stop")
+	})
+}
@@ -0,0 +1,205 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cloudogu/k8s-ces-control/packages/auth"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+
+	// internalCaller is used for actions triggered by k8s-ces-control itself, e.g. the expiry of the debug mode.
+	internalCaller = "k8s-ces-control"
+	// anonymousCaller is used for grpc calls without an authenticated identity.
+	anonymousCaller = "anonymous"
+)
+
+// Entry describes a mutating action which should be recorded in the audit log.
+type Entry struct {
+	// Action is the name of the action, e.g. "StopDogu".
+	Action string
+	// Resource is the affected resource.
+	Resource Resource
+	// Parameters contains the relevant request parameters.
+	Parameters map[string]string
+	// Started is the time the action was started and is used to calculate the latency.
+	Started time.Time
+	// Err is the error returned by the action or nil if the action was successful.
+	Err error
+}
+
+// record is a single line in the audit log.
+type record struct {
+	Time         time.Time         `json:"time"`
+	Caller       string            `json:"caller"`
+	AuthMethod   string            `json:"authMethod,omitempty"`
+	Role         string            `json:"role,omitempty"`
+	PeerAddress  string            `json:"peerAddress,omitempty"`
+	Method       string            `json:"method,omitempty"`
+	Action       string            `json:"action"`
+	ResourceKind string            `json:"resourceKind,omitempty"`
+	ResourceName string            `json:"resourceName,omitempty"`
+	Parameters   map[string]string `json:"parameters,omitempty"`
+	Outcome      string            `json:"outcome"`
+	Error        string            `json:"error,omitempty"`
+	LatencyMs    int64             `json:"latencyMs"`
+}
+
+type nowClock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (r *realClock) Now() time.Time {
+	return time.Now()
+}
+
+type eventCreator interface {
+	Create(ctx context.Context, event *corev1.Event, opts metav1.CreateOptions) (*corev1.Event, error)
+}
+
+// Logger writes audit records as JSON lines and optionally mirrors them as kubernetes events on the affected resource.
+type Logger struct {
+	mutex     sync.Mutex
+	writer    io.Writer
+	events    eventCreator
+	namespace string
+	clock     nowClock
+}
+
+// NewLogger creates a new audit logger writing to the given writer. If events is not nil, records of dogus and
+// backups are additionally created as kubernetes events in the given namespace.
+func NewLogger(writer io.Writer, events eventCreator, namespace string) *Logger {
+	return &Logger{
+		writer:    writer,
+		events:    events,
+		namespace: namespace,
+		clock:     &realClock{},
+	}
+}
+
+// Record writes the given entry to the audit log. Failures are logged but never returned because auditing must not
+// change the result of the audited action.
+func (l *Logger) Record(ctx context.Context, entry Entry) {
+	rec := l.createRecord(ctx, entry)
+
+	err := l.write(rec)
+	if err != nil {
+		logrus.Error(fmt.Errorf("failed to write audit record for action %s: %w", entry.Action, err))
+	}
+
+	if l.events != nil && entry.Resource.apiVersion() != "" {
+		err = l.createEvent(ctx, entry.Resource, rec)
+		if err != nil {
+			logrus.Error(fmt.Errorf("failed to create audit event for %s %s: %w", entry.Resource.Kind, entry.Resource.Name, err))
+		}
+	}
+}
+
+func (l *Logger) createRecord(ctx context.Context, entry Entry) record {
+	now := l.clock.Now()
+	rec := record{
+		Time:         now,
+		Caller:       Caller(ctx),
+		Action:       entry.Action,
+		ResourceKind: entry.Resource.Kind,
+		ResourceName: entry.Resource.Name,
+		Parameters:   entry.Parameters,
+		Outcome:      outcomeSuccess,
+		LatencyMs:    now.Sub(entry.Started).Milliseconds(),
+	}
+
+	if method, ok := grpc.Method(ctx); ok {
+		rec.Method = method
+	}
+
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		rec.AuthMethod = identity.AuthMethod
+		rec.Role = identity.Role.String()
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		rec.PeerAddress = p.Addr.String()
+	}
+
+	if entry.Err != nil {
+		rec.Outcome = outcomeFailure
+		rec.Error = entry.Err.Error()
+	}
+
+	return rec
+}
+
+// Caller returns the name of the authenticated caller of a grpc call, "anonymous" for a call without an authenticated
+// identity or "k8s-ces-control" for an action triggered by k8s-ces-control itself.
+func Caller(ctx context.Context) string {
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		return identity.Name
+	}
+
+	if _, ok := grpc.Method(ctx); ok {
+		return anonymousCaller
+	}
+
+	return internalCaller
+}
+
+func (l *Logger) write(rec record) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	_, err = l.writer.Write(append(line, '\n'))
+	return err
+}
+
+func (l *Logger) createEvent(ctx context.Context, resource Resource, rec record) error {
+	eventType := corev1.EventTypeNormal
+	message := fmt.Sprintf("%s by %s succeeded", rec.Action, rec.Caller)
+	if rec.Outcome == outcomeFailure {
+		eventType = corev1.EventTypeWarning
+		message = fmt.Sprintf("%s by %s failed: %s", rec.Action, rec.Caller, rec.Error)
+	}
+
+	timestamp := metav1.NewTime(rec.Time)
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: resource.Name + ".",
+			Namespace:    l.namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: resource.apiVersion(),
+			Kind:       resource.Kind,
+			Name:       resource.Name,
+			Namespace:  l.namespace,
+		},
+		Reason:         rec.Action,
+		Message:        message,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: internalCaller},
+		FirstTimestamp: timestamp,
+		LastTimestamp:  timestamp,
+		Count:          1,
+	}
+
+	// the event is created even if the client cancelled the call in the meantime
+	_, err := l.events.Create(context.WithoutCancel(ctx), event, metav1.CreateOptions{})
+	return err
+}
@@ -0,0 +1,214 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-ces-control/packages/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testTime = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+func TestNewLogger(t *testing.T) {
+	t.Run("should create logger", func(t *testing.T) {
+		// given
+		writer := &bytes.Buffer{}
+		eventsMock := newMockEventCreator(t)
+
+		// when
+		actual := NewLogger(writer, eventsMock, "ecosystem")
+
+		// then
+		assert.Equal(t, writer, actual.writer)
+		assert.Equal(t, eventsMock, actual.events)
+		assert.Equal(t, "ecosystem", actual.namespace)
+		assert.NotNil(t, actual.clock)
+	})
+}
+
+func TestLogger_Record(t *testing.T) {
+	t.Run("should write json line with identity of grpc caller", func(t *testing.T) {
+		// given
+		writer := &bytes.Buffer{}
+		clockMock := newMockNowClock(t)
+		clockMock.EXPECT().Now().Return(testTime)
+		sut := &Logger{writer: writer, clock: clockMock}
+		ctx := grpcContext("/doguAdministration.DoguAdministration/StopDogu")
+		ctx = auth.ContextWithIdentity(ctx, auth.Identity{Name: "admin-dogu", Role: auth.RoleAdmin, AuthMethod: "token"})
+
+		// when
+		sut.Record(ctx, Entry{
+			Action:     "StopDogu",
+			Resource:   DoguResource("redmine"),
+			Parameters: map[string]string{"wait": "false"},
+			Started:    testTime.Add(-1500 * time.Millisecond),
+		})
+
+		// then
+		assert.JSONEq(t, `{
+			"time": "2026-10-18T12:00:00Z",
+			"caller": "admin-dogu",
+			"authMethod": "token",
+			"role": "admin",
+			"peerAddress": "10.0.0.1:4711",
+			"method": "/doguAdministration.DoguAdministration/StopDogu",
+			"action": "StopDogu",
+			"resourceKind": "Dogu",
+			"resourceName": "redmine",
+			"parameters": {"wait": "false"},
+			"outcome": "success",
+			"latencyMs": 1500
+		}`, writer.String())
+		assert.True(t, bytes.HasSuffix(writer.Bytes(), []byte("\n")))
+	})
+	t.Run("should record failure of anonymous caller", func(t *testing.T) {
+		// given
+		writer := &bytes.Buffer{}
+		clockMock := newMockNowClock(t)
+		clockMock.EXPECT().Now().Return(testTime)
+		sut := &Logger{writer: writer, clock: clockMock}
+
+		// when
+		sut.Record(grpcContext("/backup.BackupManagement/DeleteBackup"), Entry{
+			Action:   "DeleteBackup",
+			Resource: BackupResource("backup-1"),
+			Started:  testTime,
+			Err:      assert.AnError,
+		})
+
+		// then
+		assert.Contains(t, writer.String(), `"caller":"anonymous"`)
+		assert.Contains(t, writer.String(), `"outcome":"failure"`)
+		assert.Contains(t, writer.String(), `"error":"assert.AnError general error for testing"`)
+	})
+	t.Run("should record internal caller without grpc context", func(t *testing.T) {
+		// given
+		writer := &bytes.Buffer{}
+		clockMock := newMockNowClock(t)
+		clockMock.EXPECT().Now().Return(testTime)
+		sut := &Logger{writer: writer, clock: clockMock}
+
+		// when
+		sut.Record(context.Background(), Entry{Action: "DisableDebugMode", Resource: Resource{Kind: KindDebugMode}, Started: testTime})
+
+		// then
+		assert.Contains(t, writer.String(), `"caller":"k8s-ces-control"`)
+		assert.NotContains(t, writer.String(), `"method"`)
+	})
+	t.Run("should mirror dogu record as event", func(t *testing.T) {
+		// given
+		writer := &bytes.Buffer{}
+		clockMock := newMockNowClock(t)
+		clockMock.EXPECT().Now().Return(testTime)
+		eventsMock := newMockEventCreator(t)
+		expectedEvent := &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "redmine.", Namespace: "ecosystem"},
+			InvolvedObject: corev1.ObjectReference{
+				APIVersion: "k8s.cloudogu.com/v2",
+				Kind:       "Dogu",
+				Name:       "redmine",
+				Namespace:  "ecosystem",
+			},
+			Reason:         "RestartDogu",
+			Message:        "RestartDogu by anonymous failed: assert.AnError general error for testing",
+			Type:           corev1.EventTypeWarning,
+			Source:         corev1.EventSource{Component: "k8s-ces-control"},
+			FirstTimestamp: metav1.NewTime(testTime),
+			LastTimestamp:  metav1.NewTime(testTime),
+			Count:          1,
+		}
+		eventsMock.EXPECT().Create(mock.Anything, expectedEvent, metav1.CreateOptions{}).Return(expectedEvent, nil)
+		sut := &Logger{writer: writer, clock: clockMock, events: eventsMock, namespace: "ecosystem"}
+
+		// when
+		sut.Record(grpcContext("/doguAdministration.DoguAdministration/RestartDogu"), Entry{
+			Action:   "RestartDogu",
+			Resource: DoguResource("redmine"),
+			Started:  testTime,
+			Err:      assert.AnError,
+		})
+
+		// then
+		assert.NotEmpty(t, writer.String())
+	})
+	t.Run("should not mirror records of other resources as event", func(t *testing.T) {
+		// given
+		clockMock := newMockNowClock(t)
+		clockMock.EXPECT().Now().Return(testTime)
+		eventsMock := newMockEventCreator(t)
+		sut := &Logger{writer: &bytes.Buffer{}, clock: clockMock, events: eventsMock, namespace: "ecosystem"}
+
+		// when
+		sut.Record(context.Background(), Entry{Action: "EnableDebugMode", Resource: Resource{Kind: KindDebugMode, Name: "debug-mode"}, Started: testTime})
+
+		// then
+		eventsMock.AssertNotCalled(t, "Create")
+	})
+	t.Run("should ignore event errors", func(t *testing.T) {
+		// given
+		clockMock := newMockNowClock(t)
+		clockMock.EXPECT().Now().Return(testTime)
+		eventsMock := newMockEventCreator(t)
+		eventsMock.EXPECT().Create(mock.Anything, mock.Anything, metav1.CreateOptions{}).Return(nil, assert.AnError)
+		writer := &bytes.Buffer{}
+		sut := &Logger{writer: writer, clock: clockMock, events: eventsMock, namespace: "ecosystem"}
+
+		// when
+		sut.Record(context.Background(), Entry{Action: "CreateBackup", Resource: BackupResource("backup-1"), Started: testTime})
+
+		// then
+		assert.Contains(t, writer.String(), `"outcome":"success"`)
+	})
+}
+
+func TestCaller(t *testing.T) {
+	t.Run("should return the name of the authenticated caller", func(t *testing.T) {
+		// given
+		ctx := auth.ContextWithIdentity(grpcContext("/maintenance.DebugMode/Enable"), auth.Identity{Name: "admin-dogu"})
+
+		// when
+		actual := Caller(ctx)
+
+		// then
+		assert.Equal(t, "admin-dogu", actual)
+	})
+	t.Run("should return anonymous for grpc calls without identity", func(t *testing.T) {
+		// when
+		actual := Caller(grpcContext("/maintenance.DebugMode/Enable"))
+
+		// then
+		assert.Equal(t, "anonymous", actual)
+	})
+	t.Run("should return k8s-ces-control without grpc call", func(t *testing.T) {
+		// when
+		actual := Caller(context.Background())
+
+		// then
+		assert.Equal(t, "k8s-ces-control", actual)
+	})
+}
+
+func grpcContext(method string) context.Context {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4711}})
+	return grpc.NewContextWithServerTransportStream(ctx, &testTransportStream{method: method})
+}
+
+type testTransportStream struct {
+	grpc.ServerTransportStream
+	method string
+}
+
+func (s *testTransportStream) Method() string {
+	return s.method
+}
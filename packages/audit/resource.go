@@ -0,0 +1,42 @@
+package audit
+
+const (
+	KindDogu           = "Dogu"
+	KindBackup         = "Backup"
+	KindBackupSchedule = "BackupSchedule"
+	KindDebugMode      = "DebugMode"
+	KindSupportArchive = "SupportArchive"
+)
+
+// Resource identifies the resource affected by an audited action.
+type Resource struct {
+	Kind string
+	Name string
+}
+
+// DoguResource returns the resource of the dogu with the given name.
+func DoguResource(name string) Resource {
+	return Resource{Kind: KindDogu, Name: name}
+}
+
+// BackupResource returns the resource of the backup with the given name.
+func BackupResource(name string) Resource {
+	return Resource{Kind: KindBackup, Name: name}
+}
+
+// apiVersion returns the api version of resources for which kubernetes events are created. Other resources return
+// an empty string.
+func (r Resource) apiVersion() string {
+	if r.Name == "" {
+		return ""
+	}
+
+	switch r.Kind {
+	case KindDogu:
+		return "k8s.cloudogu.com/v2"
+	case KindBackup:
+		return "k8s.cloudogu.com/v1"
+	default:
+		return ""
+	}
+}
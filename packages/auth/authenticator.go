@@ -0,0 +1,255 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "bearer "
+)
+
+var reloadInterval = time.Second * 30
+
+var errMissingCredentials = errors.New("no bearer token or client certificate found")
+
+// fileConfig is the structure of the mounted auth configuration file.
+type fileConfig struct {
+	Tokens       []tokenConfig       `yaml:"tokens"`
+	Certificates []certificateConfig `yaml:"certificates"`
+}
+
+type tokenConfig struct {
+	Subject string `yaml:"subject"`
+	Token   string `yaml:"token"`
+	Role    string `yaml:"role"`
+}
+
+type certificateConfig struct {
+	CommonName string `yaml:"commonName"`
+	Role       string `yaml:"role"`
+}
+
+type tokenIdentity struct {
+	token    []byte
+	identity Identity
+}
+
+// Authenticator maps bearer tokens and client certificates to identities. The configuration file is checked
+// periodically so that rotated tokens are used without restarting the pod.
+type Authenticator struct {
+	configFile string
+
+	mutex        sync.RWMutex
+	tokens       []tokenIdentity
+	certificates map[string]Identity
+	loadedFile   []byte
+}
+
+// NewAuthenticator creates a new Authenticator and loads the given configuration file initially.
+func NewAuthenticator(configFile string) (*Authenticator, error) {
+	a := &Authenticator{configFile: configFile}
+
+	_, err := a.reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Watch checks the configuration file for changes until the given context is done.
+func (a *Authenticator) Watch(ctx context.Context) {
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := a.reload()
+			if err != nil {
+				logrus.Error(fmt.Errorf("failed to reload auth configuration, keep using the previous one: %w", err))
+				continue
+			}
+			if reloaded {
+				logrus.Info("Reloaded auth configuration")
+			}
+		}
+	}
+}
+
+// Authenticate returns the identity of the caller. A bearer token takes precedence over a client certificate.
+func (a *Authenticator) Authenticate(ctx context.Context) (Identity, error) {
+	token, hasToken, err := bearerTokenFromContext(ctx)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	if hasToken {
+		return a.authenticateToken(token)
+	}
+
+	commonName, hasCertificate := clientCertificateCommonName(ctx)
+	if hasCertificate {
+		return a.authenticateCertificate(commonName)
+	}
+
+	return Identity{}, errMissingCredentials
+}
+
+func (a *Authenticator) authenticateToken(token string) (Identity, error) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare(t.token, []byte(token)) == 1 {
+			return t.identity, nil
+		}
+	}
+
+	return Identity{}, errors.New("invalid bearer token")
+}
+
+func (a *Authenticator) authenticateCertificate(commonName string) (Identity, error) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	identity, ok := a.certificates[commonName]
+	if !ok {
+		return Identity{}, fmt.Errorf("no role configured for client certificate %q", commonName)
+	}
+
+	return identity, nil
+}
+
+func bearerTokenFromContext(ctx context.Context) (string, bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false, nil
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return "", false, nil
+	}
+
+	if !strings.HasPrefix(strings.ToLower(values[0]), bearerPrefix) {
+		return "", false, errors.New("authorization header must use the bearer scheme")
+	}
+
+	return strings.TrimSpace(values[0][len(bearerPrefix):]), true, nil
+}
+
+// clientCertificateCommonName returns the common name of the verified client certificate.
+func clientCertificateCommonName(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return "", false
+	}
+
+	chains := tlsInfo.State.VerifiedChains
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return "", false
+	}
+
+	return chains[0][0].Subject.CommonName, true
+}
+
+// reload reads the configuration file and replaces the current configuration if the file has changed.
+func (a *Authenticator) reload() (bool, error) {
+	content, err := os.ReadFile(a.configFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read auth configuration file %s: %w", a.configFile, err)
+	}
+
+	a.mutex.RLock()
+	unchanged := a.loadedFile != nil && bytes.Equal(a.loadedFile, content)
+	a.mutex.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	tokens, certificates, err := parseConfig(content)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse auth configuration file %s: %w", a.configFile, err)
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.tokens = tokens
+	a.certificates = certificates
+	a.loadedFile = content
+
+	return true, nil
+}
+
+func parseConfig(content []byte) ([]tokenIdentity, map[string]Identity, error) {
+	cfg := fileConfig{}
+	err := yaml.Unmarshal(content, &cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var multiError error
+	tokens := make([]tokenIdentity, 0, len(cfg.Tokens))
+	for i, t := range cfg.Tokens {
+		if t.Subject == "" || t.Token == "" {
+			multiError = errors.Join(multiError, fmt.Errorf("token %d: subject and token must not be empty", i))
+			continue
+		}
+
+		role, err := ParseRole(t.Role)
+		if err != nil {
+			multiError = errors.Join(multiError, fmt.Errorf("token %q: %w", t.Subject, err))
+			continue
+		}
+
+		tokens = append(tokens, tokenIdentity{
+			token:    []byte(t.Token),
+			identity: Identity{Name: t.Subject, Role: role, AuthMethod: authMethodToken},
+		})
+	}
+
+	certificates := make(map[string]Identity, len(cfg.Certificates))
+	for i, c := range cfg.Certificates {
+		if c.CommonName == "" {
+			multiError = errors.Join(multiError, fmt.Errorf("certificate %d: common name must not be empty", i))
+			continue
+		}
+
+		role, err := ParseRole(c.Role)
+		if err != nil {
+			multiError = errors.Join(multiError, fmt.Errorf("certificate %q: %w", c.CommonName, err))
+			continue
+		}
+
+		certificates[c.CommonName] = Identity{Name: c.CommonName, Role: role, AuthMethod: authMethodCertificate}
+	}
+
+	if multiError != nil {
+		return nil, nil, multiError
+	}
+
+	return tokens, certificates, nil
+}
@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const testConfig = `
+tokens:
+  - subject: admin-dogu
+    token: admin-token
+    role: admin
+  - subject: monitoring
+    token: viewer-token
+    role: viewer
+certificates:
+  - commonName: operator-client
+    role: operator
+`
+
+func TestNewAuthenticator(t *testing.T) {
+	t.Run("should load tokens and certificates", func(t *testing.T) {
+		// given
+		configFile := writeConfig(t, t.TempDir(), testConfig)
+
+		// when
+		authenticator, err := NewAuthenticator(configFile)
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, authenticator.tokens, 2)
+		assert.Equal(t, Identity{Name: "operator-client", Role: RoleOperator, AuthMethod: "certificate"}, authenticator.certificates["operator-client"])
+	})
+	t.Run("should fail if file does not exist", func(t *testing.T) {
+		// when
+		_, err := NewAuthenticator(filepath.Join(t.TempDir(), "auth.yaml"))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read auth configuration file")
+	})
+	t.Run("should report all invalid entries", func(t *testing.T) {
+		// given
+		configFile := writeConfig(t, t.TempDir(), `
+tokens:
+  - subject: a
+    token: b
+    role: banana
+  - subject: c
+certificates:
+  - role: admin
+`)
+
+		// when
+		_, err := NewAuthenticator(configFile)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "token \"a\": unknown role \"banana\"")
+		assert.ErrorContains(t, err, "token 1: subject and token must not be empty")
+		assert.ErrorContains(t, err, "certificate 0: common name must not be empty")
+	})
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	authenticator, err := NewAuthenticator(writeConfig(t, t.TempDir(), testConfig))
+	require.NoError(t, err)
+
+	t.Run("should authenticate bearer token", func(t *testing.T) {
+		// given
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer admin-token"))
+
+		// when
+		identity, err := authenticator.Authenticate(ctx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Identity{Name: "admin-dogu", Role: RoleAdmin, AuthMethod: "token"}, identity)
+	})
+	t.Run("should fail for invalid bearer token", func(t *testing.T) {
+		// given
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer wrong"))
+
+		// when
+		_, err := authenticator.Authenticate(ctx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid bearer token")
+	})
+	t.Run("should fail for other authorization scheme", func(t *testing.T) {
+		// given
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic YTpi"))
+
+		// when
+		_, err := authenticator.Authenticate(ctx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "authorization header must use the bearer scheme")
+	})
+	t.Run("should authenticate client certificate", func(t *testing.T) {
+		// given
+		ctx := contextWithClientCertificate("operator-client")
+
+		// when
+		identity, err := authenticator.Authenticate(ctx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Identity{Name: "operator-client", Role: RoleOperator, AuthMethod: "certificate"}, identity)
+	})
+	t.Run("should fail for unknown client certificate", func(t *testing.T) {
+		// given
+		ctx := contextWithClientCertificate("somebody")
+
+		// when
+		_, err := authenticator.Authenticate(ctx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "no role configured for client certificate \"somebody\"")
+	})
+	t.Run("should fail without credentials", func(t *testing.T) {
+		// when
+		_, err := authenticator.Authenticate(context.Background())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errMissingCredentials)
+	})
+}
+
+func TestAuthenticator_Watch(t *testing.T) {
+	t.Run("should reload rotated tokens until context is cancelled", func(t *testing.T) {
+		// given
+		previousInterval := reloadInterval
+		reloadInterval = 10 * time.Millisecond
+		defer func() { reloadInterval = previousInterval }()
+
+		dir := t.TempDir()
+		authenticator, err := NewAuthenticator(writeConfig(t, dir, testConfig))
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		tokenCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer rotated"))
+
+		// when
+		go authenticator.Watch(ctx)
+		writeConfig(t, dir, "tokens:\n  - subject: admin-dogu\n    token: rotated\n    role: admin\n")
+
+		// then
+		assert.Eventually(t, func() bool {
+			_, err := authenticator.Authenticate(tokenCtx)
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+	})
+	t.Run("should keep previous configuration if rotated file is invalid", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		authenticator, err := NewAuthenticator(writeConfig(t, dir, testConfig))
+		require.NoError(t, err)
+		writeConfig(t, dir, "tokens: [")
+
+		// when
+		reloaded, err := authenticator.reload()
+
+		// then
+		require.Error(t, err)
+		assert.False(t, reloaded)
+		assert.Len(t, authenticator.tokens, 2)
+	})
+}
+
+func writeConfig(t *testing.T, dir string, content string) string {
+	t.Helper()
+	configFile := filepath.Join(dir, "auth.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(content), 0600))
+	return configFile
+}
+
+func contextWithClientCertificate(commonName string) context.Context {
+	certificate := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{certificate}},
+		}},
+	})
+}
@@ -0,0 +1,31 @@
+package auth
+
+import "context"
+
+const (
+	authMethodToken       = "token"
+	authMethodCertificate = "certificate"
+)
+
+// Identity describes an authenticated caller.
+type Identity struct {
+	// Name is the subject of the token or the common name of the client certificate.
+	Name string
+	// Role is the role mapped to the caller.
+	Role Role
+	// AuthMethod is the method the caller used to authenticate, either "token" or "certificate".
+	AuthMethod string
+}
+
+type identityContextKey struct{}
+
+// ContextWithIdentity returns a copy of the context containing the given identity.
+func ContextWithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// IdentityFromContext returns the identity of the caller if the request was authenticated.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityContextKey{}).(Identity)
+	return identity, ok
+}
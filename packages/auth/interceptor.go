@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type authenticator interface {
+	// Authenticate returns the identity of the caller.
+	Authenticate(ctx context.Context) (Identity, error)
+}
+
+// Authorize authenticates the caller and checks whether the caller's role permits calling the given fully qualified
+// grpc method. It returns a context containing the identity of the caller.
+func Authorize(ctx context.Context, authenticator authenticator, fullMethod string) (context.Context, error) {
+	required := RequiredRole(fullMethod)
+	if required == RoleNone {
+		return ctx, nil
+	}
+
+	identity, err := authenticator.Authenticate(ctx)
+	if err != nil {
+		logrus.Warnf("denied unauthenticated call to %s: %v", fullMethod, err)
+		return ctx, status.Errorf(codes.Unauthenticated, "authentication failed: %v", err)
+	}
+
+	if !identity.Role.includes(required) {
+		logrus.Warnf("denied call to %s for %q with role %s; required role is %s", fullMethod, identity.Name, identity.Role, required)
+		return ctx, status.Errorf(codes.PermissionDenied, "role %s is not allowed to call %s", identity.Role, fullMethod)
+	}
+
+	return ContextWithIdentity(ctx, identity), nil
+}
+
+// UnaryServerInterceptor authorizes every unary call.
+func UnaryServerInterceptor(authenticator authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		authorizedCtx, err := Authorize(ctx, authenticator, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(authorizedCtx, req)
+	}
+}
+
+// StreamServerInterceptor authorizes every streaming call.
+func StreamServerInterceptor(authenticator authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		authorizedCtx, err := Authorize(ss.Context(), authenticator, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authorizedServerStream{ServerStream: ss, ctx: authorizedCtx})
+	}
+}
+
+// authorizedServerStream overrides the context of the wrapped stream with the context containing the identity.
+type authorizedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context containing the identity of the caller.
+func (s *authorizedServerStream) Context() context.Context {
+	return s.ctx
+}
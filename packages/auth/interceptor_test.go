@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testCtx = context.Background()
+
+func TestAuthorize(t *testing.T) {
+	t.Run("should not authenticate public methods", func(t *testing.T) {
+		// given
+		authenticatorMock := newMockAuthenticator(t)
+
+		// when
+		ctx, err := Authorize(testCtx, authenticatorMock, "/grpc.health.v1.Health/Check")
+
+		// then
+		require.NoError(t, err)
+		_, ok := IdentityFromContext(ctx)
+		assert.False(t, ok)
+	})
+	t.Run("should add identity to context", func(t *testing.T) {
+		// given
+		identity := Identity{Name: "admin-dogu", Role: RoleAdmin, AuthMethod: "token"}
+		authenticatorMock := newMockAuthenticator(t)
+		authenticatorMock.EXPECT().Authenticate(testCtx).Return(identity, nil)
+
+		// when
+		ctx, err := Authorize(testCtx, authenticatorMock, "/backup.BackupManagement/DeleteBackup")
+
+		// then
+		require.NoError(t, err)
+		actual, ok := IdentityFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, identity, actual)
+	})
+	t.Run("should fail with unauthenticated", func(t *testing.T) {
+		// given
+		authenticatorMock := newMockAuthenticator(t)
+		authenticatorMock.EXPECT().Authenticate(testCtx).Return(Identity{}, assert.AnError)
+
+		// when
+		_, err := Authorize(testCtx, authenticatorMock, "/health.DoguHealth/GetAll")
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+	t.Run("should fail with permission denied for insufficient role", func(t *testing.T) {
+		// given
+		authenticatorMock := newMockAuthenticator(t)
+		authenticatorMock.EXPECT().Authenticate(testCtx).Return(Identity{Name: "monitoring", Role: RoleViewer}, nil)
+
+		// when
+		_, err := Authorize(testCtx, authenticatorMock, "/doguAdministration.DoguAdministration/StopDogu")
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.ErrorContains(t, err, "role viewer is not allowed to call /doguAdministration.DoguAdministration/StopDogu")
+	})
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Run("should call handler with identity", func(t *testing.T) {
+		// given
+		authenticatorMock := newMockAuthenticator(t)
+		authenticatorMock.EXPECT().Authenticate(testCtx).Return(Identity{Name: "operator", Role: RoleOperator}, nil)
+		info := &grpc.UnaryServerInfo{FullMethod: "/doguAdministration.DoguAdministration/RestartDogu"}
+		handler := func(ctx context.Context, req any) (any, error) {
+			identity, _ := IdentityFromContext(ctx)
+			return identity.Name, nil
+		}
+
+		// when
+		resp, err := UnaryServerInterceptor(authenticatorMock)(testCtx, "request", info, handler)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "operator", resp)
+	})
+	t.Run("should not call handler if permission is denied", func(t *testing.T) {
+		// given
+		authenticatorMock := newMockAuthenticator(t)
+		authenticatorMock.EXPECT().Authenticate(testCtx).Return(Identity{Name: "operator", Role: RoleOperator}, nil)
+		info := &grpc.UnaryServerInfo{FullMethod: "/maintenance.DebugMode/Enable"}
+		handler := func(ctx context.Context, req any) (any, error) {
+			t.Fatal("handler must not be called")
+			return nil, nil
+		}
+
+		// when
+		_, err := UnaryServerInterceptor(authenticatorMock)(testCtx, "request", info, handler)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	t.Run("should call handler with stream containing identity", func(t *testing.T) {
+		// given
+		authenticatorMock := newMockAuthenticator(t)
+		authenticatorMock.EXPECT().Authenticate(testCtx).Return(Identity{Name: "monitoring", Role: RoleViewer}, nil)
+		info := &grpc.StreamServerInfo{FullMethod: "/logging.DoguLogMessages/QueryForDogu"}
+		var actual Identity
+		handler := func(srv any, stream grpc.ServerStream) error {
+			actual, _ = IdentityFromContext(stream.Context())
+			return nil
+		}
+
+		// when
+		err := StreamServerInterceptor(authenticatorMock)(nil, &testServerStream{ctx: testCtx}, info, handler)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "monitoring", actual.Name)
+	})
+	t.Run("should not call handler if permission is denied", func(t *testing.T) {
+		// given
+		authenticatorMock := newMockAuthenticator(t)
+		authenticatorMock.EXPECT().Authenticate(testCtx).Return(Identity{Name: "monitoring", Role: RoleViewer}, nil)
+		info := &grpc.StreamServerInfo{FullMethod: "/maintenance.SupportArchive/DownloadSupportArchive"}
+		handler := func(srv any, stream grpc.ServerStream) error {
+			t.Fatal("handler must not be called")
+			return nil
+		}
+
+		// when
+		err := StreamServerInterceptor(authenticatorMock)(nil, &testServerStream{ctx: testCtx}, info, handler)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
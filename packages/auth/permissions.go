@@ -0,0 +1,67 @@
+package auth
+
+// methodPermissions maps the fully qualified grpc methods to the minimal role required to call them.
+// Methods missing in this table require the admin role.
+var methodPermissions = map[string]Role{
+	// probes and service discovery
+	"/grpc.health.v1.Health/Check":                                   RoleNone,
+	"/grpc.health.v1.Health/List":                                    RoleNone,
+	"/grpc.health.v1.Health/Watch":                                   RoleNone,
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      RoleViewer,
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": RoleViewer,
+
+	// dogu administration
+	"/doguAdministration.DoguAdministration/GetDoguList":    RoleViewer,
+	"/doguAdministration.DoguAdministration/GetBlueprintId": RoleViewer,
+	"/doguAdministration.DoguAdministration/StartDogu":      RoleOperator,
+	"/doguAdministration.DoguAdministration/StopDogu":       RoleOperator,
+	"/doguAdministration.DoguAdministration/RestartDogu":    RoleOperator,
+
+	// dogu health
+	"/health.DoguHealth/GetByName":  RoleViewer,
+	"/health.DoguHealth/GetByNames": RoleViewer,
+	"/health.DoguHealth/GetAll":     RoleViewer,
+
+	// logging
+	"/logging.DoguLogMessages/GetForDogu":               RoleViewer,
+	"/logging.DoguLogMessages/QueryForDogu":             RoleViewer,
+	"/logging.DoguLogMessages/FollowForDogu":            RoleViewer,
+	"/logging.DoguLogMessages/GetLogStatistics":         RoleViewer,
+	"/logging.DoguLogMessages/GetLogVolumeReport":       RoleViewer,
+	"/logging.DoguLogMessages/ApplyLogLevelWithRestart": RoleOperator,
+	"/logging.DoguLogMessages/GetLoggers":               RoleViewer,
+	"/logging.DoguLogMessages/SetLoggerLevel":           RoleOperator,
+	"/logging.DoguLogMessages/ApplyLogLevels":           RoleOperator,
+
+	// debug mode
+	"/maintenance.DebugMode/Status":     RoleViewer,
+	"/maintenance.DebugMode/GetHistory": RoleViewer,
+	"/maintenance.DebugMode/Enable":     RoleAdmin,
+	"/maintenance.DebugMode/Disable":    RoleAdmin,
+
+	// support archives
+	"/maintenance.SupportArchive/AllSupportArchives":     RoleViewer,
+	"/maintenance.SupportArchive/Create":                 RoleAdmin,
+	"/maintenance.SupportArchive/DownloadSupportArchive": RoleAdmin,
+	"/maintenance.SupportArchive/DeleteSupportArchive":   RoleAdmin,
+
+	// backup
+	"/backup.BackupManagement/AllBackups":         RoleViewer,
+	"/backup.BackupManagement/AllRestores":        RoleViewer,
+	"/backup.BackupManagement/GetSchedule":        RoleViewer,
+	"/backup.BackupManagement/GetRetentionPolicy": RoleViewer,
+	"/backup.BackupManagement/CreateBackup":       RoleAdmin,
+	"/backup.BackupManagement/DeleteBackup":       RoleAdmin,
+	"/backup.BackupManagement/CreateRestore":      RoleAdmin,
+	"/backup.BackupManagement/SetSchedule":        RoleAdmin,
+}
+
+// RequiredRole returns the minimal role required to call the given fully qualified grpc method.
+func RequiredRole(fullMethod string) Role {
+	role, ok := methodPermissions[fullMethod]
+	if !ok {
+		return RoleAdmin
+	}
+
+	return role
+}
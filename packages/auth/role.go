@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Role defines which methods an authenticated caller may use. Roles are ordered, a higher role includes the
+// permissions of all lower roles.
+type Role int
+
+const (
+	// RoleNone is used for methods that can be called without authentication.
+	RoleNone Role = iota
+	// RoleViewer may read information like dogu lists, health states and logs.
+	RoleViewer
+	// RoleOperator may additionally start, stop and restart dogus and change log levels.
+	RoleOperator
+	// RoleAdmin may additionally manage backups, restores, support archives and the debug mode.
+	RoleAdmin
+)
+
+// String converts the Role to a string.
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleOperator:
+		return "operator"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return "none"
+	}
+}
+
+// ParseRole maps a string to a Role.
+func ParseRole(role string) (Role, error) {
+	switch strings.ToLower(strings.TrimSpace(role)) {
+	case RoleViewer.String():
+		return RoleViewer, nil
+	case RoleOperator.String():
+		return RoleOperator, nil
+	case RoleAdmin.String():
+		return RoleAdmin, nil
+	default:
+		return RoleNone, fmt.Errorf("unknown role %q, valid roles are [viewer, operator, admin]", role)
+	}
+}
+
+// includes returns true if the role grants at least the permissions of the required role.
+func (r Role) includes(required Role) bool {
+	return r >= required
+}
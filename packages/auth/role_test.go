@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRole(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Role
+		wantErr bool
+	}{
+		{input: "viewer", want: RoleViewer},
+		{input: "Operator", want: RoleOperator},
+		{input: " ADMIN ", want: RoleAdmin},
+		{input: "none", want: RoleNone, wantErr: true},
+		{input: "banana", want: RoleNone, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRole(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, "unknown role")
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRole_includes(t *testing.T) {
+	assert.True(t, RoleAdmin.includes(RoleOperator))
+	assert.True(t, RoleOperator.includes(RoleOperator))
+	assert.True(t, RoleViewer.includes(RoleNone))
+	assert.False(t, RoleViewer.includes(RoleOperator))
+	assert.False(t, RoleOperator.includes(RoleAdmin))
+}
+
+func TestRequiredRole(t *testing.T) {
+	assert.Equal(t, RoleNone, RequiredRole("/grpc.health.v1.Health/Check"))
+	assert.Equal(t, RoleViewer, RequiredRole("/doguAdministration.DoguAdministration/GetDoguList"))
+	assert.Equal(t, RoleOperator, RequiredRole("/doguAdministration.DoguAdministration/StopDogu"))
+	assert.Equal(t, RoleAdmin, RequiredRole("/backup.BackupManagement/DeleteBackup"))
+	assert.Equal(t, RoleAdmin, RequiredRole("/unknown.Service/Method"))
+}
@@ -6,6 +6,7 @@ import (
 	backupClientV1 "github.com/cloudogu/k8s-backup-lib/api/ecosystem"
 	backupV1 "github.com/cloudogu/k8s-backup-lib/api/v1"
 	v3 "github.com/cloudogu/k8s-blueprint-lib/v3/api/v3"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
 	componentV1 "github.com/cloudogu/k8s-component-lib/api/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,3 +43,8 @@ type componentClient interface {
 type cronJobClient interface {
 	Get(ctx context.Context, name string, opts metav1.GetOptions) (*batchv1.CronJob, error)
 }
+
+type auditLogger interface {
+	// Record writes the given entry to the audit log.
+	Record(ctx context.Context, entry audit.Entry)
+}
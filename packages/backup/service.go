@@ -10,6 +10,7 @@ import (
 	pbBackup "github.com/cloudogu/ces-control-api/generated/backup"
 	v1 "github.com/cloudogu/k8s-backup-lib/api/v1"
 	v3 "github.com/cloudogu/k8s-blueprint-lib/v3/api/v3"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -40,10 +41,11 @@ type DefaultBackupService struct {
 	componentClient      componentClient
 	blueprintLister      blueprintLister
 	cronJobClient        cronJobClient
+	auditLogger          auditLogger
 }
 
 // NewBackupService returns an instance of defaultBackupService.
-func NewBackupService(backupClient backupInterface, restoreClient restoreInterface, backupScheduleClient backupScheduleClient, componentClient componentClient, blueprintLister blueprintLister, cronJobClient cronJobClient) *DefaultBackupService {
+func NewBackupService(backupClient backupInterface, restoreClient restoreInterface, backupScheduleClient backupScheduleClient, componentClient componentClient, blueprintLister blueprintLister, cronJobClient cronJobClient, auditLogger auditLogger) *DefaultBackupService {
 	return &DefaultBackupService{
 		backupClient:         backupClient,
 		restoreClient:        restoreClient,
@@ -51,11 +53,14 @@ func NewBackupService(backupClient backupInterface, restoreClient restoreInterfa
 		componentClient:      componentClient,
 		blueprintLister:      blueprintLister,
 		cronJobClient:        cronJobClient,
+		auditLogger:          auditLogger,
 	}
 }
 
 func (s *DefaultBackupService) DeleteBackup(ctx context.Context, req *pbBackup.DeleteBackupRequest) (*pbBackup.DeleteBackupResponse, error) {
+	started := time.Now()
 	err := s.backupClient.Delete(ctx, req.Name, metav1.DeleteOptions{})
+	s.auditLogger.Record(ctx, audit.Entry{Action: "DeleteBackup", Resource: audit.BackupResource(req.Name), Started: started, Err: err})
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete backup: %w", err)
 	}
@@ -63,7 +68,8 @@ func (s *DefaultBackupService) DeleteBackup(ctx context.Context, req *pbBackup.D
 }
 
 func (s *DefaultBackupService) CreateBackup(ctx context.Context, _ *pbBackup.CreateBackupRequest) (*pbBackup.CreateBackupResponse, error) {
-	timestamp := time.Now().Format("20060102-1504")
+	started := time.Now()
+	timestamp := started.Format("20060102-1504")
 	backupName := fmt.Sprintf("backup-%s", timestamp)
 	backup := &v1.Backup{
 		ObjectMeta: metav1.ObjectMeta{
@@ -74,6 +80,7 @@ func (s *DefaultBackupService) CreateBackup(ctx context.Context, _ *pbBackup.Cre
 		},
 	}
 	_, err := s.backupClient.Create(ctx, backup, metav1.CreateOptions{})
+	s.auditLogger.Record(ctx, audit.Entry{Action: "CreateBackup", Resource: audit.BackupResource(backupName), Started: started, Err: err})
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +109,14 @@ func (s *DefaultBackupService) AllBackups(ctx context.Context, _ *pbBackup.GetAl
 // CreateRestore creates a restore for the given backup.
 // The restore is only created if the backup is restorable.
 func (s *DefaultBackupService) CreateRestore(ctx context.Context, request *pbBackup.CreateRestoreRequest) (*pbBackup.CreateRestoreResponse, error) {
+	started := time.Now()
+	response, err := s.createRestore(ctx, request)
+	s.auditLogger.Record(ctx, audit.Entry{Action: "CreateRestore", Resource: audit.BackupResource(request.BackupId), Started: started, Err: err})
+
+	return response, err
+}
+
+func (s *DefaultBackupService) createRestore(ctx context.Context, request *pbBackup.CreateRestoreRequest) (*pbBackup.CreateRestoreResponse, error) {
 	backup, err := s.backupClient.Get(ctx, request.BackupId, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get backup: %w", err)
@@ -223,7 +238,15 @@ func (s *DefaultBackupService) GetSchedule(ctx context.Context, _ *pbBackup.GetB
 }
 
 func (s *DefaultBackupService) SetSchedule(ctx context.Context, req *pbBackup.SetBackupScheduleRequest) (*pbBackup.SetBackupScheduleResponse, error) {
+	started := time.Now()
 	err := setBackupSchedule(ctx, s.backupScheduleClient, req.Schedule)
+	s.auditLogger.Record(ctx, audit.Entry{
+		Action:     "SetSchedule",
+		Resource:   audit.Resource{Kind: audit.KindBackupSchedule, Name: backupScheduleName},
+		Parameters: map[string]string{"schedule": req.Schedule},
+		Started:    started,
+		Err:        err,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set backup schedule: %w", err)
 	}
@@ -2,11 +2,13 @@ package backup
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/cloudogu/ces-control-api/generated/backup"
 	backupV1 "github.com/cloudogu/k8s-backup-lib/api/v1"
 	v3 "github.com/cloudogu/k8s-blueprint-lib/v3/api/v3"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
 	componentV1 "github.com/cloudogu/k8s-component-lib/api/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -361,8 +363,14 @@ func TestDefaultBackupService_SetSchedule(t *testing.T) {
 		mBackupScheduleClient.EXPECT().Get(testCtx, "ces-schedule", metav1.GetOptions{}).Return(nil, k8sErrors.NewNotFound(schema.GroupResource{}, "not found"))
 		mBackupScheduleClient.EXPECT().Create(testCtx, expectedSchedule, metav1.CreateOptions{}).Return(expectedSchedule, nil)
 
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "SetSchedule" && entry.Parameters["schedule"] == "* 2 3 * *" && entry.Err == nil
+		})).Return()
+
 		svc := &DefaultBackupService{
 			backupScheduleClient: mBackupScheduleClient,
+			auditLogger:          auditLoggerMock,
 		}
 
 		response, err := svc.SetSchedule(testCtx, &backup.SetBackupScheduleRequest{Schedule: "* 2 3 * *"})
@@ -375,8 +383,14 @@ func TestDefaultBackupService_SetSchedule(t *testing.T) {
 		mBackupScheduleClient := newMockBackupScheduleClient(t)
 		mBackupScheduleClient.EXPECT().Get(testCtx, "ces-schedule", metav1.GetOptions{}).Return(nil, assert.AnError)
 
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "SetSchedule" && errors.Is(entry.Err, assert.AnError)
+		})).Return()
+
 		svc := &DefaultBackupService{
 			backupScheduleClient: mBackupScheduleClient,
+			auditLogger:          auditLoggerMock,
 		}
 
 		_, err := svc.SetSchedule(testCtx, &backup.SetBackupScheduleRequest{Schedule: "* 2 3 * *"})
@@ -534,10 +548,15 @@ func Test_createBackups(t *testing.T) {
 		}
 
 		backupClientMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).Return(&backupOne, nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "CreateBackup" && entry.Resource.Kind == audit.KindBackup && entry.Err == nil
+		})).Return()
 
 		sut := DefaultBackupService{
 			backupClient:  backupClientMock,
 			restoreClient: nil,
+			auditLogger:   auditLoggerMock,
 		}
 
 		// when
@@ -551,10 +570,15 @@ func Test_createBackups(t *testing.T) {
 		backupClientMock := newMockBackupInterface(t)
 
 		backupClientMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).Return(nil, assert.AnError)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "CreateBackup" && entry.Resource.Kind == audit.KindBackup && errors.Is(entry.Err, assert.AnError)
+		})).Return()
 
 		sut := DefaultBackupService{
 			backupClient:  backupClientMock,
 			restoreClient: nil,
+			auditLogger:   auditLoggerMock,
 		}
 
 		// when
@@ -657,10 +681,15 @@ func Test_deleteBackups(t *testing.T) {
 		backupClientMock := newMockBackupInterface(t)
 
 		backupClientMock.EXPECT().Delete(testCtx, mock.Anything, metav1.DeleteOptions{}).Return(nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "DeleteBackup" && entry.Resource == audit.BackupResource("backup_one") && entry.Err == nil
+		})).Return()
 
 		sut := DefaultBackupService{
 			backupClient:  backupClientMock,
 			restoreClient: nil,
+			auditLogger:   auditLoggerMock,
 		}
 
 		// when
@@ -674,10 +703,15 @@ func Test_deleteBackups(t *testing.T) {
 		backupClientMock := newMockBackupInterface(t)
 
 		backupClientMock.EXPECT().Delete(testCtx, mock.Anything, metav1.DeleteOptions{}).Return(assert.AnError)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "DeleteBackup" && entry.Resource == audit.BackupResource("backup_one") && errors.Is(entry.Err, assert.AnError)
+		})).Return()
 
 		sut := DefaultBackupService{
 			backupClient:  backupClientMock,
 			restoreClient: nil,
+			auditLogger:   auditLoggerMock,
 		}
 
 		// when
@@ -686,3 +720,28 @@ func Test_deleteBackups(t *testing.T) {
 		require.ErrorIs(t, err, assert.AnError)
 	})
 }
+
+func Test_createRestore(t *testing.T) {
+	t.Run("should record failed restore in audit log", func(t *testing.T) {
+		// given
+		testCtx := context.TODO()
+		backupClientMock := newMockBackupInterface(t)
+		backupClientMock.EXPECT().Get(testCtx, "backup_one", metav1.GetOptions{}).Return(nil, assert.AnError)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "CreateRestore" && entry.Resource == audit.BackupResource("backup_one") && errors.Is(entry.Err, assert.AnError)
+		})).Return()
+
+		sut := DefaultBackupService{
+			backupClient: backupClientMock,
+			auditLogger:  auditLoggerMock,
+		}
+
+		// when
+		_, err := sut.CreateRestore(testCtx, &backup.CreateRestoreRequest{BackupId: "backup_one"})
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get backup")
+	})
+}
@@ -0,0 +1,177 @@
+package certificate
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var reloadInterval = time.Second * 30
+
+// Reloader provides the server certificate and the trusted client CAs for TLS connections. It checks the mounted
+// files periodically and replaces the certificates when the files change so that rotated secrets are used without
+// restarting the pod.
+type Reloader struct {
+	certFile          string
+	keyFile           string
+	clientCAFile      string
+	requireClientCert bool
+
+	mutex       sync.RWMutex
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+	loadedFiles [][]byte
+}
+
+// NewReloader creates a new Reloader and loads the certificates initially. The client CA file is optional unless
+// client certificates are required.
+func NewReloader(certFile string, keyFile string, clientCAFile string, requireClientCert bool) (*Reloader, error) {
+	if requireClientCert && clientCAFile == "" {
+		return nil, fmt.Errorf("a client CA file is required when client certificates are required")
+	}
+
+	r := &Reloader{
+		certFile:          certFile,
+		keyFile:           keyFile,
+		clientCAFile:      clientCAFile,
+		requireClientCert: requireClientCert,
+	}
+
+	_, err := r.reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// TLSConfig returns a tls.Config which always uses the latest loaded certificates.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.getConfigForClient,
+	}
+}
+
+// Watch checks the certificate files for changes until the given context is done.
+func (r *Reloader) Watch(ctx context.Context) {
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.reload()
+			if err != nil {
+				logrus.Error(fmt.Errorf("failed to reload tls certificates, keep using the previous ones: %w", err))
+				continue
+			}
+			if reloaded {
+				logrus.Info("Reloaded tls certificates")
+			}
+		}
+	}
+}
+
+func (r *Reloader) getConfigForClient(_ *tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*r.certificate},
+		ClientCAs:    r.clientCAs,
+		ClientAuth:   r.clientAuthType(),
+		// grpc requires the http2 protocol to be negotiated via ALPN
+		NextProtos: []string{"h2"},
+	}, nil
+}
+
+func (r *Reloader) clientAuthType() tls.ClientAuthType {
+	if r.requireClientCert {
+		return tls.RequireAndVerifyClientCert
+	}
+
+	if r.clientCAs != nil {
+		return tls.VerifyClientCertIfGiven
+	}
+
+	return tls.NoClientCert
+}
+
+// reload reads all certificate files and replaces the current certificates if any file has changed.
+func (r *Reloader) reload() (bool, error) {
+	files, err := r.readFiles()
+	if err != nil {
+		return false, err
+	}
+
+	r.mutex.RLock()
+	unchanged := filesEqual(r.loadedFiles, files)
+	r.mutex.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	certificate, err := tls.X509KeyPair(files[0], files[1])
+	if err != nil {
+		return false, fmt.Errorf("failed to parse server certificate %s and key %s: %w", r.certFile, r.keyFile, err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(files[2]) {
+			return false, fmt.Errorf("failed to parse client CA file %s: no valid certificates found", r.clientCAFile)
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.certificate = &certificate
+	r.clientCAs = clientCAs
+	r.loadedFiles = files
+
+	return true, nil
+}
+
+func (r *Reloader) readFiles() ([][]byte, error) {
+	paths := []string{r.certFile, r.keyFile}
+	if r.clientCAFile != "" {
+		paths = append(paths, r.clientCAFile)
+	}
+
+	files := make([][]byte, 0, len(paths))
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+		}
+		files = append(files, content)
+	}
+
+	return files, nil
+}
+
+func filesEqual(a [][]byte, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,263 @@
+package certificate
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReloader(t *testing.T) {
+	t.Run("should load server certificate without client CA", func(t *testing.T) {
+		// given
+		certFile, keyFile := writeCertificate(t, t.TempDir(), "server")
+
+		// when
+		reloader, err := NewReloader(certFile, keyFile, "", false)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, reloader.certificate)
+		assert.Nil(t, reloader.clientCAs)
+		assert.Equal(t, tls.NoClientCert, reloader.clientAuthType())
+	})
+	t.Run("should verify client certificates if given when client CA is set", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		certFile, keyFile := writeCertificate(t, dir, "server")
+		caFile, _ := writeCertificate(t, dir, "ca")
+
+		// when
+		reloader, err := NewReloader(certFile, keyFile, caFile, false)
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, reloader.clientCAs)
+		assert.Equal(t, tls.VerifyClientCertIfGiven, reloader.clientAuthType())
+	})
+	t.Run("should require client certificates", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		certFile, keyFile := writeCertificate(t, dir, "server")
+		caFile, _ := writeCertificate(t, dir, "ca")
+
+		// when
+		reloader, err := NewReloader(certFile, keyFile, caFile, true)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, tls.RequireAndVerifyClientCert, reloader.clientAuthType())
+	})
+	t.Run("should fail if client certificates are required without client CA", func(t *testing.T) {
+		// given
+		certFile, keyFile := writeCertificate(t, t.TempDir(), "server")
+
+		// when
+		_, err := NewReloader(certFile, keyFile, "", true)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "a client CA file is required when client certificates are required")
+	})
+	t.Run("should fail if certificate file does not exist", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+
+		// when
+		_, err := NewReloader(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), "", false)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read file")
+	})
+	t.Run("should fail for invalid key pair", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		certFile, _ := writeCertificate(t, dir, "server")
+		_, otherKeyFile := writeCertificate(t, dir, "other")
+
+		// when
+		_, err := NewReloader(certFile, otherKeyFile, "", false)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse server certificate")
+	})
+	t.Run("should fail for invalid client CA", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		certFile, keyFile := writeCertificate(t, dir, "server")
+		caFile := filepath.Join(dir, "ca.crt")
+		require.NoError(t, os.WriteFile(caFile, []byte("no certificate"), 0600))
+
+		// when
+		_, err := NewReloader(certFile, keyFile, caFile, false)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse client CA file")
+	})
+}
+
+func TestReloader_reload(t *testing.T) {
+	t.Run("should not reload unchanged files", func(t *testing.T) {
+		// given
+		certFile, keyFile := writeCertificate(t, t.TempDir(), "server")
+		reloader, err := NewReloader(certFile, keyFile, "", false)
+		require.NoError(t, err)
+
+		// when
+		reloaded, err := reloader.reload()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, reloaded)
+	})
+	t.Run("should reload rotated certificate", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		certFile, keyFile := writeCertificate(t, dir, "server")
+		reloader, err := NewReloader(certFile, keyFile, "", false)
+		require.NoError(t, err)
+		previous := reloader.certificate
+		writeCertificate(t, dir, "server")
+
+		// when
+		reloaded, err := reloader.reload()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, reloaded)
+		assert.NotEqual(t, previous.Certificate, reloader.certificate.Certificate)
+	})
+	t.Run("should keep previous certificate if rotated files are invalid", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		certFile, keyFile := writeCertificate(t, dir, "server")
+		reloader, err := NewReloader(certFile, keyFile, "", false)
+		require.NoError(t, err)
+		previous := reloader.certificate
+		require.NoError(t, os.WriteFile(keyFile, []byte("broken"), 0600))
+
+		// when
+		reloaded, err := reloader.reload()
+
+		// then
+		require.Error(t, err)
+		assert.False(t, reloaded)
+		assert.Same(t, previous, reloader.certificate)
+	})
+}
+
+func TestReloader_Watch(t *testing.T) {
+	t.Run("should reload certificates until context is cancelled", func(t *testing.T) {
+		// given
+		previousInterval := reloadInterval
+		reloadInterval = 10 * time.Millisecond
+		defer func() { reloadInterval = previousInterval }()
+
+		dir := t.TempDir()
+		certFile, keyFile := writeCertificate(t, dir, "server")
+		reloader, err := NewReloader(certFile, keyFile, "", false)
+		require.NoError(t, err)
+		previous := reloader.getCertificate()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+
+		// when
+		go func() {
+			reloader.Watch(ctx)
+			close(done)
+		}()
+		writeCertificate(t, dir, "server")
+
+		// then
+		assert.Eventually(t, func() bool {
+			return reloader.getCertificate() != previous
+		}, time.Second, 10*time.Millisecond)
+		cancel()
+		assert.Eventually(t, func() bool {
+			select {
+			case <-done:
+				return true
+			default:
+				return false
+			}
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestReloader_TLSConfig(t *testing.T) {
+	t.Run("should serve current certificate with http2 protocol", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		certFile, keyFile := writeCertificate(t, dir, "server")
+		caFile, _ := writeCertificate(t, dir, "ca")
+		reloader, err := NewReloader(certFile, keyFile, caFile, true)
+		require.NoError(t, err)
+
+		// when
+		config, err := reloader.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+		assert.Equal(t, reloader.certificate.Certificate, config.Certificates[0].Certificate)
+		assert.Same(t, reloader.clientCAs, config.ClientCAs)
+		assert.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+		assert.Equal(t, []string{"h2"}, config.NextProtos)
+	})
+}
+
+func (r *Reloader) getCertificate() *tls.Certificate {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.certificate
+}
+
+func writeCertificate(t *testing.T, dir string, name string) (certFile string, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+
+	return certFile, keyFile
+}
@@ -0,0 +1,203 @@
+package client
+
+import (
+	"fmt"
+
+	pbBackup "github.com/cloudogu/ces-control-api/generated/backup"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+func backupCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "backup",
+		Usage: "create, list, delete and restore backups and manage the backup schedule",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "create",
+				Usage:  "create a backup",
+				Flags:  withClientFlags(),
+				Action: clientAction(createBackup),
+			},
+			{
+				Name:   "list",
+				Usage:  "list the backups",
+				Flags:  withClientFlags(),
+				Action: clientAction(listBackups),
+			},
+			{
+				Name:      "delete",
+				Usage:     "delete a backup",
+				ArgsUsage: "<backup>",
+				Flags:     withClientFlags(),
+				Action:    clientAction(deleteBackup),
+			},
+			{
+				Name:      "restore",
+				Usage:     "restore a backup",
+				ArgsUsage: "<backup>",
+				Flags:     withClientFlags(),
+				Action:    clientAction(restoreBackup),
+			},
+			{
+				Name:   "restores",
+				Usage:  "list the restores",
+				Flags:  withClientFlags(),
+				Action: clientAction(listRestores),
+			},
+			{
+				Name:   "schedule",
+				Usage:  "show the backup schedule and retention policy",
+				Flags:  withClientFlags(),
+				Action: clientAction(showBackupSchedule),
+				Subcommands: []*cli.Command{
+					{
+						Name:      "set",
+						Usage:     "set the backup schedule",
+						ArgsUsage: "<cron expression>",
+						Flags:     withClientFlags(),
+						Action:    clientAction(setBackupSchedule),
+					},
+				},
+			},
+		},
+	}
+}
+
+func createBackup(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	response, err := pbBackup.NewBackupManagementClient(conn).CreateBackup(ctx, &pbBackup.CreateBackupRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	return p.printMessage(response, "created backup")
+}
+
+func listBackups(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	response, err := pbBackup.NewBackupManagementClient(conn).AllBackups(ctx, &pbBackup.GetAllBackupsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	t := table{headers: []string{"ID", "STATUS", "START", "END", "RESTORABLE", "BLUEPRINT"}}
+	for _, backup := range response.GetBackups() {
+		t.rows = append(t.rows, []string{
+			backup.GetId(),
+			backup.GetStatus(),
+			backup.GetStartTime(),
+			backup.GetEndTime(),
+			formatBool(backup.GetRestorable()),
+			backup.GetBlueprintId(),
+		})
+	}
+
+	return p.print(response, t)
+}
+
+func deleteBackup(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	err := requireArgs(c, 1)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	name := c.Args().First()
+	response, err := pbBackup.NewBackupManagementClient(conn).DeleteBackup(ctx, &pbBackup.DeleteBackupRequest{Name: name})
+	if err != nil {
+		return fmt.Errorf("failed to delete backup %s: %w", name, err)
+	}
+
+	return p.printMessage(response, fmt.Sprintf("deleted backup %s", name))
+}
+
+func restoreBackup(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	err := requireArgs(c, 1)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	name := c.Args().First()
+	response, err := pbBackup.NewBackupManagementClient(conn).CreateRestore(ctx, &pbBackup.CreateRestoreRequest{BackupId: name})
+	if err != nil {
+		return fmt.Errorf("failed to restore backup %s: %w", name, err)
+	}
+
+	return p.printMessage(response, fmt.Sprintf("started restore of backup %s", name))
+}
+
+func listRestores(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	response, err := pbBackup.NewBackupManagementClient(conn).AllRestores(ctx, &pbBackup.GetAllRestoresRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list restores: %w", err)
+	}
+
+	t := table{headers: []string{"BACKUP", "START", "SUCCESS", "BLUEPRINT"}}
+	for _, restore := range response.GetRestores() {
+		t.rows = append(t.rows, []string{
+			restore.GetBackupId(),
+			restore.GetStartTime(),
+			formatBool(restore.GetSuccess()),
+			restore.GetBlueprintId(),
+		})
+	}
+
+	return p.print(response, t)
+}
+
+func showBackupSchedule(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	client := pbBackup.NewBackupManagementClient(conn)
+	schedule, err := client.GetSchedule(ctx, &pbBackup.GetBackupScheduleRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get backup schedule: %w", err)
+	}
+
+	retention, err := client.GetRetentionPolicy(ctx, &pbBackup.GetRetentionPolicyRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get retention policy: %w", err)
+	}
+
+	if p.format == outputJSON {
+		return p.printJSONObject(map[string]proto.Message{"schedule": schedule, "retentionPolicy": retention})
+	}
+
+	return p.printTable(table{
+		headers: []string{"SCHEDULE", "RETENTION POLICY"},
+		rows:    [][]string{{schedule.GetSchedule(), retention.GetPolicy().String()}},
+	})
+}
+
+func setBackupSchedule(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	err := requireArgs(c, 1)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	schedule := c.Args().First()
+	response, err := pbBackup.NewBackupManagementClient(conn).SetSchedule(ctx, &pbBackup.SetBackupScheduleRequest{Schedule: schedule})
+	if err != nil {
+		return fmt.Errorf("failed to set backup schedule: %w", err)
+	}
+
+	return p.printMessage(response, fmt.Sprintf("set backup schedule to %q", schedule))
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+)
+
+// Commands returns the client commands which call a running k8s-ces-control server.
+func Commands() []*cli.Command {
+	return []*cli.Command{
+		doguCommand(),
+		healthCommand(),
+		logsCommand(),
+		logLevelCommand(),
+		debugCommand(),
+		backupCommand(),
+		supportArchiveCommand(),
+	}
+}
+
+// clientActionFunc is the action of a client command using a connection to the server.
+type clientActionFunc func(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error
+
+// clientAction connects to the server and creates the printer for the selected output format before running the
+// given action.
+func clientAction(action clientActionFunc) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		p, err := newPrinter(c.App.Writer, c.String(flagOutput))
+		if err != nil {
+			return err
+		}
+
+		conn, err := dial(connectionOptionsFromContext(c))
+		if err != nil {
+			return err
+		}
+		defer func() { _ = conn.Close() }()
+
+		return action(c, conn, p)
+	}
+}
+
+// withClientFlags returns the client flags followed by the given command specific flags.
+func withClientFlags(flags ...cli.Flag) []cli.Flag {
+	return append(clientFlags(), flags...)
+}
+
+// requireArgs returns an error if the command was not called with exactly the given number of arguments.
+func requireArgs(c *cli.Context, count int) error {
+	if c.NArg() != count {
+		return fmt.Errorf("%s requires %d argument(s): %s", c.Command.FullName(), count, c.Command.ArgsUsage)
+	}
+
+	return nil
+}
+
+// parseTime parses an absolute RFC 3339 timestamp or a duration relative to now, e.g. "2h" for two hours ago. An
+// empty value results in the zero time.
+func parseTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err == nil {
+		return now.Add(-duration), nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: use a duration like 2h or a timestamp like 2006-01-02T15:04:05Z", value)
+	}
+
+	return timestamp, nil
+}
+
+func formatBool(value bool) string {
+	if value {
+		return "yes"
+	}
+
+	return "no"
+}
+
+func formatList(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommands(t *testing.T) {
+	// when
+	commands := Commands()
+
+	// then
+	names := make([]string, 0, len(commands))
+	for _, command := range commands {
+		names = append(names, command.Name)
+	}
+	assert.Equal(t, []string{"dogu", "health", "logs", "loglevel", "debug", "backup", "support-archive"}, names)
+}
+
+func Test_parseTime(t *testing.T) {
+	now := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should return zero time for empty value", func(t *testing.T) {
+		// when
+		actual, err := parseTime("", now)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, actual.IsZero())
+	})
+	t.Run("should parse duration relative to now", func(t *testing.T) {
+		// when
+		actual, err := parseTime("90m", now)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 5, 4, 10, 30, 0, 0, time.UTC), actual)
+	})
+	t.Run("should parse timestamp", func(t *testing.T) {
+		// when
+		actual, err := parseTime("2026-05-01T08:00:00Z", now)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC), actual)
+	})
+	t.Run("should fail for invalid value", func(t *testing.T) {
+		// when
+		_, err := parseTime("yesterday", now)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid time \"yesterday\"")
+	})
+}
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	flagServer    = "server"
+	flagToken     = "token"
+	flagCAFile    = "ca-file"
+	flagCertFile  = "cert-file"
+	flagKeyFile   = "key-file"
+	flagPlaintext = "plaintext"
+	flagTimeout   = "timeout"
+	flagOutput    = "output"
+
+	defaultServer  = "localhost:50051"
+	defaultTimeout = 30 * time.Second
+)
+
+// clientFlags returns the flags to connect to a running server and to select the output format.
+func clientFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    flagServer,
+			Usage:   "address of the k8s-ces-control server",
+			Value:   defaultServer,
+			EnvVars: []string{"CES_CONTROL_SERVER"},
+		},
+		&cli.StringFlag{
+			Name:    flagToken,
+			Usage:   "bearer token to authenticate with",
+			EnvVars: []string{"CES_CONTROL_TOKEN"},
+		},
+		&cli.StringFlag{
+			Name:    flagCAFile,
+			Usage:   "CA certificate to verify the server certificate; the system CAs are used if empty",
+			EnvVars: []string{"CES_CONTROL_CA_FILE"},
+		},
+		&cli.StringFlag{
+			Name:    flagCertFile,
+			Usage:   "client certificate for mutual tls",
+			EnvVars: []string{"CES_CONTROL_CERT_FILE"},
+		},
+		&cli.StringFlag{
+			Name:    flagKeyFile,
+			Usage:   "private key of the client certificate",
+			EnvVars: []string{"CES_CONTROL_KEY_FILE"},
+		},
+		&cli.BoolFlag{
+			Name:    flagPlaintext,
+			Usage:   "connect without tls",
+			EnvVars: []string{"CES_CONTROL_PLAINTEXT"},
+		},
+		&cli.DurationFlag{
+			Name:  flagTimeout,
+			Usage: "timeout of unary calls; streams are not limited",
+			Value: defaultTimeout,
+		},
+		&cli.StringFlag{
+			Name:    flagOutput,
+			Aliases: []string{"o"},
+			Usage:   "output format: table or json",
+			Value:   outputTable,
+		},
+	}
+}
+
+// connectionOptions contains the settings to connect to a running server.
+type connectionOptions struct {
+	server    string
+	token     string
+	caFile    string
+	certFile  string
+	keyFile   string
+	plaintext bool
+}
+
+func connectionOptionsFromContext(c *cli.Context) connectionOptions {
+	return connectionOptions{
+		server:    c.String(flagServer),
+		token:     c.String(flagToken),
+		caFile:    c.String(flagCAFile),
+		certFile:  c.String(flagCertFile),
+		keyFile:   c.String(flagKeyFile),
+		plaintext: c.Bool(flagPlaintext),
+	}
+}
+
+// dial creates a connection to the server described by the given options.
+func dial(options connectionOptions) (*grpc.ClientConn, error) {
+	dialOptions, err := createDialOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(options.server, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", options.server, err)
+	}
+
+	return conn, nil
+}
+
+func createDialOptions(options connectionOptions) ([]grpc.DialOption, error) {
+	var dialOptions []grpc.DialOption
+
+	if options.plaintext {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		tlsConfig, err := createTLSConfig(options)
+		if err != nil {
+			return nil, err
+		}
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+
+	if options.token != "" {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(&tokenCredentials{
+			token:      options.token,
+			requireTLS: !options.plaintext,
+		}))
+	}
+
+	return dialOptions, nil
+}
+
+func createTLSConfig(options connectionOptions) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if options.caFile != "" {
+		caCert, err := os.ReadFile(options.caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse CA certificate %s", options.caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if options.certFile != "" || options.keyFile != "" {
+		if options.certFile == "" || options.keyFile == "" {
+			return nil, fmt.Errorf("the flags --%s and --%s must be set together", flagCertFile, flagKeyFile)
+		}
+
+		certificate, err := tls.LoadX509KeyPair(options.certFile, options.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// tokenCredentials sends the bearer token with every call.
+type tokenCredentials struct {
+	token      string
+	requireTLS bool
+}
+
+// GetRequestMetadata returns the authorization header.
+func (t *tokenCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+// RequireTransportSecurity returns true unless the connection was explicitly configured as plaintext.
+func (t *tokenCredentials) RequireTransportSecurity() bool {
+	return t.requireTLS
+}
+
+// callContext returns the context of a unary call limited by the timeout flag.
+func callContext(c *cli.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(c.Context, c.Duration(flagTimeout))
+}
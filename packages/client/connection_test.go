@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_createDialOptions(t *testing.T) {
+	t.Run("should use plaintext without token", func(t *testing.T) {
+		// when
+		options, err := createDialOptions(connectionOptions{server: defaultServer, plaintext: true})
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, options, 1)
+	})
+	t.Run("should add token credentials", func(t *testing.T) {
+		// when
+		options, err := createDialOptions(connectionOptions{server: defaultServer, token: "secret"})
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, options, 2)
+	})
+	t.Run("should fail for missing CA file", func(t *testing.T) {
+		// when
+		_, err := createDialOptions(connectionOptions{server: defaultServer, caFile: "/does/not/exist/ca.crt"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read CA certificate")
+	})
+}
+
+func Test_createTLSConfig(t *testing.T) {
+	t.Run("should use system CAs by default", func(t *testing.T) {
+		// when
+		tlsConfig, err := createTLSConfig(connectionOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, tlsConfig.RootCAs)
+		assert.Empty(t, tlsConfig.Certificates)
+	})
+	t.Run("should fail for invalid CA file", func(t *testing.T) {
+		// given
+		caFile := filepath.Join(t.TempDir(), "ca.crt")
+		require.NoError(t, os.WriteFile(caFile, []byte("no certificate"), 0600))
+
+		// when
+		_, err := createTLSConfig(connectionOptions{caFile: caFile})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse CA certificate")
+	})
+	t.Run("should fail if key file is missing", func(t *testing.T) {
+		// when
+		_, err := createTLSConfig(connectionOptions{certFile: "client.crt"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "must be set together")
+	})
+	t.Run("should fail for missing client certificate", func(t *testing.T) {
+		// when
+		_, err := createTLSConfig(connectionOptions{certFile: "/does/not/exist/client.crt", keyFile: "/does/not/exist/client.key"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to load client certificate")
+	})
+}
+
+func Test_tokenCredentials(t *testing.T) {
+	// given
+	sut := &tokenCredentials{token: "secret", requireTLS: true}
+
+	// when
+	md, err := sut.GetRequestMetadata(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer secret"}, md)
+	assert.True(t, sut.RequireTransportSecurity())
+}
@@ -0,0 +1,221 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	pbMaintenance "github.com/cloudogu/ces-control-api/generated/maintenance"
+	"github.com/cloudogu/ces-control-api/generated/types"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+)
+
+const (
+	flagTimer           = "timer"
+	flagMaintenanceMode = "maintenance-mode"
+	flagLogLevel        = "log-level"
+	flagLimit           = "limit"
+	flagSupportArchive  = "support-archive"
+
+	defaultDebugModeTimer = 15
+	defaultHistoryLimit   = 20
+)
+
+func debugCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "debug",
+		Usage: "enable, disable and show the debug mode",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "enable",
+				Usage:     "set the log level of the given or all dogus to debug for a limited time",
+				ArgsUsage: "[dogu...]",
+				Flags: withClientFlags(
+					&cli.IntFlag{
+						Name:  flagTimer,
+						Usage: "minutes after which the debug mode is disabled automatically",
+						Value: defaultDebugModeTimer,
+					},
+					&cli.BoolFlag{
+						Name:  flagMaintenanceMode,
+						Usage: "activate the maintenance mode while the dogus are restarted",
+					},
+					&cli.StringFlag{
+						Name:  flagLogLevel,
+						Usage: "log level of the dogus in debug mode: trace, debug, info, warn or error",
+						Value: "debug",
+					},
+					&cli.BoolFlag{
+						Name:  flagSupportArchive,
+						Usage: "create a support archive of the debug window right before the debug mode is disabled",
+					},
+				),
+				Action: clientAction(enableDebugMode),
+			},
+			{
+				Name:   "disable",
+				Usage:  "restore the previous log levels of the dogus in debug mode",
+				Flags:  withClientFlags(),
+				Action: clientAction(disableDebugMode),
+			},
+			{
+				Name:   "status",
+				Usage:  "show whether the debug mode is enabled",
+				Flags:  withClientFlags(),
+				Action: clientAction(showDebugModeStatus),
+			},
+			{
+				Name:  "history",
+				Usage: "show the past sessions of the debug mode, the most recent first",
+				Flags: withClientFlags(
+					&cli.IntFlag{
+						Name:  flagLimit,
+						Usage: "maximum number of sessions",
+						Value: defaultHistoryLimit,
+					},
+				),
+				Action: clientAction(showDebugModeHistory),
+			},
+		},
+	}
+}
+
+func enableDebugMode(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	timer := c.Int(flagTimer)
+	response, err := pbMaintenance.NewDebugModeClient(conn).Enable(ctx, &pbMaintenance.ToggleDebugModeRequest{
+		WithMaintenanceMode: c.Bool(flagMaintenanceMode),
+		Timer:               int32(timer),
+		DoguNames:           c.Args().Slice(),
+		LogLevel:            strings.ToUpper(c.String(flagLogLevel)),
+		SupportArchive:      c.Bool(flagSupportArchive),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enable debug mode: %w", err)
+	}
+
+	if c.NArg() > 0 {
+		return p.printMessage(response, fmt.Sprintf("enabled debug mode of dogus %s for %d minutes", strings.Join(c.Args().Slice(), ", "), timer))
+	}
+	return p.printMessage(response, fmt.Sprintf("enabled debug mode for %d minutes", timer))
+}
+
+func disableDebugMode(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	response, err := pbMaintenance.NewDebugModeClient(conn).Disable(ctx, &pbMaintenance.ToggleDebugModeRequest{
+		WithMaintenanceMode: c.Bool(flagMaintenanceMode),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to disable debug mode: %w", err)
+	}
+
+	return p.printMessage(response, "disabled debug mode")
+}
+
+func showDebugModeStatus(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	response, err := pbMaintenance.NewDebugModeClient(conn).Status(ctx, &types.BasicRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get debug mode status: %w", err)
+	}
+
+	disableAt, logLevel, dogus, phase, archive := "-", "-", "-", "-", "-"
+	if response.GetIsEnabled() {
+		disableAt = time.UnixMilli(response.GetDisableAtTimestamp()).Format(time.RFC3339)
+		logLevel = response.GetLogLevel()
+		dogus = strings.Join(response.GetDoguNames(), ",")
+		if response.GetAllDogus() {
+			dogus = "all"
+		}
+	}
+
+	if response.GetPhase() != "" {
+		phase = response.GetPhase()
+	}
+	if response.GetSupportArchiveName() != "" {
+		archive = response.GetSupportArchiveName()
+	}
+
+	err = p.print(response, table{
+		headers: []string{"ENABLED", "DISABLE AT", "LOG LEVEL", "DOGUS", "PHASE", "SUPPORT ARCHIVE"},
+		rows:    [][]string{{formatBool(response.GetIsEnabled()), disableAt, logLevel, dogus, phase, archive}},
+	})
+	if err != nil || p.format == outputJSON || len(response.GetDogus()) == 0 {
+		return err
+	}
+
+	_, err = fmt.Fprintln(p.writer)
+	if err != nil {
+		return err
+	}
+	return p.printTable(debugModeDoguTable(response.GetDogus()))
+}
+
+// debugModeDoguTable lists the log levels and the restart state of the dogus in debug mode. An empty log level is the
+// default level of the dogu.
+func debugModeDoguTable(dogus []*pbMaintenance.DebugModeDoguStatus) table {
+	formatLevel := func(level string) string {
+		if level == "" {
+			return "default"
+		}
+		return level
+	}
+
+	t := table{headers: []string{"DOGU", "LEVEL BEFORE", "LEVEL NOW", "RESTART"}}
+	for _, dogu := range dogus {
+		t.rows = append(t.rows, []string{dogu.GetDoguName(), formatLevel(dogu.GetLogLevelBefore()), formatLevel(dogu.GetLogLevelAfter()), dogu.GetRestartState().String()})
+	}
+
+	return t
+}
+
+func showDebugModeHistory(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	response, err := pbMaintenance.NewDebugModeClient(conn).GetHistory(ctx, &pbMaintenance.DebugModeHistoryRequest{Limit: int32(c.Int(flagLimit))})
+	if err != nil {
+		return fmt.Errorf("failed to get debug mode history: %w", err)
+	}
+
+	return p.print(response, debugModeHistoryTable(response.GetSessions()))
+}
+
+func debugModeHistoryTable(sessions []*pbMaintenance.DebugModeSession) table {
+	t := table{headers: []string{"ENABLED AT", "ENABLED BY", "DISABLED AT", "DISABLED BY", "DURATION", "LOG LEVEL", "DOGUS", "SUPPORT ARCHIVE"}}
+	for _, session := range sessions {
+		disabledAt, disabledBy := "active", "-"
+		if !session.GetActive() {
+			disabledAt = time.UnixMilli(session.GetDisabledAtTimestamp()).Format(time.RFC3339)
+			disabledBy = session.GetDisabledBy()
+		}
+		dogus := formatList(session.GetDoguNames())
+		if session.GetAllDogus() {
+			dogus = "all"
+		}
+		archive := "-"
+		if session.GetSupportArchiveName() != "" {
+			archive = session.GetSupportArchiveName()
+		}
+
+		t.rows = append(t.rows, []string{
+			time.UnixMilli(session.GetEnabledAtTimestamp()).Format(time.RFC3339),
+			session.GetEnabledBy(),
+			disabledAt,
+			disabledBy,
+			(time.Duration(session.GetDurationSeconds()) * time.Second).String(),
+			session.GetLogLevel(),
+			dogus,
+			archive,
+		})
+	}
+
+	return t
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	pbMaintenance "github.com/cloudogu/ces-control-api/generated/maintenance"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_debugModeDoguTable(t *testing.T) {
+	// when
+	actual := debugModeDoguTable([]*pbMaintenance.DebugModeDoguStatus{
+		{DoguName: "cas", LogLevelBefore: "WARN", LogLevelAfter: "TRACE", RestartState: pbMaintenance.DebugModeRestartState_READY},
+		{DoguName: "redmine", LogLevelAfter: "TRACE", RestartState: pbMaintenance.DebugModeRestartState_FAILED},
+	})
+
+	// then
+	assert.Equal(t, [][]string{
+		{"cas", "WARN", "TRACE", "READY"},
+		{"redmine", "default", "TRACE", "FAILED"},
+	}, actual.rows)
+}
+
+func Test_debugModeHistoryTable(t *testing.T) {
+	// given
+	enabledAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+	// when
+	actual := debugModeHistoryTable([]*pbMaintenance.DebugModeSession{
+		{EnabledBy: "admin", EnabledAtTimestamp: enabledAt.Add(time.Hour).UnixMilli(), DurationSeconds: 90, DoguNames: []string{"cas", "redmine"}, LogLevel: "TRACE", Active: true},
+		{EnabledBy: "admin", EnabledAtTimestamp: enabledAt.UnixMilli(), DisabledBy: "k8s-ces-control", DisabledAtTimestamp: enabledAt.Add(15 * time.Minute).UnixMilli(), DurationSeconds: 900, AllDogus: true, LogLevel: "DEBUG", SupportArchiveName: "debug-mode-support-archive-20261018101500z"},
+	})
+
+	// then
+	assert.Equal(t, [][]string{
+		{time.UnixMilli(enabledAt.Add(time.Hour).UnixMilli()).Format(time.RFC3339), "admin", "active", "-", "1m30s", "TRACE", "cas,redmine", "-"},
+		{time.UnixMilli(enabledAt.UnixMilli()).Format(time.RFC3339), "admin", time.UnixMilli(enabledAt.Add(15 * time.Minute).UnixMilli()).Format(time.RFC3339), "k8s-ces-control", "15m0s", "DEBUG", "all", "debug-mode-support-archive-20261018101500z"},
+	}, actual.rows)
+}
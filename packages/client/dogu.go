@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pbDoguAdministration "github.com/cloudogu/ces-control-api/generated/doguAdministration"
+	"github.com/cloudogu/ces-control-api/generated/types"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+)
+
+func doguCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "dogu",
+		Usage: "list, start, stop and restart dogus",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "list the installed dogus",
+				Flags:  withClientFlags(),
+				Action: clientAction(listDogus),
+			},
+			doguActionCommand("start", "started", func(client pbDoguAdministration.DoguAdministrationClient) doguActionFunc {
+				return client.StartDogu
+			}),
+			doguActionCommand("stop", "stopped", func(client pbDoguAdministration.DoguAdministrationClient) doguActionFunc {
+				return client.StopDogu
+			}),
+			doguActionCommand("restart", "restarted", func(client pbDoguAdministration.DoguAdministrationClient) doguActionFunc {
+				return client.RestartDogu
+			}),
+		},
+	}
+}
+
+func listDogus(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	response, err := pbDoguAdministration.NewDoguAdministrationClient(conn).GetDoguList(ctx, &pbDoguAdministration.DoguListRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list dogus: %w", err)
+	}
+
+	t := table{headers: []string{"NAME", "VERSION", "LOG LEVEL", "DISPLAY NAME"}}
+	for _, dogu := range response.GetDogus() {
+		t.rows = append(t.rows, []string{dogu.GetName(), dogu.GetVersion(), dogu.GetLogLevel(), dogu.GetDisplayName()})
+	}
+
+	return p.print(response, t)
+}
+
+type doguActionFunc func(ctx context.Context, in *pbDoguAdministration.DoguAdministrationRequest, opts ...grpc.CallOption) (*types.BasicResponse, error)
+
+func doguActionCommand(name string, pastTense string, selectAction func(pbDoguAdministration.DoguAdministrationClient) doguActionFunc) *cli.Command {
+	return &cli.Command{
+		Name:      name,
+		Usage:     fmt.Sprintf("%s a dogu", name),
+		ArgsUsage: "<dogu>",
+		Flags:     withClientFlags(),
+		Action: clientAction(func(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+			err := requireArgs(c, 1)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := callContext(c)
+			defer cancel()
+
+			doguName := c.Args().First()
+			action := selectAction(pbDoguAdministration.NewDoguAdministrationClient(conn))
+			response, err := action(ctx, &pbDoguAdministration.DoguAdministrationRequest{DoguName: doguName})
+			if err != nil {
+				return fmt.Errorf("failed to %s dogu %s: %w", name, doguName, err)
+			}
+
+			return p.printMessage(response, fmt.Sprintf("%s dogu %s", pastTense, doguName))
+		}),
+	}
+}
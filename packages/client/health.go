@@ -0,0 +1,52 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	pbHealth "github.com/cloudogu/ces-control-api/generated/health"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+)
+
+func healthCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "health",
+		Usage:     "show the health of all or the given dogus",
+		ArgsUsage: "[dogu...]",
+		Flags:     withClientFlags(),
+		Action:    clientAction(showHealth),
+	}
+}
+
+func showHealth(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	client := pbHealth.NewDoguHealthClient(conn)
+
+	var response *pbHealth.DoguHealthMapResponse
+	var err error
+	if c.NArg() == 0 {
+		response, err = client.GetAll(ctx, &pbHealth.DoguHealthAllRequest{})
+	} else {
+		response, err = client.GetByNames(ctx, &pbHealth.DoguHealthListRequest{Dogus: c.Args().Slice()})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get dogu health: %w", err)
+	}
+
+	results := response.GetResults()
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	t := table{headers: []string{"DOGU", "HEALTHY"}}
+	for _, name := range names {
+		t.rows = append(t.rows, []string{name, formatBool(results[name].GetHealthy())})
+	}
+
+	return p.print(response, t)
+}
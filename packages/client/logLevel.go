@@ -0,0 +1,196 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	pbLogging "github.com/cloudogu/ces-control-api/generated/logging"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+)
+
+const (
+	flagLogger      = "logger"
+	flagParallelism = "parallelism"
+)
+
+func logLevelCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "loglevel",
+		Usage: "show and change the log levels of dogus",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "list",
+				Usage:     "list the loggers of a dogu with their levels",
+				ArgsUsage: "<dogu>",
+				Flags:     withClientFlags(),
+				Action:    clientAction(listLoggers),
+			},
+			{
+				Name:      "set",
+				Usage:     "set the log level of a dogu and restart it if the level changed",
+				ArgsUsage: "<dogu> <trace|debug|info|warn|error>",
+				Flags: withClientFlags(
+					&cli.StringFlag{
+						Name:  flagLogger,
+						Usage: "set the level of this logger instead of the root logger; the level must be allowed by the dogu",
+					},
+				),
+				Action: clientAction(setLogLevel),
+			},
+			{
+				Name:      "apply",
+				Usage:     "set the log level of several or all dogus and restart the changed dogus in the order of their dependencies",
+				ArgsUsage: "<trace|debug|info|warn|error> [dogu...]",
+				Flags: withClientFlags(
+					&cli.BoolFlag{
+						Name:  flagAll,
+						Usage: "set the log level of all dogus",
+					},
+					&cli.IntFlag{
+						Name:  flagParallelism,
+						Usage: "maximum number of dogus restarted at the same time; the server default is used if not set",
+					},
+				),
+				Action: clientAction(applyLogLevels),
+			},
+		},
+	}
+}
+
+func listLoggers(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	err := requireArgs(c, 1)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	doguName := c.Args().First()
+	response, err := pbLogging.NewDoguLogMessagesClient(conn).GetLoggers(ctx, &pbLogging.DoguLoggersRequest{DoguName: doguName})
+	if err != nil {
+		return fmt.Errorf("failed to list loggers of dogu %s: %w", doguName, err)
+	}
+
+	t := table{headers: []string{"LOGGER", "LEVEL", "SOURCE", "ALLOWED LEVELS"}}
+	for _, logger := range response.GetLoggers() {
+		t.rows = append(t.rows, []string{logger.GetName(), logger.GetLevel(), logger.GetSource().String(), strings.Join(logger.GetAllowedLevels(), ",")})
+	}
+
+	return p.print(response, t)
+}
+
+func setLogLevel(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	err := requireArgs(c, 2)
+	if err != nil {
+		return err
+	}
+
+	doguName := c.Args().Get(0)
+	levelName := strings.ToUpper(c.Args().Get(1))
+	if c.IsSet(flagLogger) {
+		return setLoggerLevel(c, conn, p, doguName, c.String(flagLogger), levelName)
+	}
+
+	level, ok := pbLogging.LogLevel_value[levelName]
+	if !ok {
+		return fmt.Errorf("invalid log level %q: use trace, debug, info, warn or error", c.Args().Get(1))
+	}
+
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	response, err := pbLogging.NewDoguLogMessagesClient(conn).ApplyLogLevelWithRestart(ctx, &pbLogging.LogLevelRequest{
+		DoguName: doguName,
+		LogLevel: pbLogging.LogLevel(level),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set log level of dogu %s: %w", doguName, err)
+	}
+
+	return p.printMessage(response, fmt.Sprintf("set log level of dogu %s to %s", doguName, levelName))
+}
+
+func setLoggerLevel(c *cli.Context, conn grpc.ClientConnInterface, p *printer, doguName string, logger string, level string) error {
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	response, err := pbLogging.NewDoguLogMessagesClient(conn).SetLoggerLevel(ctx, &pbLogging.LoggerLevelRequest{
+		DoguName: doguName,
+		Logger:   logger,
+		Level:    level,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set level of logger %s of dogu %s: %w", logger, doguName, err)
+	}
+
+	return p.printMessage(response, fmt.Sprintf("set level of logger %s of dogu %s to %s", logger, doguName, level))
+}
+
+func applyLogLevels(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	if c.NArg() == 0 || (c.NArg() == 1 && !c.Bool(flagAll)) {
+		return fmt.Errorf("%s requires a log level and dogus or --%s: %s", c.Command.FullName(), flagAll, c.Command.ArgsUsage)
+	}
+	if c.Bool(flagAll) && c.NArg() > 1 {
+		return fmt.Errorf("%s --%s does not accept dogu arguments", c.Command.FullName(), flagAll)
+	}
+
+	level, ok := pbLogging.LogLevel_value[strings.ToUpper(c.Args().First())]
+	if !ok {
+		return fmt.Errorf("invalid log level %q: use trace, debug, info, warn or error", c.Args().First())
+	}
+
+	doguNames := c.Args().Tail()
+	// restarting the dogus may take longer than the timeout of a call, so the stream runs until it ends
+	stream, err := pbLogging.NewDoguLogMessagesClient(conn).ApplyLogLevels(c.Context, &pbLogging.BulkLogLevelRequest{
+		DoguNames:   doguNames,
+		AllDogus:    c.Bool(flagAll),
+		LogLevel:    pbLogging.LogLevel(level),
+		Parallelism: int32(c.Int(flagParallelism)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set log level of %s: %w", describeDogus(doguNames), err)
+	}
+
+	return printLogLevelProgress(stream.Recv, describeDogus(doguNames), p)
+}
+
+// printLogLevelProgress prints the received progress of every dogu until the stream ends. It fails if the log level of
+// a dogu could not be applied.
+func printLogLevelProgress(recv func() (*pbLogging.LogLevelProgress, error), description string, p *printer) error {
+	var failedDogus []string
+	for {
+		progress, err := recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set log level of %s: %w", description, err)
+		}
+
+		if progress.GetState() == pbLogging.LogLevelProgressState_FAILED {
+			failedDogus = append(failedDogus, progress.GetDoguName())
+		}
+
+		switch {
+		case p.format == outputJSON:
+			err = p.printJSONLine(progress)
+		case progress.GetError() != "":
+			_, err = fmt.Fprintf(p.writer, "%s %s: %s\n", progress.GetDoguName(), progress.GetState(), progress.GetError())
+		default:
+			_, err = fmt.Fprintf(p.writer, "%s %s\n", progress.GetDoguName(), progress.GetState())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(failedDogus) > 0 {
+		return fmt.Errorf("failed to set log level of dogus %s", strings.Join(failedDogus, ", "))
+	}
+
+	return nil
+}
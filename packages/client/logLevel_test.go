@@ -0,0 +1,70 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	pbLogging "github.com/cloudogu/ces-control-api/generated/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_printLogLevelProgress(t *testing.T) {
+	receiver := func(err error, messages ...*pbLogging.LogLevelProgress) func() (*pbLogging.LogLevelProgress, error) {
+		return func() (*pbLogging.LogLevelProgress, error) {
+			if len(messages) == 0 {
+				return nil, err
+			}
+			message := messages[0]
+			messages = messages[1:]
+			return message, nil
+		}
+	}
+
+	t.Run("should print the progress of every dogu", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		p, _ := newPrinter(out, outputTable)
+		recv := receiver(io.EOF,
+			&pbLogging.LogLevelProgress{DoguName: "cas", State: pbLogging.LogLevelProgressState_UNCHANGED},
+			&pbLogging.LogLevelProgress{DoguName: "ldap", State: pbLogging.LogLevelProgressState_CHANGED},
+			&pbLogging.LogLevelProgress{DoguName: "ldap", State: pbLogging.LogLevelProgressState_RESTARTED},
+		)
+
+		// when
+		err := printLogLevelProgress(recv, "all dogus", p)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "cas UNCHANGED\nldap CHANGED\nldap RESTARTED\n", out.String())
+	})
+	t.Run("should print errors and fail for failed dogus", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		p, _ := newPrinter(out, outputTable)
+		recv := receiver(io.EOF,
+			&pbLogging.LogLevelProgress{DoguName: "cas", State: pbLogging.LogLevelProgressState_FAILED, Error: "restart failed"},
+			&pbLogging.LogLevelProgress{DoguName: "ldap", State: pbLogging.LogLevelProgressState_UNCHANGED},
+		)
+
+		// when
+		err := printLogLevelProgress(recv, "all dogus", p)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to set log level of dogus cas")
+		assert.Equal(t, "cas FAILED: restart failed\nldap UNCHANGED\n", out.String())
+	})
+	t.Run("should fail if receiving fails", func(t *testing.T) {
+		// given
+		p, _ := newPrinter(&bytes.Buffer{}, outputTable)
+
+		// when
+		err := printLogLevelProgress(receiver(assert.AnError), "dogus cas, ldap", p)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to set log level of dogus cas, ldap")
+	})
+}
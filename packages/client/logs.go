@@ -0,0 +1,290 @@
+package client
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	pbLogging "github.com/cloudogu/ces-control-api/generated/logging"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	flagLines  = "lines"
+	flagSince  = "since"
+	flagUntil  = "until"
+	flagFilter = "filter"
+	flagFollow = "follow"
+	flagAll    = "all"
+
+	defaultLines = 100
+)
+
+func logsCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "logs",
+		Usage:     "print the latest log lines of a dogu, query the logs of dogus by time and filter or follow new logs",
+		ArgsUsage: "<dogu> [dogu...]",
+		Flags: withClientFlags(
+			&cli.IntFlag{
+				Name:  flagLines,
+				Usage: "number of latest log lines; ignored if --since, --until, --filter or several dogus are set",
+				Value: defaultLines,
+			},
+			&cli.StringFlag{
+				Name:  flagSince,
+				Usage: "only show logs after this time, either a duration like 2h or a timestamp like 2006-01-02T15:04:05Z",
+			},
+			&cli.StringFlag{
+				Name:  flagUntil,
+				Usage: "only show logs before this time, either a duration like 1h or a timestamp",
+			},
+			&cli.StringFlag{
+				Name:  flagFilter,
+				Usage: "only show log lines containing this text",
+			},
+			&cli.BoolFlag{
+				Name:    flagFollow,
+				Aliases: []string{"f"},
+				Usage:   "print new log lines of a single dogu until interrupted; only --filter is used with this flag",
+			},
+			&cli.BoolFlag{
+				Name:  flagAll,
+				Usage: "query the logs of all dogus; the lines are merged by time and labeled with their dogu",
+			},
+		),
+		Action: clientAction(showLogs),
+	}
+}
+
+func showLogs(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	client := pbLogging.NewDoguLogMessagesClient(conn)
+
+	if c.Bool(flagAll) || c.NArg() > 1 {
+		if c.Bool(flagFollow) {
+			return fmt.Errorf("%s --%s supports a single dogu only", c.Command.FullName(), flagFollow)
+		}
+		if c.Bool(flagAll) && c.NArg() > 0 {
+			return fmt.Errorf("%s --%s does not accept dogu arguments", c.Command.FullName(), flagAll)
+		}
+
+		return queryLogs(c, client, c.Args().Slice(), p)
+	}
+
+	err := requireArgs(c, 1)
+	if err != nil {
+		return err
+	}
+
+	doguName := c.Args().First()
+
+	if c.Bool(flagFollow) {
+		return followLogs(c, client, doguName, p)
+	}
+
+	if c.IsSet(flagSince) || c.IsSet(flagUntil) || c.IsSet(flagFilter) {
+		return queryLogs(c, client, []string{doguName}, p)
+	}
+
+	return latestLogs(c, client, doguName, p)
+}
+
+func latestLogs(c *cli.Context, client pbLogging.DoguLogMessagesClient, doguName string, p *printer) error {
+	stream, err := client.GetForDogu(c.Context, &pbLogging.DoguLogMessageRequest{DoguName: doguName, LineCount: int32(c.Int(flagLines))})
+	if err != nil {
+		return fmt.Errorf("failed to get logs of dogu %s: %w", doguName, err)
+	}
+
+	archive := &bytes.Buffer{}
+	err = receiveChunks(stream.Recv, archive)
+	if err != nil {
+		return fmt.Errorf("failed to receive logs of dogu %s: %w", doguName, err)
+	}
+
+	return writeZippedLogs(archive.Bytes(), p)
+}
+
+// queryLogs queries the logs of the given dogus or of all dogus if none is given. The lines of several dogus are
+// labeled with their dogu.
+func queryLogs(c *cli.Context, client pbLogging.DoguLogMessagesClient, doguNames []string, p *printer) error {
+	now := time.Now()
+	request := &pbLogging.DoguLogMessageQueryRequest{AllDogus: len(doguNames) == 0}
+	if len(doguNames) == 1 {
+		request.DoguName = doguNames[0]
+	} else {
+		request.DoguNames = doguNames
+	}
+
+	since, err := parseTime(c.String(flagSince), now)
+	if err != nil {
+		return err
+	}
+	if !since.IsZero() {
+		request.StartDate = timestamppb.New(since)
+	}
+
+	until, err := parseTime(c.String(flagUntil), now)
+	if err != nil {
+		return err
+	}
+	if !until.IsZero() {
+		request.EndDate = timestamppb.New(until)
+	}
+
+	if c.IsSet(flagFilter) {
+		filter := c.String(flagFilter)
+		request.Filter = &filter
+	}
+
+	description := describeDogus(doguNames)
+	stream, err := client.QueryForDogu(c.Context, request)
+	if err != nil {
+		return fmt.Errorf("failed to query logs of %s: %w", description, err)
+	}
+
+	return printLogMessages(stream.Recv, description, len(doguNames) != 1, p)
+}
+
+func followLogs(c *cli.Context, client pbLogging.DoguLogMessagesClient, doguName string, p *printer) error {
+	request := &pbLogging.DoguLogMessageFollowRequest{DoguName: doguName}
+	if c.IsSet(flagFilter) {
+		filter := c.String(flagFilter)
+		request.Filter = &filter
+	}
+
+	stream, err := client.FollowForDogu(c.Context, request)
+	if err != nil {
+		return fmt.Errorf("failed to follow logs of dogu %s: %w", doguName, err)
+	}
+
+	err = printLogMessages(stream.Recv, "dogu "+doguName, false, p)
+	if status.Code(err) == codes.Canceled {
+		// following ends when the user interrupts the command
+		return nil
+	}
+
+	return err
+}
+
+// printLogMessages prints every received log message until the stream ends. Labeled messages are printed with the
+// dogu which logged them.
+func printLogMessages(recv func() (*pbLogging.DoguLogMessage, error), description string, labeled bool, p *printer) error {
+	for {
+		message, err := recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive logs of %s: %w", description, err)
+		}
+
+		timestamp := message.GetTimestamp().AsTime().Format(time.RFC3339)
+		switch {
+		case p.format == outputJSON:
+			err = p.printJSONLine(message)
+		case labeled:
+			_, err = fmt.Fprintf(p.writer, "%s %s %s\n", timestamp, message.GetDoguName(), message.GetMessage())
+		default:
+			_, err = fmt.Fprintf(p.writer, "%s %s\n", timestamp, message.GetMessage())
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func describeDogus(doguNames []string) string {
+	if len(doguNames) == 0 {
+		return "all dogus"
+	}
+	if len(doguNames) == 1 {
+		return "dogu " + doguNames[0]
+	}
+
+	return "dogus " + strings.Join(doguNames, ", ")
+}
+
+// receiveChunks writes the data of all received chunks to the given writer until the stream ends.
+func receiveChunks[T chunkedData](recv func() (T, error), writer io.Writer) error {
+	for {
+		chunk, err := recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = writer.Write(chunk.GetData())
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// chunkedData is implemented by the messages of download streams.
+type chunkedData interface {
+	GetData() []byte
+}
+
+// logLine is the json representation of a line of the latest logs.
+type logLine struct {
+	Message string `json:"message"`
+}
+
+// writeZippedLogs extracts the log files of the given zip archive. In json output, every line is written as an
+// object of its own.
+func writeZippedLogs(archive []byte, p *printer) error {
+	if len(archive) == 0 {
+		return nil
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return fmt.Errorf("failed to read log archive: %w", err)
+	}
+
+	for _, file := range reader.File {
+		err = writeZippedLogFile(file, p)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeZippedLogFile(file *zip.File, p *printer) error {
+	content, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s in log archive: %w", file.Name, err)
+	}
+	defer func() { _ = content.Close() }()
+
+	if p.format != outputJSON {
+		_, err = io.Copy(p.writer, content)
+		return err
+	}
+
+	encoder := json.NewEncoder(p.writer)
+	scanner := bufio.NewScanner(content)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		err = encoder.Encode(logLine{Message: scanner.Text()})
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	pbLogging "github.com/cloudogu/ces-control-api/generated/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type testChunk []byte
+
+func (c testChunk) GetData() []byte {
+	return c
+}
+
+func chunkReceiver(chunks []testChunk, err error) func() (testChunk, error) {
+	return func() (testChunk, error) {
+		if len(chunks) == 0 {
+			return nil, err
+		}
+		chunk := chunks[0]
+		chunks = chunks[1:]
+		return chunk, nil
+	}
+}
+
+func Test_receiveChunks(t *testing.T) {
+	t.Run("should write all chunks", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+
+		// when
+		err := receiveChunks(chunkReceiver([]testChunk{testChunk("PK"), testChunk("zip")}, io.EOF), out)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "PKzip", out.String())
+	})
+	t.Run("should fail if receiving fails", func(t *testing.T) {
+		// when
+		err := receiveChunks(chunkReceiver([]testChunk{testChunk("PK")}, assert.AnError), &bytes.Buffer{})
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func createLogArchive(t *testing.T, content string) []byte {
+	t.Helper()
+	archive := &bytes.Buffer{}
+	writer := zip.NewWriter(archive)
+	file, err := writer.Create("cas.log")
+	require.NoError(t, err)
+	_, err = file.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return archive.Bytes()
+}
+
+func Test_writeZippedLogs(t *testing.T) {
+	t.Run("should write log lines", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		p, _ := newPrinter(out, outputTable)
+
+		// when
+		err := writeZippedLogs(createLogArchive(t, "first\nsecond\n"), p)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "first\nsecond\n", out.String())
+	})
+	t.Run("should write log lines as json", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		p, _ := newPrinter(out, outputJSON)
+
+		// when
+		err := writeZippedLogs(createLogArchive(t, "first\nsecond\n"), p)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "{\"message\":\"first\"}\n{\"message\":\"second\"}\n", out.String())
+	})
+	t.Run("should write nothing for empty archive", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		p, _ := newPrinter(out, outputTable)
+
+		// when
+		err := writeZippedLogs(nil, p)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, out.String())
+	})
+	t.Run("should fail for invalid archive", func(t *testing.T) {
+		// given
+		p, _ := newPrinter(&bytes.Buffer{}, outputTable)
+
+		// when
+		err := writeZippedLogs([]byte("no zip"), p)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read log archive")
+	})
+}
+
+func Test_printLogMessages(t *testing.T) {
+	messages := []*pbLogging.DoguLogMessage{
+		{Timestamp: timestamppb.New(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)), Message: "first", DoguName: "cas"},
+		{Timestamp: timestamppb.New(time.Date(2026, 10, 1, 12, 0, 1, 0, time.UTC)), Message: "second", DoguName: "ldap"},
+	}
+	receiver := func(err error) func() (*pbLogging.DoguLogMessage, error) {
+		remaining := messages
+		return func() (*pbLogging.DoguLogMessage, error) {
+			if len(remaining) == 0 {
+				return nil, err
+			}
+			message := remaining[0]
+			remaining = remaining[1:]
+			return message, nil
+		}
+	}
+
+	t.Run("should print messages with timestamp until the stream ends", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		p, _ := newPrinter(out, outputTable)
+
+		// when
+		err := printLogMessages(receiver(io.EOF), "dogu cas", false, p)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "2026-10-01T12:00:00Z first\n2026-10-01T12:00:01Z second\n", out.String())
+	})
+	t.Run("should print messages with their dogu", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		p, _ := newPrinter(out, outputTable)
+
+		// when
+		err := printLogMessages(receiver(io.EOF), "all dogus", true, p)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "2026-10-01T12:00:00Z cas first\n2026-10-01T12:00:01Z ldap second\n", out.String())
+	})
+	t.Run("should fail if receiving fails", func(t *testing.T) {
+		// given
+		p, _ := newPrinter(&bytes.Buffer{}, outputTable)
+
+		// when
+		err := printLogMessages(receiver(assert.AnError), "dogu cas", false, p)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to receive logs of dogu cas")
+	})
+}
+
+func Test_describeDogus(t *testing.T) {
+	assert.Equal(t, "all dogus", describeDogus(nil))
+	assert.Equal(t, "dogu cas", describeDogus([]string{"cas"}))
+	assert.Equal(t, "dogus cas, ldap", describeDogus([]string{"cas", "ldap"}))
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// table contains the human-readable representation of a response.
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+// printer writes responses either as a table or as json.
+type printer struct {
+	writer io.Writer
+	format string
+}
+
+func newPrinter(writer io.Writer, format string) (*printer, error) {
+	switch format {
+	case outputTable, outputJSON:
+		return &printer{writer: writer, format: format}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q; use %s or %s", format, outputTable, outputJSON)
+	}
+}
+
+// print writes the given message as json or the given table, depending on the output format.
+func (p *printer) print(message proto.Message, t table) error {
+	if p.format == outputJSON {
+		return p.printJSON(message)
+	}
+
+	return p.printTable(t)
+}
+
+// printMessage writes the given message as json or the given text, depending on the output format. It is used for
+// responses without a meaningful table, e.g. of mutating calls.
+func (p *printer) printMessage(message proto.Message, text string) error {
+	if p.format == outputJSON {
+		return p.printJSON(message)
+	}
+
+	_, err := fmt.Fprintln(p.writer, text)
+	return err
+}
+
+func (p *printer) printJSON(message proto.Message) error {
+	content, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	_, err = fmt.Fprintln(p.writer, string(content))
+	return err
+}
+
+// printJSONObject writes the given messages as fields of a single json object. It is used for commands combining the
+// responses of several calls.
+func (p *printer) printJSONObject(messages map[string]proto.Message) error {
+	fields := map[string]json.RawMessage{}
+	for name, message := range messages {
+		content, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("failed to marshal response: %w", err)
+		}
+		fields[name] = content
+	}
+
+	content, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	_, err = fmt.Fprintln(p.writer, string(content))
+	return err
+}
+
+// printJSONLine writes the given message as a single line of json. It is used for streamed responses.
+func (p *printer) printJSONLine(message proto.Message) error {
+	content, err := protojson.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	_, err = fmt.Fprintln(p.writer, string(content))
+	return err
+}
+
+func (p *printer) printTable(t table) error {
+	writer := tabwriter.NewWriter(p.writer, 0, 0, 3, ' ', 0)
+	_, err := fmt.Fprintln(writer, strings.Join(t.headers, "\t"))
+	if err != nil {
+		return err
+	}
+
+	for _, row := range t.rows {
+		_, err = fmt.Fprintln(writer, strings.Join(row, "\t"))
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func Test_newPrinter(t *testing.T) {
+	t.Run("should fail for unsupported format", func(t *testing.T) {
+		// when
+		_, err := newPrinter(&bytes.Buffer{}, "yaml")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unsupported output format \"yaml\"")
+	})
+}
+
+func Test_printer_print(t *testing.T) {
+	message, _ := structpb.NewStruct(map[string]any{"name": "cas", "version": "7.0.5.1-6"})
+	t.Run("should print table", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		sut, _ := newPrinter(out, outputTable)
+
+		// when
+		err := sut.print(message, table{
+			headers: []string{"NAME", "VERSION"},
+			rows:    [][]string{{"cas", "7.0.5.1-6"}, {"postgresql", "14.15-2"}},
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "NAME         VERSION\ncas          7.0.5.1-6\npostgresql   14.15-2\n", out.String())
+	})
+	t.Run("should print json", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		sut, _ := newPrinter(out, outputJSON)
+
+		// when
+		err := sut.print(message, table{})
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name": "cas", "version": "7.0.5.1-6"}`, out.String())
+	})
+}
+
+func Test_printer_printMessage(t *testing.T) {
+	t.Run("should print text", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		sut, _ := newPrinter(out, outputTable)
+
+		// when
+		err := sut.printMessage(&structpb.Struct{}, "restarted dogu cas")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "restarted dogu cas\n", out.String())
+	})
+	t.Run("should print json", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		sut, _ := newPrinter(out, outputJSON)
+
+		// when
+		err := sut.printMessage(&structpb.Struct{}, "restarted dogu cas")
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t, `{}`, out.String())
+	})
+}
+
+func Test_printer_printJSONObject(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	sut, _ := newPrinter(out, outputJSON)
+	schedule, _ := structpb.NewStruct(map[string]any{"schedule": "0 0 * * *"})
+
+	// when
+	err := sut.printJSONObject(map[string]proto.Message{"schedule": schedule, "empty": &structpb.Struct{}})
+
+	// then
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"schedule": {"schedule": "0 0 * * *"}, "empty": {}}`, out.String())
+}
@@ -0,0 +1,229 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	pbMaintenance "github.com/cloudogu/ces-control-api/generated/maintenance"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	flagExclude = "exclude"
+	flagFile    = "file"
+
+	excludeSystemState   = "system-state"
+	excludeSensitiveData = "sensitive-data"
+	excludeLogs          = "logs"
+	excludeEvents        = "events"
+	excludeVolumeInfo    = "volume-info"
+	excludeSystemInfo    = "system-info"
+)
+
+func supportArchiveCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "support-archive",
+		Usage: "create, list, download and delete support archives",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a support archive",
+				Flags: withClientFlags(
+					&cli.StringSliceFlag{
+						Name: flagExclude,
+						Usage: fmt.Sprintf("contents to exclude: %s, %s, %s, %s, %s or %s",
+							excludeSystemState, excludeSensitiveData, excludeLogs, excludeEvents, excludeVolumeInfo, excludeSystemInfo),
+					},
+					&cli.StringFlag{
+						Name:  flagSince,
+						Usage: "start of the logs and events, either a duration like 48h or a timestamp; defaults to 4 days ago",
+					},
+					&cli.StringFlag{
+						Name:  flagUntil,
+						Usage: "end of the logs and events, either a duration or a timestamp; defaults to now",
+					},
+				),
+				Action: clientAction(createSupportArchive),
+			},
+			{
+				Name:   "list",
+				Usage:  "list the support archives",
+				Flags:  withClientFlags(),
+				Action: clientAction(listSupportArchives),
+			},
+			{
+				Name:      "download",
+				Usage:     "download a support archive",
+				ArgsUsage: "<archive>",
+				Flags: withClientFlags(
+					&cli.StringFlag{
+						Name:    flagFile,
+						Aliases: []string{"f"},
+						Usage:   "file to write the archive to or - for stdout; defaults to <archive>.zip",
+					},
+				),
+				Action: clientAction(downloadSupportArchive),
+			},
+			{
+				Name:      "delete",
+				Usage:     "delete a support archive",
+				ArgsUsage: "<archive>",
+				Flags:     withClientFlags(),
+				Action:    clientAction(deleteSupportArchive),
+			},
+		},
+	}
+}
+
+func createSupportArchive(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	excluded, err := excludedContents(c.StringSlice(flagExclude))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	timeframe := &pbMaintenance.ContentTimeframe{}
+	since, err := parseTime(c.String(flagSince), now)
+	if err != nil {
+		return err
+	}
+	if !since.IsZero() {
+		timeframe.StartDateTime = timestamppb.New(since)
+	}
+
+	until, err := parseTime(c.String(flagUntil), now)
+	if err != nil {
+		return err
+	}
+	if !until.IsZero() {
+		timeframe.EndDateTime = timestamppb.New(until)
+	}
+
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	response, err := pbMaintenance.NewSupportArchiveClient(conn).Create(ctx, &pbMaintenance.CreateSupportArchiveRequest{
+		ExcludedContents: excluded,
+		ContentTimeframe: timeframe,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create support archive: %w", err)
+	}
+
+	return p.printMessage(response, "requested support archive; use 'support-archive list' to follow its status")
+}
+
+func excludedContents(names []string) (*pbMaintenance.ExcludedContents, error) {
+	excluded := &pbMaintenance.ExcludedContents{}
+	for _, name := range names {
+		switch name {
+		case excludeSystemState:
+			excluded.SystemState = true
+		case excludeSensitiveData:
+			excluded.SensitiveData = true
+		case excludeLogs:
+			excluded.Logs = true
+		case excludeEvents:
+			excluded.Events = true
+		case excludeVolumeInfo:
+			excluded.VolumeInfo = true
+		case excludeSystemInfo:
+			excluded.SystemInfo = true
+		default:
+			return nil, fmt.Errorf("unknown content %q to exclude", name)
+		}
+	}
+
+	return excluded, nil
+}
+
+func listSupportArchives(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	response, err := pbMaintenance.NewSupportArchiveClient(conn).AllSupportArchives(ctx, &pbMaintenance.GetAllSupportArchivesRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list support archives: %w", err)
+	}
+
+	t := table{headers: []string{"NAME", "CREATED", "STATUS"}}
+	for _, archive := range response.GetSupportArchives() {
+		t.rows = append(t.rows, []string{
+			archive.GetName(),
+			archive.GetCreatedDateTime().AsTime().Format(time.RFC3339),
+			archive.GetStatus().String(),
+		})
+	}
+
+	return p.print(response, t)
+}
+
+func downloadSupportArchive(c *cli.Context, conn grpc.ClientConnInterface, _ *printer) error {
+	err := requireArgs(c, 1)
+	if err != nil {
+		return err
+	}
+
+	name := c.Args().First()
+	stream, err := pbMaintenance.NewSupportArchiveClient(conn).DownloadSupportArchive(c.Context, &pbMaintenance.DownloadSupportArchiveRequest{Name: name})
+	if err != nil {
+		return fmt.Errorf("failed to download support archive %s: %w", name, err)
+	}
+
+	file := c.String(flagFile)
+	if file == "" {
+		file = name + ".zip"
+	}
+
+	return writeDownload(file, c.App.Writer, func(writer io.Writer) error {
+		return receiveChunks(stream.Recv, writer)
+	})
+}
+
+// writeDownload writes a download to the given file or to stdout if the file is "-". A partially written file is
+// removed if the download fails.
+func writeDownload(file string, stdout io.Writer, download func(io.Writer) error) error {
+	if file == "-" {
+		return download(stdout)
+	}
+
+	out, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", file, err)
+	}
+
+	err = download(out)
+	closeErr := out.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file)
+		return fmt.Errorf("failed to download to %s: %w", file, err)
+	}
+
+	_, err = fmt.Fprintf(stdout, "downloaded to %s\n", file)
+	return err
+}
+
+func deleteSupportArchive(c *cli.Context, conn grpc.ClientConnInterface, p *printer) error {
+	err := requireArgs(c, 1)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := callContext(c)
+	defer cancel()
+
+	name := c.Args().First()
+	response, err := pbMaintenance.NewSupportArchiveClient(conn).DeleteSupportArchive(ctx, &pbMaintenance.DeleteSupportArchiveRequest{Name: name})
+	if err != nil {
+		return fmt.Errorf("failed to delete support archive %s: %w", name, err)
+	}
+
+	return p.printMessage(response, fmt.Sprintf("deleted support archive %s", name))
+}
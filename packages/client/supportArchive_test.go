@@ -0,0 +1,83 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_excludedContents(t *testing.T) {
+	t.Run("should exclude the given contents", func(t *testing.T) {
+		// when
+		excluded, err := excludedContents([]string{"logs", "sensitive-data"})
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, excluded.Logs)
+		assert.True(t, excluded.SensitiveData)
+		assert.False(t, excluded.Events)
+		assert.False(t, excluded.SystemState)
+	})
+	t.Run("should fail for unknown content", func(t *testing.T) {
+		// when
+		_, err := excludedContents([]string{"passwords"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown content \"passwords\"")
+	})
+}
+
+func Test_writeDownload(t *testing.T) {
+	t.Run("should write to file", func(t *testing.T) {
+		// given
+		file := filepath.Join(t.TempDir(), "archive.zip")
+		stdout := &bytes.Buffer{}
+
+		// when
+		err := writeDownload(file, stdout, func(writer io.Writer) error {
+			_, err := writer.Write([]byte("PKzip"))
+			return err
+		})
+
+		// then
+		require.NoError(t, err)
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "PKzip", string(content))
+		assert.Equal(t, "downloaded to "+file+"\n", stdout.String())
+	})
+	t.Run("should write to stdout", func(t *testing.T) {
+		// given
+		stdout := &bytes.Buffer{}
+
+		// when
+		err := writeDownload("-", stdout, func(writer io.Writer) error {
+			_, err := writer.Write([]byte("PKzip"))
+			return err
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "PKzip", stdout.String())
+	})
+	t.Run("should remove partial file if download fails", func(t *testing.T) {
+		// given
+		file := filepath.Join(t.TempDir(), "archive.zip")
+
+		// when
+		err := writeDownload(file, &bytes.Buffer{}, func(writer io.Writer) error {
+			_, _ = writer.Write([]byte("PK"))
+			return assert.AnError
+		})
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.NoFileExists(t, file)
+	})
+}
@@ -1,8 +1,14 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
 
 	"github.com/bombsimon/logrusr/v2"
 	backupClientV1 "github.com/cloudogu/k8s-backup-lib/api/ecosystem"
@@ -31,6 +37,47 @@ const (
 	lokiGatewayUrlEnvironmentVariable      = "LOKI_GATEWAY_URL"
 	lokiGatewayUsernameEnvironmentVariable = "LOKI_GATEWAY_USERNAME"
 	lokiGatewayPasswordEnvironmentVariable = "LOKI_GATEWAY_PASSWORD"
+
+	lokiGatewayUsernameFileEnvironmentVariable = "LOKI_GATEWAY_USERNAME_FILE"
+	lokiGatewayPasswordFileEnvironmentVariable = "LOKI_GATEWAY_PASSWORD_FILE"
+	lokiGatewayTokenFileEnvironmentVariable    = "LOKI_GATEWAY_TOKEN_FILE"
+	lokiGatewayCaFileEnvironmentVariable       = "LOKI_GATEWAY_CA_FILE"
+
+	logBackendEnvironmentVariable = "LOG_BACKEND"
+
+	openSearchUrlEnvironmentVariable          = "OPENSEARCH_URL"
+	openSearchIndexEnvironmentVariable        = "OPENSEARCH_INDEX"
+	defaultOpenSearchIndex                    = "fluent-bit"
+	openSearchUsernameEnvironmentVariable     = "OPENSEARCH_USERNAME"
+	openSearchPasswordEnvironmentVariable     = "OPENSEARCH_PASSWORD"
+	openSearchUsernameFileEnvironmentVariable = "OPENSEARCH_USERNAME_FILE"
+	openSearchPasswordFileEnvironmentVariable = "OPENSEARCH_PASSWORD_FILE"
+	openSearchCaFileEnvironmentVariable       = "OPENSEARCH_CA_FILE"
+
+	tlsCertFileEnvironmentVariable          = "TLS_CERT_FILE"
+	tlsKeyFileEnvironmentVariable           = "TLS_KEY_FILE"
+	tlsClientCaFileEnvironmentVariable      = "TLS_CLIENT_CA_FILE"
+	tlsRequireClientCertEnvironmentVariable = "TLS_REQUIRE_CLIENT_CERT"
+
+	authConfigFileEnvironmentVariable = "AUTH_CONFIG_FILE"
+
+	auditLogFileEnvironmentVariable          = "AUDIT_LOG_FILE"
+	auditKubernetesEventsEnvironmentVariable = "AUDIT_KUBERNETES_EVENTS"
+
+	shutdownTimeoutEnvironmentVariable  = "SHUTDOWN_TIMEOUT"
+	defaultShutdownTimeout              = 30 * time.Second
+	lokiQueryTimeoutEnvironmentVariable = "LOKI_QUERY_TIMEOUT"
+	defaultLokiQueryTimeout             = 30 * time.Second
+	doguWaitTimeoutEnvironmentVariable  = "DOGU_WAIT_TIMEOUT"
+	defaultDoguWaitTimeout              = 10 * time.Minute
+
+	gatewayAddressEnvironmentVariable = "GATEWAY_ADDRESS"
+
+	listenAddressEnvironmentVariable = "LISTEN_ADDRESS"
+	defaultListenAddress             = ":50051"
+
+	debugModeWatchIntervalEnvironmentVariable = "DEBUG_MODE_WATCH_INTERVAL"
+	defaultDebugModeWatchInterval             = 30 * time.Second
 )
 
 type clusterClient struct {
@@ -105,29 +152,25 @@ func CreateClusterClient() (*clusterClient, error) {
 }
 
 // ConfigureApplication performs the default configuration for the control app including configuring the logging and
-// current stage of the system.
+// current stage of the system. The settings are read from the optional configuration file and the environment
+// variables, which override the values of the file. All invalid settings are reported at once.
 func ConfigureApplication() error {
-	err := configureLogLevel()
-	if err != nil {
-		return err
-	}
-
-	err = configureNamespace()
-	if err != nil {
-		return err
-	}
-
-	err = configureCurrentStage()
-	if err != nil {
-		return err
-	}
-
-	err = configureLokiGateway()
-	if err != nil {
-		return err
-	}
-
-	return nil
+	var errs []error
+	// the configuration file must be read first because every other setting may be set in it
+	errs = append(errs, configureConfigFile())
+	errs = append(errs, configureLogLevel())
+	errs = append(errs, configureNamespace())
+	errs = append(errs, configureCurrentStage())
+	errs = append(errs, configureListenAddress())
+	errs = append(errs, configureLogBackend())
+	errs = append(errs, configureTLS())
+	configureAuth()
+	errs = append(errs, configureAudit())
+	errs = append(errs, configureTimeouts())
+	errs = append(errs, configureDebugModeWatchInterval())
+	errs = append(errs, configureGateway())
+
+	return errors.Join(errs...)
 }
 
 // IsDevelopmentStage return true whether the current stage is set to development.
@@ -136,9 +179,9 @@ func IsDevelopmentStage() bool {
 }
 
 func configureCurrentStage() error {
-	stage, ok := os.LookupEnv(stagingEnvironmentVariable)
+	stage, ok := stageSetting.lookup()
 	if !ok {
-		logrus.Printf("No stage was set via the environment variable [%s]. Using default stage [production].", stagingEnvironmentVariable)
+		logrus.Printf("No stage was set via the %s. Using default stage [production].", stageSetting)
 		currentStage = stageProduction
 		return nil
 	}
@@ -148,7 +191,7 @@ func configureCurrentStage() error {
 	} else if stage == stageDevelopment {
 		logrus.Warningf("Using stage [development]. This is not recommended for production systems!")
 	} else {
-		return fmt.Errorf("found invalid value [%s] for environment variable [%s], only the values [production, development] are valid values", stage, stagingEnvironmentVariable)
+		return fmt.Errorf("found invalid value [%s] for %s, only the values [production, development] are valid values", stage, stageSetting)
 	}
 
 	currentStage = stage
@@ -159,14 +202,14 @@ func configureCurrentStage() error {
 var CurrentNamespace = ""
 
 func configureNamespace() error {
-	namespace, ok := os.LookupEnv(namespaceEnvironmentVariable)
+	namespace, ok := namespaceSetting.lookup()
 	if !ok {
-		logrus.Errorf("No namespace was set via the environment variable [%s]. A namespace is required.", namespaceEnvironmentVariable)
+		logrus.Errorf("No namespace was set via the %s. A namespace is required.", namespaceSetting)
 	}
 
 	CurrentNamespace = namespace
 	if CurrentNamespace == "" {
-		return fmt.Errorf("found invalid value for namespace [%s]: namespace cannot be empty: set valid value with environment variable [%s]", CurrentNamespace, namespaceEnvironmentVariable)
+		return fmt.Errorf("found invalid value for namespace [%s]: namespace cannot be empty: set valid value with %s", CurrentNamespace, namespaceSetting)
 	}
 
 	logrus.Infof("Using namespace [%s].", CurrentNamespace)
@@ -175,66 +218,465 @@ func configureNamespace() error {
 }
 
 func configureLogLevel() error {
-	logLevel, ok := os.LookupEnv(logLevelEnvironmentVariable)
+	logLevel, err := parseLogLevel()
+	if err != nil {
+		return err
+	}
+
+	applyLogLevel(logLevel)
+	return nil
+}
+
+func parseLogLevel() (logrus.Level, error) {
+	logLevel, ok := logLevelSetting.lookup()
 	if !ok {
-		logrus.SetLevel(defaultLogLevel)
-		return nil
+		return defaultLogLevel, nil
 	}
 
 	logLevelParsed, err := logrus.ParseLevel(logLevel)
 	if err != nil {
-		return fmt.Errorf("could not parse log level %s to logrus level: %w", logLevel, err)
+		return defaultLogLevel, fmt.Errorf("could not parse log level %s to logrus level: %w", logLevel, err)
 	}
 
-	logrus.StandardLogger().SetLevel(logLevelParsed)
-	logrus.Infof("Using log level: %s", logLevelParsed)
+	return logLevelParsed, nil
+}
+
+func applyLogLevel(logLevel logrus.Level) {
+	logrus.StandardLogger().SetLevel(logLevel)
+	logrus.Infof("Using log level: %s", logLevel)
 
 	// create logrus logger that can be styled and formatted
 	logrusLog := logrus.New()
 	logrusLog.SetFormatter(&logrus.TextFormatter{})
-	logrusLog.SetLevel(logLevelParsed)
+	logrusLog.SetLevel(logLevel)
 
 	// convert logrus logger to logr logger
 	logrusLogrLogger := logrusr.New(logrusLog)
 	log.SetLogger(logrusLogrLogger)
+}
+
+// CurrentListenAddress is the address the grpc server listens on.
+var CurrentListenAddress = defaultListenAddress
 
+func configureListenAddress() error {
+	address, ok := listenAddressSetting.lookup()
+	if !ok || address == "" {
+		CurrentListenAddress = defaultListenAddress
+		return nil
+	}
+
+	_, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("found invalid value [%s] for %s, only addresses like :50051 are valid: %w", address, listenAddressSetting, err)
+	}
+
+	CurrentListenAddress = address
 	return nil
 }
 
+// LogBackend is the backend the logs of the dogus are read from.
+type LogBackend string
+
+const (
+	LogBackendLoki       LogBackend = "loki"
+	LogBackendKubernetes LogBackend = "kubernetes"
+	LogBackendOpenSearch LogBackend = "opensearch"
+)
+
+var logBackends = []LogBackend{LogBackendLoki, LogBackendKubernetes, LogBackendOpenSearch}
+
+// CurrentLogBackend is the backend the logs of the dogus are read from. Only the configuration of this backend is
+// loaded.
+var CurrentLogBackend = LogBackendLoki
+
+func configureLogBackend() error {
+	backend, ok := logBackendSetting.lookup()
+	if !ok || backend == "" {
+		backend = string(LogBackendLoki)
+	}
+
+	if !slices.Contains(logBackends, LogBackend(backend)) {
+		return fmt.Errorf("found invalid value [%s] for %s, only the values %v are valid values", backend, logBackendSetting, logBackends)
+	}
+
+	CurrentLogBackend = LogBackend(backend)
+	logrus.Infof("Reading dogu logs from [%s].", CurrentLogBackend)
+
+	switch CurrentLogBackend {
+	case LogBackendLoki:
+		return configureLokiGateway()
+	case LogBackendOpenSearch:
+		return configureOpenSearch()
+	default:
+		return nil
+	}
+}
+
+// LokiGatewayConfig contains the address of the loki gateway and how to authenticate against it. Either a token file
+// or a username and a password, each given as value or as file, are set.
 type LokiGatewayConfig struct {
-	Url      string
-	Username string
-	Password string
+	Url          string
+	Username     string
+	UsernameFile string
+	Password     string
+	PasswordFile string
+	// TokenFile contains a bearer token which is used instead of basic auth.
+	TokenFile string
+	// CAFile contains the CA bundle to verify the certificate of the gateway. The system CAs are used if it is empty.
+	CAFile string
 }
 
 var CurrentLokiGatewayConfig *LokiGatewayConfig
 
 func configureLokiGateway() error {
-	url, ok := os.LookupEnv(lokiGatewayUrlEnvironmentVariable)
-	if !ok || url == "" {
-		logrus.Errorf("No loki gateway url was set via the environment variable [%s]. A loki gateway url is required.", lokiGatewayUrlEnvironmentVariable)
+	var errs []error
+
+	gatewayUrl := lokiGatewayUrlSetting.get()
+	if gatewayUrl == "" {
+		errs = append(errs, fmt.Errorf("no loki gateway url was set via the %s: a loki gateway url is required", lokiGatewayUrlSetting))
+	} else if parsedUrl, err := url.Parse(gatewayUrl); err != nil || parsedUrl.Scheme == "" || parsedUrl.Host == "" {
+		errs = append(errs, fmt.Errorf("found invalid value [%s] for %s, only absolute urls like http://k8s-loki-gateway.ecosystem.svc.cluster.local:80 are valid", gatewayUrl, lokiGatewayUrlSetting))
 	}
 
-	username, ok := os.LookupEnv(lokiGatewayUsernameEnvironmentVariable)
-	if !ok || username == "" {
-		logrus.Errorf("No loki gateway username was set via the environment variable [%s]. A loki gateway username is required.", lokiGatewayUsernameEnvironmentVariable)
+	lokiConfig := &LokiGatewayConfig{
+		Url:          gatewayUrl,
+		Username:     lokiGatewayUsernameSetting.get(),
+		UsernameFile: lokiGatewayUsernameFileSetting.get(),
+		Password:     lokiGatewayPasswordSetting.get(),
+		PasswordFile: lokiGatewayPasswordFileSetting.get(),
+		TokenFile:    lokiGatewayTokenFileSetting.get(),
+		CAFile:       lokiGatewayCaFileSetting.get(),
 	}
 
-	password, ok := os.LookupEnv(lokiGatewayPasswordEnvironmentVariable)
-	if !ok || password == "" {
-		logrus.Errorf("No loki gateway password was set via the environment variable [%s]. A loki gateway password is required.", lokiGatewayPasswordEnvironmentVariable)
+	if lokiConfig.TokenFile != "" {
+		if lokiConfig.Username != "" || lokiConfig.UsernameFile != "" || lokiConfig.Password != "" || lokiConfig.PasswordFile != "" {
+			errs = append(errs, fmt.Errorf("the %s cannot be combined with a loki gateway username or password", lokiGatewayTokenFileSetting))
+		}
+	} else {
+		errs = append(errs, validateCredential("loki gateway", "username", lokiConfig.Username, lokiGatewayUsernameSetting, lokiConfig.UsernameFile, lokiGatewayUsernameFileSetting))
+		errs = append(errs, validateCredential("loki gateway", "password", lokiConfig.Password, lokiGatewayPasswordSetting, lokiConfig.PasswordFile, lokiGatewayPasswordFileSetting))
 	}
 
-	CurrentLokiGatewayConfig = &LokiGatewayConfig{
-		Url:      url,
-		Username: username,
-		Password: password,
+	err := errors.Join(errs...)
+	if err != nil {
+		return err
 	}
+
+	CurrentLokiGatewayConfig = lokiConfig
 	logrus.Info("Loki gateway configuration loaded successfully")
 
 	return nil
 }
 
+// OpenSearchConfig contains the address of an OpenSearch or Elasticsearch compatible endpoint, the index pattern of
+// the logs and how to authenticate against it. The username and password are each given as value or as file.
+type OpenSearchConfig struct {
+	Url          string
+	Index        string
+	Username     string
+	UsernameFile string
+	Password     string
+	PasswordFile string
+	// CAFile contains the CA bundle to verify the certificate of the endpoint. The system CAs are used if it is empty.
+	CAFile string
+}
+
+var CurrentOpenSearchConfig *OpenSearchConfig
+
+func configureOpenSearch() error {
+	var errs []error
+
+	openSearchUrl := openSearchUrlSetting.get()
+	if openSearchUrl == "" {
+		errs = append(errs, fmt.Errorf("no opensearch url was set via the %s: an opensearch url is required for the log backend [%s]", openSearchUrlSetting, LogBackendOpenSearch))
+	} else if parsedUrl, err := url.Parse(openSearchUrl); err != nil || parsedUrl.Scheme == "" || parsedUrl.Host == "" {
+		errs = append(errs, fmt.Errorf("found invalid value [%s] for %s, only absolute urls like https://opensearch.logging.svc.cluster.local:9200 are valid", openSearchUrl, openSearchUrlSetting))
+	}
+
+	index := openSearchIndexSetting.get()
+	if index == "" {
+		index = defaultOpenSearchIndex
+	}
+
+	openSearchConfig := &OpenSearchConfig{
+		Url:          openSearchUrl,
+		Index:        index,
+		Username:     openSearchUsernameSetting.get(),
+		UsernameFile: openSearchUsernameFileSetting.get(),
+		Password:     openSearchPasswordSetting.get(),
+		PasswordFile: openSearchPasswordFileSetting.get(),
+		CAFile:       openSearchCaFileSetting.get(),
+	}
+
+	errs = append(errs, validateCredential("opensearch", "username", openSearchConfig.Username, openSearchUsernameSetting, openSearchConfig.UsernameFile, openSearchUsernameFileSetting))
+	errs = append(errs, validateCredential("opensearch", "password", openSearchConfig.Password, openSearchPasswordSetting, openSearchConfig.PasswordFile, openSearchPasswordFileSetting))
+
+	err := errors.Join(errs...)
+	if err != nil {
+		return err
+	}
+
+	CurrentOpenSearchConfig = openSearchConfig
+	logrus.Infof("Using opensearch index [%s] at [%s].", index, openSearchUrl)
+
+	return nil
+}
+
+func validateCredential(service string, name string, value string, valueSetting setting, file string, fileSetting setting) error {
+	if value == "" && file == "" {
+		return fmt.Errorf("no %s %s was set via the %s or the %s: a %s %s is required", service, name, valueSetting, fileSetting, service, name)
+	}
+
+	if value != "" && file != "" {
+		return fmt.Errorf("the %s and the %s must not be set together", valueSetting, fileSetting)
+	}
+
+	return nil
+}
+
+// TLSConfig contains the paths to the mounted certificate files used to secure the grpc server.
+type TLSConfig struct {
+	CertFile          string
+	KeyFile           string
+	ClientCAFile      string
+	RequireClientCert bool
+}
+
+// Enabled returns true if a server certificate is configured.
+func (c *TLSConfig) Enabled() bool {
+	return c != nil && c.CertFile != ""
+}
+
+var CurrentTLSConfig *TLSConfig
+
+func configureTLS() error {
+	certFile := tlsCertFileSetting.get()
+	keyFile := tlsKeyFileSetting.get()
+	clientCAFile := tlsClientCaFileSetting.get()
+
+	requireClientCert, err := parseBool(tlsRequireClientCertSetting)
+	if err != nil {
+		return err
+	}
+
+	if (certFile == "") != (keyFile == "") {
+		return fmt.Errorf("the %s and the %s must be set together", tlsCertFileSetting, tlsKeyFileSetting)
+	}
+
+	if certFile == "" && (clientCAFile != "" || requireClientCert) {
+		return fmt.Errorf("client certificates can only be used if tls is enabled via the %s and the %s", tlsCertFileSetting, tlsKeyFileSetting)
+	}
+
+	if requireClientCert && clientCAFile == "" {
+		return fmt.Errorf("the %s is required if the %s is set to true", tlsClientCaFileSetting, tlsRequireClientCertSetting)
+	}
+
+	CurrentTLSConfig = &TLSConfig{
+		CertFile:          certFile,
+		KeyFile:           keyFile,
+		ClientCAFile:      clientCAFile,
+		RequireClientCert: requireClientCert,
+	}
+
+	if !CurrentTLSConfig.Enabled() {
+		logrus.Warnf("No server certificate was set via the %s and the %s. The server will accept plaintext connections.", tlsCertFileSetting, tlsKeyFileSetting)
+		return nil
+	}
+
+	logrus.Infof("Using tls with certificate [%s] (client certificates required: %t).", certFile, requireClientCert)
+
+	return nil
+}
+
+// AuthConfig contains the path to the mounted file which maps bearer tokens and client certificates to roles.
+type AuthConfig struct {
+	ConfigFile string
+}
+
+// Enabled returns true if an auth configuration file is configured.
+func (c *AuthConfig) Enabled() bool {
+	return c != nil && c.ConfigFile != ""
+}
+
+var CurrentAuthConfig *AuthConfig
+
+func configureAuth() {
+	CurrentAuthConfig = &AuthConfig{
+		ConfigFile: authConfigFileSetting.get(),
+	}
+
+	if !CurrentAuthConfig.Enabled() {
+		logrus.Warnf("No auth configuration was set via the %s. Every client will be allowed to call every method.", authConfigFileSetting)
+		return
+	}
+
+	logrus.Infof("Using auth configuration [%s].", CurrentAuthConfig.ConfigFile)
+}
+
+// AuditConfig contains the settings of the audit log of mutating grpc calls.
+type AuditConfig struct {
+	// LogFile is the file the audit records are appended to. The records are written to stdout if it is empty.
+	LogFile string
+	// KubernetesEvents enables mirroring the audit records as events on the affected dogu and backup resources.
+	KubernetesEvents bool
+}
+
+var CurrentAuditConfig *AuditConfig
+
+func configureAudit() error {
+	kubernetesEvents, err := parseBool(auditKubernetesEventsSetting)
+	if err != nil {
+		return err
+	}
+
+	CurrentAuditConfig = &AuditConfig{
+		LogFile:          auditLogFileSetting.get(),
+		KubernetesEvents: kubernetesEvents,
+	}
+
+	logrus.Infof("Writing audit log to [%s] (kubernetes events: %t).", CurrentAuditConfig.destination(), kubernetesEvents)
+
+	return nil
+}
+
+func (c *AuditConfig) destination() string {
+	if c.LogFile == "" {
+		return "stdout"
+	}
+
+	return c.LogFile
+}
+
+// TimeoutConfig contains the timeouts of the control app. They are reloaded on a change of the configuration file.
+type TimeoutConfig struct {
+	// Shutdown is the time running calls may take to finish after a termination signal was received.
+	Shutdown time.Duration
+	// LokiQuery is the time a single query to the loki gateway may take.
+	LokiQuery time.Duration
+	// DoguWait is the time to wait for a dogu to be started or stopped.
+	DoguWait time.Duration
+}
+
+var currentTimeouts atomic.Pointer[TimeoutConfig]
+
+func init() {
+	currentTimeouts.Store(&TimeoutConfig{
+		Shutdown:  defaultShutdownTimeout,
+		LokiQuery: defaultLokiQueryTimeout,
+		DoguWait:  defaultDoguWaitTimeout,
+	})
+}
+
+// CurrentTimeouts returns the currently configured timeouts. It is safe to be called while the configuration file is
+// reloaded.
+func CurrentTimeouts() TimeoutConfig {
+	return *currentTimeouts.Load()
+}
+
+func configureTimeouts() error {
+	timeouts, err := parseTimeouts()
+	if err != nil {
+		return err
+	}
+
+	currentTimeouts.Store(timeouts)
+	return nil
+}
+
+func parseTimeouts() (*TimeoutConfig, error) {
+	shutdown, shutdownErr := parsePositiveDuration(shutdownTimeoutSetting, defaultShutdownTimeout)
+	lokiQuery, lokiQueryErr := parsePositiveDuration(lokiQueryTimeoutSetting, defaultLokiQueryTimeout)
+	doguWait, doguWaitErr := parsePositiveDuration(doguWaitTimeoutSetting, defaultDoguWaitTimeout)
+
+	err := errors.Join(shutdownErr, lokiQueryErr, doguWaitErr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TimeoutConfig{
+		Shutdown:  shutdown,
+		LokiQuery: lokiQuery,
+		DoguWait:  doguWait,
+	}, nil
+}
+
+// CurrentDebugModeWatchInterval is the interval in which the informers of the debug mode resync and after which a
+// failed deactivation of the expired debug mode is retried.
+var CurrentDebugModeWatchInterval = defaultDebugModeWatchInterval
+
+func configureDebugModeWatchInterval() error {
+	interval, err := parsePositiveDuration(debugModeWatchIntervalSetting, defaultDebugModeWatchInterval)
+	if err != nil {
+		return err
+	}
+
+	CurrentDebugModeWatchInterval = interval
+	return nil
+}
+
+func parsePositiveDuration(s setting, defaultValue time.Duration) (time.Duration, error) {
+	durationStr, ok := s.lookup()
+	if !ok || durationStr == "" {
+		return defaultValue, nil
+	}
+
+	duration, err := time.ParseDuration(durationStr)
+	if err != nil {
+		return 0, fmt.Errorf("found invalid value [%s] for %s, only durations like 30s are valid: %w", durationStr, s, err)
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("the %s must be a positive duration", s)
+	}
+
+	return duration, nil
+}
+
+func parseBool(s setting) (bool, error) {
+	boolStr, ok := s.lookup()
+	if !ok || boolStr == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(boolStr)
+	if err != nil {
+		return false, fmt.Errorf("found invalid value [%s] for %s, only boolean values are valid: %w", boolStr, s, err)
+	}
+
+	return value, nil
+}
+
+// GatewayConfig contains the settings of the optional http/json gateway to the grpc services.
+type GatewayConfig struct {
+	// Address is the address the gateway listens on, e.g. ":8080". The gateway is disabled if it is empty.
+	Address string
+}
+
+// Enabled returns true if a listen address for the gateway is configured.
+func (c *GatewayConfig) Enabled() bool {
+	return c != nil && c.Address != ""
+}
+
+var CurrentGatewayConfig *GatewayConfig
+
+func configureGateway() error {
+	address := gatewayAddressSetting.get()
+	CurrentGatewayConfig = &GatewayConfig{Address: address}
+
+	if !CurrentGatewayConfig.Enabled() {
+		logrus.Infof("No gateway address was set via the %s. The http gateway is disabled.", gatewayAddressSetting)
+		return nil
+	}
+
+	_, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("found invalid value [%s] for %s, only addresses like :8080 are valid: %w", address, gatewayAddressSetting, err)
+	}
+
+	logrus.Infof("Serving the http gateway at [%s].", address)
+	return nil
+}
+
 // PrintCloudoguLogo prints the awesome cloudogu logo.
 func PrintCloudoguLogo() {
 	logrus.Println("                                     ./////,                    ")
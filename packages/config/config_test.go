@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
@@ -41,6 +42,8 @@ func TestConfigureApplication(t *testing.T) {
 		previousStage, stageExists := setEnv(t, stageEnv, "development")
 		defer cleanupEnv(t, stageEnv, previousStage, stageExists)
 
+		setLokiEnv(t)
+
 		// when
 		err := ConfigureApplication()
 
@@ -68,6 +71,8 @@ func TestConfigureApplication(t *testing.T) {
 		previousStage, stageExists := setEnv(t, stageEnv, "production")
 		defer cleanupEnv(t, stageEnv, previousStage, stageExists)
 
+		setLokiEnv(t)
+
 		// when
 		err := ConfigureApplication()
 
@@ -95,6 +100,8 @@ func TestConfigureApplication(t *testing.T) {
 		previousNamespace, namespaceExists := setEnv(t, namespaceEnv, "ecosystem")
 		defer cleanupEnv(t, namespaceEnv, previousNamespace, namespaceExists)
 
+		setLokiEnv(t)
+
 		// when
 		err := ConfigureApplication()
 
@@ -171,6 +178,96 @@ func TestConfigureApplication(t *testing.T) {
 
 		assert.Equal(t, logrus.InfoLevel, logrus.GetLevel())
 	})
+	t.Run("should report all invalid settings at once", func(t *testing.T) {
+		// given
+		previousStageVar := currentStage
+		defer func() { currentStage = previousStageVar }()
+		previousNamespaceVar := CurrentNamespace
+		defer func() { CurrentNamespace = previousNamespaceVar }()
+		previousLogrusLogLevel := logrus.GetLevel()
+		defer logrus.SetLevel(previousLogrusLogLevel)
+
+		t.Setenv(logLevelEnv, "banana")
+		t.Setenv(namespaceEnv, "")
+		t.Setenv("LOKI_GATEWAY_URL", "")
+		t.Setenv("SHUTDOWN_TIMEOUT", "soon")
+
+		// when
+		err := ConfigureApplication()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "could not parse log level banana to logrus level")
+		assert.ErrorContains(t, err, "namespace cannot be empty")
+		assert.ErrorContains(t, err, "no loki gateway url was set")
+		assert.ErrorContains(t, err, "SHUTDOWN_TIMEOUT")
+	})
+	t.Run("should read settings from the config file and override them with env vars", func(t *testing.T) {
+		// given
+		previousStageVar := currentStage
+		defer func() { currentStage = previousStageVar }()
+		previousNamespaceVar := CurrentNamespace
+		defer func() { CurrentNamespace = previousNamespaceVar }()
+		previousLogrusLogLevel := logrus.GetLevel()
+		defer logrus.SetLevel(previousLogrusLogLevel)
+		previousLokiConfig := CurrentLokiGatewayConfig
+		defer func() { CurrentLokiGatewayConfig = previousLokiConfig }()
+		previousTimeouts := currentTimeouts.Load()
+		defer currentTimeouts.Store(previousTimeouts)
+		defer func() { CurrentListenAddress = defaultListenAddress }()
+		defer func() { fileValues = map[string]string{} }()
+
+		configFile := writeConfigFile(t, `
+logLevel: debug
+namespace: file-namespace
+listenAddress: ":50052"
+loki:
+  url: http://k8s-loki-gateway:80
+  username: admin
+  password: secret
+timeouts:
+  doguWait: 5m
+`)
+		t.Setenv("CONFIG_FILE", configFile)
+		t.Setenv(namespaceEnv, "ecosystem")
+		// the file values are only used if the env vars are not set
+		t.Setenv(logLevelEnv, "")
+		require.NoError(t, os.Unsetenv(logLevelEnv))
+
+		// when
+		err := ConfigureApplication()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, logrus.DebugLevel, logrus.GetLevel())
+		assert.Equal(t, "ecosystem", CurrentNamespace)
+		assert.Equal(t, ":50052", CurrentListenAddress)
+		assert.Equal(t, "admin", CurrentLokiGatewayConfig.Username)
+		assert.Equal(t, 5*time.Minute, CurrentTimeouts().DoguWait)
+	})
+	t.Run("should name the config file key of an invalid value", func(t *testing.T) {
+		// given
+		previousStageVar := currentStage
+		defer func() { currentStage = previousStageVar }()
+		previousNamespaceVar := CurrentNamespace
+		defer func() { CurrentNamespace = previousNamespaceVar }()
+		previousLogrusLogLevel := logrus.GetLevel()
+		defer logrus.SetLevel(previousLogrusLogLevel)
+		defer func() { fileValues = map[string]string{} }()
+
+		t.Setenv("CONFIG_FILE", writeConfigFile(t, "stage: banana\nloki:\n  urll: http://k8s-loki-gateway:80\n"))
+		t.Setenv(namespaceEnv, "ecosystem")
+		t.Setenv(stageEnv, "")
+		require.NoError(t, os.Unsetenv(stageEnv))
+
+		// when
+		err := ConfigureApplication()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown key [loki.urll]")
+		assert.ErrorContains(t, err, "found invalid value [banana] for config file key [stage]")
+	})
 	t.Run("should fail if stage is invalid", func(t *testing.T) {
 		// given
 		previousStageVar := currentStage
@@ -201,6 +298,13 @@ func TestConfigureApplication(t *testing.T) {
 	})
 }
 
+func setLokiEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("LOKI_GATEWAY_URL", "http://k8s-loki-gateway:80")
+	t.Setenv("LOKI_GATEWAY_USERNAME", "admin")
+	t.Setenv("LOKI_GATEWAY_PASSWORD", "secret")
+}
+
 func setEnv(t *testing.T, key, value string) (previous string, exists bool) {
 	t.Helper()
 	previous, exists = os.LookupEnv(key)
@@ -373,3 +477,578 @@ func TestCreateClusterClient(t *testing.T) {
 		assert.NotNil(t, actual.BlueprintLister)
 	})
 }
+
+func Test_configureTLS(t *testing.T) {
+	t.Run("should disable tls if no certificate is set", func(t *testing.T) {
+		// given
+		previousTLSConfig := CurrentTLSConfig
+		defer func() { CurrentTLSConfig = previousTLSConfig }()
+
+		// when
+		err := configureTLS()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, CurrentTLSConfig.Enabled())
+	})
+	t.Run("should set tls config with required client certificates", func(t *testing.T) {
+		// given
+		previousTLSConfig := CurrentTLSConfig
+		defer func() { CurrentTLSConfig = previousTLSConfig }()
+		t.Setenv("TLS_CERT_FILE", "/etc/k8s-ces-control/tls.crt")
+		t.Setenv("TLS_KEY_FILE", "/etc/k8s-ces-control/tls.key")
+		t.Setenv("TLS_CLIENT_CA_FILE", "/etc/k8s-ces-control/ca.crt")
+		t.Setenv("TLS_REQUIRE_CLIENT_CERT", "true")
+
+		// when
+		err := configureTLS()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, CurrentTLSConfig.Enabled())
+		assert.Equal(t, &TLSConfig{
+			CertFile:          "/etc/k8s-ces-control/tls.crt",
+			KeyFile:           "/etc/k8s-ces-control/tls.key",
+			ClientCAFile:      "/etc/k8s-ces-control/ca.crt",
+			RequireClientCert: true,
+		}, CurrentTLSConfig)
+	})
+	t.Run("should fail if key file is missing", func(t *testing.T) {
+		// given
+		t.Setenv("TLS_CERT_FILE", "/etc/k8s-ces-control/tls.crt")
+
+		// when
+		err := configureTLS()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "the environment variable [TLS_CERT_FILE] and the environment variable [TLS_KEY_FILE] must be set together")
+	})
+	t.Run("should name the config file keys if tls is configured in the config file", func(t *testing.T) {
+		// given
+		defer func() { fileValues = map[string]string{} }()
+		fileValues = map[string]string{"tls.keyFile": "/etc/k8s-ces-control/tls.key"}
+
+		// when
+		err := configureTLS()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "the environment variable [TLS_CERT_FILE] and the config file key [tls.keyFile] must be set together")
+	})
+	t.Run("should fail for client CA without server certificate", func(t *testing.T) {
+		// given
+		t.Setenv("TLS_CLIENT_CA_FILE", "/etc/k8s-ces-control/ca.crt")
+
+		// when
+		err := configureTLS()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "client certificates can only be used if tls is enabled")
+	})
+	t.Run("should fail if client certificates are required without client CA", func(t *testing.T) {
+		// given
+		t.Setenv("TLS_CERT_FILE", "/etc/k8s-ces-control/tls.crt")
+		t.Setenv("TLS_KEY_FILE", "/etc/k8s-ces-control/tls.key")
+		t.Setenv("TLS_REQUIRE_CLIENT_CERT", "true")
+
+		// when
+		err := configureTLS()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "the environment variable [TLS_CLIENT_CA_FILE] is required if the environment variable [TLS_REQUIRE_CLIENT_CERT] is set to true")
+	})
+	t.Run("should fail for invalid boolean", func(t *testing.T) {
+		// given
+		t.Setenv("TLS_REQUIRE_CLIENT_CERT", "banana")
+
+		// when
+		err := configureTLS()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "found invalid value [banana] for environment variable [TLS_REQUIRE_CLIENT_CERT]")
+	})
+}
+
+func Test_configureAuth(t *testing.T) {
+	t.Run("should disable auth if no file is set", func(t *testing.T) {
+		// given
+		previousAuthConfig := CurrentAuthConfig
+		defer func() { CurrentAuthConfig = previousAuthConfig }()
+
+		// when
+		configureAuth()
+
+		// then
+		assert.False(t, CurrentAuthConfig.Enabled())
+	})
+	t.Run("should set auth config file", func(t *testing.T) {
+		// given
+		previousAuthConfig := CurrentAuthConfig
+		defer func() { CurrentAuthConfig = previousAuthConfig }()
+		t.Setenv("AUTH_CONFIG_FILE", "/etc/k8s-ces-control/auth/auth.yaml")
+
+		// when
+		configureAuth()
+
+		// then
+		assert.True(t, CurrentAuthConfig.Enabled())
+		assert.Equal(t, "/etc/k8s-ces-control/auth/auth.yaml", CurrentAuthConfig.ConfigFile)
+	})
+}
+
+func Test_configureAudit(t *testing.T) {
+	t.Run("should write to stdout without events by default", func(t *testing.T) {
+		// given
+		previousAuditConfig := CurrentAuditConfig
+		defer func() { CurrentAuditConfig = previousAuditConfig }()
+
+		// when
+		err := configureAudit()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "", CurrentAuditConfig.LogFile)
+		assert.False(t, CurrentAuditConfig.KubernetesEvents)
+		assert.Equal(t, "stdout", CurrentAuditConfig.destination())
+	})
+	t.Run("should set log file and events", func(t *testing.T) {
+		// given
+		previousAuditConfig := CurrentAuditConfig
+		defer func() { CurrentAuditConfig = previousAuditConfig }()
+		t.Setenv("AUDIT_LOG_FILE", "/var/log/audit.log")
+		t.Setenv("AUDIT_KUBERNETES_EVENTS", "true")
+
+		// when
+		err := configureAudit()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "/var/log/audit.log", CurrentAuditConfig.LogFile)
+		assert.True(t, CurrentAuditConfig.KubernetesEvents)
+	})
+	t.Run("should fail for invalid events flag", func(t *testing.T) {
+		// given
+		previousAuditConfig := CurrentAuditConfig
+		defer func() { CurrentAuditConfig = previousAuditConfig }()
+		t.Setenv("AUDIT_KUBERNETES_EVENTS", "sometimes")
+
+		// when
+		err := configureAudit()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "AUDIT_KUBERNETES_EVENTS")
+	})
+}
+
+func Test_configureTimeouts(t *testing.T) {
+	t.Run("should use default timeouts", func(t *testing.T) {
+		// given
+		previousTimeouts := currentTimeouts.Load()
+		defer currentTimeouts.Store(previousTimeouts)
+		currentTimeouts.Store(&TimeoutConfig{Shutdown: time.Minute})
+
+		// when
+		err := configureTimeouts()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, TimeoutConfig{Shutdown: 30 * time.Second, LokiQuery: 30 * time.Second, DoguWait: 10 * time.Minute}, CurrentTimeouts())
+	})
+	t.Run("should set timeouts", func(t *testing.T) {
+		// given
+		previousTimeouts := currentTimeouts.Load()
+		defer currentTimeouts.Store(previousTimeouts)
+		t.Setenv("SHUTDOWN_TIMEOUT", "2m")
+		t.Setenv("LOKI_QUERY_TIMEOUT", "1m")
+		t.Setenv("DOGU_WAIT_TIMEOUT", "5m")
+
+		// when
+		err := configureTimeouts()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, TimeoutConfig{Shutdown: 2 * time.Minute, LokiQuery: time.Minute, DoguWait: 5 * time.Minute}, CurrentTimeouts())
+	})
+	t.Run("should fail for invalid duration", func(t *testing.T) {
+		// given
+		t.Setenv("SHUTDOWN_TIMEOUT", "soon")
+
+		// when
+		err := configureTimeouts()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "SHUTDOWN_TIMEOUT")
+	})
+	t.Run("should fail for negative duration", func(t *testing.T) {
+		// given
+		t.Setenv("SHUTDOWN_TIMEOUT", "-5s")
+
+		// when
+		err := configureTimeouts()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "must be a positive duration")
+	})
+	t.Run("should report all invalid timeouts and keep the previous ones", func(t *testing.T) {
+		// given
+		previousTimeouts := currentTimeouts.Load()
+		defer currentTimeouts.Store(previousTimeouts)
+		t.Setenv("LOKI_QUERY_TIMEOUT", "0s")
+		t.Setenv("DOGU_WAIT_TIMEOUT", "later")
+
+		// when
+		err := configureTimeouts()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "LOKI_QUERY_TIMEOUT")
+		assert.ErrorContains(t, err, "DOGU_WAIT_TIMEOUT")
+		assert.Same(t, previousTimeouts, currentTimeouts.Load())
+	})
+}
+
+func Test_configureDebugModeWatchInterval(t *testing.T) {
+	t.Run("should use default interval", func(t *testing.T) {
+		// given
+		defer func() { CurrentDebugModeWatchInterval = defaultDebugModeWatchInterval }()
+		CurrentDebugModeWatchInterval = time.Minute
+
+		// when
+		err := configureDebugModeWatchInterval()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, CurrentDebugModeWatchInterval)
+	})
+	t.Run("should set interval", func(t *testing.T) {
+		// given
+		defer func() { CurrentDebugModeWatchInterval = defaultDebugModeWatchInterval }()
+		t.Setenv("DEBUG_MODE_WATCH_INTERVAL", "10s")
+
+		// when
+		err := configureDebugModeWatchInterval()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 10*time.Second, CurrentDebugModeWatchInterval)
+	})
+	t.Run("should fail for invalid duration", func(t *testing.T) {
+		// given
+		t.Setenv("DEBUG_MODE_WATCH_INTERVAL", "often")
+
+		// when
+		err := configureDebugModeWatchInterval()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "DEBUG_MODE_WATCH_INTERVAL")
+	})
+}
+
+func Test_configureListenAddress(t *testing.T) {
+	t.Run("should use default address", func(t *testing.T) {
+		// given
+		defer func() { CurrentListenAddress = defaultListenAddress }()
+		CurrentListenAddress = ":1234"
+
+		// when
+		err := configureListenAddress()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ":50051", CurrentListenAddress)
+	})
+	t.Run("should set address", func(t *testing.T) {
+		// given
+		defer func() { CurrentListenAddress = defaultListenAddress }()
+		t.Setenv("LISTEN_ADDRESS", "127.0.0.1:50052")
+
+		// when
+		err := configureListenAddress()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1:50052", CurrentListenAddress)
+	})
+	t.Run("should fail for invalid address", func(t *testing.T) {
+		// given
+		t.Setenv("LISTEN_ADDRESS", "50051")
+
+		// when
+		err := configureListenAddress()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "LISTEN_ADDRESS")
+	})
+}
+
+func Test_configureLokiGateway(t *testing.T) {
+	t.Run("should set loki gateway config", func(t *testing.T) {
+		// given
+		previousLokiConfig := CurrentLokiGatewayConfig
+		defer func() { CurrentLokiGatewayConfig = previousLokiConfig }()
+		t.Setenv("LOKI_GATEWAY_URL", "http://k8s-loki-gateway:80")
+		t.Setenv("LOKI_GATEWAY_USERNAME", "admin")
+		t.Setenv("LOKI_GATEWAY_PASSWORD", "secret")
+
+		// when
+		err := configureLokiGateway()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &LokiGatewayConfig{Url: "http://k8s-loki-gateway:80", Username: "admin", Password: "secret"}, CurrentLokiGatewayConfig)
+	})
+	t.Run("should report all missing settings", func(t *testing.T) {
+		// given
+		t.Setenv("LOKI_GATEWAY_URL", "")
+		t.Setenv("LOKI_GATEWAY_USERNAME", "")
+		t.Setenv("LOKI_GATEWAY_PASSWORD", "")
+
+		// when
+		err := configureLokiGateway()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "no loki gateway url was set via the environment variable [LOKI_GATEWAY_URL]")
+		assert.ErrorContains(t, err, "no loki gateway username was set via the environment variable [LOKI_GATEWAY_USERNAME]")
+		assert.ErrorContains(t, err, "no loki gateway password was set via the environment variable [LOKI_GATEWAY_PASSWORD]")
+	})
+	t.Run("should set credential files and CA file", func(t *testing.T) {
+		// given
+		previousLokiConfig := CurrentLokiGatewayConfig
+		defer func() { CurrentLokiGatewayConfig = previousLokiConfig }()
+		t.Setenv("LOKI_GATEWAY_URL", "https://k8s-loki-gateway:443")
+		t.Setenv("LOKI_GATEWAY_USERNAME", "admin")
+		t.Setenv("LOKI_GATEWAY_PASSWORD_FILE", "/etc/k8s-ces-control/loki/password")
+		t.Setenv("LOKI_GATEWAY_CA_FILE", "/etc/k8s-ces-control/loki/ca.crt")
+
+		// when
+		err := configureLokiGateway()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &LokiGatewayConfig{
+			Url:          "https://k8s-loki-gateway:443",
+			Username:     "admin",
+			PasswordFile: "/etc/k8s-ces-control/loki/password",
+			CAFile:       "/etc/k8s-ces-control/loki/ca.crt",
+		}, CurrentLokiGatewayConfig)
+	})
+	t.Run("should set token file without username and password", func(t *testing.T) {
+		// given
+		previousLokiConfig := CurrentLokiGatewayConfig
+		defer func() { CurrentLokiGatewayConfig = previousLokiConfig }()
+		t.Setenv("LOKI_GATEWAY_URL", "http://k8s-loki-gateway:80")
+		t.Setenv("LOKI_GATEWAY_TOKEN_FILE", "/etc/k8s-ces-control/loki/token")
+
+		// when
+		err := configureLokiGateway()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "/etc/k8s-ces-control/loki/token", CurrentLokiGatewayConfig.TokenFile)
+	})
+	t.Run("should fail if token file is combined with basic auth", func(t *testing.T) {
+		// given
+		t.Setenv("LOKI_GATEWAY_URL", "http://k8s-loki-gateway:80")
+		t.Setenv("LOKI_GATEWAY_TOKEN_FILE", "/etc/k8s-ces-control/loki/token")
+		t.Setenv("LOKI_GATEWAY_PASSWORD", "secret")
+
+		// when
+		err := configureLokiGateway()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "the environment variable [LOKI_GATEWAY_TOKEN_FILE] cannot be combined with a loki gateway username or password")
+	})
+	t.Run("should fail if value and file are set together", func(t *testing.T) {
+		// given
+		t.Setenv("LOKI_GATEWAY_URL", "http://k8s-loki-gateway:80")
+		t.Setenv("LOKI_GATEWAY_USERNAME", "admin")
+		t.Setenv("LOKI_GATEWAY_PASSWORD", "secret")
+		t.Setenv("LOKI_GATEWAY_PASSWORD_FILE", "/etc/k8s-ces-control/loki/password")
+
+		// when
+		err := configureLokiGateway()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "the environment variable [LOKI_GATEWAY_PASSWORD] and the environment variable [LOKI_GATEWAY_PASSWORD_FILE] must not be set together")
+	})
+	t.Run("should fail for relative url", func(t *testing.T) {
+		// given
+		t.Setenv("LOKI_GATEWAY_URL", "k8s-loki-gateway")
+		t.Setenv("LOKI_GATEWAY_USERNAME", "admin")
+		t.Setenv("LOKI_GATEWAY_PASSWORD", "secret")
+
+		// when
+		err := configureLokiGateway()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "found invalid value [k8s-loki-gateway] for environment variable [LOKI_GATEWAY_URL]")
+	})
+}
+
+func Test_configureLogBackend(t *testing.T) {
+	t.Run("should use loki by default", func(t *testing.T) {
+		// given
+		previousLogBackend := CurrentLogBackend
+		defer func() { CurrentLogBackend = previousLogBackend }()
+		previousLokiConfig := CurrentLokiGatewayConfig
+		defer func() { CurrentLokiGatewayConfig = previousLokiConfig }()
+		t.Setenv("LOKI_GATEWAY_URL", "http://k8s-loki-gateway:80")
+		t.Setenv("LOKI_GATEWAY_USERNAME", "admin")
+		t.Setenv("LOKI_GATEWAY_PASSWORD", "secret")
+
+		// when
+		err := configureLogBackend()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, LogBackendLoki, CurrentLogBackend)
+		assert.Equal(t, "http://k8s-loki-gateway:80", CurrentLokiGatewayConfig.Url)
+	})
+	t.Run("should not require the loki gateway for the kubernetes backend", func(t *testing.T) {
+		// given
+		previousLogBackend := CurrentLogBackend
+		defer func() { CurrentLogBackend = previousLogBackend }()
+		t.Setenv("LOG_BACKEND", "kubernetes")
+
+		// when
+		err := configureLogBackend()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, LogBackendKubernetes, CurrentLogBackend)
+	})
+	t.Run("should require the opensearch settings for the opensearch backend", func(t *testing.T) {
+		// given
+		t.Setenv("LOG_BACKEND", "opensearch")
+
+		// when
+		err := configureLogBackend()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "no opensearch url was set via the environment variable [OPENSEARCH_URL]")
+		assert.NotContains(t, err.Error(), "loki")
+	})
+	t.Run("should fail for unknown backend", func(t *testing.T) {
+		// given
+		t.Setenv("LOG_BACKEND", "syslog")
+
+		// when
+		err := configureLogBackend()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "found invalid value [syslog] for environment variable [LOG_BACKEND], only the values [loki kubernetes opensearch] are valid values")
+	})
+}
+
+func Test_configureOpenSearch(t *testing.T) {
+	t.Run("should set opensearch config with default index", func(t *testing.T) {
+		// given
+		previousOpenSearchConfig := CurrentOpenSearchConfig
+		defer func() { CurrentOpenSearchConfig = previousOpenSearchConfig }()
+		t.Setenv("OPENSEARCH_URL", "https://opensearch:9200")
+		t.Setenv("OPENSEARCH_USERNAME", "admin")
+		t.Setenv("OPENSEARCH_PASSWORD_FILE", "/etc/k8s-ces-control/opensearch/password")
+		t.Setenv("OPENSEARCH_CA_FILE", "/etc/k8s-ces-control/opensearch/ca.crt")
+
+		// when
+		err := configureOpenSearch()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &OpenSearchConfig{
+			Url:          "https://opensearch:9200",
+			Index:        "fluent-bit",
+			Username:     "admin",
+			PasswordFile: "/etc/k8s-ces-control/opensearch/password",
+			CAFile:       "/etc/k8s-ces-control/opensearch/ca.crt",
+		}, CurrentOpenSearchConfig)
+	})
+	t.Run("should set index", func(t *testing.T) {
+		// given
+		previousOpenSearchConfig := CurrentOpenSearchConfig
+		defer func() { CurrentOpenSearchConfig = previousOpenSearchConfig }()
+		t.Setenv("OPENSEARCH_URL", "https://opensearch:9200")
+		t.Setenv("OPENSEARCH_INDEX", "logstash-*")
+		t.Setenv("OPENSEARCH_USERNAME", "admin")
+		t.Setenv("OPENSEARCH_PASSWORD", "secret")
+
+		// when
+		err := configureOpenSearch()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "logstash-*", CurrentOpenSearchConfig.Index)
+	})
+	t.Run("should report all invalid settings", func(t *testing.T) {
+		// given
+		t.Setenv("OPENSEARCH_URL", "opensearch")
+		t.Setenv("OPENSEARCH_USERNAME", "admin")
+		t.Setenv("OPENSEARCH_USERNAME_FILE", "/etc/k8s-ces-control/opensearch/username")
+
+		// when
+		err := configureOpenSearch()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "found invalid value [opensearch] for environment variable [OPENSEARCH_URL]")
+		assert.ErrorContains(t, err, "the environment variable [OPENSEARCH_USERNAME] and the environment variable [OPENSEARCH_USERNAME_FILE] must not be set together")
+		assert.ErrorContains(t, err, "no opensearch password was set via the environment variable [OPENSEARCH_PASSWORD] or the environment variable [OPENSEARCH_PASSWORD_FILE]")
+	})
+}
+
+func Test_configureGateway(t *testing.T) {
+	t.Run("should disable gateway if no address is set", func(t *testing.T) {
+		// given
+		previousGatewayConfig := CurrentGatewayConfig
+		defer func() { CurrentGatewayConfig = previousGatewayConfig }()
+
+		// when
+		err := configureGateway()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, CurrentGatewayConfig.Enabled())
+	})
+	t.Run("should set gateway address", func(t *testing.T) {
+		// given
+		previousGatewayConfig := CurrentGatewayConfig
+		defer func() { CurrentGatewayConfig = previousGatewayConfig }()
+		t.Setenv("GATEWAY_ADDRESS", ":8080")
+
+		// when
+		err := configureGateway()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, CurrentGatewayConfig.Enabled())
+		assert.Equal(t, ":8080", CurrentGatewayConfig.Address)
+	})
+	t.Run("should fail for invalid address", func(t *testing.T) {
+		// given
+		previousGatewayConfig := CurrentGatewayConfig
+		defer func() { CurrentGatewayConfig = previousGatewayConfig }()
+		t.Setenv("GATEWAY_ADDRESS", "8080")
+
+		// when
+		err := configureGateway()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "GATEWAY_ADDRESS")
+	})
+}
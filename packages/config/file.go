@@ -0,0 +1,294 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+const configFileEnvironmentVariable = "CONFIG_FILE"
+
+// configFileReloadInterval is the interval in which the configuration file is checked for changes.
+var configFileReloadInterval = 30 * time.Second
+
+// setting is a single configuration value. It is read from its environment variable or, if the environment variable
+// is not set, from its key in the configuration file.
+type setting struct {
+	environmentVariable string
+	fileKey             string
+}
+
+var (
+	logLevelSetting                = setting{environmentVariable: logLevelEnvironmentVariable, fileKey: "logLevel"}
+	stageSetting                   = setting{environmentVariable: stagingEnvironmentVariable, fileKey: "stage"}
+	namespaceSetting               = setting{environmentVariable: namespaceEnvironmentVariable, fileKey: "namespace"}
+	listenAddressSetting           = setting{environmentVariable: listenAddressEnvironmentVariable, fileKey: "listenAddress"}
+	gatewayAddressSetting          = setting{environmentVariable: gatewayAddressEnvironmentVariable, fileKey: "gatewayAddress"}
+	lokiGatewayUrlSetting          = setting{environmentVariable: lokiGatewayUrlEnvironmentVariable, fileKey: "loki.url"}
+	lokiGatewayUsernameSetting     = setting{environmentVariable: lokiGatewayUsernameEnvironmentVariable, fileKey: "loki.username"}
+	lokiGatewayPasswordSetting     = setting{environmentVariable: lokiGatewayPasswordEnvironmentVariable, fileKey: "loki.password"}
+	lokiGatewayUsernameFileSetting = setting{environmentVariable: lokiGatewayUsernameFileEnvironmentVariable, fileKey: "loki.usernameFile"}
+	lokiGatewayPasswordFileSetting = setting{environmentVariable: lokiGatewayPasswordFileEnvironmentVariable, fileKey: "loki.passwordFile"}
+	lokiGatewayTokenFileSetting    = setting{environmentVariable: lokiGatewayTokenFileEnvironmentVariable, fileKey: "loki.tokenFile"}
+	lokiGatewayCaFileSetting       = setting{environmentVariable: lokiGatewayCaFileEnvironmentVariable, fileKey: "loki.caFile"}
+	logBackendSetting              = setting{environmentVariable: logBackendEnvironmentVariable, fileKey: "logs.backend"}
+	openSearchUrlSetting           = setting{environmentVariable: openSearchUrlEnvironmentVariable, fileKey: "opensearch.url"}
+	openSearchIndexSetting         = setting{environmentVariable: openSearchIndexEnvironmentVariable, fileKey: "opensearch.index"}
+	openSearchUsernameSetting      = setting{environmentVariable: openSearchUsernameEnvironmentVariable, fileKey: "opensearch.username"}
+	openSearchPasswordSetting      = setting{environmentVariable: openSearchPasswordEnvironmentVariable, fileKey: "opensearch.password"}
+	openSearchUsernameFileSetting  = setting{environmentVariable: openSearchUsernameFileEnvironmentVariable, fileKey: "opensearch.usernameFile"}
+	openSearchPasswordFileSetting  = setting{environmentVariable: openSearchPasswordFileEnvironmentVariable, fileKey: "opensearch.passwordFile"}
+	openSearchCaFileSetting        = setting{environmentVariable: openSearchCaFileEnvironmentVariable, fileKey: "opensearch.caFile"}
+	tlsCertFileSetting             = setting{environmentVariable: tlsCertFileEnvironmentVariable, fileKey: "tls.certFile"}
+	tlsKeyFileSetting              = setting{environmentVariable: tlsKeyFileEnvironmentVariable, fileKey: "tls.keyFile"}
+	tlsClientCaFileSetting         = setting{environmentVariable: tlsClientCaFileEnvironmentVariable, fileKey: "tls.clientCaFile"}
+	tlsRequireClientCertSetting    = setting{environmentVariable: tlsRequireClientCertEnvironmentVariable, fileKey: "tls.requireClientCert"}
+	authConfigFileSetting          = setting{environmentVariable: authConfigFileEnvironmentVariable, fileKey: "auth.configFile"}
+	auditLogFileSetting            = setting{environmentVariable: auditLogFileEnvironmentVariable, fileKey: "audit.logFile"}
+	auditKubernetesEventsSetting   = setting{environmentVariable: auditKubernetesEventsEnvironmentVariable, fileKey: "audit.kubernetesEvents"}
+	shutdownTimeoutSetting         = setting{environmentVariable: shutdownTimeoutEnvironmentVariable, fileKey: "timeouts.shutdown"}
+	lokiQueryTimeoutSetting        = setting{environmentVariable: lokiQueryTimeoutEnvironmentVariable, fileKey: "timeouts.lokiQuery"}
+	doguWaitTimeoutSetting         = setting{environmentVariable: doguWaitTimeoutEnvironmentVariable, fileKey: "timeouts.doguWait"}
+	debugModeWatchIntervalSetting  = setting{environmentVariable: debugModeWatchIntervalEnvironmentVariable, fileKey: "debugMode.watchInterval"}
+)
+
+// settings contains every setting that may be set in the configuration file.
+var settings = []setting{
+	logLevelSetting,
+	stageSetting,
+	namespaceSetting,
+	listenAddressSetting,
+	gatewayAddressSetting,
+	lokiGatewayUrlSetting,
+	lokiGatewayUsernameSetting,
+	lokiGatewayPasswordSetting,
+	lokiGatewayUsernameFileSetting,
+	lokiGatewayPasswordFileSetting,
+	lokiGatewayTokenFileSetting,
+	lokiGatewayCaFileSetting,
+	logBackendSetting,
+	openSearchUrlSetting,
+	openSearchIndexSetting,
+	openSearchUsernameSetting,
+	openSearchPasswordSetting,
+	openSearchUsernameFileSetting,
+	openSearchPasswordFileSetting,
+	openSearchCaFileSetting,
+	tlsCertFileSetting,
+	tlsKeyFileSetting,
+	tlsClientCaFileSetting,
+	tlsRequireClientCertSetting,
+	authConfigFileSetting,
+	auditLogFileSetting,
+	auditKubernetesEventsSetting,
+	shutdownTimeoutSetting,
+	lokiQueryTimeoutSetting,
+	doguWaitTimeoutSetting,
+	debugModeWatchIntervalSetting,
+}
+
+// reloadableSettings contains the settings which are applied on a change of the configuration file without restart.
+var reloadableSettings = []setting{
+	logLevelSetting,
+	shutdownTimeoutSetting,
+	lokiQueryTimeoutSetting,
+	doguWaitTimeoutSetting,
+}
+
+// fileValues contains the values of the configuration file by their dotted key, e.g. "loki.url". It is only replaced
+// by ConfigureApplication and by Watch after the configuration was loaded.
+var fileValues = map[string]string{}
+
+// lookup returns the value of the environment variable or, if it is not set, the value of the configuration file.
+func (s setting) lookup() (string, bool) {
+	if value, ok := os.LookupEnv(s.environmentVariable); ok {
+		return value, true
+	}
+
+	value, ok := fileValues[s.fileKey]
+	return value, ok
+}
+
+// get returns the value of the setting or an empty string if it is not set.
+func (s setting) get() string {
+	value, _ := s.lookup()
+	return value
+}
+
+// String describes the source of the setting so that errors point to the place where an invalid value must be fixed.
+func (s setting) String() string {
+	if _, ok := os.LookupEnv(s.environmentVariable); !ok {
+		if _, ok := fileValues[s.fileKey]; ok {
+			return fmt.Sprintf("config file key [%s]", s.fileKey)
+		}
+	}
+
+	return fmt.Sprintf("environment variable [%s]", s.environmentVariable)
+}
+
+func configureConfigFile() error {
+	path := os.Getenv(configFileEnvironmentVariable)
+	if path == "" {
+		fileValues = map[string]string{}
+		return nil
+	}
+
+	// the values of a file with unknown keys are still used so that every other problem is reported as well
+	values, err := readConfigFile(path)
+	if values == nil {
+		values = map[string]string{}
+	}
+	fileValues = values
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Using configuration file [%s]. Environment variables override its values.", path)
+
+	return nil
+}
+
+func readConfigFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file [%s]: %w", path, err)
+	}
+
+	values, err := parseConfigFile(content)
+	if err != nil {
+		return values, fmt.Errorf("invalid configuration file [%s]: %w", path, err)
+	}
+
+	return values, nil
+}
+
+// parseConfigFile parses the yaml content into values by their dotted key. Unknown keys and lists are reported as
+// errors so that typos do not silently fall back to defaults. The valid values are returned nevertheless.
+func parseConfigFile(content []byte) (map[string]string, error) {
+	document := map[string]any{}
+	err := yaml.Unmarshal(content, &document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse yaml: %w", err)
+	}
+
+	values := map[string]string{}
+	errs := []error{flattenConfigFile("", document, values)}
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if !slices.ContainsFunc(settings, func(s setting) bool { return s.fileKey == key }) {
+			errs = append(errs, fmt.Errorf("unknown key [%s]", key))
+		}
+	}
+
+	return values, errors.Join(errs...)
+}
+
+func flattenConfigFile(prefix string, document map[string]any, values map[string]string) error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(document)) {
+		fileKey := key
+		if prefix != "" {
+			fileKey = prefix + "." + key
+		}
+
+		switch value := document[key].(type) {
+		case map[string]any:
+			errs = append(errs, flattenConfigFile(fileKey, value, values))
+		case []any:
+			errs = append(errs, fmt.Errorf("key [%s] must not be a list", fileKey))
+		case nil:
+			values[fileKey] = ""
+		default:
+			values[fileKey] = fmt.Sprint(value)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Watch checks the configuration file for changes until the context is done. The log level and the timeouts of a
+// changed file are applied without restart and onReload is called afterward, so that the timeouts can be passed on.
+// Invalid changes are logged and ignored. Watch returns immediately if no configuration file is configured.
+func Watch(ctx context.Context, onReload func()) {
+	path := os.Getenv(configFileEnvironmentVariable)
+	if path == "" {
+		return
+	}
+
+	// the content is only remembered to log every invalid change once, the values are compared to the loaded ones
+	var lastContent []byte
+	ticker := time.NewTicker(configFileReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Debug("stopped watching the configuration file")
+			return
+		case <-ticker.C:
+			content, err := os.ReadFile(path)
+			if err != nil {
+				logrus.Errorf("failed to read configuration file [%s]: %v", path, err)
+				continue
+			}
+
+			if bytes.Equal(content, lastContent) {
+				continue
+			}
+			lastContent = content
+
+			changed, err := reloadConfigFile(content)
+			if err != nil {
+				logrus.Errorf("failed to reload configuration file [%s], keeping the previous configuration: %v", path, err)
+				continue
+			}
+			if !changed {
+				continue
+			}
+
+			logrus.Infof("Reloaded configuration file [%s].", path)
+			onReload()
+		}
+	}
+}
+
+// reloadConfigFile applies the reloadable settings of the content. It returns false if the values did not change.
+func reloadConfigFile(content []byte) (bool, error) {
+	values, err := parseConfigFile(content)
+	if err != nil {
+		return false, err
+	}
+
+	if maps.Equal(values, fileValues) {
+		return false, nil
+	}
+
+	previousValues := fileValues
+	fileValues = values
+
+	level, levelErr := parseLogLevel()
+	timeouts, timeoutsErr := parseTimeouts()
+	err = errors.Join(levelErr, timeoutsErr)
+	if err != nil {
+		fileValues = previousValues
+		return false, err
+	}
+
+	for _, s := range settings {
+		if !slices.Contains(reloadableSettings, s) && previousValues[s.fileKey] != values[s.fileKey] {
+			logrus.Warnf("The config file key [%s] was changed. The change is applied after a restart.", s.fileKey)
+		}
+	}
+
+	applyLogLevel(level)
+	currentTimeouts.Store(timeouts)
+
+	return true, nil
+}
@@ -0,0 +1,243 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(content), 0600)
+	require.NoError(t, err)
+
+	return path
+}
+
+func Test_parseConfigFile(t *testing.T) {
+	t.Run("should flatten nested keys", func(t *testing.T) {
+		// given
+		content := []byte(`
+logLevel: info
+tls:
+  certFile: /etc/tls.crt
+  requireClientCert: true
+timeouts:
+  shutdown: 1m
+`)
+
+		// when
+		values, err := parseConfigFile(content)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"logLevel":              "info",
+			"tls.certFile":          "/etc/tls.crt",
+			"tls.requireClientCert": "true",
+			"timeouts.shutdown":     "1m",
+		}, values)
+	})
+	t.Run("should report all unknown keys and lists", func(t *testing.T) {
+		// given
+		content := []byte(`
+loglevel: info
+tls:
+  cert: /etc/tls.crt
+stage: [production]
+namespace: ecosystem
+`)
+
+		// when
+		values, err := parseConfigFile(content)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown key [loglevel]")
+		assert.ErrorContains(t, err, "unknown key [tls.cert]")
+		assert.ErrorContains(t, err, "key [stage] must not be a list")
+		assert.Equal(t, "ecosystem", values["namespace"])
+	})
+	t.Run("should fail for invalid yaml", func(t *testing.T) {
+		// when
+		_, err := parseConfigFile([]byte("logLevel: [info"))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse yaml")
+	})
+}
+
+func Test_configureConfigFile(t *testing.T) {
+	t.Run("should not use a file if none is set", func(t *testing.T) {
+		// given
+		defer func() { fileValues = map[string]string{} }()
+		fileValues = map[string]string{"logLevel": "info"}
+
+		// when
+		err := configureConfigFile()
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, fileValues)
+	})
+	t.Run("should fail for missing file", func(t *testing.T) {
+		// given
+		defer func() { fileValues = map[string]string{} }()
+		t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+
+		// when
+		err := configureConfigFile()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read configuration file")
+		assert.Empty(t, fileValues)
+	})
+}
+
+func Test_setting(t *testing.T) {
+	t.Run("should prefer the env var over the file value", func(t *testing.T) {
+		// given
+		defer func() { fileValues = map[string]string{} }()
+		fileValues = map[string]string{"stage": "development"}
+		t.Setenv("STAGE", "production")
+
+		// when
+		value, ok := stageSetting.lookup()
+
+		// then
+		assert.True(t, ok)
+		assert.Equal(t, "production", value)
+		assert.Equal(t, "environment variable [STAGE]", stageSetting.String())
+	})
+	t.Run("should use the file value if the env var is not set", func(t *testing.T) {
+		// given
+		defer func() { fileValues = map[string]string{} }()
+		fileValues = map[string]string{"stage": "development"}
+		t.Setenv("STAGE", "")
+		require.NoError(t, os.Unsetenv("STAGE"))
+
+		// when
+		value, ok := stageSetting.lookup()
+
+		// then
+		assert.True(t, ok)
+		assert.Equal(t, "development", value)
+		assert.Equal(t, "config file key [stage]", stageSetting.String())
+	})
+}
+
+func Test_reloadConfigFile(t *testing.T) {
+	t.Run("should apply log level and timeouts", func(t *testing.T) {
+		// given
+		defer func() { fileValues = map[string]string{} }()
+		previousTimeouts := currentTimeouts.Load()
+		defer currentTimeouts.Store(previousTimeouts)
+		previousLogrusLogLevel := logrus.GetLevel()
+		defer logrus.SetLevel(previousLogrusLogLevel)
+		t.Setenv(logLevelEnv, "")
+		require.NoError(t, os.Unsetenv(logLevelEnv))
+
+		// when
+		changed, err := reloadConfigFile([]byte("logLevel: debug\ntimeouts:\n  lokiQuery: 1m\nlistenAddress: \":1234\"\n"))
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, logrus.DebugLevel, logrus.GetLevel())
+		assert.Equal(t, time.Minute, CurrentTimeouts().LokiQuery)
+		assert.Equal(t, defaultListenAddress, CurrentListenAddress)
+	})
+	t.Run("should not apply unchanged values", func(t *testing.T) {
+		// given
+		defer func() { fileValues = map[string]string{} }()
+		fileValues = map[string]string{"timeouts.doguWait": "1m"}
+
+		// when
+		changed, err := reloadConfigFile([]byte("# only a comment was added\ntimeouts:\n  doguWait: 1m\n"))
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("should keep the previous configuration for invalid values", func(t *testing.T) {
+		// given
+		defer func() { fileValues = map[string]string{} }()
+		fileValues = map[string]string{"timeouts.doguWait": "1m"}
+		previousTimeouts := currentTimeouts.Load()
+		defer currentTimeouts.Store(previousTimeouts)
+
+		// when
+		changed, err := reloadConfigFile([]byte("timeouts:\n  doguWait: 2m\n  shutdown: never\n"))
+
+		// then
+		require.Error(t, err)
+		assert.False(t, changed)
+		assert.ErrorContains(t, err, "config file key [timeouts.shutdown]")
+		assert.Equal(t, map[string]string{"timeouts.doguWait": "1m"}, fileValues)
+		assert.Same(t, previousTimeouts, currentTimeouts.Load())
+	})
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("should return immediately without config file", func(t *testing.T) {
+		// given
+		t.Setenv("CONFIG_FILE", "")
+
+		// when
+		Watch(context.Background(), func() { t.Error("unexpected reload") })
+
+		// then
+		// Watch returned
+	})
+	t.Run("should reload changed file until the context is done", func(t *testing.T) {
+		// given
+		defer func() { fileValues = map[string]string{} }()
+		previousTimeouts := currentTimeouts.Load()
+		defer currentTimeouts.Store(previousTimeouts)
+		previousLogrusLogLevel := logrus.GetLevel()
+		defer logrus.SetLevel(previousLogrusLogLevel)
+		previousInterval := configFileReloadInterval
+		defer func() { configFileReloadInterval = previousInterval }()
+		configFileReloadInterval = time.Millisecond
+
+		path := writeConfigFile(t, "timeouts:\n  doguWait: 1m\n")
+		t.Setenv("CONFIG_FILE", path)
+		require.NoError(t, configureConfigFile())
+		t.Setenv("DOGU_WAIT_TIMEOUT", "")
+		require.NoError(t, os.Unsetenv("DOGU_WAIT_TIMEOUT"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		reloaded := make(chan struct{}, 1)
+		watchStopped := make(chan struct{})
+		go func() {
+			defer close(watchStopped)
+			Watch(ctx, func() { reloaded <- struct{}{} })
+		}()
+
+		// when
+		// the file is replaced atomically like a mounted ConfigMap so that no partially written file is read
+		changedPath := writeConfigFile(t, "timeouts:\n  doguWait: 3m\n")
+		err := os.Rename(changedPath, path)
+		require.NoError(t, err)
+
+		// then
+		select {
+		case <-reloaded:
+		case <-time.After(5 * time.Second):
+			t.Fatal("configuration file was not reloaded")
+		}
+		assert.Equal(t, 3*time.Minute, CurrentTimeouts().DoguWait)
+
+		cancel()
+		<-watchStopped
+	})
+}
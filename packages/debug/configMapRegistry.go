@@ -8,7 +8,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -17,7 +19,11 @@ const (
 	keyDebugModeEnabled   = "enabled"
 	keyDisableAtTimestamp = "disable-at-timestamp"
 	keyDoguLogLevel       = "dogus"
-	timestampFormat       = time.RFC822
+	keyTargetDogus        = "target-dogus"
+	keyTargetLogLevel     = "target-log-level"
+	timestampFormat       = time.RFC3339
+	// legacyTimestampFormat is the format of registries written by older versions. It lacks the seconds.
+	legacyTimestampFormat = time.RFC822
 )
 
 var maxThirtySecondsBackoff = wait.Backoff{
@@ -43,8 +49,9 @@ func NewConfigMapDebugModeRegistry(doguConfigRepository doguConfigRepository, do
 	}
 }
 
-// Enable writes `enabled: true` in the registry.
-func (c *configMapDebugModeRegistry) Enable(ctx context.Context, timerInMinutes int32) error {
+// Enable writes `enabled: true` in the registry together with the dogus and the log level of the debug mode. Empty
+// dogus cover all dogus.
+func (c *configMapDebugModeRegistry) Enable(ctx context.Context, timerInMinutes int32, doguNames []string, logLevel string) error {
 	cm, err := c.getRegistry(ctx)
 	if err != nil {
 		return err
@@ -58,6 +65,8 @@ func (c *configMapDebugModeRegistry) Enable(ctx context.Context, timerInMinutes
 	disableAtTimestamp := time.Now().Add(time.Minute * timerDuration)
 	cm.Data[keyDisableAtTimestamp] = disableAtTimestamp.Format(timestampFormat)
 	cm.Data[keyDebugModeEnabled] = "true"
+	cm.Data[keyTargetDogus] = strings.Join(doguNames, ",")
+	cm.Data[keyTargetLogLevel] = logLevel
 
 	return c.updateConfigMap(ctx, cm)
 }
@@ -167,6 +176,25 @@ func (c *configMapDebugModeRegistry) Status(ctx context.Context) (isEnabled bool
 	return
 }
 
+// Targets returns the dogus and the log level of the debug mode. It returns no dogus if the registry does not exist.
+func (c *configMapDebugModeRegistry) Targets(ctx context.Context) (doguNames []string, logLevel string, err error) {
+	registry, err := c.createRegistryIfNotFound(ctx, true)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+
+	return getTargetDogus(registry), registry.Data[keyTargetLogLevel], nil
+}
+
+func getTargetDogus(registry *corev1.ConfigMap) []string {
+	return slices.DeleteFunc(strings.Split(registry.Data[keyTargetDogus], ","), func(doguName string) bool {
+		return doguName == ""
+	})
+}
+
 func getDisableAtTimeStamp(registry *corev1.ConfigMap) (int64, error) {
 	if registry.Data == nil {
 		return 0, fmt.Errorf("registry %s is not initialized", registry.Name)
@@ -178,7 +206,7 @@ func getDisableAtTimeStamp(registry *corev1.ConfigMap) (int64, error) {
 		return 0, nil
 	}
 
-	timeDisableAt, err := time.Parse(timestampFormat, disableAtTimestampStr)
+	timeDisableAt, err := parseDisableAtTimestamp(disableAtTimestampStr)
 	if err != nil {
 		return 0, fmt.Errorf("failed to parse time from disableAtTimestampStr %s: %w", disableAtTimestampStr, err)
 	}
@@ -186,6 +214,19 @@ func getDisableAtTimeStamp(registry *corev1.ConfigMap) (int64, error) {
 	return timeDisableAt.UnixMilli(), nil
 }
 
+func parseDisableAtTimestamp(value string) (time.Time, error) {
+	timestamp, err := time.Parse(timestampFormat, value)
+	if err != nil {
+		legacyTimestamp, legacyErr := time.Parse(legacyTimestampFormat, value)
+		if legacyErr != nil {
+			return time.Time{}, err
+		}
+		return legacyTimestamp, nil
+	}
+
+	return timestamp, nil
+}
+
 func isRegistryEnabled(registry *corev1.ConfigMap) (isEnabled bool, err error) {
 	if registry.Data == nil {
 		return false, fmt.Errorf("registry %s is not initialized", registry.Name)
@@ -204,7 +245,8 @@ func isRegistryEnabled(registry *corev1.ConfigMap) (isEnabled bool, err error) {
 	return isEnabled, nil
 }
 
-// BackupDoguLogLevels save the actual log levels from the ces registry to the debug mode registry.
+// BackupDoguLogLevels save the actual log levels of the dogus of the debug mode from the ces registry to the debug
+// mode registry.
 func (c *configMapDebugModeRegistry) BackupDoguLogLevels(ctx context.Context) error {
 	registry, err := c.getRegistry(ctx)
 	if err != nil {
@@ -220,7 +262,7 @@ func (c *configMapDebugModeRegistry) BackupDoguLogLevels(ctx context.Context) er
 		return registryNotEnabledError()
 	}
 
-	newRegistry, err := c.doguLogLevelRegistry.MarshalFromCesRegistryToString(ctx)
+	newRegistry, err := c.doguLogLevelRegistry.MarshalFromCesRegistryToString(ctx, getTargetDogus(registry))
 	if err != nil {
 		return fmt.Errorf("failed to renew dogu log level registry: %w", err)
 	}
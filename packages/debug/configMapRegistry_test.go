@@ -44,7 +44,7 @@ func Test_configMapDebugModeRegistry_BackupDoguLogLevels(t *testing.T) {
 		// given
 		expectedDoguLogLevelRegistryStr := "dogua: DEBUG\ndogub: ERROR\n"
 		doguLogLevelRegistryMock := newMockDoguLogLevelRegistry(t)
-		doguLogLevelRegistryMock.EXPECT().MarshalFromCesRegistryToString(testCtx).Return(expectedDoguLogLevelRegistryStr, nil)
+		doguLogLevelRegistryMock.EXPECT().MarshalFromCesRegistryToString(testCtx, []string{}).Return(expectedDoguLogLevelRegistryStr, nil)
 
 		configMapRegistry := &v1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode-registry", Namespace: testNamespace},
@@ -70,6 +70,30 @@ func Test_configMapDebugModeRegistry_BackupDoguLogLevels(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("should backup only the dogus of the debug mode", func(t *testing.T) {
+		// given
+		doguLogLevelRegistryMock := newMockDoguLogLevelRegistry(t)
+		doguLogLevelRegistryMock.EXPECT().MarshalFromCesRegistryToString(testCtx, []string{"cas", "redmine"}).Return("cas: INFO\nredmine: \"\"\n", nil)
+
+		configMapRegistry := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode-registry", Namespace: testNamespace},
+			Data:       map[string]string{"enabled": "true", "target-dogus": "cas,redmine"},
+		}
+
+		configMapClientMock := newMockConfigMapInterface(t)
+		configMapClientMock.EXPECT().Get(testCtx, "debug-mode-registry", metav1.GetOptions{}).Return(configMapRegistry, nil)
+		configMapClientMock.EXPECT().Update(testCtx, configMapRegistry, metav1.UpdateOptions{}).Return(nil, nil)
+
+		sut := configMapDebugModeRegistry{configMapInterface: configMapClientMock, doguLogLevelRegistry: doguLogLevelRegistryMock}
+
+		// when
+		err := sut.BackupDoguLogLevels(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "cas: INFO\nredmine: \"\"\n", configMapRegistry.Data["dogus"])
+	})
+
 	t.Run("should return error on error getting registry config map", func(t *testing.T) {
 		// given
 		configMapClientMock := newMockConfigMapInterface(t)
@@ -126,7 +150,7 @@ func Test_configMapDebugModeRegistry_BackupDoguLogLevels(t *testing.T) {
 	t.Run("should return error on error marshal from string ", func(t *testing.T) {
 		// given
 		doguLogLevelRegistryMock := newMockDoguLogLevelRegistry(t)
-		doguLogLevelRegistryMock.EXPECT().MarshalFromCesRegistryToString(testCtx).Return("", assert.AnError)
+		doguLogLevelRegistryMock.EXPECT().MarshalFromCesRegistryToString(testCtx, []string{}).Return("", assert.AnError)
 
 		configMapRegistry := &v1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode-registry", Namespace: testNamespace},
@@ -227,7 +251,12 @@ func Test_configMapDebugModeRegistry_Enable(t *testing.T) {
 
 		expectedUpdatedConfigMapRegistry := &v1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode-registry", Namespace: testNamespace},
-			Data:       map[string]string{"enabled": "true", "disable-at-timestamp": time.Now().Add(time.Minute * 15).Format(time.RFC822)},
+			Data: map[string]string{
+				"enabled":              "true",
+				"disable-at-timestamp": time.Now().Add(time.Minute * 15).Format(time.RFC3339),
+				"target-dogus":         "cas,redmine",
+				"target-log-level":     "TRACE",
+			},
 		}
 
 		doguLogeLevelRegistry := newMockDoguLogLevelRegistry(t)
@@ -238,7 +267,7 @@ func Test_configMapDebugModeRegistry_Enable(t *testing.T) {
 		sut := &configMapDebugModeRegistry{configMapInterface: configMapInterfaceMock, doguLogLevelRegistry: doguLogeLevelRegistry, namespace: testNamespace}
 
 		// when
-		err := sut.Enable(testCtx, 15)
+		err := sut.Enable(testCtx, 15, []string{"cas", "redmine"}, "TRACE")
 
 		// then
 		require.NoError(t, err)
@@ -255,7 +284,7 @@ func Test_configMapDebugModeRegistry_Enable(t *testing.T) {
 		sut := configMapDebugModeRegistry{configMapInterface: configMapInterfaceMock, namespace: testNamespace}
 
 		// when
-		err := sut.Enable(testCtx, 15)
+		err := sut.Enable(testCtx, 15, []string{"cas", "redmine"}, "TRACE")
 
 		// then
 		require.Error(t, err)
@@ -389,7 +418,7 @@ func Test_configMapDebugModeRegistry_Status(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		// given
 		now := time.Now()
-		expectedFormat := now.Format(time.RFC822)
+		expectedFormat := now.Format(time.RFC3339)
 		registryCm := &v1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode-registry", Namespace: testNamespace},
 			Data:       map[string]string{"enabled": "true", "disable-at-timestamp": expectedFormat},
@@ -405,7 +434,7 @@ func Test_configMapDebugModeRegistry_Status(t *testing.T) {
 		// then
 		require.NoError(t, err)
 		assert.Equal(t, true, enabled)
-		assert.Equal(t, expectedFormat, time.UnixMilli(timestamp).Format(time.RFC822))
+		assert.Equal(t, now.Truncate(time.Second).UnixMilli(), timestamp)
 	})
 
 	t.Run("should return false on missing registry config map", func(t *testing.T) {
@@ -470,6 +499,59 @@ func Test_configMapDebugModeRegistry_Status(t *testing.T) {
 	})
 }
 
+func Test_configMapDebugModeRegistry_Targets(t *testing.T) {
+	t.Run("should return the dogus and the log level of the debug mode", func(t *testing.T) {
+		// given
+		configMapRegistry := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode-registry", Namespace: testNamespace},
+			Data:       map[string]string{"enabled": "true", "target-dogus": "cas,redmine", "target-log-level": "TRACE"},
+		}
+		configMapClientMock := newMockConfigMapInterface(t)
+		configMapClientMock.EXPECT().Get(testCtx, "debug-mode-registry", metav1.GetOptions{}).Return(configMapRegistry, nil)
+
+		sut := configMapDebugModeRegistry{configMapInterface: configMapClientMock, namespace: testNamespace}
+
+		// when
+		doguNames, logLevel, err := sut.Targets(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cas", "redmine"}, doguNames)
+		assert.Equal(t, "TRACE", logLevel)
+	})
+
+	t.Run("should return no dogus on missing registry config map", func(t *testing.T) {
+		// given
+		configMapClientMock := newMockConfigMapInterface(t)
+		configMapClientMock.EXPECT().Get(testCtx, "debug-mode-registry", metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, ""))
+
+		sut := configMapDebugModeRegistry{configMapInterface: configMapClientMock, namespace: testNamespace}
+
+		// when
+		doguNames, logLevel, err := sut.Targets(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, doguNames)
+		assert.Empty(t, logLevel)
+	})
+
+	t.Run("should return error on error getting registry config map", func(t *testing.T) {
+		// given
+		configMapClientMock := newMockConfigMapInterface(t)
+		configMapClientMock.EXPECT().Get(testCtx, "debug-mode-registry", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		sut := configMapDebugModeRegistry{configMapInterface: configMapClientMock, namespace: testNamespace}
+
+		// when
+		_, _, err := sut.Targets(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
 func Test_configMapDebugModeRegistry_createRegistryIfNotFound(t *testing.T) {
 	t.Run("should create a new empty registry if no found", func(t *testing.T) {
 		// given
@@ -595,6 +677,30 @@ func Test_getDisableAtTimeStamp(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, int64(0), timestamp)
 	})
+
+	t.Run("should keep the seconds and the timezone", func(t *testing.T) {
+		// given
+		configMap := v1.ConfigMap{Data: map[string]string{"disable-at-timestamp": "2026-10-18T10:48:37+02:00"}}
+
+		// when
+		timestamp, err := getDisableAtTimeStamp(&configMap)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 10, 18, 8, 48, 37, 0, time.UTC).UnixMilli(), timestamp)
+	})
+
+	t.Run("should parse timestamps written by older versions", func(t *testing.T) {
+		// given
+		configMap := v1.ConfigMap{Data: map[string]string{"disable-at-timestamp": "10 Nov 23 10:48 UTC"}}
+
+		// when
+		timestamp, err := getDisableAtTimeStamp(&configMap)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2023, 11, 10, 10, 48, 0, 0, time.UTC).UnixMilli(), timestamp)
+	})
 }
 
 func Test_isRegistryEnabled(t *testing.T) {
@@ -26,24 +26,32 @@ func NewDoguLogLevelRegistryMap(doguConfig doguConfigRepository, doguReg doguDes
 	}
 }
 
-// MarshalFromCesRegistryToString marshals the registry to yaml string.
-func (d *doguLogLevelYamlRegistryMap) MarshalFromCesRegistryToString(ctx context.Context) (string, error) {
-	allDogus, err := d.doguReg.GetCurrentOfAll(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to get all dogus: %w", err)
+// MarshalFromCesRegistryToString marshals the log levels of the given dogus to a yaml string. If no dogus are given,
+// the log levels of all dogus are marshalled.
+func (d *doguLogLevelYamlRegistryMap) MarshalFromCesRegistryToString(ctx context.Context, doguNames []string) (string, error) {
+	if len(doguNames) == 0 {
+		allDogus, err := d.doguReg.GetCurrentOfAll(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get all dogus: %w", err)
+		}
+
+		for _, dogu := range allDogus {
+			doguNames = append(doguNames, dogu.GetSimpleName())
+		}
 	}
 
 	var multiError error
-	for _, dogu := range allDogus {
-		doguConfig, _ := d.doguConfigRepository.Get(ctx, common.SimpleName(dogu.GetSimpleName()))
+	d.logLevelRegistryMap = map[string]string{}
+	for _, doguName := range doguNames {
+		doguConfig, _ := d.doguConfigRepository.Get(ctx, common.SimpleName(doguName))
 		logLevel, exists := doguConfig.Get(keyDoguConfigLogLevel)
 
 		if !exists {
-			d.logLevelRegistryMap[dogu.GetSimpleName()] = ""
+			d.logLevelRegistryMap[doguName] = ""
 			continue
 		}
 
-		d.logLevelRegistryMap[dogu.GetSimpleName()] = string(logLevel)
+		d.logLevelRegistryMap[doguName] = string(logLevel)
 	}
 
 	out, err := yaml.Marshal(d.logLevelRegistryMap)
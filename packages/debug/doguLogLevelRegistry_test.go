@@ -123,7 +123,7 @@ func Test_doguLogLevelRegistry_MarshalFromCesRegistryToString(t *testing.T) {
 		}
 
 		// when
-		result, err := sut.MarshalFromCesRegistryToString(testCtx)
+		result, err := sut.MarshalFromCesRegistryToString(testCtx, nil)
 
 		// then
 		require.NoError(t, err)
@@ -141,7 +141,7 @@ func Test_doguLogLevelRegistry_MarshalFromCesRegistryToString(t *testing.T) {
 		}
 
 		// when
-		_, err := sut.MarshalFromCesRegistryToString(testCtx)
+		_, err := sut.MarshalFromCesRegistryToString(testCtx, nil)
 
 		// then
 		require.Error(t, err)
@@ -171,10 +171,28 @@ func Test_doguLogLevelRegistry_MarshalFromCesRegistryToString(t *testing.T) {
 		}
 
 		// when
-		result, err := sut.MarshalFromCesRegistryToString(testCtx)
+		result, err := sut.MarshalFromCesRegistryToString(testCtx, nil)
 
 		// then
 		require.NoError(t, err)
 		assert.Equal(t, expectedRegistryStr, result)
 	})
+	t.Run("should marshal only the given dogus", func(t *testing.T) {
+		// given
+		doguConfigRepositoryMock := newMockDoguConfigRepository(t)
+		doguConfig := config.CreateDoguConfig("redmine", config.Entries{"logging/root": "WARN"})
+		doguConfigRepositoryMock.EXPECT().Get(context.TODO(), common.SimpleName("redmine")).Return(doguConfig, nil)
+
+		sut := &doguLogLevelYamlRegistryMap{
+			doguConfigRepository: doguConfigRepositoryMock,
+			logLevelRegistryMap:  map[string]string{"cas": "INFO"},
+		}
+
+		// when
+		result, err := sut.MarshalFromCesRegistryToString(testCtx, []string{"redmine"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "redmine: WARN\n", result)
+	})
 }
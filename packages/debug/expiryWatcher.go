@@ -0,0 +1,353 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudogu/ces-control-api/generated/maintenance"
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	// expiryLeaseName is the name of the lease which elects the replica watching the expiry of the debug mode.
+	expiryLeaseName     = "k8s-ces-control-debug-mode-expiry"
+	expiryLeaseDuration = 15 * time.Second
+	expiryRenewDeadline = 10 * time.Second
+	expiryRetryPeriod   = 2 * time.Second
+	debugModeName       = "debug-mode"
+	// supportArchiveLead is the time before the deactivation time of the DebugMode resource at which its support
+	// archive is created with the deactivation time as end. The debug mode operator rolls back the log levels at the
+	// deactivation time, so the archive cannot be created when the debug mode is disabled.
+	supportArchiveLead = time.Minute
+)
+
+var watchInterval = time.Second * 30
+
+// SetWatchInterval sets the interval in which the informers of the debug mode resync and after which a failed
+// deactivation of the debug mode is retried. It must be called before the watch is started.
+func SetWatchInterval(interval time.Duration) {
+	watchInterval = interval
+}
+
+// expiryWatcher disables the debug mode when its deactivation time is reached. It watches the debug mode registry
+// and the DebugMode resource with informers and arms a timer for the earliest deactivation time, which is re-armed
+// whenever the debug mode is extended or disabled. Only the replica holding the expiry lease watches, so that several
+// replicas do not disable the debug mode at the same time. The DebugMode resource is only watched if the cluster
+// serves it when the replica becomes the leader.
+type expiryWatcher struct {
+	configMapInterface configMapInterface
+	debugModeClient    debugModeInterface
+	serverResources    serverResourcesGetter
+	leases             leasesGetter
+	history            debugModeHistory
+	namespace          string
+	identity           string
+	debugModeService   debugModeServer
+}
+
+// NewExpiryWatcher creates a watcher which disables the debug mode on expiry. The identity distinguishes the replicas
+// in the leader election and is usually the name of the pod.
+func NewExpiryWatcher(configMapInterface configMapInterface, debugModeClient debugModeInterface, serverResources serverResourcesGetter, leases leasesGetter, namespace string, identity string, debugModeService debugModeServer) *expiryWatcher {
+	return &expiryWatcher{
+		configMapInterface: configMapInterface,
+		debugModeClient:    debugModeClient,
+		serverResources:    serverResources,
+		leases:             leases,
+		history:            NewConfigMapDebugModeHistory(configMapInterface, namespace),
+		namespace:          namespace,
+		identity:           identity,
+		debugModeService:   debugModeService,
+	}
+}
+
+// StartWatch takes part in the leader election and watches the expiry of the debug mode while this replica is the
+// leader. The watch stops when the given context is done.
+func (w *expiryWatcher) StartWatch(ctx context.Context) {
+	go w.runLeaderElection(ctx)
+}
+
+func (w *expiryWatcher) runLeaderElection(ctx context.Context) {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Name: expiryLeaseName, Namespace: w.namespace},
+		Client:     w.leases,
+		LockConfig: resourcelock.ResourceLockConfig{Identity: w.identity},
+	}
+
+	// RunOrDie returns when the leadership is lost, so the replica applies for it again until the context is done.
+	for ctx.Err() == nil {
+		leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			Name:            expiryLeaseName,
+			LeaseDuration:   expiryLeaseDuration,
+			RenewDeadline:   expiryRenewDeadline,
+			RetryPeriod:     expiryRetryPeriod,
+			ReleaseOnCancel: true,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: w.watch,
+				OnStoppedLeading: func() {
+					logrus.Infof("%s stopped watching the expiry of the debug mode", w.identity)
+				},
+			},
+		})
+	}
+}
+
+func (w *expiryWatcher) watch(ctx context.Context) {
+	logrus.Infof("%s started watching the expiry of the debug mode", w.identity)
+
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(any) { notify() },
+		UpdateFunc: func(any, any) { notify() },
+		DeleteFunc: func(any) { notify() },
+	}
+
+	registryStore, registryInformer := cache.NewInformerWithOptions(cache.InformerOptions{
+		ListerWatcher: w.registryListWatch(),
+		ObjectType:    &corev1.ConfigMap{},
+		Handler:       handler,
+		ResyncPeriod:  watchInterval,
+	})
+	debugModeStore, debugModeInformer := cache.NewInformerWithOptions(cache.InformerOptions{
+		ListerWatcher: w.debugModeListWatch(),
+		ObjectType:    &v1.DebugMode{},
+		Handler:       handler,
+		ResyncPeriod:  watchInterval,
+	})
+	go registryInformer.RunWithContext(ctx)
+
+	// without its CRD, listing the DebugMode resource fails until the watch ends
+	served, err := debugModeServed(w.serverResources)
+	if err != nil {
+		logrus.Warnf("watching only the expiry of the debug mode registry: %v", err)
+	}
+	if served {
+		go debugModeInformer.RunWithContext(ctx)
+	}
+
+	timer := time.NewTimer(0)
+	timer.Stop()
+	defer timer.Stop()
+
+	// handledUntil is the time up to which the debug mode was disabled. Deactivation times before it are ignored
+	// while the informers have not yet seen the result of the deactivation.
+	var handledUntil, retryAt, archiveEnd time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Debug("stopped watching the expiry of the debug mode")
+			return
+		case <-changed:
+		case <-timer.C:
+			if !archiveEnd.IsZero() {
+				retryAt = w.captureSupportArchive(ctx, archiveEnd)
+				break
+			}
+
+			_, err := w.debugModeService.Disable(ctx, &maintenance.ToggleDebugModeRequest{})
+			if err != nil {
+				logrus.Error(fmt.Errorf("failed to disable expired debug mode: %w", err))
+				retryAt = time.Now().Add(watchInterval)
+			} else {
+				logrus.Info("disabled expired debug mode")
+				handledUntil, retryAt = time.Now(), time.Time{}
+			}
+		}
+
+		debugMode := w.storedDebugMode(debugModeStore)
+		deactivation, ok := nextDeactivation(w.storedRegistry(registryStore), debugMode, handledUntil)
+		if !ok {
+			timer.Stop()
+			continue
+		}
+
+		// the support archive of the DebugMode resource is created ahead of its deactivation; once the deactivation is
+		// reached, disabling the debug mode creates it instead
+		archiveEnd = w.pendingSupportArchiveEnd(ctx, debugMode, handledUntil)
+		if !archiveEnd.IsZero() && retryAt.Before(archiveEnd) {
+			deactivation = archiveEnd.Add(-supportArchiveLead)
+		} else {
+			archiveEnd = time.Time{}
+		}
+		if deactivation.Before(retryAt) {
+			deactivation = retryAt
+		}
+
+		logrus.Debugf("debug mode expires at %s", deactivation.Format(time.RFC3339))
+		timer.Reset(time.Until(deactivation))
+	}
+}
+
+// captureSupportArchive creates the support archive of the DebugMode resource ahead of its deactivation. It returns the
+// time at which a failed attempt is retried.
+func (w *expiryWatcher) captureSupportArchive(ctx context.Context, end time.Time) (retryAt time.Time) {
+	err := w.debugModeService.CaptureSupportArchive(ctx, end)
+	if err != nil {
+		logrus.Error(err)
+		return time.Now().Add(watchInterval)
+	}
+
+	return time.Time{}
+}
+
+// pendingSupportArchiveEnd returns the deactivation time of an active DebugMode resource after handledUntil if a
+// support archive was requested for the active session but not created yet, otherwise the zero time. The debug mode
+// registry needs no archive ahead of time, because the service restores its log levels itself after creating the
+// archive.
+func (w *expiryWatcher) pendingSupportArchiveEnd(ctx context.Context, debugMode *v1.DebugMode, handledUntil time.Time) time.Time {
+	deactivation := debugModeDeactivation(debugMode)
+	if deactivation.IsZero() || !deactivation.After(handledUntil) {
+		return time.Time{}
+	}
+
+	sessions, err := w.history.List(ctx, 1)
+	if err != nil {
+		logrus.Warnf("could not get the last debug mode session from the history: %v", err)
+		return time.Time{}
+	}
+	if len(sessions) == 0 || !sessions[0].supportArchivePending() {
+		return time.Time{}
+	}
+
+	return deactivation
+}
+
+// registryListWatch lists and watches only the debug mode registry.
+func (w *expiryWatcher) registryListWatch() cache.ListerWatcher {
+	selector := fields.OneTermEqualSelector("metadata.name", registryName).String()
+	return singleObjectListWatch{&cache.ListWatch{
+		ListWithContextFunc: func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return w.configMapInterface.List(ctx, options)
+		},
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return w.configMapInterface.Watch(ctx, options)
+		},
+	}}
+}
+
+// debugModeListWatch lists and watches the DebugMode resource. Its client cannot list, so the list is emulated by
+// getting the singleton resource.
+func (w *expiryWatcher) debugModeListWatch() cache.ListerWatcher {
+	selector := fields.OneTermEqualSelector("metadata.name", debugModeName).String()
+	return singleObjectListWatch{&cache.ListWatch{
+		ListWithContextFunc: func(ctx context.Context, _ metav1.ListOptions) (runtime.Object, error) {
+			list := &v1.DebugModeList{}
+			debugMode, err := w.debugModeClient.Get(ctx, debugModeName, metav1.GetOptions{})
+			if k8serrors.IsNotFound(err) {
+				return list, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			list.ResourceVersion = debugMode.ResourceVersion
+			list.Items = []v1.DebugMode{*debugMode}
+			return list, nil
+		},
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return w.debugModeClient.Watch(ctx, options)
+		},
+	}}
+}
+
+// singleObjectListWatch opts out of the streaming watch list of the informers, so that the objects are always
+// listed first.
+type singleObjectListWatch struct {
+	*cache.ListWatch
+}
+
+// IsWatchListSemanticsUnSupported tells the informer to list the objects instead of streaming them.
+func (singleObjectListWatch) IsWatchListSemanticsUnSupported() bool {
+	return true
+}
+
+func (w *expiryWatcher) storedRegistry(store cache.Store) *corev1.ConfigMap {
+	obj, exists, err := store.GetByKey(w.namespace + "/" + registryName)
+	if err != nil || !exists {
+		return nil
+	}
+
+	registry, _ := obj.(*corev1.ConfigMap)
+	return registry
+}
+
+func (w *expiryWatcher) storedDebugMode(store cache.Store) *v1.DebugMode {
+	obj, exists, err := store.GetByKey(w.namespace + "/" + debugModeName)
+	if err != nil || !exists {
+		return nil
+	}
+
+	debugMode, _ := obj.(*v1.DebugMode)
+	return debugMode
+}
+
+// nextDeactivation returns the earliest deactivation time of the registry and the DebugMode resource after
+// handledUntil. It returns false if the debug mode is not enabled.
+func nextDeactivation(registry *corev1.ConfigMap, debugMode *v1.DebugMode, handledUntil time.Time) (time.Time, bool) {
+	var next time.Time
+	for _, deactivation := range []time.Time{registryDeactivation(registry), debugModeDeactivation(debugMode)} {
+		if deactivation.IsZero() || !deactivation.After(handledUntil) {
+			continue
+		}
+		if next.IsZero() || deactivation.Before(next) {
+			next = deactivation
+		}
+	}
+
+	return next, !next.IsZero()
+}
+
+// registryDeactivation returns the deactivation time of an enabled registry or the zero time.
+func registryDeactivation(registry *corev1.ConfigMap) time.Time {
+	if registry == nil {
+		return time.Time{}
+	}
+
+	enabled, err := isRegistryEnabled(registry)
+	if err != nil || !enabled {
+		return time.Time{}
+	}
+
+	value, ok := registry.Data[keyDisableAtTimestamp]
+	if !ok {
+		return time.Time{}
+	}
+
+	deactivation, err := parseDisableAtTimestamp(value)
+	if err != nil {
+		logrus.Warnf("ignoring the debug mode registry with the invalid %s %q: %v", keyDisableAtTimestamp, value, err)
+		return time.Time{}
+	}
+
+	return deactivation
+}
+
+// debugModeDeactivation returns the deactivation time of an active DebugMode resource or the zero time. Resources
+// which are already rolled back or failed are ignored.
+func debugModeDeactivation(debugMode *v1.DebugMode) time.Time {
+	if debugMode == nil || debugMode.Status.Phase != v1.DebugModeStatusSet {
+		return time.Time{}
+	}
+
+	return debugMode.Spec.DeactivateTimestamp.Time
+}
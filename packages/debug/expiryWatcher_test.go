@@ -0,0 +1,321 @@
+package debug
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/ces-control-api/generated/maintenance"
+	debugModeV1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_nextDeactivation(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	registry := func(enabled string, disableAt time.Time) *v1.ConfigMap {
+		return &v1.ConfigMap{Data: map[string]string{
+			keyDebugModeEnabled:   enabled,
+			keyDisableAtTimestamp: disableAt.Format(timestampFormat),
+		}}
+	}
+	debugMode := func(phase debugModeV1.StatusPhase, deactivateAt time.Time) *debugModeV1.DebugMode {
+		return &debugModeV1.DebugMode{
+			Spec:   debugModeV1.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(deactivateAt)},
+			Status: debugModeV1.DebugModeStatus{Phase: phase},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		registry     *v1.ConfigMap
+		debugMode    *debugModeV1.DebugMode
+		handledUntil time.Time
+		want         time.Time
+		wantOk       bool
+	}{
+		{name: "should return false without registry and debug mode"},
+		{name: "should return the deactivation of an enabled registry", registry: registry("true", now.Add(time.Hour)), want: now.Add(time.Hour), wantOk: true},
+		{name: "should ignore a disabled registry", registry: registry("false", now.Add(time.Hour))},
+		{name: "should ignore an uninitialized registry", registry: &v1.ConfigMap{}},
+		{name: "should ignore a registry with an invalid timestamp", registry: &v1.ConfigMap{Data: map[string]string{keyDebugModeEnabled: "true", keyDisableAtTimestamp: "invalid"}}},
+		{name: "should read legacy timestamps", registry: &v1.ConfigMap{Data: map[string]string{keyDebugModeEnabled: "true", keyDisableAtTimestamp: "10 Nov 23 10:48 UTC"}}, want: time.Date(2023, time.November, 10, 10, 48, 0, 0, time.UTC), wantOk: true},
+		{name: "should return the deactivation of an active debug mode", debugMode: debugMode(debugModeV1.DebugModeStatusSet, now.Add(time.Hour)), want: now.Add(time.Hour), wantOk: true},
+		{name: "should ignore a completed debug mode", debugMode: debugMode(debugModeV1.DebugModeStatusCompleted, now.Add(time.Hour))},
+		{name: "should return the earliest deactivation", registry: registry("true", now.Add(2*time.Hour)), debugMode: debugMode(debugModeV1.DebugModeStatusSet, now.Add(time.Hour)), want: now.Add(time.Hour), wantOk: true},
+		{name: "should return an expired deactivation", registry: registry("true", now.Add(-time.Hour)), want: now.Add(-time.Hour), wantOk: true},
+		{name: "should ignore handled deactivations", registry: registry("true", now.Add(-time.Hour)), handledUntil: now},
+		{name: "should return an extended deactivation after a handled one", registry: registry("true", now.Add(time.Hour)), handledUntil: now, want: now.Add(time.Hour), wantOk: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			got, ok := nextDeactivation(tt.registry, tt.debugMode, tt.handledUntil)
+
+			// then
+			assert.Equal(t, tt.wantOk, ok)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func Test_expiryWatcher_watch(t *testing.T) {
+	notFound := k8serrors.NewNotFound(schema.GroupResource{}, debugModeName)
+	expiredRegistry := func() *v1.ConfigMap {
+		return &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: registryName, Namespace: testNamespace},
+			Data: map[string]string{
+				keyDebugModeEnabled:   "true",
+				keyDisableAtTimestamp: time.Now().Add(-time.Minute).Format(timestampFormat),
+			},
+		}
+	}
+	runWatch := func(sut *expiryWatcher) (cancel func()) {
+		ctx, cancelCtx := context.WithCancel(testCtx)
+		done := make(chan struct{})
+		go func() {
+			sut.watch(ctx)
+			close(done)
+		}()
+
+		return func() {
+			cancelCtx()
+			<-done
+		}
+	}
+
+	t.Run("should disable the expired debug mode of the registry", func(t *testing.T) {
+		// given
+		clientSet := fake.NewClientset(expiredRegistry())
+		clientSet.Resources = withDebugModeOperator().Resources
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(mock.Anything, debugModeName, metav1.GetOptions{}).Return(nil, notFound).Maybe()
+		debugModeClientMock.EXPECT().Watch(mock.Anything, mock.Anything).Return(watch.NewFake(), nil).Maybe()
+		disabled := make(chan struct{})
+		debugModeServiceMock := newMockDebugModeServer(t)
+		debugModeServiceMock.EXPECT().Disable(mock.Anything, &maintenance.ToggleDebugModeRequest{}).
+			Run(func(context.Context, *maintenance.ToggleDebugModeRequest) { close(disabled) }).
+			Return(nil, nil).Once()
+
+		sut := NewExpiryWatcher(clientSet.CoreV1().ConfigMaps(testNamespace), debugModeClientMock, clientSet.Discovery(), clientSet.CoordinationV1(), testNamespace, "test", debugModeServiceMock)
+
+		// when
+		cancel := runWatch(sut)
+		defer cancel()
+
+		// then
+		select {
+		case <-disabled:
+		case <-time.After(5 * time.Second):
+			t.Fatal("debug mode was not disabled")
+		}
+	})
+
+	t.Run("should watch only the registry if the DebugMode resource is not served", func(t *testing.T) {
+		// given
+		clientSet := fake.NewClientset(expiredRegistry())
+		disabled := make(chan struct{})
+		debugModeServiceMock := newMockDebugModeServer(t)
+		debugModeServiceMock.EXPECT().Disable(mock.Anything, &maintenance.ToggleDebugModeRequest{}).
+			Run(func(context.Context, *maintenance.ToggleDebugModeRequest) { close(disabled) }).
+			Return(nil, nil).Once()
+
+		sut := NewExpiryWatcher(clientSet.CoreV1().ConfigMaps(testNamespace), newMockDebugModeInterface(t), clientSet.Discovery(), clientSet.CoordinationV1(), testNamespace, "test", debugModeServiceMock)
+
+		// when
+		cancel := runWatch(sut)
+		defer cancel()
+
+		// then
+		select {
+		case <-disabled:
+		case <-time.After(5 * time.Second):
+			t.Fatal("debug mode was not disabled")
+		}
+	})
+
+	t.Run("should disable the expired debug mode resource", func(t *testing.T) {
+		// given
+		clientSet := fake.NewClientset()
+		clientSet.Resources = withDebugModeOperator().Resources
+		debugMode := &debugModeV1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: debugModeName, Namespace: testNamespace, ResourceVersion: "1"},
+			Spec:       debugModeV1.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(time.Now().Add(-time.Minute))},
+			Status:     debugModeV1.DebugModeStatus{Phase: debugModeV1.DebugModeStatusSet},
+		}
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(mock.Anything, debugModeName, metav1.GetOptions{}).Return(debugMode, nil).Maybe()
+		debugModeClientMock.EXPECT().Watch(mock.Anything, mock.Anything).Return(watch.NewFake(), nil).Maybe()
+		disabled := make(chan struct{})
+		debugModeServiceMock := newMockDebugModeServer(t)
+		debugModeServiceMock.EXPECT().Disable(mock.Anything, &maintenance.ToggleDebugModeRequest{}).
+			Run(func(context.Context, *maintenance.ToggleDebugModeRequest) { close(disabled) }).
+			Return(nil, nil).Once()
+
+		sut := NewExpiryWatcher(clientSet.CoreV1().ConfigMaps(testNamespace), debugModeClientMock, clientSet.Discovery(), clientSet.CoordinationV1(), testNamespace, "test", debugModeServiceMock)
+
+		// when
+		cancel := runWatch(sut)
+		defer cancel()
+
+		// then
+		select {
+		case <-disabled:
+		case <-time.After(5 * time.Second):
+			t.Fatal("debug mode was not disabled")
+		}
+	})
+
+	t.Run("should create the support archive of the debug mode resource ahead of its deactivation", func(t *testing.T) {
+		// given
+		clientSet := fake.NewClientset()
+		clientSet.Resources = withDebugModeOperator().Resources
+		history := NewConfigMapDebugModeHistory(clientSet.CoreV1().ConfigMaps(testNamespace), testNamespace)
+		err := history.Start(testCtx, debugSession{EnabledBy: "admin", EnabledAt: time.Now(), SupportArchive: true})
+		require.NoError(t, err)
+		deactivateAt := metav1.NewTime(time.Now().Add(supportArchiveLead / 2))
+		debugMode := &debugModeV1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: debugModeName, Namespace: testNamespace, ResourceVersion: "1"},
+			Spec:       debugModeV1.DebugModeSpec{DeactivateTimestamp: deactivateAt},
+			Status:     debugModeV1.DebugModeStatus{Phase: debugModeV1.DebugModeStatusSet},
+		}
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(mock.Anything, debugModeName, metav1.GetOptions{}).Return(debugMode, nil).Maybe()
+		debugModeClientMock.EXPECT().Watch(mock.Anything, mock.Anything).Return(watch.NewFake(), nil).Maybe()
+		captured := make(chan struct{})
+		debugModeServiceMock := newMockDebugModeServer(t)
+		debugModeServiceMock.EXPECT().CaptureSupportArchive(mock.Anything, deactivateAt.Time).
+			RunAndReturn(func(context.Context, time.Time) error {
+				close(captured)
+				return history.SetSupportArchiveName(testCtx, "debug-mode-support-archive")
+			}).Once()
+
+		sut := NewExpiryWatcher(clientSet.CoreV1().ConfigMaps(testNamespace), debugModeClientMock, clientSet.Discovery(), clientSet.CoordinationV1(), testNamespace, "test", debugModeServiceMock)
+
+		// when
+		cancel := runWatch(sut)
+		defer cancel()
+
+		// then
+		select {
+		case <-captured:
+		case <-time.After(5 * time.Second):
+			t.Fatal("support archive was not created")
+		}
+		time.Sleep(50 * time.Millisecond)
+		debugModeServiceMock.AssertNotCalled(t, "Disable", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should retry a failed deactivation after the watch interval", func(t *testing.T) {
+		// given
+		oldWatchInterval := watchInterval
+		watchInterval = 10 * time.Millisecond
+		defer func() { watchInterval = oldWatchInterval }()
+
+		clientSet := fake.NewClientset(expiredRegistry())
+		clientSet.Resources = withDebugModeOperator().Resources
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(mock.Anything, debugModeName, metav1.GetOptions{}).Return(nil, notFound).Maybe()
+		debugModeClientMock.EXPECT().Watch(mock.Anything, mock.Anything).Return(watch.NewFake(), nil).Maybe()
+		disabled := make(chan struct{})
+		debugModeServiceMock := newMockDebugModeServer(t)
+		debugModeServiceMock.EXPECT().Disable(mock.Anything, &maintenance.ToggleDebugModeRequest{}).Return(nil, assert.AnError).Once()
+		debugModeServiceMock.EXPECT().Disable(mock.Anything, &maintenance.ToggleDebugModeRequest{}).
+			Run(func(context.Context, *maintenance.ToggleDebugModeRequest) { close(disabled) }).
+			Return(nil, nil).Once()
+
+		sut := NewExpiryWatcher(clientSet.CoreV1().ConfigMaps(testNamespace), debugModeClientMock, clientSet.Discovery(), clientSet.CoordinationV1(), testNamespace, "test", debugModeServiceMock)
+
+		// when
+		cancel := runWatch(sut)
+		defer cancel()
+
+		// then
+		select {
+		case <-disabled:
+		case <-time.After(5 * time.Second):
+			t.Fatal("debug mode was not disabled after the retry")
+		}
+	})
+
+	t.Run("should not disable a debug mode which is not expired", func(t *testing.T) {
+		// given
+		registry := expiredRegistry()
+		registry.Data[keyDisableAtTimestamp] = time.Now().Add(time.Hour).Format(timestampFormat)
+		clientSet := fake.NewClientset(registry)
+		clientSet.Resources = withDebugModeOperator().Resources
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(mock.Anything, debugModeName, metav1.GetOptions{}).Return(nil, notFound).Maybe()
+		debugModeClientMock.EXPECT().Watch(mock.Anything, mock.Anything).Return(watch.NewFake(), nil).Maybe()
+		debugModeServiceMock := newMockDebugModeServer(t)
+
+		sut := NewExpiryWatcher(clientSet.CoreV1().ConfigMaps(testNamespace), debugModeClientMock, clientSet.Discovery(), clientSet.CoordinationV1(), testNamespace, "test", debugModeServiceMock)
+
+		// when
+		cancel := runWatch(sut)
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+
+		// then
+		debugModeServiceMock.AssertNotCalled(t, "Disable", mock.Anything, mock.Anything)
+	})
+}
+
+func Test_expiryWatcher_StartWatch(t *testing.T) {
+	t.Run("should acquire the lease and disable the expired debug mode", func(t *testing.T) {
+		// given
+		registry := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: registryName, Namespace: testNamespace},
+			Data: map[string]string{
+				keyDebugModeEnabled:   "true",
+				keyDisableAtTimestamp: time.Now().Add(-time.Minute).Format(timestampFormat),
+			},
+		}
+		clientSet := fake.NewClientset(registry)
+		clientSet.Resources = withDebugModeOperator().Resources
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(mock.Anything, debugModeName, metav1.GetOptions{}).Return(nil, k8serrors.NewNotFound(schema.GroupResource{}, debugModeName)).Maybe()
+		debugModeClientMock.EXPECT().Watch(mock.Anything, mock.Anything).Return(watch.NewFake(), nil).Maybe()
+		disabled := make(chan struct{})
+		debugModeServiceMock := newMockDebugModeServer(t)
+		debugModeServiceMock.EXPECT().Disable(mock.Anything, &maintenance.ToggleDebugModeRequest{}).
+			Run(func(context.Context, *maintenance.ToggleDebugModeRequest) { close(disabled) }).
+			Return(nil, nil).Once()
+
+		sut := NewExpiryWatcher(clientSet.CoreV1().ConfigMaps(testNamespace), debugModeClientMock, clientSet.Discovery(), clientSet.CoordinationV1(), testNamespace, "test-pod", debugModeServiceMock)
+		ctx, cancel := context.WithCancel(testCtx)
+		defer cancel()
+
+		// when
+		sut.StartWatch(ctx)
+
+		// then
+		select {
+		case <-disabled:
+		case <-time.After(5 * time.Second):
+			t.Fatal("debug mode was not disabled")
+		}
+		lease, err := clientSet.CoordinationV1().Leases(testNamespace).Get(testCtx, expiryLeaseName, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, "test-pod", *lease.Spec.HolderIdentity)
+	})
+}
+
+func TestSetWatchInterval(t *testing.T) {
+	// given
+	oldWatchInterval := watchInterval
+	defer func() { watchInterval = oldWatchInterval }()
+
+	// when
+	SetWatchInterval(time.Minute)
+
+	// then
+	assert.Equal(t, time.Minute, watchInterval)
+}
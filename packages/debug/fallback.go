@@ -0,0 +1,99 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	debugModeResourceName = "debugmodes"
+	// debugModeOperatorSelector selects the pods of the debug mode operator.
+	debugModeOperatorSelector = "app.kubernetes.io/name=k8s-debug-mode-operator"
+)
+
+// debugModeOperatorAvailable returns whether the cluster serves the DebugMode resource and the debug mode operator is
+// running. Otherwise, the service drives the debug mode of all dogus itself with the debug mode registry.
+func (s *defaultDebugModeService) debugModeOperatorAvailable(ctx context.Context) (bool, error) {
+	served, err := debugModeServed(s.serverResources)
+	if err != nil || !served {
+		return false, err
+	}
+
+	pods, err := s.podClient.List(ctx, metav1.ListOptions{LabelSelector: debugModeOperatorSelector})
+	if err != nil {
+		return false, fmt.Errorf("failed to list pods of the debug mode operator: %w", err)
+	}
+
+	return slices.ContainsFunc(pods.Items, func(pod corev1.Pod) bool { return pod.Status.Phase == corev1.PodRunning }), nil
+}
+
+// debugModeServed returns whether the cluster serves the DebugMode resource, i.e. whether its CRD is installed.
+func debugModeServed(serverResources serverResourcesGetter) (bool, error) {
+	resources, err := serverResources.ServerResourcesForGroupVersion(v1.GroupVersion.String())
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get resources of %s: %w", v1.GroupVersion, err)
+	}
+
+	return slices.ContainsFunc(resources.APIResources, func(resource metav1.APIResource) bool { return resource.Name == debugModeResourceName }), nil
+}
+
+// restartDogus restarts the given dogus, which are sorted by dogusInDependencyOrder, one after another. A dogu on which
+// another of the given dogus depends is restarted and waited for before its dependents are restarted, the other dogus
+// are not waited for. The restarts are not cancelled if the client cancels the call, because the log levels are written
+// already.
+func (s *defaultDebugModeService) restartDogus(ctx context.Context, dogus []*core.Dogu) error {
+	dependencies := map[string]bool{}
+	for _, dogu := range dogus {
+		for _, dependency := range dogu.GetAllDependenciesOfType(core.DependencyTypeDogu) {
+			dependencies[dependency.Name] = true
+		}
+	}
+
+	var multiError error
+	for _, dogu := range dogus {
+		doguName := dogu.GetSimpleName()
+		err := s.doguInterActor.RestartDoguWithWait(context.WithoutCancel(ctx), doguName, dependencies[doguName])
+		if err != nil {
+			multiError = errors.Join(multiError, err)
+		}
+	}
+
+	if multiError != nil {
+		return fmt.Errorf("failed to restart dogus: %w", multiError)
+	}
+
+	return nil
+}
+
+// dogusInDependencyOrder returns the descriptors of the given dogus sorted so that every dogu follows its dependencies.
+// It fails if the dogus cannot be sorted, so that they are never restarted out of order.
+func (s *defaultDebugModeService) dogusInDependencyOrder(ctx context.Context, doguNames []string) ([]*core.Dogu, error) {
+	installedDogus, err := s.doguDescriptorGetter.GetCurrentOfAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installed dogus to sort them by dependency: %w", err)
+	}
+
+	var dogus []*core.Dogu
+	for _, dogu := range installedDogus {
+		if slices.Contains(doguNames, dogu.GetSimpleName()) {
+			dogus = append(dogus, dogu)
+		}
+	}
+	sortedDogus, err := core.SortDogusByDependencyWithError(dogus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sort dogus by dependency: %w", err)
+	}
+
+	return sortedDogus, nil
+}
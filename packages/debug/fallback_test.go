@@ -0,0 +1,401 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	common "github.com/cloudogu/ces-commons-lib/dogu"
+	"github.com/cloudogu/ces-control-api/generated/maintenance"
+	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
+)
+
+// withDebugModeOperator returns a client set which serves the DebugMode resource and runs the debug mode operator.
+func withDebugModeOperator() *fake.Clientset {
+	clientSet := fake.NewClientset(debugModeOperatorPod(corev1.PodRunning))
+	clientSet.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "k8s.cloudogu.com/v1",
+		APIResources: []metav1.APIResource{{Name: "dogus"}, {Name: "debugmodes"}},
+	}}
+	return clientSet
+}
+
+func debugModeOperatorPod(phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "k8s-debug-mode-operator-controller-manager",
+			Namespace: testNamespace,
+			Labels:    map[string]string{"app.kubernetes.io/name": "k8s-debug-mode-operator"},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func Test_defaultDebugModeService_debugModeOperatorAvailable(t *testing.T) {
+	t.Run("should be available if the resource is served and the operator is running", func(t *testing.T) {
+		// given
+		clientSet := withDebugModeOperator()
+		sut := defaultDebugModeService{serverResources: clientSet.Discovery(), podClient: clientSet.CoreV1().Pods(testNamespace)}
+
+		// when
+		available, err := sut.debugModeOperatorAvailable(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, available)
+	})
+	t.Run("should not be available if the group is not served", func(t *testing.T) {
+		// given
+		clientSet := fake.NewClientset(debugModeOperatorPod(corev1.PodRunning))
+		sut := defaultDebugModeService{serverResources: clientSet.Discovery(), podClient: clientSet.CoreV1().Pods(testNamespace)}
+
+		// when
+		available, err := sut.debugModeOperatorAvailable(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, available)
+	})
+	t.Run("should not be available if the DebugMode resource is not served", func(t *testing.T) {
+		// given
+		clientSet := withDebugModeOperator()
+		clientSet.Resources[0].APIResources = []metav1.APIResource{{Name: "dogus"}}
+		sut := defaultDebugModeService{serverResources: clientSet.Discovery(), podClient: clientSet.CoreV1().Pods(testNamespace)}
+
+		// when
+		available, err := sut.debugModeOperatorAvailable(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, available)
+	})
+	t.Run("should not be available if the operator is not running", func(t *testing.T) {
+		// given
+		clientSet := fake.NewClientset(debugModeOperatorPod(corev1.PodPending))
+		clientSet.Resources = withDebugModeOperator().Resources
+		sut := defaultDebugModeService{serverResources: clientSet.Discovery(), podClient: clientSet.CoreV1().Pods(testNamespace)}
+
+		// when
+		available, err := sut.debugModeOperatorAvailable(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, available)
+	})
+	t.Run("should return error if the resources cannot be discovered", func(t *testing.T) {
+		// given
+		serverResourcesMock := newMockServerResourcesGetter(t)
+		serverResourcesMock.EXPECT().ServerResourcesForGroupVersion("k8s.cloudogu.com/v1").Return(nil, assert.AnError)
+		sut := defaultDebugModeService{serverResources: serverResourcesMock}
+
+		// when
+		_, err := sut.debugModeOperatorAvailable(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get resources of k8s.cloudogu.com/v1")
+	})
+}
+
+func Test_defaultDebugModeService_restartDogus(t *testing.T) {
+	dependsOn := func(doguNames ...string) []core.Dependency {
+		var dependencies []core.Dependency
+		for _, doguName := range doguNames {
+			dependencies = append(dependencies, core.Dependency{Type: core.DependencyTypeDogu, Name: doguName})
+		}
+		return dependencies
+	}
+
+	t.Run("should restart the dogus after their dependencies and wait for the dependencies", func(t *testing.T) {
+		// given
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return([]*core.Dogu{
+			{Name: "official/redmine", Dependencies: dependsOn("cas", "postgresql")},
+			{Name: "official/cas", Dependencies: dependsOn("ldap")},
+			{Name: "official/ldap"},
+			{Name: "official/postgresql"},
+			{Name: "official/jenkins", Dependencies: dependsOn("cas")},
+		}, nil)
+		var restarted []string
+		record := func(_ context.Context, doguName string, _ bool) { restarted = append(restarted, doguName) }
+		doguInterActorMock := newMockDoguInterActor(t)
+		doguInterActorMock.EXPECT().RestartDoguWithWait(mock.Anything, "ldap", true).Run(record).Return(nil)
+		doguInterActorMock.EXPECT().RestartDoguWithWait(mock.Anything, "cas", true).Run(record).Return(nil)
+		doguInterActorMock.EXPECT().RestartDoguWithWait(mock.Anything, "postgresql", true).Run(record).Return(nil)
+		doguInterActorMock.EXPECT().RestartDoguWithWait(mock.Anything, "redmine", false).Run(record).Return(assert.AnError)
+		sut := defaultDebugModeService{doguDescriptorGetter: descriptorGetterMock, doguInterActor: doguInterActorMock}
+
+		// when
+		dogus, err := sut.dogusInDependencyOrder(testCtx, []string{"redmine", "cas", "ldap", "postgresql"})
+		require.NoError(t, err)
+		err = sut.restartDogus(testCtx, dogus)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to restart dogus")
+		require.Len(t, restarted, 4)
+		assert.Less(t, slices.Index(restarted, "ldap"), slices.Index(restarted, "cas"))
+		assert.Less(t, slices.Index(restarted, "cas"), slices.Index(restarted, "redmine"))
+		assert.Less(t, slices.Index(restarted, "postgresql"), slices.Index(restarted, "redmine"))
+	})
+	t.Run("should fail to order the dogus if the installed dogus cannot be read", func(t *testing.T) {
+		// given
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return(nil, assert.AnError)
+		sut := defaultDebugModeService{doguDescriptorGetter: descriptorGetterMock}
+
+		// when
+		dogus, err := sut.dogusInDependencyOrder(testCtx, []string{"redmine", "cas"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get installed dogus to sort them by dependency")
+		assert.Nil(t, dogus)
+	})
+	t.Run("should fail to order the dogus if their dependencies are cyclic", func(t *testing.T) {
+		// given
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return([]*core.Dogu{
+			{Name: "official/redmine", Dependencies: dependsOn("cas")},
+			{Name: "official/cas", Dependencies: dependsOn("redmine")},
+		}, nil)
+		sut := defaultDebugModeService{doguDescriptorGetter: descriptorGetterMock}
+
+		// when
+		dogus, err := sut.dogusInDependencyOrder(testCtx, []string{"redmine", "cas"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to sort dogus by dependency")
+		assert.Nil(t, dogus)
+	})
+}
+
+func Test_defaultDebugModeService_withoutDebugModeOperator(t *testing.T) {
+	notFound := k8serrors.NewNotFound(schema.GroupResource{}, "debug-mode")
+	installedDogus := func() []*core.Dogu {
+		return []*core.Dogu{
+			{Name: "official/redmine", Dependencies: []core.Dependency{{Type: core.DependencyTypeDogu, Name: "cas"}}},
+			{Name: "official/cas"},
+		}
+	}
+
+	t.Run("should enable the debug mode of all dogus with the debug mode registry", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		debugModeRegistryMock.EXPECT().Enable(testCtx, int32(15), []string(nil), "DEBUG").Return(nil)
+		debugModeRegistryMock.EXPECT().BackupDoguLogLevels(testCtx).Return(nil)
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(nil, notFound)
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).RunAndReturn(func(context.Context) ([]*core.Dogu, error) {
+			return installedDogus(), nil
+		})
+		doguConfigRepositoryMock := newMockDoguConfigRepository(t)
+		doguConfigRepositoryMock.EXPECT().Get(testCtx, common.SimpleName("cas")).Return(config.CreateDoguConfig("cas", config.Entries{"logging/root": "WARN"}), nil)
+		doguConfigRepositoryMock.EXPECT().Get(testCtx, common.SimpleName("redmine")).Return(config.CreateDoguConfig("redmine", config.Entries{}), nil)
+		doguInterActorMock := newMockDoguInterActor(t)
+		doguInterActorMock.EXPECT().SetLogLevelInAllDogus(testCtx, "DEBUG").Return(nil)
+		restartCas := doguInterActorMock.EXPECT().RestartDoguWithWait(mock.Anything, "cas", true).Return(nil).Call
+		restartRedmine := doguInterActorMock.EXPECT().RestartDoguWithWait(mock.Anything, "redmine", false).Return(nil).Call
+		mock.InOrder(restartCas, restartRedmine)
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().Start(testCtx, mock.MatchedBy(func(session debugSession) bool {
+			return session.DoguNames == nil && session.LogLevel == "DEBUG" &&
+				maps.Equal(session.LogLevelsBefore, map[string]string{"cas": "WARN", "redmine": ""})
+		})).Return(nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "EnableDebugMode" && entry.Err == nil
+		})).Return()
+		clientSet := fake.NewClientset()
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, doguInterActor: doguInterActorMock, doguDescriptorGetter: descriptorGetterMock, doguConfigRepository: doguConfigRepositoryMock, history: historyMock, serverResources: clientSet.Discovery(), podClient: clientSet.CoreV1().Pods(testNamespace), auditLogger: auditLoggerMock}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 15})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should not enable the debug mode if the dogus cannot be ordered by dependency", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(nil, notFound)
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return([]*core.Dogu{
+			{Name: "official/redmine", Dependencies: []core.Dependency{{Type: core.DependencyTypeDogu, Name: "cas"}}},
+			{Name: "official/cas", Dependencies: []core.Dependency{{Type: core.DependencyTypeDogu, Name: "redmine"}}},
+		}, nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "EnableDebugMode" && entry.Err != nil
+		})).Return()
+		clientSet := fake.NewClientset()
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, doguDescriptorGetter: descriptorGetterMock, serverResources: clientSet.Discovery(), podClient: clientSet.CoreV1().Pods(testNamespace), auditLogger: auditLoggerMock}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 15})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to sort dogus by dependency")
+	})
+	t.Run("should extend the debug mode of all dogus driven by the debug mode registry", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, 0, nil)
+		debugModeRegistryMock.EXPECT().Targets(testCtx).Return(nil, "DEBUG", nil)
+		debugModeRegistryMock.EXPECT().Enable(testCtx, int32(30), []string(nil), "DEBUG").Return(nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.Anything).Return()
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock, auditLogger: auditLoggerMock}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 30})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to enable the debug mode for some dogus while it is enabled for all dogus", func(t *testing.T) {
+		// given
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return(installedDogus(), nil)
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, 0, nil)
+		debugModeRegistryMock.EXPECT().Targets(testCtx).Return(nil, "DEBUG", nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.Anything).Return()
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock, doguDescriptorGetter: descriptorGetterMock, auditLogger: auditLoggerMock}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 30, DoguNames: []string{"cas"}})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.ErrorContains(t, err, "debug mode is already enabled for all dogus with log level DEBUG")
+	})
+	t.Run("should restore the log levels of all dogus and restart them", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, 0, nil)
+		debugModeRegistryMock.EXPECT().Targets(testCtx).Return(nil, "DEBUG", nil)
+		debugModeRegistryMock.EXPECT().RestoreDoguLogLevels(testCtx).Return(nil)
+		debugModeRegistryMock.EXPECT().Disable(testCtx).Return(nil)
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).RunAndReturn(func(context.Context) ([]*core.Dogu, error) {
+			return installedDogus(), nil
+		})
+		doguInterActorMock := newMockDoguInterActor(t)
+		restartCas := doguInterActorMock.EXPECT().RestartDoguWithWait(mock.Anything, "cas", true).Return(nil).Call
+		restartRedmine := doguInterActorMock.EXPECT().RestartDoguWithWait(mock.Anything, "redmine", false).Return(nil).Call
+		mock.InOrder(restartCas, restartRedmine)
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return([]debugSession{{EnabledBy: "admin", LogLevel: "DEBUG"}}, nil)
+		historyMock.EXPECT().End(testCtx, "k8s-ces-control", mock.Anything).Return(nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "DisableDebugMode" && entry.Err == nil
+		})).Return()
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock, doguInterActor: doguInterActorMock, doguDescriptorGetter: descriptorGetterMock, history: historyMock, auditLogger: auditLoggerMock}
+
+		// when
+		_, err := sut.Disable(testCtx, &maintenance.ToggleDebugModeRequest{})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should keep the registry if the dogus to restart cannot be determined", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, 0, nil)
+		debugModeRegistryMock.EXPECT().Targets(testCtx).Return(nil, "DEBUG", nil)
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return(nil, assert.AnError)
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return([]debugSession{{EnabledBy: "admin", LogLevel: "DEBUG"}}, nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "DisableDebugMode" && errors.Is(entry.Err, assert.AnError)
+		})).Return()
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock, doguDescriptorGetter: descriptorGetterMock, history: historyMock, auditLogger: auditLoggerMock}
+
+		// when
+		_, err := sut.Disable(testCtx, &maintenance.ToggleDebugModeRequest{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get installed dogus")
+	})
+	t.Run("should return all installed dogus of the debug mode driven by the debug mode registry", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, 15, nil)
+		debugModeRegistryMock.EXPECT().Targets(testCtx).Return(nil, "DEBUG", nil)
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return(installedDogus(), nil)
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return([]debugSession{{LogLevelsBefore: map[string]string{"cas": "WARN", "redmine": "ERROR"}}}, nil)
+		doguConfigRepositoryMock := newMockDoguConfigRepository(t)
+		doguConfigRepositoryMock.EXPECT().Get(testCtx, common.SimpleName("cas")).Return(config.CreateDoguConfig("cas", config.Entries{"logging/root": "DEBUG"}), nil)
+		doguConfigRepositoryMock.EXPECT().Get(testCtx, common.SimpleName("redmine")).Return(config.CreateDoguConfig("redmine", config.Entries{"logging/root": "DEBUG"}), nil)
+		deployments := fake.NewClientset().AppsV1().Deployments(testNamespace)
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock, doguDescriptorGetter: descriptorGetterMock, history: historyMock, doguConfigRepository: doguConfigRepositoryMock, deploymentClient: deployments}
+
+		// when
+		response, err := sut.Status(testCtx, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, response.IsEnabled)
+		assert.True(t, response.AllDogus)
+		assert.Equal(t, int64(15), response.DisableAtTimestamp)
+		assert.Equal(t, []string{"cas", "redmine"}, response.DoguNames)
+		assert.Equal(t, "DEBUG", response.LogLevel)
+		assert.Empty(t, response.Phase)
+		assert.Equal(t, []*maintenance.DebugModeDoguStatus{
+			{DoguName: "cas", LogLevelBefore: "WARN", LogLevelAfter: "DEBUG", RestartState: maintenance.DebugModeRestartState_UNKNOWN},
+			{DoguName: "redmine", LogLevelBefore: "ERROR", LogLevelAfter: "DEBUG", RestartState: maintenance.DebugModeRestartState_UNKNOWN},
+		}, response.Dogus)
+	})
+	t.Run("should return a disabled debug mode if the DebugMode resource does not exist", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(nil, notFound)
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return(nil, nil)
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, history: historyMock}
+
+		// when
+		response, err := sut.Status(testCtx, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, response.IsEnabled)
+		assert.Empty(t, response.DoguNames)
+	})
+}
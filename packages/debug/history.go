@@ -0,0 +1,177 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	historyName      = "debug-mode-history"
+	keyHistory       = "sessions"
+	maxHistoryLength = 100
+)
+
+// debugSession is a single period in which the debug mode was enabled.
+type debugSession struct {
+	EnabledBy  string    `json:"enabledBy"`
+	EnabledAt  time.Time `json:"enabledAt"`
+	DisabledBy string    `json:"disabledBy,omitempty"`
+	DisabledAt time.Time `json:"disabledAt,omitzero"`
+	// DoguNames contains the dogus of the debug mode. It is empty if the debug mode covers all dogus.
+	DoguNames []string `json:"dogus,omitempty"`
+	LogLevel  string   `json:"logLevel"`
+	// LogLevelsBefore contains the log levels of the dogus before the debug mode was enabled. An empty level is the
+	// default level of the dogu.
+	LogLevelsBefore map[string]string `json:"logLevelsBefore,omitempty"`
+	// SupportArchive is true if a support archive is created right before the debug mode is disabled.
+	SupportArchive bool `json:"supportArchive,omitempty"`
+	// SupportArchiveName is the name of the support archive created for the session.
+	SupportArchiveName string `json:"supportArchiveName,omitempty"`
+}
+
+func (s debugSession) isActive() bool {
+	return s.DisabledAt.IsZero()
+}
+
+// supportArchivePending returns whether a support archive was requested for the active session but not created yet.
+func (s debugSession) supportArchivePending() bool {
+	return s.isActive() && s.SupportArchive && s.SupportArchiveName == ""
+}
+
+// configMapDebugModeHistory stores the sessions of the debug mode as json in a config map. Only the most recent
+// sessions are kept.
+type configMapDebugModeHistory struct {
+	configMapInterface configMapInterface
+	namespace          string
+}
+
+// NewConfigMapDebugModeHistory creates a new instance of configMapDebugModeHistory.
+func NewConfigMapDebugModeHistory(configMapInterface configMapInterface, namespace string) *configMapDebugModeHistory {
+	return &configMapDebugModeHistory{configMapInterface: configMapInterface, namespace: namespace}
+}
+
+// Start records the given session unless a session is already active, which is the case if the debug mode is
+// extended.
+func (h *configMapDebugModeHistory) Start(ctx context.Context, session debugSession) error {
+	return h.update(ctx, func(sessions []debugSession) []debugSession {
+		if len(sessions) > 0 && sessions[len(sessions)-1].isActive() {
+			return sessions
+		}
+
+		sessions = append(sessions, session)
+		if len(sessions) > maxHistoryLength {
+			sessions = sessions[len(sessions)-maxHistoryLength:]
+		}
+		return sessions
+	})
+}
+
+// End records the end of the active session. It does nothing if no session is active.
+func (h *configMapDebugModeHistory) End(ctx context.Context, disabledBy string, disabledAt time.Time) error {
+	return h.update(ctx, func(sessions []debugSession) []debugSession {
+		if len(sessions) == 0 || !sessions[len(sessions)-1].isActive() {
+			return sessions
+		}
+
+		sessions[len(sessions)-1].DisabledBy = disabledBy
+		sessions[len(sessions)-1].DisabledAt = disabledAt
+		return sessions
+	})
+}
+
+// SetSupportArchiveName records the name of the support archive created for the active session. It does nothing if no
+// session is active.
+func (h *configMapDebugModeHistory) SetSupportArchiveName(ctx context.Context, name string) error {
+	return h.update(ctx, func(sessions []debugSession) []debugSession {
+		if len(sessions) == 0 || !sessions[len(sessions)-1].isActive() {
+			return sessions
+		}
+
+		sessions[len(sessions)-1].SupportArchiveName = name
+		return sessions
+	})
+}
+
+// List returns at most limit sessions, the most recent first.
+func (h *configMapDebugModeHistory) List(ctx context.Context, limit int) ([]debugSession, error) {
+	cm, err := h.configMapInterface.Get(ctx, historyName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, wrapRegistryError(h.namespace, historyName, "get", err)
+	}
+
+	sessions, err := parseHistory(cm)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []debugSession
+	for i := len(sessions) - 1; i >= 0 && len(result) < limit; i-- {
+		result = append(result, sessions[i])
+	}
+
+	return result, nil
+}
+
+func (h *configMapDebugModeHistory) update(ctx context.Context, modify func([]debugSession) []debugSession) error {
+	err := retryOnConflict(func() error {
+		cm, err := h.configMapInterface.Get(ctx, historyName, metav1.GetOptions{})
+		exists := !errors.IsNotFound(err)
+		if !exists {
+			cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: historyName, Namespace: h.namespace}}
+		} else if err != nil {
+			return err
+		}
+
+		sessions, err := parseHistory(cm)
+		if err != nil {
+			return err
+		}
+
+		content, err := json.Marshal(modify(sessions))
+		if err != nil {
+			return fmt.Errorf("failed to marshal debug mode history: %w", err)
+		}
+		cm.Data = map[string]string{keyHistory: string(content)}
+
+		if !exists {
+			_, err = h.configMapInterface.Create(ctx, cm, metav1.CreateOptions{})
+			if errors.IsAlreadyExists(err) {
+				// another replica created the history in the meantime, so the update is retried
+				return errors.NewConflict(corev1.Resource("configmaps"), historyName, err)
+			}
+			return err
+		}
+
+		_, err = h.configMapInterface.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return wrapRegistryError(h.namespace, historyName, "update", err)
+	}
+
+	return nil
+}
+
+func parseHistory(cm *corev1.ConfigMap) ([]debugSession, error) {
+	content, ok := cm.Data[keyHistory]
+	if !ok || content == "" {
+		return nil, nil
+	}
+
+	var sessions []debugSession
+	err := json.Unmarshal([]byte(content), &sessions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse debug mode history: %w", err)
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,128 @@
+package debug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_configMapDebugModeHistory(t *testing.T) {
+	enabledAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should record a session from start to end", func(t *testing.T) {
+		// given
+		sut := NewConfigMapDebugModeHistory(fake.NewClientset().CoreV1().ConfigMaps(testNamespace), testNamespace)
+
+		// when
+		err := sut.Start(testCtx, debugSession{EnabledBy: "admin", EnabledAt: enabledAt, DoguNames: []string{"cas"}, LogLevel: "TRACE", LogLevelsBefore: map[string]string{"cas": "WARN"}})
+		require.NoError(t, err)
+		err = sut.End(testCtx, "k8s-ces-control", enabledAt.Add(time.Hour))
+		require.NoError(t, err)
+
+		// then
+		sessions, err := sut.List(testCtx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []debugSession{{
+			EnabledBy:       "admin",
+			EnabledAt:       enabledAt,
+			DisabledBy:      "k8s-ces-control",
+			DisabledAt:      enabledAt.Add(time.Hour),
+			DoguNames:       []string{"cas"},
+			LogLevel:        "TRACE",
+			LogLevelsBefore: map[string]string{"cas": "WARN"},
+		}}, sessions)
+	})
+	t.Run("should keep the active session when the debug mode is extended", func(t *testing.T) {
+		// given
+		sut := NewConfigMapDebugModeHistory(fake.NewClientset().CoreV1().ConfigMaps(testNamespace), testNamespace)
+		require.NoError(t, sut.Start(testCtx, debugSession{EnabledBy: "admin", EnabledAt: enabledAt, LogLevel: "DEBUG"}))
+
+		// when
+		err := sut.Start(testCtx, debugSession{EnabledBy: "other", EnabledAt: enabledAt.Add(time.Minute), LogLevel: "DEBUG"})
+
+		// then
+		require.NoError(t, err)
+		sessions, err := sut.List(testCtx, 10)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, "admin", sessions[0].EnabledBy)
+		assert.True(t, sessions[0].isActive())
+	})
+	t.Run("should record the support archive of the active session", func(t *testing.T) {
+		// given
+		sut := NewConfigMapDebugModeHistory(fake.NewClientset().CoreV1().ConfigMaps(testNamespace), testNamespace)
+		require.NoError(t, sut.Start(testCtx, debugSession{EnabledBy: "admin", EnabledAt: enabledAt, LogLevel: "DEBUG", SupportArchive: true}))
+
+		// when
+		err := sut.SetSupportArchiveName(testCtx, "debug-mode-support-archive-20261018110000z")
+		require.NoError(t, err)
+		err = sut.End(testCtx, "k8s-ces-control", enabledAt.Add(time.Hour))
+		require.NoError(t, err)
+
+		// then
+		sessions, err := sut.List(testCtx, 10)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.True(t, sessions[0].SupportArchive)
+		assert.Equal(t, "debug-mode-support-archive-20261018110000z", sessions[0].SupportArchiveName)
+	})
+	t.Run("should ignore the end without active session", func(t *testing.T) {
+		// given
+		sut := NewConfigMapDebugModeHistory(fake.NewClientset().CoreV1().ConfigMaps(testNamespace), testNamespace)
+
+		// when
+		err := sut.End(testCtx, "admin", enabledAt)
+
+		// then
+		require.NoError(t, err)
+		sessions, err := sut.List(testCtx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+	t.Run("should list the most recent sessions first and drop the oldest", func(t *testing.T) {
+		// given
+		sut := NewConfigMapDebugModeHistory(fake.NewClientset().CoreV1().ConfigMaps(testNamespace), testNamespace)
+		for i := range maxHistoryLength + 2 {
+			start := enabledAt.Add(time.Duration(i) * time.Hour)
+			require.NoError(t, sut.Start(testCtx, debugSession{EnabledBy: "admin", EnabledAt: start}))
+			require.NoError(t, sut.End(testCtx, "admin", start.Add(time.Minute)))
+		}
+
+		// when
+		sessions, err := sut.List(testCtx, maxHistoryLength+10)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, sessions, maxHistoryLength)
+		assert.Equal(t, enabledAt.Add(time.Duration(maxHistoryLength+1)*time.Hour), sessions[0].EnabledAt)
+		assert.Equal(t, enabledAt.Add(2*time.Hour), sessions[maxHistoryLength-1].EnabledAt)
+	})
+	t.Run("should return no sessions without history", func(t *testing.T) {
+		// given
+		sut := NewConfigMapDebugModeHistory(fake.NewClientset().CoreV1().ConfigMaps(testNamespace), testNamespace)
+
+		// when
+		sessions, err := sut.List(testCtx, 10)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+	t.Run("should return error on an invalid history", func(t *testing.T) {
+		// given
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: historyName, Namespace: testNamespace}, Data: map[string]string{keyHistory: "{"}}
+		sut := NewConfigMapDebugModeHistory(fake.NewClientset(cm).CoreV1().ConfigMaps(testNamespace), testNamespace)
+
+		// when
+		_, err := sut.List(testCtx, 10)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse debug mode history")
+	})
+}
@@ -2,15 +2,21 @@ package debug
 
 import (
 	"context"
+	"time"
 
 	common "github.com/cloudogu/ces-commons-lib/dogu"
 	pbMaintenance "github.com/cloudogu/ces-control-api/generated/maintenance"
 	"github.com/cloudogu/ces-control-api/generated/types"
 	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
 	debugClientV1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
 	ecoSystemV2 "github.com/cloudogu/k8s-dogu-lib/v2/client"
 	"github.com/cloudogu/k8s-registry-lib/config"
+	supportArchiveV1 "github.com/cloudogu/k8s-support-archive-lib/client/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	appsV1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	coordinationV1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
@@ -25,10 +31,33 @@ type coreV1Interface interface {
 	v1.CoreV1Interface
 }
 
+//nolint:unused
+//goland:noinspection GoUnusedType
+type appsV1Interface interface {
+	appsV1.AppsV1Interface
+}
+
 type configMapInterface interface {
 	v1.ConfigMapInterface
 }
 
+type deploymentInterface interface {
+	appsV1.DeploymentInterface
+}
+
+type podInterface interface {
+	v1.PodInterface
+}
+
+type serverResourcesGetter interface {
+	// ServerResourcesForGroupVersion returns the resources the cluster serves for the given group and version.
+	ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error)
+}
+
+type leasesGetter interface {
+	coordinationV1.LeasesGetter
+}
+
 type debugModeInterface interface {
 	debugClientV1.DebugModeInterface
 }
@@ -41,22 +70,42 @@ type doguDescriptorGetter interface {
 }
 
 type debugModeRegistry interface {
-	// Enable enables the debug mode in the registry.
-	Enable(ctx context.Context, timer int32) error
+	// Enable enables the debug mode for the given dogus, or for all dogus if none are given, and log level in the
+	// registry.
+	Enable(ctx context.Context, timer int32, doguNames []string, logLevel string) error
 	// Disable disables the debug mode registry.
 	Disable(ctx context.Context) error
 	// Status returns a boolean if the mode is enabled or disabled and if the status is enabled the timestamp where the
 	// mode should be automatically disabled. If the mode is disabled the timestamp will be 0.
 	Status(ctx context.Context) (isEnabled bool, DisableAtTimestamp int64, err error)
-	// BackupDoguLogLevels saves all current log levels from all dogus.
+	// Targets returns the dogus and the log level of the debug mode. No dogus are returned if it covers all dogus.
+	Targets(ctx context.Context) (doguNames []string, logLevel string, err error)
+	// BackupDoguLogLevels saves the current log levels of the dogus of the debug mode or of all dogus if it covers all
+	// dogus.
 	BackupDoguLogLevels(ctx context.Context) error
 	// RestoreDoguLogLevels restores all backuped log levels from dogus.
 	RestoreDoguLogLevels(ctx context.Context) error
 }
 
+type debugModeHistory interface {
+	// Start records the start of a debug mode session unless a session is already active.
+	Start(ctx context.Context, session debugSession) error
+	// End records the end of the active debug mode session.
+	End(ctx context.Context, disabledBy string, disabledAt time.Time) error
+	// SetSupportArchiveName records the name of the support archive created for the active debug mode session.
+	SetSupportArchiveName(ctx context.Context, name string) error
+	// List returns at most limit sessions, the most recent first.
+	List(ctx context.Context, limit int) ([]debugSession, error)
+}
+
+type supportArchiveClient interface {
+	supportArchiveV1.SupportArchiveInterface
+}
+
 type doguLogLevelRegistry interface {
-	// MarshalFromCesRegistryToString converts the log levels from the ces registry to a string
-	MarshalFromCesRegistryToString(ctx context.Context) (string, error)
+	// MarshalFromCesRegistryToString converts the log levels of the given dogus or of all dogus if none are given from
+	// the ces registry to a string
+	MarshalFromCesRegistryToString(ctx context.Context, doguNames []string) (string, error)
 	// UnMarshalFromStringToCesRegistry writes the log level string to the ces registry.
 	UnMarshalFromStringToCesRegistry(ctx context.Context, unmarshal string) error
 }
@@ -68,14 +117,25 @@ type doguInterActor interface {
 	StartAllDogus(ctx context.Context) error
 	// SetLogLevelInAllDogus sets the specified log level to all dogus.
 	SetLogLevelInAllDogus(ctx context.Context, logLevel string) error
+	// SetLogLevelInDogus sets the specified log level to the given dogus.
+	SetLogLevelInDogus(ctx context.Context, logLevel string, doguNames []string) error
+	// RestartDoguWithWait restarts the specified dogu and waits until it is restarted if specified.
+	RestartDoguWithWait(ctx context.Context, doguName string, waitForRollout bool) error
 }
 
 type debugModeServer interface {
 	// Disable disables the debug mode.
 	Disable(context.Context, *pbMaintenance.ToggleDebugModeRequest) (*types.BasicResponse, error)
+	// CaptureSupportArchive creates the support archive of the active debug mode session ahead of its planned end.
+	CaptureSupportArchive(ctx context.Context, end time.Time) error
 }
 
 type doguConfigRepository interface {
 	Get(context.Context, common.SimpleName) (config.DoguConfig, error)
 	Update(context.Context, config.DoguConfig) (config.DoguConfig, error)
 }
+
+type auditLogger interface {
+	// Record writes the given entry to the audit log.
+	Record(ctx context.Context, entry audit.Entry)
+}
@@ -113,17 +113,17 @@ func (_c *mockDebugModeRegistry_Disable_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
-// Enable provides a mock function with given fields: ctx, timer
-func (_m *mockDebugModeRegistry) Enable(ctx context.Context, timer int32) error {
-	ret := _m.Called(ctx, timer)
+// Enable provides a mock function with given fields: ctx, timer, doguNames, logLevel
+func (_m *mockDebugModeRegistry) Enable(ctx context.Context, timer int32, doguNames []string, logLevel string) error {
+	ret := _m.Called(ctx, timer, doguNames, logLevel)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Enable")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int32) error); ok {
-		r0 = rf(ctx, timer)
+	if rf, ok := ret.Get(0).(func(context.Context, int32, []string, string) error); ok {
+		r0 = rf(ctx, timer, doguNames, logLevel)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -139,13 +139,15 @@ type mockDebugModeRegistry_Enable_Call struct {
 // Enable is a helper method to define mock.On call
 //   - ctx context.Context
 //   - timer int32
-func (_e *mockDebugModeRegistry_Expecter) Enable(ctx interface{}, timer interface{}) *mockDebugModeRegistry_Enable_Call {
-	return &mockDebugModeRegistry_Enable_Call{Call: _e.mock.On("Enable", ctx, timer)}
+//   - doguNames []string
+//   - logLevel string
+func (_e *mockDebugModeRegistry_Expecter) Enable(ctx interface{}, timer interface{}, doguNames interface{}, logLevel interface{}) *mockDebugModeRegistry_Enable_Call {
+	return &mockDebugModeRegistry_Enable_Call{Call: _e.mock.On("Enable", ctx, timer, doguNames, logLevel)}
 }
 
-func (_c *mockDebugModeRegistry_Enable_Call) Run(run func(ctx context.Context, timer int32)) *mockDebugModeRegistry_Enable_Call {
+func (_c *mockDebugModeRegistry_Enable_Call) Run(run func(ctx context.Context, timer int32, doguNames []string, logLevel string)) *mockDebugModeRegistry_Enable_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int32))
+		run(args[0].(context.Context), args[1].(int32), args[2].([]string), args[3].(string))
 	})
 	return _c
 }
@@ -155,7 +157,7 @@ func (_c *mockDebugModeRegistry_Enable_Call) Return(_a0 error) *mockDebugModeReg
 	return _c
 }
 
-func (_c *mockDebugModeRegistry_Enable_Call) RunAndReturn(run func(context.Context, int32) error) *mockDebugModeRegistry_Enable_Call {
+func (_c *mockDebugModeRegistry_Enable_Call) RunAndReturn(run func(context.Context, int32, []string, string) error) *mockDebugModeRegistry_Enable_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -269,6 +271,71 @@ func (_c *mockDebugModeRegistry_Status_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// Targets provides a mock function with given fields: ctx
+func (_m *mockDebugModeRegistry) Targets(ctx context.Context) ([]string, string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Targets")
+	}
+
+	var r0 []string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) string); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// mockDebugModeRegistry_Targets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Targets'
+type mockDebugModeRegistry_Targets_Call struct {
+	*mock.Call
+}
+
+// Targets is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockDebugModeRegistry_Expecter) Targets(ctx interface{}) *mockDebugModeRegistry_Targets_Call {
+	return &mockDebugModeRegistry_Targets_Call{Call: _e.mock.On("Targets", ctx)}
+}
+
+func (_c *mockDebugModeRegistry_Targets_Call) Run(run func(ctx context.Context)) *mockDebugModeRegistry_Targets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockDebugModeRegistry_Targets_Call) Return(doguNames []string, logLevel string, err error) *mockDebugModeRegistry_Targets_Call {
+	_c.Call.Return(doguNames, logLevel, err)
+	return _c
+}
+
+func (_c *mockDebugModeRegistry_Targets_Call) RunAndReturn(run func(context.Context) ([]string, string, error)) *mockDebugModeRegistry_Targets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // newMockDebugModeRegistry creates a new instance of mockDebugModeRegistry. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockDebugModeRegistry(t interface {
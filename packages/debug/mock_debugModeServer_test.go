@@ -5,10 +5,13 @@ package debug
 import (
 	context "context"
 
+	time "time"
+
 	maintenance "github.com/cloudogu/ces-control-api/generated/maintenance"
-	mock "github.com/stretchr/testify/mock"
 
 	types "github.com/cloudogu/ces-control-api/generated/types"
+
+	mock "github.com/stretchr/testify/mock"
 )
 
 // mockDebugModeServer is an autogenerated mock type for the debugModeServer type
@@ -24,6 +27,53 @@ func (_m *mockDebugModeServer) EXPECT() *mockDebugModeServer_Expecter {
 	return &mockDebugModeServer_Expecter{mock: &_m.Mock}
 }
 
+// CaptureSupportArchive provides a mock function with given fields: ctx, end
+func (_m *mockDebugModeServer) CaptureSupportArchive(ctx context.Context, end time.Time) error {
+	ret := _m.Called(ctx, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CaptureSupportArchive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, end)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockDebugModeServer_CaptureSupportArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CaptureSupportArchive'
+type mockDebugModeServer_CaptureSupportArchive_Call struct {
+	*mock.Call
+}
+
+// CaptureSupportArchive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - end time.Time
+func (_e *mockDebugModeServer_Expecter) CaptureSupportArchive(ctx interface{}, end interface{}) *mockDebugModeServer_CaptureSupportArchive_Call {
+	return &mockDebugModeServer_CaptureSupportArchive_Call{Call: _e.mock.On("CaptureSupportArchive", ctx, end)}
+}
+
+func (_c *mockDebugModeServer_CaptureSupportArchive_Call) Run(run func(ctx context.Context, end time.Time)) *mockDebugModeServer_CaptureSupportArchive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *mockDebugModeServer_CaptureSupportArchive_Call) Return(_a0 error) *mockDebugModeServer_CaptureSupportArchive_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDebugModeServer_CaptureSupportArchive_Call) RunAndReturn(run func(context.Context, time.Time) error) *mockDebugModeServer_CaptureSupportArchive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Disable provides a mock function with given fields: _a0, _a1
 func (_m *mockDebugModeServer) Disable(_a0 context.Context, _a1 *maintenance.ToggleDebugModeRequest) (*types.BasicResponse, error) {
 	ret := _m.Called(_a0, _a1)
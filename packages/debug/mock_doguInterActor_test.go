@@ -21,6 +21,54 @@ func (_m *mockDoguInterActor) EXPECT() *mockDoguInterActor_Expecter {
 	return &mockDoguInterActor_Expecter{mock: &_m.Mock}
 }
 
+// RestartDoguWithWait provides a mock function with given fields: ctx, doguName, waitForRollout
+func (_m *mockDoguInterActor) RestartDoguWithWait(ctx context.Context, doguName string, waitForRollout bool) error {
+	ret := _m.Called(ctx, doguName, waitForRollout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestartDoguWithWait")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, doguName, waitForRollout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockDoguInterActor_RestartDoguWithWait_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestartDoguWithWait'
+type mockDoguInterActor_RestartDoguWithWait_Call struct {
+	*mock.Call
+}
+
+// RestartDoguWithWait is a helper method to define mock.On call
+//   - ctx context.Context
+//   - doguName string
+//   - waitForRollout bool
+func (_e *mockDoguInterActor_Expecter) RestartDoguWithWait(ctx interface{}, doguName interface{}, waitForRollout interface{}) *mockDoguInterActor_RestartDoguWithWait_Call {
+	return &mockDoguInterActor_RestartDoguWithWait_Call{Call: _e.mock.On("RestartDoguWithWait", ctx, doguName, waitForRollout)}
+}
+
+func (_c *mockDoguInterActor_RestartDoguWithWait_Call) Run(run func(ctx context.Context, doguName string, waitForRollout bool)) *mockDoguInterActor_RestartDoguWithWait_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *mockDoguInterActor_RestartDoguWithWait_Call) Return(_a0 error) *mockDoguInterActor_RestartDoguWithWait_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDoguInterActor_RestartDoguWithWait_Call) RunAndReturn(run func(context.Context, string, bool) error) *mockDoguInterActor_RestartDoguWithWait_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetLogLevelInAllDogus provides a mock function with given fields: ctx, logLevel
 func (_m *mockDoguInterActor) SetLogLevelInAllDogus(ctx context.Context, logLevel string) error {
 	ret := _m.Called(ctx, logLevel)
@@ -68,6 +116,54 @@ func (_c *mockDoguInterActor_SetLogLevelInAllDogus_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// SetLogLevelInDogus provides a mock function with given fields: ctx, logLevel, doguNames
+func (_m *mockDoguInterActor) SetLogLevelInDogus(ctx context.Context, logLevel string, doguNames []string) error {
+	ret := _m.Called(ctx, logLevel, doguNames)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLogLevelInDogus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, logLevel, doguNames)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockDoguInterActor_SetLogLevelInDogus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLogLevelInDogus'
+type mockDoguInterActor_SetLogLevelInDogus_Call struct {
+	*mock.Call
+}
+
+// SetLogLevelInDogus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - logLevel string
+//   - doguNames []string
+func (_e *mockDoguInterActor_Expecter) SetLogLevelInDogus(ctx interface{}, logLevel interface{}, doguNames interface{}) *mockDoguInterActor_SetLogLevelInDogus_Call {
+	return &mockDoguInterActor_SetLogLevelInDogus_Call{Call: _e.mock.On("SetLogLevelInDogus", ctx, logLevel, doguNames)}
+}
+
+func (_c *mockDoguInterActor_SetLogLevelInDogus_Call) Run(run func(ctx context.Context, logLevel string, doguNames []string)) *mockDoguInterActor_SetLogLevelInDogus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *mockDoguInterActor_SetLogLevelInDogus_Call) Return(_a0 error) *mockDoguInterActor_SetLogLevelInDogus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDoguInterActor_SetLogLevelInDogus_Call) RunAndReturn(run func(context.Context, string, []string) error) *mockDoguInterActor_SetLogLevelInDogus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StartAllDogus provides a mock function with given fields: ctx
 func (_m *mockDoguInterActor) StartAllDogus(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -21,9 +21,9 @@ func (_m *mockDoguLogLevelRegistry) EXPECT() *mockDoguLogLevelRegistry_Expecter
 	return &mockDoguLogLevelRegistry_Expecter{mock: &_m.Mock}
 }
 
-// MarshalFromCesRegistryToString provides a mock function with given fields: ctx
-func (_m *mockDoguLogLevelRegistry) MarshalFromCesRegistryToString(ctx context.Context) (string, error) {
-	ret := _m.Called(ctx)
+// MarshalFromCesRegistryToString provides a mock function with given fields: ctx, doguNames
+func (_m *mockDoguLogLevelRegistry) MarshalFromCesRegistryToString(ctx context.Context, doguNames []string) (string, error) {
+	ret := _m.Called(ctx, doguNames)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MarshalFromCesRegistryToString")
@@ -31,17 +31,17 @@ func (_m *mockDoguLogLevelRegistry) MarshalFromCesRegistryToString(ctx context.C
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (string, error)); ok {
+		return rf(ctx, doguNames)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) string); ok {
+		r0 = rf(ctx, doguNames)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, doguNames)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,13 +56,14 @@ type mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call struct {
 
 // MarshalFromCesRegistryToString is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *mockDoguLogLevelRegistry_Expecter) MarshalFromCesRegistryToString(ctx interface{}) *mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call {
-	return &mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call{Call: _e.mock.On("MarshalFromCesRegistryToString", ctx)}
+//   - doguNames []string
+func (_e *mockDoguLogLevelRegistry_Expecter) MarshalFromCesRegistryToString(ctx interface{}, doguNames interface{}) *mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call {
+	return &mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call{Call: _e.mock.On("MarshalFromCesRegistryToString", ctx, doguNames)}
 }
 
-func (_c *mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call) Run(run func(ctx context.Context)) *mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call {
+func (_c *mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call) Run(run func(ctx context.Context, doguNames []string)) *mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].([]string))
 	})
 	return _c
 }
@@ -72,7 +73,7 @@ func (_c *mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call) Return(_
 	return _c
 }
 
-func (_c *mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call) RunAndReturn(run func(context.Context) (string, error)) *mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call {
+func (_c *mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call) RunAndReturn(run func(context.Context, []string) (string, error)) *mockDoguLogLevelRegistry_MarshalFromCesRegistryToString_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2,42 +2,136 @@ package debug
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	pbMaintenance "github.com/cloudogu/ces-control-api/generated/maintenance"
 	"github.com/cloudogu/ces-control-api/generated/types"
+	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
+	"github.com/cloudogu/k8s-ces-control/packages/logging"
 )
 
+var debugModeResource = audit.Resource{Kind: audit.KindDebugMode, Name: "debug-mode"}
+
+const (
+	defaultDebugLogLevel = "DEBUG"
+	// defaultHistoryLimit is the number of sessions returned by GetHistory if no limit is requested.
+	defaultHistoryLimit = 20
+)
+
+// debugLogLevels are the log levels the dogus can be set to in debug mode.
+var debugLogLevels = []string{"ERROR", "WARN", "INFO", "DEBUG", "TRACE"}
+
 type defaultDebugModeService struct {
 	pbMaintenance.UnimplementedDebugModeServer
-	debugModeClient   debugModeInterface
-	debugModeRegistry debugModeRegistry
-	doguInterActor    doguInterActor
+	debugModeClient      debugModeInterface
+	debugModeRegistry    debugModeRegistry
+	doguInterActor       doguInterActor
+	doguDescriptorGetter doguDescriptorGetter
+	doguConfigRepository doguConfigRepository
+	deploymentClient     deploymentInterface
+	history              debugModeHistory
+	supportArchiveClient supportArchiveClient
+	serverResources      serverResourcesGetter
+	podClient            podInterface
+	auditLogger          auditLogger
 }
 
 // NewDebugModeService returns an instance of debugModeService.
-func NewDebugModeService(debugMode debugModeInterface, doguInterActor doguInterActor, doguConfigRepository doguConfigRepository, doguDescriptorGetter doguDescriptorGetter, clusterClient clusterClientSet, namespace string) *defaultDebugModeService {
+func NewDebugModeService(debugMode debugModeInterface, doguInterActor doguInterActor, doguConfigRepository doguConfigRepository, doguDescriptorGetter doguDescriptorGetter, clusterClient clusterClientSet, supportArchiveClient supportArchiveClient, namespace string, auditLogger auditLogger) *defaultDebugModeService {
 	cmDebugModeRegistry := NewConfigMapDebugModeRegistry(doguConfigRepository, doguDescriptorGetter, clusterClient, namespace)
 	return &defaultDebugModeService{
-		debugModeClient:   debugMode,
-		debugModeRegistry: cmDebugModeRegistry,
-		doguInterActor:    doguInterActor,
+		debugModeClient:      debugMode,
+		debugModeRegistry:    cmDebugModeRegistry,
+		doguInterActor:       doguInterActor,
+		doguDescriptorGetter: doguDescriptorGetter,
+		doguConfigRepository: doguConfigRepository,
+		deploymentClient:     clusterClient.AppsV1().Deployments(namespace),
+		history:              NewConfigMapDebugModeHistory(clusterClient.CoreV1().ConfigMaps(namespace), namespace),
+		supportArchiveClient: supportArchiveClient,
+		serverResources:      clusterClient.Discovery(),
+		podClient:            clusterClient.CoreV1().Pods(namespace),
+		auditLogger:          auditLogger,
 	}
 }
 
-// Enable enables the debug mode, sets dogu log level to debug and restarts all dogus.
+// Enable enables the debug mode, sets the log level of the requested dogus, or of all dogus if none are requested, to
+// the target log level and restarts them. If requested, a support archive of the debug window is created right before
+// the debug mode is disabled.
 func (s *defaultDebugModeService) Enable(ctx context.Context, req *pbMaintenance.ToggleDebugModeRequest) (*types.BasicResponse, error) {
+	started := time.Now()
+	response, err := s.enable(ctx, req)
+	s.auditLogger.Record(ctx, audit.Entry{
+		Action:   "EnableDebugMode",
+		Resource: debugModeResource,
+		Parameters: map[string]string{
+			"timer":          strconv.Itoa(int(req.GetTimer())),
+			"dogus":          strings.Join(req.GetDoguNames(), ","),
+			"logLevel":       req.GetLogLevel(),
+			"supportArchive": strconv.FormatBool(req.GetSupportArchive()),
+		},
+		Started: started,
+		Err:     err,
+	})
+
+	return response, err
+}
+
+func (s *defaultDebugModeService) enable(ctx context.Context, req *pbMaintenance.ToggleDebugModeRequest) (*types.BasicResponse, error) {
+	logLevel, err := debugLogLevelFromRequest(req.GetLogLevel())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	doguNames := debugDoguNamesFromRequest(req.GetDoguNames())
+	if len(doguNames) > 0 {
+		return s.enableWithRegistry(ctx, req.GetTimer(), doguNames, logLevel, req.GetSupportArchive())
+	}
+
 	logrus.Info("Starting to enable debug-mode...")
 
+	registryEnabled, scopedDogus, _, err := s.registryScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(scopedDogus) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "debug mode is already enabled for dogus %s", strings.Join(scopedDogus, ", "))
+	}
+
+	operatorAvailable := false
+	if !registryEnabled {
+		operatorAvailable, err = s.debugModeOperatorAvailable(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !operatorAvailable {
+		logrus.Info("The debug mode operator is not available, the debug mode of all dogus is driven by the debug mode registry")
+		return s.enableWithRegistry(ctx, req.GetTimer(), nil, logLevel, req.GetSupportArchive())
+	}
+
 	timestamp := time.Now().Add(time.Duration(req.Timer) * time.Minute)
 
 	debugMode, err := s.debugModeClient.Get(ctx, "debug-mode", metav1.GetOptions{})
+	// an existing debug mode which is not completed is extended and continues its session
+	newSession := k8serrors.IsNotFound(err) || (err == nil && debugMode.Status.Phase == v1.DebugModeStatusCompleted)
+	var levelsBefore map[string]string
+	if newSession {
+		levelsBefore = s.currentLogLevels(ctx, s.installedDoguNames(ctx))
+	}
+
 	if err != nil && k8serrors.IsNotFound(err) {
 		debugMode = &v1.DebugMode{
 			TypeMeta: metav1.TypeMeta{},
@@ -46,7 +140,7 @@ func (s *defaultDebugModeService) Enable(ctx context.Context, req *pbMaintenance
 			},
 			Spec: v1.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(timestamp),
-				TargetLogLevel:      "DEBUG",
+				TargetLogLevel:      logLevel,
 			},
 		}
 		_, err = s.debugModeClient.Create(ctx, debugMode, metav1.CreateOptions{})
@@ -60,6 +154,7 @@ func (s *defaultDebugModeService) Enable(ctx context.Context, req *pbMaintenance
 	}
 
 	debugMode.Spec.DeactivateTimestamp = metav1.NewTime(timestamp)
+	debugMode.Spec.TargetLogLevel = logLevel
 
 	_, err = s.debugModeClient.Update(ctx, debugMode, metav1.UpdateOptions{})
 	if err != nil {
@@ -67,11 +162,274 @@ func (s *defaultDebugModeService) Enable(ctx context.Context, req *pbMaintenance
 		return nil, fmt.Errorf("ERROR: failed to update debug-mode: %q", err)
 	}
 
+	if newSession {
+		s.startSession(ctx, nil, logLevel, levelsBefore, req.GetSupportArchive())
+	}
+
+	return &types.BasicResponse{}, nil
+}
+
+// enableWithRegistry enables the debug mode for the given dogus, or for all dogus if none are given, without the
+// DebugMode resource. This is the case if the debug mode is enabled only for some dogus, because the DebugMode resource
+// always affects all dogus, or if the debug mode operator is not available. The service sets the log levels itself,
+// keeps their previous levels in the debug mode registry and restarts the dogus in the order of their dependencies.
+// Enabling the debug mode again for the same dogus and log level extends the timer.
+func (s *defaultDebugModeService) enableWithRegistry(ctx context.Context, timer int32, doguNames []string, logLevel string, supportArchive bool) (*types.BasicResponse, error) {
+	logrus.Infof("Starting to enable debug-mode for %s...", scopeDescription(doguNames))
+
+	if len(doguNames) > 0 {
+		err := s.checkDogusInstalled(ctx, doguNames)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	enabled, enabledDogus, enabledLogLevel, err := s.registryScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		if !slices.Equal(enabledDogus, doguNames) || enabledLogLevel != logLevel {
+			return nil, status.Errorf(codes.FailedPrecondition, "debug mode is already enabled for %s with log level %s", scopeDescription(enabledDogus), enabledLogLevel)
+		}
+
+		err = s.debugModeRegistry.Enable(ctx, timer, doguNames, logLevel)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extend debug mode: %w", err)
+		}
+		return &types.BasicResponse{}, nil
+	}
+
+	clusterWide, err := s.isClusterWideEnabled(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if clusterWide {
+		return nil, status.Error(codes.FailedPrecondition, "debug mode is already enabled for all dogus")
+	}
+
+	err = s.checkLogLevelAllowed(ctx, doguNames, logLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	restartDogus := doguNames
+	if len(doguNames) == 0 {
+		restartDogus, err = s.getInstalledDoguNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sortedDogus, err := s.dogusInDependencyOrder(ctx, restartDogus)
+	if err != nil {
+		return nil, err
+	}
+	levelsBefore := s.currentLogLevels(ctx, restartDogus)
+
+	err = s.debugModeRegistry.Enable(ctx, timer, doguNames, logLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable debug mode registry: %w", err)
+	}
+
+	err = s.debugModeRegistry.BackupDoguLogLevels(ctx)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to backup log levels: %w", err), s.debugModeRegistry.Disable(ctx))
+	}
+
+	if len(doguNames) == 0 {
+		err = s.doguInterActor.SetLogLevelInAllDogus(ctx, logLevel)
+	} else {
+		err = s.doguInterActor.SetLogLevelInDogus(ctx, logLevel, doguNames)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to set log level %s: %w", logLevel, err)
+		restoreErr := s.debugModeRegistry.RestoreDoguLogLevels(ctx)
+		if restoreErr != nil {
+			return nil, errors.Join(err, restoreErr)
+		}
+		return nil, errors.Join(err, s.debugModeRegistry.Disable(ctx))
+	}
+
+	s.startSession(ctx, doguNames, logLevel, levelsBefore, supportArchive)
+
+	err = s.restartDogus(ctx, sortedDogus)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.BasicResponse{}, nil
 }
 
-// Disable returns an error because the method is unimplemented.
+func (s *defaultDebugModeService) checkDogusInstalled(ctx context.Context, doguNames []string) error {
+	installedDogus, err := s.doguDescriptorGetter.GetCurrentOfAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get installed dogus: %w", err)
+	}
+
+	var unknownDogus []string
+	for _, doguName := range doguNames {
+		if !slices.ContainsFunc(installedDogus, func(dogu *core.Dogu) bool { return dogu.GetSimpleName() == doguName }) {
+			unknownDogus = append(unknownDogus, doguName)
+		}
+	}
+	if len(unknownDogus) > 0 {
+		return status.Errorf(codes.InvalidArgument, "dogus %s are not installed", strings.Join(unknownDogus, ", "))
+	}
+
+	return nil
+}
+
+// checkLogLevelAllowed returns an error if the root logger of one of the given dogus, or of any installed dogu if none
+// are given, does not allow the log level according to the dogu descriptor.
+func (s *defaultDebugModeService) checkLogLevelAllowed(ctx context.Context, doguNames []string, logLevel string) error {
+	installedDogus, err := s.doguDescriptorGetter.GetCurrentOfAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get installed dogus: %w", err)
+	}
+
+	var invalidLevels []string
+	for _, dogu := range installedDogus {
+		if len(doguNames) > 0 && !slices.Contains(doguNames, dogu.GetSimpleName()) {
+			continue
+		}
+
+		err = logging.ValidateRootLogLevel(dogu, logLevel)
+		if err != nil {
+			invalidLevels = append(invalidLevels, fmt.Sprintf("dogu %s: %v", dogu.GetSimpleName(), err))
+		}
+	}
+	if len(invalidLevels) > 0 {
+		return status.Errorf(codes.InvalidArgument, "log level %s is not allowed: %s", logLevel, strings.Join(invalidLevels, "; "))
+	}
+
+	return nil
+}
+
+// registryScope returns whether the debug mode is driven by the debug mode registry and its dogus and log level. No
+// dogus are returned if the registry drives the debug mode of all dogus.
+func (s *defaultDebugModeService) registryScope(ctx context.Context) (enabled bool, doguNames []string, logLevel string, err error) {
+	enabled, _, err = s.debugModeRegistry.Status(ctx)
+	if err != nil {
+		return false, nil, "", fmt.Errorf("failed to get debug mode registry status: %w", err)
+	}
+	if !enabled {
+		return false, nil, "", nil
+	}
+
+	doguNames, logLevel, err = s.debugModeRegistry.Targets(ctx)
+	if err != nil {
+		return false, nil, "", fmt.Errorf("failed to get dogus of debug mode: %w", err)
+	}
+
+	return true, doguNames, logLevel, nil
+}
+
+func scopeDescription(doguNames []string) string {
+	if len(doguNames) == 0 {
+		return "all dogus"
+	}
+
+	return "dogus " + strings.Join(doguNames, ", ")
+}
+
+func (s *defaultDebugModeService) isClusterWideEnabled(ctx context.Context) (bool, error) {
+	debugMode, err := s.getDebugMode(ctx)
+	if err != nil || debugMode == nil {
+		return false, err
+	}
+
+	return isDebugModeActive(debugMode), nil
+}
+
+// getDebugMode returns the DebugMode resource or nil if the debug mode was never enabled with the resource or the
+// resource is not served at all.
+func (s *defaultDebugModeService) getDebugMode(ctx context.Context) (*v1.DebugMode, error) {
+	debugMode, err := s.debugModeClient.Get(ctx, "debug-mode", metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ERROR: failed to get debug-mode: %q", err)
+	}
+
+	return debugMode, nil
+}
+
+func isDebugModeActive(debugMode *v1.DebugMode) bool {
+	return debugMode.Status.Phase != v1.DebugModeStatusCompleted
+}
+
+// IsActive returns whether the debug mode is active and when it is disabled automatically. The debug mode is active if
+// either the debug mode registry or the DebugMode resource drives it.
+func (s *defaultDebugModeService) IsActive(ctx context.Context) (bool, time.Time, error) {
+	enabled, disableAtTimestamp, err := s.debugModeRegistry.Status(ctx)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("failed to get debug mode registry status: %w", err)
+	}
+	if enabled {
+		return true, time.UnixMilli(disableAtTimestamp), nil
+	}
+
+	debugMode, err := s.getDebugMode(ctx)
+	if err != nil || debugMode == nil || !isDebugModeActive(debugMode) {
+		return false, time.Time{}, err
+	}
+
+	return true, debugMode.Spec.DeactivateTimestamp.Time, nil
+}
+
+func debugLogLevelFromRequest(logLevel string) (string, error) {
+	if logLevel == "" {
+		return defaultDebugLogLevel, nil
+	}
+
+	logLevel = strings.ToUpper(logLevel)
+	if !slices.Contains(debugLogLevels, logLevel) {
+		return "", fmt.Errorf("log level %s must be one of %s", logLevel, strings.Join(debugLogLevels, ", "))
+	}
+
+	return logLevel, nil
+}
+
+func debugDoguNamesFromRequest(doguNames []string) []string {
+	var result []string
+	for _, doguName := range doguNames {
+		if strings.TrimSpace(doguName) != "" {
+			result = append(result, strings.TrimSpace(doguName))
+		}
+	}
+
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// Disable disables the debug mode. If it is driven by the debug mode registry, the log levels of its dogus are
+// restored and the dogus are restarted; otherwise the DebugMode resource is deactivated immediately and the debug mode
+// operator restores the log levels. If requested, a support archive of the debug window is created first.
 func (s *defaultDebugModeService) Disable(ctx context.Context, _ *pbMaintenance.ToggleDebugModeRequest) (*types.BasicResponse, error) {
+	started := time.Now()
+	response, err := s.disable(ctx)
+	s.auditLogger.Record(ctx, audit.Entry{Action: "DisableDebugMode", Resource: debugModeResource, Started: started, Err: err})
+
+	return response, err
+}
+
+func (s *defaultDebugModeService) disable(ctx context.Context) (*types.BasicResponse, error) {
+	registryEnabled, doguNames, _, err := s.registryScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the archive is only an addition to the debug mode, so it does not prevent disabling it
+	err = s.captureSupportArchive(ctx, time.Now())
+	if err != nil {
+		logrus.Warn(err)
+	}
+
+	if registryEnabled {
+		return s.disableWithRegistry(ctx, doguNames)
+	}
+
 	logrus.Info("Starting to disable debug-mode...")
 
 	debugMode, err := s.debugModeClient.Get(ctx, "debug-mode", metav1.GetOptions{})
@@ -86,18 +444,159 @@ func (s *defaultDebugModeService) Disable(ctx context.Context, _ *pbMaintenance.
 		return nil, fmt.Errorf("ERROR: failed to update debug-mode: %q", err)
 	}
 
+	s.endSession(ctx)
+
+	return &types.BasicResponse{}, nil
+}
+
+// disableWithRegistry restores the log levels of the dogus of a debug mode driven by the debug mode registry and
+// restarts them in the order of their dependencies. No dogus are given if the debug mode covers all dogus. The
+// registry is kept if the log levels cannot be restored or the dogus to restart cannot be determined, so that disabling
+// can be retried.
+func (s *defaultDebugModeService) disableWithRegistry(ctx context.Context, doguNames []string) (*types.BasicResponse, error) {
+	logrus.Infof("Starting to disable debug-mode for %s...", scopeDescription(doguNames))
+
+	restartDogus := doguNames
+	if len(doguNames) == 0 {
+		var err error
+		restartDogus, err = s.getInstalledDoguNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sortedDogus, err := s.dogusInDependencyOrder(ctx, restartDogus)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.debugModeRegistry.RestoreDoguLogLevels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore log levels: %w", err)
+	}
+
+	restartErr := s.restartDogus(ctx, sortedDogus)
+
+	err = s.debugModeRegistry.Disable(ctx)
+	if err != nil {
+		return nil, errors.Join(restartErr, fmt.Errorf("failed to disable debug mode registry: %w", err))
+	}
+
+	s.endSession(ctx)
+
+	if restartErr != nil {
+		return nil, restartErr
+	}
+
 	return &types.BasicResponse{}, nil
 }
 
-// Status return an error because the method is unimplemented.
+// Status returns whether the debug mode is enabled, when it is disabled and which dogus are in debug mode. It reports
+// the phase and the conditions of the DebugMode resource, the log levels and the restart state of every dogu in
+// debug mode and the support archive of the most recent session. A debug mode driven by the debug mode registry has
+// no phase and conditions.
 func (s *defaultDebugModeService) Status(ctx context.Context, _ *types.BasicRequest) (result *pbMaintenance.DebugModeStatusResponse, e error) {
-	debugMode, err := s.debugModeClient.Get(ctx, "debug-mode", metav1.GetOptions{})
+	enabled, disableAtTimestamp, err := s.debugModeRegistry.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debug mode registry status: %w", err)
+	}
+	if enabled {
+		doguNames, logLevel, err := s.debugModeRegistry.Targets(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dogus of debug mode: %w", err)
+		}
 
+		allDogus := len(doguNames) == 0
+		if allDogus {
+			doguNames = s.installedDoguNames(ctx)
+		}
+
+		return &pbMaintenance.DebugModeStatusResponse{
+			IsEnabled:          true,
+			DisableAtTimestamp: disableAtTimestamp,
+			DoguNames:          doguNames,
+			LogLevel:           logLevel,
+			AllDogus:           allDogus,
+			Dogus:              s.doguStatuses(ctx, doguNames),
+			SupportArchiveName: s.supportArchiveName(ctx),
+		}, nil
+	}
+
+	debugMode, err := s.getDebugMode(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("ERROR: failed to get debug-mode: %q", err)
+		return nil, err
+	}
+	if debugMode == nil {
+		return &pbMaintenance.DebugModeStatusResponse{SupportArchiveName: s.supportArchiveName(ctx)}, nil
+	}
+
+	response := &pbMaintenance.DebugModeStatusResponse{
+		IsEnabled:          isDebugModeActive(debugMode),
+		DisableAtTimestamp: debugMode.Spec.DeactivateTimestamp.UnixMilli(),
+		Phase:              string(debugMode.Status.Phase),
+		Errors:             debugMode.Status.Errors,
+		Conditions:         debugModeConditions(debugMode.Status.Conditions),
+		SupportArchiveName: s.supportArchiveName(ctx),
+	}
+	if response.IsEnabled {
+		response.AllDogus = true
+		response.LogLevel = debugMode.Spec.TargetLogLevel
+		response.DoguNames = s.installedDoguNames(ctx)
+		response.Dogus = s.doguStatuses(ctx, response.DoguNames)
+	}
+
+	return response, nil
+}
+
+// GetHistory returns the most recent sessions of the debug mode, the most recent first.
+func (s *defaultDebugModeService) GetHistory(ctx context.Context, req *pbMaintenance.DebugModeHistoryRequest) (*pbMaintenance.DebugModeHistoryResponse, error) {
+	limit := int(req.GetLimit())
+	if limit < 0 || limit > maxHistoryLength {
+		return nil, status.Errorf(codes.InvalidArgument, "limit %d must be between 0 and %d", limit, maxHistoryLength)
+	}
+	if limit == 0 {
+		limit = defaultHistoryLimit
+	}
+
+	sessions, err := s.history.List(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debug mode history: %w", err)
+	}
+
+	now := time.Now()
+	response := &pbMaintenance.DebugModeHistoryResponse{}
+	for _, session := range sessions {
+		response.Sessions = append(response.Sessions, sessionToProto(session, now))
+	}
+
+	return response, nil
+}
+
+// installedDoguNames returns the names of all installed dogus. The names are only informational, so errors are
+// logged and no names are returned.
+func (s *defaultDebugModeService) installedDoguNames(ctx context.Context) []string {
+	doguNames, err := s.getInstalledDoguNames(ctx)
+	if err != nil {
+		logrus.Warnf("could not get installed dogus for the debug mode status: %v", err)
+		return nil
+	}
+
+	return doguNames
+}
+
+// getInstalledDoguNames returns the sorted names of all installed dogus.
+func (s *defaultDebugModeService) getInstalledDoguNames(ctx context.Context) ([]string, error) {
+	dogus, err := s.doguDescriptorGetter.GetCurrentOfAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installed dogus: %w", err)
+	}
+
+	doguNames := make([]string, 0, len(dogus))
+	for _, dogu := range dogus {
+		doguNames = append(doguNames, dogu.GetSimpleName())
 	}
+	slices.Sort(doguNames)
 
-	return &pbMaintenance.DebugModeStatusResponse{IsEnabled: debugMode.Status.Phase != v1.DebugModeStatusCompleted, DisableAtTimestamp: debugMode.Spec.DeactivateTimestamp.UnixMilli()}, nil
+	return doguNames, nil
 }
 
 func noInheritCancel(_ context.Context) (context.Context, context.CancelFunc) {
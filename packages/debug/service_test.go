@@ -1,13 +1,29 @@
 package debug
 
 import (
+	"maps"
+	"slices"
+
+	common "github.com/cloudogu/ces-commons-lib/dogu"
 	"github.com/cloudogu/ces-control-api/generated/maintenance"
+	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
 	debugModeV1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-registry-lib/config"
 	"github.com/cloudogu/k8s-registry-lib/repository"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
 	"testing"
+	"time"
 )
 
 func TestNewdefaultDebugModeService(t *testing.T) {
@@ -23,9 +39,14 @@ func TestNewdefaultDebugModeService(t *testing.T) {
 		clientSetMock.EXPECT().CoreV1().Return(coreV1Mock)
 		configMapClientMock := newMockConfigMapInterface(t)
 		coreV1Mock.EXPECT().ConfigMaps(testNamespace).Return(configMapClientMock)
+		coreV1Mock.EXPECT().Pods(testNamespace).Return(nil)
+		clientSetMock.EXPECT().Discovery().Return(nil)
+		appsV1Mock := newMockAppsV1Interface(t)
+		clientSetMock.EXPECT().AppsV1().Return(appsV1Mock)
+		appsV1Mock.EXPECT().Deployments(testNamespace).Return(nil)
 
 		// when
-		service := NewDebugModeService(debugModeClientMock, doguInterActorMock, repository.DoguConfigRepository{}, doguDescriptionGetterMock, clientSetMock, testNamespace)
+		service := NewDebugModeService(debugModeClientMock, doguInterActorMock, repository.DoguConfigRepository{}, doguDescriptionGetterMock, clientSetMock, newMockSupportArchiveClient(t), testNamespace, newMockAuditLogger(t))
 
 		// then
 		require.NotNil(t, service)
@@ -38,8 +59,16 @@ func Test_defaultDebugModeService_Disable(t *testing.T) {
 		debugModeClientMock := newMockDebugModeInterface(t)
 		doguInterActorMock := newMockDoguInterActor(t)
 		debugModeRegistryMock := newMockDebugModeRegistry(t)
-		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, doguInterActor: doguInterActorMock}
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "DisableDebugMode" && entry.Resource.Kind == audit.KindDebugMode && entry.Err == nil
+		})).Return()
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return(nil, nil)
+		historyMock.EXPECT().End(testCtx, "k8s-ces-control", mock.Anything).Return(nil)
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, doguInterActor: doguInterActorMock, history: historyMock, auditLogger: auditLoggerMock}
 
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
 		debugMode := &debugModeV1.DebugMode{}
 		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(debugMode, nil)
 		debugModeClientMock.EXPECT().Update(testCtx, debugMode, metav1.UpdateOptions{}).Return(debugMode, nil)
@@ -57,7 +86,16 @@ func Test_defaultDebugModeService_Disable(t *testing.T) {
 		debugMode := &debugModeV1.DebugMode{}
 		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(debugMode, assert.AnError)
 
-		sut := defaultDebugModeService{debugModeClient: debugModeClientMock}
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "DisableDebugMode" && entry.Err != nil
+		})).Return()
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return(nil, nil)
+
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, history: historyMock, auditLogger: auditLoggerMock}
 
 		// when
 		_, err := sut.Disable(testCtx, &maintenance.ToggleDebugModeRequest{})
@@ -76,16 +114,54 @@ func Test_defaultDebugModeService_Enable(t *testing.T) {
 		doguInterActorMock := newMockDoguInterActor(t)
 
 		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
 
 		debugMode := &debugModeV1.DebugMode{}
 		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(debugMode, nil)
 		debugModeClientMock.EXPECT().Update(testCtx, debugMode, metav1.UpdateOptions{}).Return(debugMode, nil)
 
-		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, doguInterActor: doguInterActorMock}
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "EnableDebugMode" && entry.Parameters["timer"] == "15" && entry.Err == nil
+		})).Return()
+
+		clientSet := withDebugModeOperator()
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, doguInterActor: doguInterActorMock, serverResources: clientSet.Discovery(), podClient: clientSet.CoreV1().Pods(testNamespace), auditLogger: auditLoggerMock}
 
 		// when
 		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{WithMaintenanceMode: true, Timer: 15})
 
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "DEBUG", debugMode.Spec.TargetLogLevel)
+	})
+	t.Run("should record a new session with the log levels of all dogus", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		debugMode := &debugModeV1.DebugMode{Status: debugModeV1.DebugModeStatus{Phase: debugModeV1.DebugModeStatusCompleted}}
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(debugMode, nil)
+		debugModeClientMock.EXPECT().Update(testCtx, debugMode, metav1.UpdateOptions{}).Return(debugMode, nil)
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return([]*core.Dogu{{Name: "official/cas"}}, nil)
+		doguConfigRepositoryMock := newMockDoguConfigRepository(t)
+		doguConfigRepositoryMock.EXPECT().Get(testCtx, common.SimpleName("cas")).Return(config.CreateDoguConfig("cas", config.Entries{"logging/root": "INFO"}), nil)
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().Start(testCtx, mock.MatchedBy(func(session debugSession) bool {
+			return len(session.DoguNames) == 0 && session.LogLevel == "DEBUG" && maps.Equal(session.LogLevelsBefore, map[string]string{"cas": "INFO"}) && session.SupportArchive
+		})).Return(assert.AnError)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "EnableDebugMode" && entry.Parameters["supportArchive"] == "true" && entry.Err == nil
+		})).Return()
+
+		clientSet := withDebugModeOperator()
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, doguDescriptorGetter: descriptorGetterMock, doguConfigRepository: doguConfigRepositoryMock, history: historyMock, serverResources: clientSet.Discovery(), podClient: clientSet.CoreV1().Pods(testNamespace), auditLogger: auditLoggerMock}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 15, SupportArchive: true})
+
 		// then
 		require.NoError(t, err)
 	})
@@ -95,7 +171,15 @@ func Test_defaultDebugModeService_Enable(t *testing.T) {
 		debugMode := &debugModeV1.DebugMode{}
 		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(debugMode, assert.AnError)
 
-		sut := defaultDebugModeService{debugModeClient: debugModeClientMock}
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "EnableDebugMode" && entry.Err != nil
+		})).Return()
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+
+		clientSet := withDebugModeOperator()
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, serverResources: clientSet.Discovery(), podClient: clientSet.CoreV1().Pods(testNamespace), auditLogger: auditLoggerMock}
 
 		// when
 		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{})
@@ -106,33 +190,545 @@ func Test_defaultDebugModeService_Enable(t *testing.T) {
 	})
 }
 
-// func Test_defaultDebugModeService_Status(t *testing.T) {
-//	t.Run("success", func(t *testing.T) {
-//		// given
-//		debugModeRegistryMock := newMockDebugModeRegistry(t)
-//		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, 15, nil)
-//		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock}
-//
-//		// when
-//		response, err := sut.Status(context.TODO(), nil)
-//
-//		// then
-//		require.NoError(t, err)
-//		assert.Equal(t, true, response.IsEnabled)
-//		assert.Equal(t, int64(15), response.DisableAtTimestamp)
-//	})
-//
-//	t.Run("should return error on status error", func(t *testing.T) {
-//		// given
-//		debugModeRegistryMock := newMockDebugModeRegistry(t)
-//		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, assert.AnError)
-//		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock}
-//
-//		// when
-//		_, err := sut.Status(context.TODO(), nil)
-//
-//		// then
-//		require.Error(t, err)
-//		assert.ErrorContains(t, err, "rpc error: code = Internal desc = failed to get status of debug mode registry")
-//	})
-// }
+func Test_defaultDebugModeService_Enable_forDogus(t *testing.T) {
+	notFound := k8serrors.NewNotFound(schema.GroupResource{}, "debug-mode")
+	installedDogus := []*core.Dogu{{Name: "official/cas"}, {Name: "official/ldap"}, {Name: "official/redmine"}}
+	recordEnable := func(t *testing.T) *mockAuditLogger {
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "EnableDebugMode"
+		})).Return()
+		return auditLoggerMock
+	}
+
+	t.Run("should set the log level of the requested dogus only and restart them", func(t *testing.T) {
+		// given
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return(installedDogus, nil)
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		debugModeRegistryMock.EXPECT().Enable(testCtx, int32(15), []string{"cas", "redmine"}, "TRACE").Return(nil)
+		debugModeRegistryMock.EXPECT().BackupDoguLogLevels(testCtx).Return(nil)
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(nil, notFound)
+		doguInterActorMock := newMockDoguInterActor(t)
+		doguInterActorMock.EXPECT().SetLogLevelInDogus(testCtx, "TRACE", []string{"cas", "redmine"}).Return(nil)
+		doguInterActorMock.EXPECT().RestartDoguWithWait(mock.Anything, "cas", false).Return(nil)
+		doguInterActorMock.EXPECT().RestartDoguWithWait(mock.Anything, "redmine", false).Return(nil)
+		doguConfigRepositoryMock := newMockDoguConfigRepository(t)
+		doguConfigRepositoryMock.EXPECT().Get(testCtx, common.SimpleName("cas")).Return(config.CreateDoguConfig("cas", config.Entries{"logging/root": "WARN"}), nil)
+		doguConfigRepositoryMock.EXPECT().Get(testCtx, common.SimpleName("redmine")).Return(config.CreateDoguConfig("redmine", config.Entries{}), nil)
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().Start(testCtx, mock.MatchedBy(func(session debugSession) bool {
+			return session.EnabledBy == "k8s-ces-control" && slices.Equal(session.DoguNames, []string{"cas", "redmine"}) && session.LogLevel == "TRACE" &&
+				maps.Equal(session.LogLevelsBefore, map[string]string{"cas": "WARN", "redmine": ""})
+		})).Return(nil)
+
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "EnableDebugMode" && entry.Parameters["dogus"] == "redmine,cas, " && entry.Parameters["logLevel"] == "trace" && entry.Err == nil
+		})).Return()
+
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, doguInterActor: doguInterActorMock, doguDescriptorGetter: descriptorGetterMock, doguConfigRepository: doguConfigRepositoryMock, history: historyMock, auditLogger: auditLoggerMock}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 15, DoguNames: []string{"redmine", "cas", " "}, LogLevel: "trace"})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should extend the timer if the debug mode is enabled for the same dogus", func(t *testing.T) {
+		// given
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return(installedDogus, nil)
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, 0, nil)
+		debugModeRegistryMock.EXPECT().Targets(testCtx).Return([]string{"cas"}, "DEBUG", nil)
+		debugModeRegistryMock.EXPECT().Enable(testCtx, int32(30), []string{"cas"}, "DEBUG").Return(nil)
+
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock, doguDescriptorGetter: descriptorGetterMock, auditLogger: recordEnable(t)}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 30, DoguNames: []string{"cas"}})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail if the debug mode is enabled for other dogus", func(t *testing.T) {
+		// given
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return(installedDogus, nil)
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, 0, nil)
+		debugModeRegistryMock.EXPECT().Targets(testCtx).Return([]string{"ldap"}, "DEBUG", nil)
+
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock, doguDescriptorGetter: descriptorGetterMock, auditLogger: recordEnable(t)}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 30, DoguNames: []string{"cas"}})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.ErrorContains(t, err, "debug mode is already enabled for dogus ldap with log level DEBUG")
+	})
+	t.Run("should fail if the debug mode is enabled for all dogus", func(t *testing.T) {
+		// given
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return(installedDogus, nil)
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugMode := &debugModeV1.DebugMode{Status: debugModeV1.DebugModeStatus{Phase: debugModeV1.DebugModeStatusWaitForRollback}}
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(debugMode, nil)
+
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, doguDescriptorGetter: descriptorGetterMock, auditLogger: recordEnable(t)}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 30, DoguNames: []string{"cas"}})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.ErrorContains(t, err, "debug mode is already enabled for all dogus")
+	})
+	t.Run("should fail for dogus which are not installed", func(t *testing.T) {
+		// given
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return(installedDogus, nil)
+
+		sut := defaultDebugModeService{doguDescriptorGetter: descriptorGetterMock, auditLogger: recordEnable(t)}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 30, DoguNames: []string{"cas", "jenkins"}})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, "dogus jenkins are not installed")
+	})
+	t.Run("should fail for an unknown log level", func(t *testing.T) {
+		// given
+		sut := defaultDebugModeService{auditLogger: recordEnable(t)}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 30, LogLevel: "VERBOSE"})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, "log level VERBOSE must be one of ERROR, WARN, INFO, DEBUG, TRACE")
+	})
+	t.Run("should fail for a log level the root logger of a dogu does not allow", func(t *testing.T) {
+		// given
+		restrictedCas := &core.Dogu{
+			Name: "official/cas",
+			Configuration: []core.ConfigurationField{
+				{Name: "logging/root", Validation: core.ValidationDescriptor{Type: "ONE_OF", Values: []string{"ERROR", "WARN", "INFO", "DEBUG"}}},
+			},
+		}
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return([]*core.Dogu{restrictedCas, {Name: "official/redmine"}}, nil)
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(nil, notFound)
+
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, doguDescriptorGetter: descriptorGetterMock, auditLogger: recordEnable(t)}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 15, DoguNames: []string{"cas", "redmine"}, LogLevel: "TRACE"})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, `log level TRACE is not allowed: dogu cas: invalid log level "TRACE" for logger root: allowed are ERROR, WARN, INFO, DEBUG`)
+	})
+	t.Run("should restore the log levels if they cannot be set", func(t *testing.T) {
+		// given
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return(installedDogus, nil)
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		debugModeRegistryMock.EXPECT().Enable(testCtx, int32(15), []string{"cas"}, "DEBUG").Return(nil)
+		debugModeRegistryMock.EXPECT().BackupDoguLogLevels(testCtx).Return(nil)
+		debugModeRegistryMock.EXPECT().RestoreDoguLogLevels(testCtx).Return(nil)
+		debugModeRegistryMock.EXPECT().Disable(testCtx).Return(nil)
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(nil, notFound)
+		doguInterActorMock := newMockDoguInterActor(t)
+		doguInterActorMock.EXPECT().SetLogLevelInDogus(testCtx, "DEBUG", []string{"cas"}).Return(assert.AnError)
+		doguConfigRepositoryMock := newMockDoguConfigRepository(t)
+		doguConfigRepositoryMock.EXPECT().Get(testCtx, common.SimpleName("cas")).Return(config.CreateDoguConfig("cas", config.Entries{}), nil)
+
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, doguInterActor: doguInterActorMock, doguDescriptorGetter: descriptorGetterMock, doguConfigRepository: doguConfigRepositoryMock, auditLogger: recordEnable(t)}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 15, DoguNames: []string{"cas"}})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to set log level DEBUG")
+	})
+	t.Run("should not enable the debug mode for all dogus while it is enabled for some dogus", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, 0, nil)
+		debugModeRegistryMock.EXPECT().Targets(testCtx).Return([]string{"cas", "redmine"}, "DEBUG", nil)
+
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock, auditLogger: recordEnable(t)}
+
+		// when
+		_, err := sut.Enable(testCtx, &maintenance.ToggleDebugModeRequest{Timer: 15})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.ErrorContains(t, err, "debug mode is already enabled for dogus cas, redmine")
+	})
+}
+
+func Test_defaultDebugModeService_Disable_forDogus(t *testing.T) {
+	t.Run("should restore the log levels of the dogus and restart them", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, 0, nil)
+		debugModeRegistryMock.EXPECT().Targets(testCtx).Return([]string{"cas", "redmine"}, "DEBUG", nil)
+		debugModeRegistryMock.EXPECT().RestoreDoguLogLevels(testCtx).Return(nil)
+		debugModeRegistryMock.EXPECT().Disable(testCtx).Return(nil)
+		doguInterActorMock := newMockDoguInterActor(t)
+		doguInterActorMock.EXPECT().RestartDoguWithWait(mock.Anything, "cas", false).Return(nil)
+		doguInterActorMock.EXPECT().RestartDoguWithWait(mock.Anything, "redmine", false).Return(nil)
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return([]*core.Dogu{{Name: "official/cas"}, {Name: "official/redmine"}}, nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "DisableDebugMode" && entry.Err == nil
+		})).Return()
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return([]debugSession{{EnabledBy: "admin", DoguNames: []string{"cas", "redmine"}}}, nil)
+		historyMock.EXPECT().End(testCtx, "k8s-ces-control", mock.Anything).Return(nil)
+
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock, doguInterActor: doguInterActorMock, doguDescriptorGetter: descriptorGetterMock, history: historyMock, auditLogger: auditLoggerMock}
+
+		// when
+		_, err := sut.Disable(testCtx, &maintenance.ToggleDebugModeRequest{})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should keep the registry if the log levels cannot be restored", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, 0, nil)
+		debugModeRegistryMock.EXPECT().Targets(testCtx).Return([]string{"cas"}, "DEBUG", nil)
+		debugModeRegistryMock.EXPECT().RestoreDoguLogLevels(testCtx).Return(assert.AnError)
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return([]*core.Dogu{{Name: "official/cas"}}, nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "DisableDebugMode" && entry.Err != nil
+		})).Return()
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return(nil, nil)
+
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock, doguDescriptorGetter: descriptorGetterMock, history: historyMock, auditLogger: auditLoggerMock}
+
+		// when
+		_, err := sut.Disable(testCtx, &maintenance.ToggleDebugModeRequest{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to restore log levels")
+	})
+}
+
+func Test_defaultDebugModeService_Status(t *testing.T) {
+	readyDeployment := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		}
+	}
+
+	t.Run("should return the dogus of a debug mode for some dogus", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, 15, nil)
+		debugModeRegistryMock.EXPECT().Targets(testCtx).Return([]string{"redmine"}, "TRACE", nil)
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return([]debugSession{{LogLevelsBefore: map[string]string{"redmine": "WARN"}}}, nil)
+		doguConfigRepositoryMock := newMockDoguConfigRepository(t)
+		doguConfigRepositoryMock.EXPECT().Get(testCtx, common.SimpleName("redmine")).Return(config.CreateDoguConfig("redmine", config.Entries{"logging/root": "TRACE"}), nil)
+		deployments := fake.NewClientset(readyDeployment("redmine")).AppsV1().Deployments(testNamespace)
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock, history: historyMock, doguConfigRepository: doguConfigRepositoryMock, deploymentClient: deployments}
+
+		// when
+		response, err := sut.Status(testCtx, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, response.IsEnabled)
+		assert.Equal(t, int64(15), response.DisableAtTimestamp)
+		assert.Equal(t, []string{"redmine"}, response.DoguNames)
+		assert.Equal(t, "TRACE", response.LogLevel)
+		assert.False(t, response.AllDogus)
+		assert.Equal(t, []*maintenance.DebugModeDoguStatus{
+			{DoguName: "redmine", LogLevelBefore: "WARN", LogLevelAfter: "TRACE", RestartState: maintenance.DebugModeRestartState_READY},
+		}, response.Dogus)
+	})
+	t.Run("should return all installed dogus of a debug mode for all dogus", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		disableAt := time.Now().Add(time.Hour)
+		debugModeClientMock := newMockDebugModeInterface(t)
+		transition := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(&debugModeV1.DebugMode{
+			Spec: debugModeV1.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(disableAt), TargetLogLevel: "DEBUG"},
+			Status: debugModeV1.DebugModeStatus{
+				Phase:  debugModeV1.DebugModeStatusSet,
+				Errors: "failed to restart cas",
+				Conditions: []metav1.Condition{{
+					Type:               debugModeV1.ConditionLogLevelSet,
+					Status:             metav1.ConditionTrue,
+					Reason:             "LogLevelsSet",
+					Message:            "log levels set",
+					LastTransitionTime: metav1.NewTime(transition),
+				}},
+			},
+		}, nil)
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return([]*core.Dogu{{Name: "official/redmine"}, {Name: "official/cas"}}, nil)
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return(nil, assert.AnError)
+		doguConfigRepositoryMock := newMockDoguConfigRepository(t)
+		doguConfigRepositoryMock.EXPECT().Get(testCtx, common.SimpleName("cas")).Return(config.CreateDoguConfig("cas", config.Entries{"logging/root": "DEBUG"}), nil)
+		doguConfigRepositoryMock.EXPECT().Get(testCtx, common.SimpleName("redmine")).Return(config.DoguConfig{}, assert.AnError)
+		failedCas := readyDeployment("cas")
+		failedCas.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded"}}
+		deployments := fake.NewClientset(failedCas).AppsV1().Deployments(testNamespace)
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, doguDescriptorGetter: descriptorGetterMock, history: historyMock, doguConfigRepository: doguConfigRepositoryMock, deploymentClient: deployments}
+
+		// when
+		response, err := sut.Status(testCtx, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, response.IsEnabled)
+		assert.Equal(t, disableAt.UnixMilli(), response.DisableAtTimestamp)
+		assert.True(t, response.AllDogus)
+		assert.Equal(t, []string{"cas", "redmine"}, response.DoguNames)
+		assert.Equal(t, "DEBUG", response.LogLevel)
+		assert.Equal(t, "SetDebugMode", response.Phase)
+		assert.Equal(t, "failed to restart cas", response.Errors)
+		assert.Equal(t, []*maintenance.DebugModeCondition{
+			{Type: "LogLevelsSet", Status: "True", Reason: "LogLevelsSet", Message: "log levels set", LastTransitionTimestamp: transition.UnixMilli()},
+		}, response.Conditions)
+		assert.Equal(t, []*maintenance.DebugModeDoguStatus{
+			{DoguName: "cas", LogLevelAfter: "DEBUG", RestartState: maintenance.DebugModeRestartState_FAILED},
+			{DoguName: "redmine", RestartState: maintenance.DebugModeRestartState_UNKNOWN},
+		}, response.Dogus)
+	})
+	t.Run("should not return dogus of a completed debug mode", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(&debugModeV1.DebugMode{
+			Status: debugModeV1.DebugModeStatus{Phase: debugModeV1.DebugModeStatusCompleted},
+		}, nil)
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return([]debugSession{{
+			EnabledBy:          "admin",
+			DisabledAt:         time.Now(),
+			SupportArchive:     true,
+			SupportArchiveName: "debug-mode-support-archive-20261018100000z",
+		}}, nil)
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock, history: historyMock}
+
+		// when
+		response, err := sut.Status(testCtx, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, response.IsEnabled)
+		assert.Empty(t, response.DoguNames)
+		assert.Empty(t, response.Dogus)
+		assert.Equal(t, "Completed", response.Phase)
+		assert.Equal(t, "debug-mode-support-archive-20261018100000z", response.SupportArchiveName)
+	})
+	t.Run("should return error on registry status error", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, assert.AnError)
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock}
+
+		// when
+		_, err := sut.Status(testCtx, nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get debug mode registry status")
+	})
+}
+
+func Test_defaultDebugModeService_IsActive(t *testing.T) {
+	t.Run("should be active if the registry drives the debug mode", func(t *testing.T) {
+		// given
+		disableAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, disableAt.UnixMilli(), nil)
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock}
+
+		// when
+		active, actualDisableAt, err := sut.IsActive(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, active)
+		assert.True(t, disableAt.Equal(actualDisableAt))
+	})
+	t.Run("should be active if the DebugMode resource drives the debug mode", func(t *testing.T) {
+		// given
+		disableAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(&debugModeV1.DebugMode{
+			Spec:   debugModeV1.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(disableAt)},
+			Status: debugModeV1.DebugModeStatus{Phase: debugModeV1.DebugModeStatusSet},
+		}, nil)
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock}
+
+		// when
+		active, actualDisableAt, err := sut.IsActive(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, active)
+		assert.True(t, disableAt.Equal(actualDisableAt))
+	})
+	t.Run("should not be active if the DebugMode resource is completed", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(&debugModeV1.DebugMode{
+			Status: debugModeV1.DebugModeStatus{Phase: debugModeV1.DebugModeStatusCompleted},
+		}, nil)
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock}
+
+		// when
+		active, _, err := sut.IsActive(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, active)
+	})
+	t.Run("should not be active if the DebugMode resource does not exist", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(nil, k8serrors.NewNotFound(schema.GroupResource{}, "debug-mode"))
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock}
+
+		// when
+		active, _, err := sut.IsActive(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, active)
+	})
+	t.Run("should fail to get the registry status", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, assert.AnError)
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock}
+
+		// when
+		_, _, err := sut.IsActive(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get debug mode registry status")
+	})
+	t.Run("should fail to get the DebugMode resource", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(false, 0, nil)
+		debugModeClientMock := newMockDebugModeInterface(t)
+		debugModeClientMock.EXPECT().Get(testCtx, "debug-mode", metav1.GetOptions{}).Return(nil, assert.AnError)
+		sut := defaultDebugModeService{debugModeClient: debugModeClientMock, debugModeRegistry: debugModeRegistryMock}
+
+		// when
+		_, _, err := sut.IsActive(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get debug-mode")
+	})
+}
+
+func Test_defaultDebugModeService_GetHistory(t *testing.T) {
+	t.Run("should return the sessions with their duration", func(t *testing.T) {
+		// given
+		enabledAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 20).Return([]debugSession{
+			{EnabledBy: "admin", EnabledAt: time.Now().Add(-time.Hour), DoguNames: []string{"cas"}, LogLevel: "TRACE"},
+			{EnabledBy: "admin", EnabledAt: enabledAt, DisabledBy: "k8s-ces-control", DisabledAt: enabledAt.Add(15 * time.Minute), LogLevel: "DEBUG"},
+		}, nil)
+		sut := defaultDebugModeService{history: historyMock}
+
+		// when
+		response, err := sut.GetHistory(testCtx, &maintenance.DebugModeHistoryRequest{})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, response.Sessions, 2)
+		assert.True(t, response.Sessions[0].Active)
+		assert.Equal(t, []string{"cas"}, response.Sessions[0].DoguNames)
+		assert.False(t, response.Sessions[0].AllDogus)
+		assert.GreaterOrEqual(t, response.Sessions[0].DurationSeconds, int64(3600))
+		assert.Equal(t, &maintenance.DebugModeSession{
+			EnabledBy:           "admin",
+			EnabledAtTimestamp:  enabledAt.UnixMilli(),
+			DisabledBy:          "k8s-ces-control",
+			DisabledAtTimestamp: enabledAt.Add(15 * time.Minute).UnixMilli(),
+			DurationSeconds:     900,
+			AllDogus:            true,
+			LogLevel:            "DEBUG",
+		}, response.Sessions[1])
+	})
+	t.Run("should reject a limit above the length of the history", func(t *testing.T) {
+		// given
+		sut := defaultDebugModeService{}
+
+		// when
+		_, err := sut.GetHistory(testCtx, &maintenance.DebugModeHistoryRequest{Limit: 101})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, "limit 101 must be between 0 and 100")
+	})
+	t.Run("should return error if the history cannot be read", func(t *testing.T) {
+		// given
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 5).Return(nil, assert.AnError)
+		sut := defaultDebugModeService{history: historyMock}
+
+		// when
+		_, err := sut.GetHistory(testCtx, &maintenance.DebugModeHistoryRequest{Limit: 5})
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get debug mode history")
+	})
+}
@@ -0,0 +1,182 @@
+package debug
+
+import (
+	"context"
+	"time"
+
+	common "github.com/cloudogu/ces-commons-lib/dogu"
+	pbMaintenance "github.com/cloudogu/ces-control-api/generated/maintenance"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
+)
+
+// progressDeadlineExceededReason is the reason of the progressing condition of a deployment whose rollout failed.
+const progressDeadlineExceededReason = "ProgressDeadlineExceeded"
+
+// startSession records the start of a debug mode session in the history. The history is only informational, so
+// errors are logged and do not fail the debug mode.
+func (s *defaultDebugModeService) startSession(ctx context.Context, doguNames []string, logLevel string, levelsBefore map[string]string, supportArchive bool) {
+	err := s.history.Start(ctx, debugSession{
+		EnabledBy:       audit.Caller(ctx),
+		EnabledAt:       time.Now(),
+		DoguNames:       doguNames,
+		LogLevel:        logLevel,
+		LogLevelsBefore: levelsBefore,
+		SupportArchive:  supportArchive,
+	})
+	if err != nil {
+		logrus.Warnf("could not record the start of the debug mode in the history: %v", err)
+	}
+}
+
+// endSession records the end of the active debug mode session in the history.
+func (s *defaultDebugModeService) endSession(ctx context.Context) {
+	err := s.history.End(ctx, audit.Caller(ctx), time.Now())
+	if err != nil {
+		logrus.Warnf("could not record the end of the debug mode in the history: %v", err)
+	}
+}
+
+// activeSession returns the active debug mode session of the history or nil if there is none.
+func (s *defaultDebugModeService) activeSession(ctx context.Context) *debugSession {
+	session := s.lastSession(ctx)
+	if session == nil || !session.isActive() {
+		return nil
+	}
+
+	return session
+}
+
+// lastSession returns the most recent debug mode session of the history or nil if there is none.
+func (s *defaultDebugModeService) lastSession(ctx context.Context) *debugSession {
+	sessions, err := s.history.List(ctx, 1)
+	if err != nil {
+		logrus.Warnf("could not get the last debug mode session from the history: %v", err)
+		return nil
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	return &sessions[0]
+}
+
+// currentLogLevels returns the configured log levels of the given dogus. An empty level is the default level of the
+// dogu. Dogus whose config cannot be read are missing.
+func (s *defaultDebugModeService) currentLogLevels(ctx context.Context, doguNames []string) map[string]string {
+	logLevels := make(map[string]string, len(doguNames))
+	for _, doguName := range doguNames {
+		doguConfig, err := s.doguConfigRepository.Get(ctx, common.SimpleName(doguName))
+		if err != nil {
+			logrus.Warnf("could not get the log level of dogu %s: %v", doguName, err)
+			continue
+		}
+
+		logLevel, _ := doguConfig.Get(keyDoguConfigLogLevel)
+		logLevels[doguName] = string(logLevel)
+	}
+
+	return logLevels
+}
+
+// doguStatuses returns the log levels before and in debug mode and the restart state of the given dogus.
+func (s *defaultDebugModeService) doguStatuses(ctx context.Context, doguNames []string) []*pbMaintenance.DebugModeDoguStatus {
+	var levelsBefore map[string]string
+	if session := s.activeSession(ctx); session != nil {
+		levelsBefore = session.LogLevelsBefore
+	}
+	levelsAfter := s.currentLogLevels(ctx, doguNames)
+
+	statuses := make([]*pbMaintenance.DebugModeDoguStatus, 0, len(doguNames))
+	for _, doguName := range doguNames {
+		statuses = append(statuses, &pbMaintenance.DebugModeDoguStatus{
+			DoguName:       doguName,
+			LogLevelBefore: levelsBefore[doguName],
+			LogLevelAfter:  levelsAfter[doguName],
+			RestartState:   s.restartState(ctx, doguName),
+		})
+	}
+
+	return statuses
+}
+
+// restartState returns the state of the rollout of the deployment of the given dogu, which is restarted when its log
+// level changes.
+func (s *defaultDebugModeService) restartState(ctx context.Context, doguName string) pbMaintenance.DebugModeRestartState {
+	deployment, err := s.deploymentClient.Get(ctx, doguName, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logrus.Warnf("could not get the deployment of dogu %s: %v", doguName, err)
+		}
+		return pbMaintenance.DebugModeRestartState_UNKNOWN
+	}
+
+	return deploymentRestartState(deployment)
+}
+
+func deploymentRestartState(deployment *appsv1.Deployment) pbMaintenance.DebugModeRestartState {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse && condition.Reason == progressDeadlineExceededReason {
+			return pbMaintenance.DebugModeRestartState_FAILED
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if replicas == 0 {
+		return pbMaintenance.DebugModeRestartState_STOPPED
+	}
+
+	status := deployment.Status
+	if status.ObservedGeneration < deployment.Generation || status.UpdatedReplicas < replicas ||
+		status.Replicas > status.UpdatedReplicas || status.AvailableReplicas < replicas {
+		return pbMaintenance.DebugModeRestartState_RESTARTING
+	}
+
+	return pbMaintenance.DebugModeRestartState_READY
+}
+
+func debugModeConditions(conditions []metav1.Condition) []*pbMaintenance.DebugModeCondition {
+	result := make([]*pbMaintenance.DebugModeCondition, 0, len(conditions))
+	for _, condition := range conditions {
+		result = append(result, &pbMaintenance.DebugModeCondition{
+			Type:                    condition.Type,
+			Status:                  string(condition.Status),
+			Reason:                  condition.Reason,
+			Message:                 condition.Message,
+			LastTransitionTimestamp: condition.LastTransitionTime.UnixMilli(),
+		})
+	}
+
+	return result
+}
+
+// sessionToProto converts a debug mode session. The duration of an active session is the time since it was enabled.
+func sessionToProto(session debugSession, now time.Time) *pbMaintenance.DebugModeSession {
+	result := &pbMaintenance.DebugModeSession{
+		EnabledBy:          session.EnabledBy,
+		EnabledAtTimestamp: session.EnabledAt.UnixMilli(),
+		DisabledBy:         session.DisabledBy,
+		DoguNames:          session.DoguNames,
+		AllDogus:           len(session.DoguNames) == 0,
+		LogLevel:           session.LogLevel,
+		Active:             session.isActive(),
+		SupportArchiveName: session.SupportArchiveName,
+	}
+
+	end := now
+	if !session.isActive() {
+		end = session.DisabledAt
+		result.DisabledAtTimestamp = session.DisabledAt.UnixMilli()
+	}
+	result.DurationSeconds = int64(end.Sub(session.EnabledAt).Seconds())
+
+	return result
+}
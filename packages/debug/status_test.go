@@ -0,0 +1,62 @@
+package debug
+
+import (
+	"testing"
+
+	pbMaintenance "github.com/cloudogu/ces-control-api/generated/maintenance"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_deploymentRestartState(t *testing.T) {
+	replicas := func(count int32) *int32 { return &count }
+	tests := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		want       pbMaintenance.DebugModeRestartState
+	}{
+		{
+			name:       "should be ready if all replicas are updated and available",
+			deployment: &appsv1.Deployment{Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1}},
+			want:       pbMaintenance.DebugModeRestartState_READY,
+		},
+		{
+			name: "should be restarting if the new generation is not observed yet",
+			deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+			},
+			want: pbMaintenance.DebugModeRestartState_RESTARTING,
+		},
+		{
+			name:       "should be restarting while old pods are running",
+			deployment: &appsv1.Deployment{Status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 1, AvailableReplicas: 1}},
+			want:       pbMaintenance.DebugModeRestartState_RESTARTING,
+		},
+		{
+			name:       "should be restarting while the new pod is not available",
+			deployment: &appsv1.Deployment{Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1}},
+			want:       pbMaintenance.DebugModeRestartState_RESTARTING,
+		},
+		{
+			name: "should be failed if the progress deadline is exceeded",
+			deployment: &appsv1.Deployment{Status: appsv1.DeploymentStatus{
+				Replicas: 2, UpdatedReplicas: 1, AvailableReplicas: 1,
+				Conditions: []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded"}},
+			}},
+			want: pbMaintenance.DebugModeRestartState_FAILED,
+		},
+		{
+			name:       "should be stopped without replicas",
+			deployment: &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: replicas(0)}},
+			want:       pbMaintenance.DebugModeRestartState_STOPPED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, deploymentRestartState(tt.deployment))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	supportArchiveV1 "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	"github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
+)
+
+// supportArchiveNamePrefix distinguishes the support archives of the debug mode from the ones created by the users.
+const supportArchiveNamePrefix = "debug-mode-support-archive-"
+
+// CaptureSupportArchive creates the support archive of the active debug mode session ahead of the given planned end of
+// the session, if it was requested when the debug mode was enabled. The expiry watcher uses it for the DebugMode
+// resource, whose log levels the debug mode operator rolls back at the deactivation time.
+func (s *defaultDebugModeService) CaptureSupportArchive(ctx context.Context, end time.Time) error {
+	return s.captureSupportArchive(ctx, end)
+}
+
+// captureSupportArchive creates a support archive of the active debug mode session up to the given end if it was
+// requested when the debug mode was enabled. It is called before the debug mode is disabled, so that the archive covers
+// the whole debug window. The archive is created only once per session, also if disabling the debug mode fails and is
+// retried.
+func (s *defaultDebugModeService) captureSupportArchive(ctx context.Context, end time.Time) error {
+	session := s.activeSession(ctx)
+	if session == nil || !session.SupportArchive || session.SupportArchiveName != "" {
+		return nil
+	}
+
+	archive := debugModeSupportArchive(*session, end)
+	timeframe := archive.Spec.ContentTimeframe
+	started := time.Now()
+	_, err := s.supportArchiveClient.Create(ctx, archive, metav1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		// a previous attempt created the archive of the session but could not record it in the history
+		err = nil
+	}
+	s.auditLogger.Record(ctx, audit.Entry{
+		Action:   "CreateSupportArchive",
+		Resource: audit.Resource{Kind: audit.KindSupportArchive, Name: archive.Name},
+		Parameters: map[string]string{
+			"startTime": timeframe.StartTime.UTC().Format(time.RFC3339),
+			"endTime":   timeframe.EndTime.UTC().Format(time.RFC3339),
+		},
+		Started: started,
+		Err:     err,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create the support archive of the debug mode: %w", err)
+	}
+
+	logrus.Infof("created support archive %s of the debug mode", archive.Name)
+	err = s.history.SetSupportArchiveName(ctx, archive.Name)
+	if err != nil {
+		logrus.Warnf("could not record the support archive %s in the debug mode history: %v", archive.Name, err)
+	}
+
+	return nil
+}
+
+// debugModeSupportArchive returns a support archive whose contents are limited to the time from the start of the
+// session until the given end. The archive is named after the start of the session, so that every session has at most
+// one archive.
+func debugModeSupportArchive(session debugSession, end time.Time) *supportArchiveV1.SupportArchive {
+	return &supportArchiveV1.SupportArchive{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: supportArchiveNamePrefix + session.EnabledAt.UTC().Format("20060102150405") + "z",
+		},
+		Spec: supportArchiveV1.SupportArchiveSpec{
+			ContentTimeframe: supportArchiveV1.ContentTimeframe{
+				StartTime: metav1.NewTime(session.EnabledAt),
+				EndTime:   metav1.NewTime(end),
+			},
+		},
+	}
+}
+
+// supportArchiveName returns the name of the support archive of the most recent debug mode session or an empty
+// string if none was created.
+func (s *defaultDebugModeService) supportArchiveName(ctx context.Context) string {
+	session := s.lastSession(ctx)
+	if session == nil {
+		return ""
+	}
+
+	return session.SupportArchiveName
+}
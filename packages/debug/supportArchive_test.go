@@ -0,0 +1,160 @@
+package debug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudogu/ces-control-api/generated/maintenance"
+	"github.com/cloudogu/cesapp-lib/core"
+	supportArchiveV1 "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
+)
+
+func Test_defaultDebugModeService_captureSupportArchive(t *testing.T) {
+	enabledAt := time.Now().Add(-time.Hour)
+	end := enabledAt.Add(time.Hour)
+
+	t.Run("should create a support archive of the debug window and record its name", func(t *testing.T) {
+		// given
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return([]debugSession{{EnabledBy: "admin", EnabledAt: enabledAt, SupportArchive: true}}, nil)
+		var archiveName string
+		supportArchiveClientMock := newMockSupportArchiveClient(t)
+		supportArchiveClientMock.EXPECT().Create(testCtx, mock.MatchedBy(func(archive *supportArchiveV1.SupportArchive) bool {
+			archiveName = archive.Name
+			timeframe := archive.Spec.ContentTimeframe
+			return timeframe.StartTime.Equal(&metav1.Time{Time: enabledAt}) && timeframe.EndTime.Equal(&metav1.Time{Time: end})
+		}), metav1.CreateOptions{}).Return(&supportArchiveV1.SupportArchive{}, nil)
+		historyMock.EXPECT().SetSupportArchiveName(testCtx, mock.AnythingOfType("string")).Return(nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "CreateSupportArchive" && entry.Resource.Kind == audit.KindSupportArchive && entry.Err == nil &&
+				entry.Parameters["startTime"] == enabledAt.UTC().Format(time.RFC3339) && entry.Parameters["endTime"] == end.UTC().Format(time.RFC3339)
+		})).Return()
+		sut := defaultDebugModeService{history: historyMock, supportArchiveClient: supportArchiveClientMock, auditLogger: auditLoggerMock}
+
+		// when
+		err := sut.captureSupportArchive(testCtx, end)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "debug-mode-support-archive-"+enabledAt.UTC().Format("20060102150405")+"z", archiveName)
+		historyMock.AssertCalled(t, "SetSupportArchiveName", testCtx, archiveName)
+	})
+	t.Run("should record the support archive of the session if a previous attempt created it", func(t *testing.T) {
+		// given
+		archiveName := "debug-mode-support-archive-" + enabledAt.UTC().Format("20060102150405") + "z"
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return([]debugSession{{EnabledBy: "admin", EnabledAt: enabledAt, SupportArchive: true}}, nil)
+		supportArchiveClientMock := newMockSupportArchiveClient(t)
+		supportArchiveClientMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).
+			Return(nil, k8serrors.NewAlreadyExists(schema.GroupResource{}, archiveName))
+		historyMock.EXPECT().SetSupportArchiveName(testCtx, archiveName).Return(nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "CreateSupportArchive" && entry.Err == nil
+		})).Return()
+		sut := defaultDebugModeService{history: historyMock, supportArchiveClient: supportArchiveClientMock, auditLogger: auditLoggerMock}
+
+		// when
+		err := sut.captureSupportArchive(testCtx, end)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should not create a support archive if it was not requested", func(t *testing.T) {
+		// given
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return([]debugSession{{EnabledBy: "admin", EnabledAt: enabledAt}}, nil)
+		sut := defaultDebugModeService{history: historyMock, supportArchiveClient: newMockSupportArchiveClient(t)}
+
+		// when
+		err := sut.captureSupportArchive(testCtx, end)
+
+		// then no support archive is created
+		require.NoError(t, err)
+	})
+	t.Run("should not create a second support archive if disabling is retried", func(t *testing.T) {
+		// given
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return([]debugSession{{EnabledBy: "admin", EnabledAt: enabledAt, SupportArchive: true, SupportArchiveName: "debug-mode-support-archive-20261018100000z"}}, nil)
+		sut := defaultDebugModeService{history: historyMock, supportArchiveClient: newMockSupportArchiveClient(t)}
+
+		// when
+		err := sut.captureSupportArchive(testCtx, end)
+
+		// then no support archive is created
+		require.NoError(t, err)
+	})
+	t.Run("should not create a support archive without active session", func(t *testing.T) {
+		// given
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return([]debugSession{{EnabledBy: "admin", EnabledAt: enabledAt, DisabledAt: time.Now(), SupportArchive: true}}, nil)
+		sut := defaultDebugModeService{history: historyMock, supportArchiveClient: newMockSupportArchiveClient(t)}
+
+		// when
+		err := sut.captureSupportArchive(testCtx, end)
+
+		// then no support archive is created
+		require.NoError(t, err)
+	})
+	t.Run("should not record a support archive which could not be created", func(t *testing.T) {
+		// given
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return([]debugSession{{EnabledBy: "admin", EnabledAt: enabledAt, SupportArchive: true}}, nil)
+		supportArchiveClientMock := newMockSupportArchiveClient(t)
+		supportArchiveClientMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).Return(nil, assert.AnError)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "CreateSupportArchive" && entry.Err != nil
+		})).Return()
+		sut := defaultDebugModeService{history: historyMock, supportArchiveClient: supportArchiveClientMock, auditLogger: auditLoggerMock}
+
+		// when
+		err := sut.captureSupportArchive(testCtx, end)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create the support archive of the debug mode")
+		historyMock.AssertNotCalled(t, "SetSupportArchiveName", mock.Anything, mock.Anything)
+	})
+}
+
+func Test_defaultDebugModeService_Disable_withSupportArchive(t *testing.T) {
+	t.Run("should create the support archive before the log levels are restored", func(t *testing.T) {
+		// given
+		debugModeRegistryMock := newMockDebugModeRegistry(t)
+		debugModeRegistryMock.EXPECT().Status(testCtx).Return(true, 0, nil)
+		debugModeRegistryMock.EXPECT().Targets(testCtx).Return([]string{"cas"}, "DEBUG", nil)
+		historyMock := newMockDebugModeHistory(t)
+		historyMock.EXPECT().List(testCtx, 1).Return([]debugSession{{EnabledBy: "admin", EnabledAt: time.Now().Add(-time.Hour), DoguNames: []string{"cas"}, SupportArchive: true}}, nil)
+		supportArchiveClientMock := newMockSupportArchiveClient(t)
+		createArchive := supportArchiveClientMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).Return(&supportArchiveV1.SupportArchive{}, nil).Call
+		historyMock.EXPECT().SetSupportArchiveName(testCtx, mock.Anything).Return(nil)
+		restore := debugModeRegistryMock.EXPECT().RestoreDoguLogLevels(testCtx).Return(nil).Call
+		mock.InOrder(createArchive, restore)
+		debugModeRegistryMock.EXPECT().Disable(testCtx).Return(nil)
+		doguInterActorMock := newMockDoguInterActor(t)
+		doguInterActorMock.EXPECT().RestartDoguWithWait(mock.Anything, "cas", false).Return(nil)
+		descriptorGetterMock := newMockDoguDescriptorGetter(t)
+		descriptorGetterMock.EXPECT().GetCurrentOfAll(testCtx).Return([]*core.Dogu{{Name: "official/cas"}}, nil)
+		historyMock.EXPECT().End(testCtx, "k8s-ces-control", mock.Anything).Return(nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.Anything).Return()
+		sut := defaultDebugModeService{debugModeRegistry: debugModeRegistryMock, doguInterActor: doguInterActorMock, doguDescriptorGetter: descriptorGetterMock, history: historyMock, supportArchiveClient: supportArchiveClientMock, auditLogger: auditLoggerMock}
+
+		// when
+		_, err := sut.Disable(testCtx, &maintenance.ToggleDebugModeRequest{})
+
+		// then
+		require.NoError(t, err)
+	})
+}
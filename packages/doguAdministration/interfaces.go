@@ -6,6 +6,7 @@ import (
 	common "github.com/cloudogu/ces-commons-lib/dogu"
 	"github.com/cloudogu/cesapp-lib/core"
 	v3 "github.com/cloudogu/k8s-blueprint-lib/v3/api/v3"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
 	"github.com/cloudogu/k8s-registry-lib/config"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -27,6 +28,11 @@ type doguInterActor interface {
 	RestartDogu(ctx context.Context, doguName string) error
 }
 
+type auditLogger interface {
+	// Record writes the given entry to the audit log.
+	Record(ctx context.Context, entry audit.Entry)
+}
+
 //nolint:unused
 //goland:noinspection GoUnusedType
 type doguConfigRepository interface {
@@ -3,12 +3,14 @@ package doguAdministration
 import (
 	"context"
 	"fmt"
+	"time"
 
 	v3 "github.com/cloudogu/k8s-blueprint-lib/v3/api/v3"
 
 	pb "github.com/cloudogu/ces-control-api/generated/doguAdministration"
 	"github.com/cloudogu/ces-control-api/generated/types"
 	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
 	"github.com/cloudogu/k8s-ces-control/packages/logging"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
@@ -23,12 +25,13 @@ type logService interface {
 }
 
 // NewDoguAdministrationServer returns a new administration server instance to start/stop.. etc. Dogus.
-func NewDoguAdministrationServer(blueprintLister BlueprintLister, doguDescriptorGetter doguDescriptorGetter, doguInterActor doguInterActor, logService logService) *server {
+func NewDoguAdministrationServer(blueprintLister BlueprintLister, doguDescriptorGetter doguDescriptorGetter, doguInterActor doguInterActor, logService logService, auditLogger auditLogger) *server {
 	return &server{
 		blueprintLister:      blueprintLister,
 		doguDescriptorGetter: doguDescriptorGetter,
 		doguInterActor:       doguInterActor,
 		loggingService:       logService,
+		auditLogger:          auditLogger,
 	}
 }
 
@@ -38,6 +41,7 @@ type server struct {
 	blueprintLister BlueprintLister
 	doguInterActor  doguInterActor
 	loggingService  logService
+	auditLogger     auditLogger
 }
 
 // StartDogu starts the specified dogu
@@ -47,7 +51,9 @@ func (s *server) StartDogu(ctx context.Context, request *pb.DoguAdministrationRe
 		return nil, status.Errorf(codes.InvalidArgument, responseMessageMissingDoguName)
 	}
 
+	started := time.Now()
 	err := s.doguInterActor.StartDogu(ctx, doguName)
+	s.auditLogger.Record(ctx, audit.Entry{Action: "StartDogu", Resource: audit.DoguResource(doguName), Started: started, Err: err})
 	if err != nil {
 		return &types.BasicResponse{}, getGRPCInternalDoguActionError("start", err)
 	}
@@ -62,7 +68,9 @@ func (s *server) StopDogu(ctx context.Context, request *pb.DoguAdministrationReq
 		return nil, status.Errorf(codes.InvalidArgument, responseMessageMissingDoguName)
 	}
 
+	started := time.Now()
 	err := s.doguInterActor.StopDogu(ctx, doguName)
+	s.auditLogger.Record(ctx, audit.Entry{Action: "StopDogu", Resource: audit.DoguResource(doguName), Started: started, Err: err})
 	if err != nil {
 		return &types.BasicResponse{}, getGRPCInternalDoguActionError("stop", err)
 	}
@@ -77,7 +85,9 @@ func (s *server) RestartDogu(ctx context.Context, request *pb.DoguAdministration
 		return nil, status.Errorf(codes.InvalidArgument, responseMessageMissingDoguName)
 	}
 
+	started := time.Now()
 	err := s.doguInterActor.RestartDogu(ctx, doguName)
+	s.auditLogger.Record(ctx, audit.Entry{Action: "RestartDogu", Resource: audit.DoguResource(doguName), Started: started, Err: err})
 	if err != nil {
 		return &types.BasicResponse{}, getGRPCInternalDoguActionError("restart", err)
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/cloudogu/ces-control-api/generated/types"
 	"github.com/cloudogu/cesapp-lib/core"
 	blueprintcrv3 "github.com/cloudogu/k8s-blueprint-lib/v3/api/v3"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
 	"github.com/cloudogu/k8s-ces-control/packages/logging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -28,6 +29,7 @@ func TestNewDoguAdministrationServer(t *testing.T) {
 		bluePrintListerMock := NewMockBlueprintLister(t)
 		doguInterActorMock := newMockDoguInterActor(t)
 		loggingMock := newMockLogService(t)
+		auditLoggerMock := newMockAuditLogger(t)
 
 		// when
 		actual := NewDoguAdministrationServer(
@@ -35,6 +37,7 @@ func TestNewDoguAdministrationServer(t *testing.T) {
 			descriptorGetter,
 			doguInterActorMock,
 			loggingMock,
+			auditLoggerMock,
 		)
 
 		// then
@@ -43,6 +46,7 @@ func TestNewDoguAdministrationServer(t *testing.T) {
 		assert.Equal(t, descriptorGetter, actual.doguDescriptorGetter)
 		assert.Equal(t, doguInterActorMock, actual.doguInterActor)
 		assert.Equal(t, loggingMock, actual.loggingService)
+		assert.Equal(t, auditLoggerMock, actual.auditLogger)
 	})
 }
 
@@ -181,9 +185,12 @@ func Test_server_StartDogu(t *testing.T) {
 		// given
 		doguInterActorMock := newMockDoguInterActor(t)
 		doguInterActorMock.EXPECT().StartDogu(testCtx, "my-dogu").Return(assert.AnError)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(auditEntryMatcher("StartDogu", "my-dogu", assert.AnError))).Return()
 
 		sut := &server{
 			doguInterActor: doguInterActorMock,
+			auditLogger:    auditLoggerMock,
 		}
 		request := &doguAdministration.DoguAdministrationRequest{DoguName: "my-dogu"}
 
@@ -200,9 +207,12 @@ func Test_server_StartDogu(t *testing.T) {
 		// given
 		doguInterActorMock := newMockDoguInterActor(t)
 		doguInterActorMock.EXPECT().StartDogu(testCtx, "my-dogu").Return(nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(auditEntryMatcher("StartDogu", "my-dogu", nil))).Return()
 
 		sut := &server{
 			doguInterActor: doguInterActorMock,
+			auditLogger:    auditLoggerMock,
 		}
 		request := &doguAdministration.DoguAdministrationRequest{DoguName: "my-dogu"}
 
@@ -239,9 +249,12 @@ func Test_server_StopDogu(t *testing.T) {
 		// given
 		doguInterActorMock := newMockDoguInterActor(t)
 		doguInterActorMock.EXPECT().StopDogu(testCtx, "my-dogu").Return(assert.AnError)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(auditEntryMatcher("StopDogu", "my-dogu", assert.AnError))).Return()
 
 		sut := &server{
 			doguInterActor: doguInterActorMock,
+			auditLogger:    auditLoggerMock,
 		}
 		request := &doguAdministration.DoguAdministrationRequest{DoguName: "my-dogu"}
 
@@ -258,9 +271,12 @@ func Test_server_StopDogu(t *testing.T) {
 		// given
 		doguInterActorMock := newMockDoguInterActor(t)
 		doguInterActorMock.EXPECT().StopDogu(testCtx, "my-dogu").Return(nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(auditEntryMatcher("StopDogu", "my-dogu", nil))).Return()
 
 		sut := &server{
 			doguInterActor: doguInterActorMock,
+			auditLogger:    auditLoggerMock,
 		}
 		request := &doguAdministration.DoguAdministrationRequest{DoguName: "my-dogu"}
 
@@ -297,9 +313,12 @@ func Test_server_RestartDogu(t *testing.T) {
 		// given
 		doguInterActorMock := newMockDoguInterActor(t)
 		doguInterActorMock.EXPECT().RestartDogu(testCtx, "my-dogu").Return(assert.AnError)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(auditEntryMatcher("RestartDogu", "my-dogu", assert.AnError))).Return()
 
 		sut := &server{
 			doguInterActor: doguInterActorMock,
+			auditLogger:    auditLoggerMock,
 		}
 		request := &doguAdministration.DoguAdministrationRequest{DoguName: "my-dogu"}
 
@@ -316,9 +335,12 @@ func Test_server_RestartDogu(t *testing.T) {
 		// given
 		doguInterActorMock := newMockDoguInterActor(t)
 		doguInterActorMock.EXPECT().RestartDogu(testCtx, "my-dogu").Return(nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(auditEntryMatcher("RestartDogu", "my-dogu", nil))).Return()
 
 		sut := &server{
 			doguInterActor: doguInterActorMock,
+			auditLogger:    auditLoggerMock,
 		}
 		request := &doguAdministration.DoguAdministrationRequest{DoguName: "my-dogu"}
 
@@ -488,3 +510,9 @@ func Test_server_GetBlueprintId(t *testing.T) {
 	})
 
 }
+
+func auditEntryMatcher(action string, doguName string, err error) func(audit.Entry) bool {
+	return func(entry audit.Entry) bool {
+		return entry.Action == action && entry.Resource == audit.DoguResource(doguName) && errors.Is(entry.Err, err) && !entry.Started.IsZero()
+	}
+}
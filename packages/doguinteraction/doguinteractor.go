@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	common "github.com/cloudogu/ces-commons-lib/dogu"
@@ -15,7 +16,24 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 )
 
-var waitTimeout = time.Minute * 10
+var (
+	waitTimeout      = time.Minute * 10
+	waitTimeoutMutex sync.RWMutex
+)
+
+// SetWaitTimeout sets the time to wait for a dogu to be started or stopped. It may be called while dogus are
+// started or stopped and affects only the subsequent waits.
+func SetWaitTimeout(timeout time.Duration) {
+	waitTimeoutMutex.Lock()
+	defer waitTimeoutMutex.Unlock()
+	waitTimeout = timeout
+}
+
+func currentWaitTimeout() time.Duration {
+	waitTimeoutMutex.RLock()
+	defer waitTimeoutMutex.RUnlock()
+	return waitTimeout
+}
 
 const (
 	doguConfigKeyLogLevel = "logging/root"
@@ -130,7 +148,8 @@ func (ddi *defaultDoguInterActor) startStopDogu(ctx context.Context, doguName st
 }
 
 func (ddi *defaultDoguInterActor) waitForDoguStartStop(ctx context.Context, doguName string) error {
-	timeoutCtx, cancelTimeoutCtx := context.WithTimeoutCause(ctx, waitTimeout, fmt.Errorf("timout (%v) reached waiting for dogu %s", waitTimeout, doguName))
+	timeout := currentWaitTimeout()
+	timeoutCtx, cancelTimeoutCtx := context.WithTimeoutCause(ctx, timeout, fmt.Errorf("timout (%v) reached waiting for dogu %s", timeout, doguName))
 	defer cancelTimeoutCtx()
 
 	watchOptions := metav1.ListOptions{
@@ -192,16 +211,33 @@ func (ddi *defaultDoguInterActor) SetLogLevelInAllDogus(ctx context.Context, log
 		return fmt.Errorf("error getting all dogus while setting log-level: %w", err)
 	}
 
-	var multiError error
+	doguNames := make([]string, 0, len(allDogus))
 	for _, dogu := range allDogus {
-		doguConfig, _ := ddi.doguConfigRepository.Get(ctx, common.SimpleName(dogu.GetSimpleName()))
+		doguNames = append(doguNames, dogu.GetSimpleName())
+	}
+
+	return ddi.SetLogLevelInDogus(ctx, logLevel, doguNames)
+}
+
+// SetLogLevelInDogus sets the specified log level to the given dogus. Errors of single dogus do not abort the other
+// dogus.
+func (ddi *defaultDoguInterActor) SetLogLevelInDogus(ctx context.Context, logLevel string, doguNames []string) error {
+	var multiError error
+	for _, doguName := range doguNames {
+		doguConfig, err := ddi.doguConfigRepository.Get(ctx, common.SimpleName(doguName))
+		if err != nil {
+			multiError = errors.Join(multiError, fmt.Errorf("failed to get config of dogu %s: %w", doguName, err))
+			continue
+		}
+
 		newConfig, err := doguConfig.Set(doguConfigKeyLogLevel, config.Value(logLevel))
 		if err != nil {
 			multiError = errors.Join(multiError, err)
+			continue
 		}
 
 		newDoguConfig := config.DoguConfig{
-			DoguName: common.SimpleName(dogu.GetSimpleName()),
+			DoguName: common.SimpleName(doguName),
 			Config:   newConfig,
 		}
 
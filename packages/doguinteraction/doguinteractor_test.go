@@ -688,6 +688,64 @@ func Test_defaultDoguInterActor_SetLogLevelInAllDogus(t *testing.T) {
 		assert.ErrorIs(t, err, assert.AnError)
 		assert.ErrorContains(t, err, "error getting all dogus while setting log-level:")
 	})
+
+	t.Run("should continue with other dogus on error getting dogu config", func(t *testing.T) {
+		// given
+		doguRegistryMock := newMockDoguDescriptorGetter(t)
+		doguRegistryMock.EXPECT().GetCurrentOfAll(testCtx).Return(
+			[]*core.Dogu{
+				{Name: "official/postgresql"},
+				{Name: "official/redmine"},
+			},
+			nil,
+		)
+
+		doguConfigRepositoryMock := newMockDoguConfigRepository(t)
+		doguConfigB := config.CreateDoguConfig("redmine", config.Entries{})
+		doguConfigRepositoryMock.EXPECT().Get(context.TODO(), common.SimpleName("postgresql")).Return(config.DoguConfig{}, assert.AnError)
+		doguConfigRepositoryMock.EXPECT().Get(context.TODO(), common.SimpleName("redmine")).Return(doguConfigB, nil)
+		doguConfigRepositoryMock.EXPECT().Update(context.TODO(), mock.Anything).RunAndReturn(func(ctx context.Context, doguConfig config.DoguConfig) (config.DoguConfig, error) {
+			assert.Equal(t, common.SimpleName("redmine"), doguConfig.DoguName)
+			return doguConfig, nil
+		}).Once()
+
+		sut := defaultDoguInterActor{
+			doguConfigRepository: doguConfigRepositoryMock,
+			doguDescriptorGetter: doguRegistryMock,
+		}
+
+		// when
+		err := sut.SetLogLevelInAllDogus(testCtx, "DEBUG")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get config of dogu postgresql")
+	})
+}
+
+func Test_defaultDoguInterActor_SetLogLevelInDogus(t *testing.T) {
+	t.Run("should set the log level of the given dogus only", func(t *testing.T) {
+		// given
+		doguConfigRepositoryMock := newMockDoguConfigRepository(t)
+		doguConfig := config.CreateDoguConfig("redmine", config.Entries{"logging/root": "WARN"})
+		doguConfigRepositoryMock.EXPECT().Get(context.TODO(), common.SimpleName("redmine")).Return(doguConfig, nil)
+		doguConfigRepositoryMock.EXPECT().Update(context.TODO(), mock.Anything).RunAndReturn(func(ctx context.Context, doguConfig config.DoguConfig) (config.DoguConfig, error) {
+			get, b := doguConfig.Get("logging/root")
+			require.True(t, b)
+			assert.Equal(t, common.SimpleName("redmine"), doguConfig.DoguName)
+			assert.Equal(t, "TRACE", get.String())
+			return doguConfig, nil
+		}).Once()
+
+		sut := defaultDoguInterActor{doguConfigRepository: doguConfigRepositoryMock}
+
+		// when
+		err := sut.SetLogLevelInDogus(testCtx, "TRACE", []string{"redmine"})
+
+		// then
+		require.NoError(t, err)
+	})
 }
 
 func Test_defaultDoguInterActor_checkIfDoguInDesiredStopState(t *testing.T) {
@@ -749,3 +807,15 @@ func Test_defaultDoguInterActor_checkIfDoguInDesiredStopState(t *testing.T) {
 		assert.False(t, isInDesiredState)
 	})
 }
+
+func TestSetWaitTimeout(t *testing.T) {
+	// given
+	oldWaitTimeout := currentWaitTimeout()
+	defer SetWaitTimeout(oldWaitTimeout)
+
+	// when
+	SetWaitTimeout(time.Minute * 3)
+
+	// then
+	assert.Equal(t, time.Minute*3, currentWaitTimeout())
+}
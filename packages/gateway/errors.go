@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorResponse is the json body of failed requests.
+type errorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// writeError writes the grpc status of the given error as json with the corresponding http status code. Errors
+// without a grpc status are written as internal server errors.
+func writeError(writer http.ResponseWriter, err error) {
+	s := status.Convert(err)
+
+	body, marshalErr := json.Marshal(errorResponse{Code: s.Code().String(), Message: s.Message()})
+	if marshalErr != nil {
+		logrus.Errorf("failed to marshal gateway error: %v", marshalErr)
+	}
+
+	writer.Header().Set("Content-Type", contentTypeJSON)
+	writer.WriteHeader(httpStatus(s.Code()))
+	_, writeErr := writer.Write(body)
+	if writeErr != nil {
+		logrus.Errorf("failed to write gateway error: %v", writeErr)
+	}
+}
+
+// httpStatus maps grpc status codes to http status codes like the grpc-gateway does.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,262 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const authorizationHeader = "authorization"
+
+type unaryMethod struct {
+	impl    any
+	handler grpc.MethodHandler
+}
+
+type streamMethod struct {
+	impl    any
+	handler grpc.StreamHandler
+}
+
+// Gateway serves the registered grpc services as http/json routes. Request messages are decoded from the json body,
+// the query parameters and the path wildcards of a route. Every call passes the same interceptors as the corresponding
+// grpc call, so that it is e.g. authorized and measured alike.
+type Gateway struct {
+	unaryInterceptor  grpc.UnaryServerInterceptor
+	streamInterceptor grpc.StreamServerInterceptor
+	mux               *http.ServeMux
+
+	unaryMethods  map[string]unaryMethod
+	streamMethods map[string]streamMethod
+}
+
+// NewGateway creates a new Gateway. The interceptors are chained in the given order like grpc.ChainUnaryInterceptor
+// and grpc.ChainStreamInterceptor chain them for the grpc server.
+func NewGateway(unaryInterceptors []grpc.UnaryServerInterceptor, streamInterceptors []grpc.StreamServerInterceptor) *Gateway {
+	return newGateway(unaryInterceptors, streamInterceptors, defaultRoutes)
+}
+
+func newGateway(unaryInterceptors []grpc.UnaryServerInterceptor, streamInterceptors []grpc.StreamServerInterceptor, routes []route) *Gateway {
+	g := &Gateway{
+		unaryInterceptor:  chainUnaryInterceptors(unaryInterceptors),
+		streamInterceptor: chainStreamInterceptors(streamInterceptors),
+		mux:               http.NewServeMux(),
+		unaryMethods:      map[string]unaryMethod{},
+		streamMethods:     map[string]streamMethod{},
+	}
+
+	for _, r := range routes {
+		g.mux.HandleFunc(r.pattern, func(writer http.ResponseWriter, request *http.Request) {
+			g.handle(writer, request, r)
+		})
+	}
+
+	return g
+}
+
+// RegisterService registers the methods of a grpc service so that they can be called via their routes. Like
+// grpc.Server.RegisterService, it must be called before the gateway serves requests.
+func (g *Gateway) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	for _, method := range desc.Methods {
+		g.unaryMethods[fullMethodName(desc.ServiceName, method.MethodName)] = unaryMethod{impl: impl, handler: method.Handler}
+	}
+
+	for _, stream := range desc.Streams {
+		if stream.ClientStreams {
+			// client streams cannot be mapped to a single http request
+			continue
+		}
+		g.streamMethods[fullMethodName(desc.ServiceName, stream.StreamName)] = streamMethod{impl: impl, handler: stream.Handler}
+	}
+}
+
+// ServeHTTP dispatches the request to the route matching its method and path.
+func (g *Gateway) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	g.mux.ServeHTTP(writer, request)
+}
+
+func (g *Gateway) handle(writer http.ResponseWriter, request *http.Request, r route) {
+	ctx := callContext(request, r.fullMethod)
+	decode := func(message any) error {
+		return decodeRequest(request, message)
+	}
+
+	if method, ok := g.unaryMethods[r.fullMethod]; ok {
+		g.handleUnary(ctx, writer, method, decode)
+		return
+	}
+
+	if method, ok := g.streamMethods[r.fullMethod]; ok {
+		g.handleStream(ctx, writer, method, decode, r)
+		return
+	}
+
+	writeError(writer, status.Errorf(codes.Unimplemented, "method %s is not registered", r.fullMethod))
+}
+
+func (g *Gateway) handleUnary(ctx context.Context, writer http.ResponseWriter, method unaryMethod, decode func(any) error) {
+	response, err := method.handler(method.impl, ctx, decode, g.unaryInterceptor)
+	if err != nil {
+		writeError(writer, err)
+		return
+	}
+
+	message, ok := response.(proto.Message)
+	if !ok {
+		writeError(writer, status.Errorf(codes.Internal, "unexpected response type %T", response))
+		return
+	}
+
+	body, err := protojson.Marshal(message)
+	if err != nil {
+		writeError(writer, status.Errorf(codes.Internal, "failed to marshal response: %v", err))
+		return
+	}
+
+	writer.Header().Set("Content-Type", contentTypeJSON)
+	writer.WriteHeader(http.StatusOK)
+	_, err = writer.Write(body)
+	if err != nil {
+		logrus.Errorf("failed to write gateway response: %v", err)
+	}
+}
+
+func (g *Gateway) handleStream(ctx context.Context, writer http.ResponseWriter, method streamMethod, decode func(any) error, r route) {
+	stream := newServerStream(ctx, writer, decode, r.rawContentType)
+	var err error
+	if g.streamInterceptor == nil {
+		err = method.handler(method.impl, stream)
+	} else {
+		info := &grpc.StreamServerInfo{FullMethod: r.fullMethod, IsServerStream: true}
+		err = g.streamInterceptor(method.impl, stream, info, method.handler)
+	}
+	if err == nil {
+		stream.start()
+		return
+	}
+
+	if !stream.started {
+		writeError(writer, err)
+		return
+	}
+
+	// the status code was already sent, so the connection is aborted to let the client notice the incomplete body
+	logrus.Errorf("failed to stream gateway response of %s: %v", r.fullMethod, err)
+	panic(http.ErrAbortHandler)
+}
+
+// chainUnaryInterceptors chains the interceptors so that the first one is the outermost. It returns nil without
+// interceptors, so that the methods are called directly.
+func chainUnaryInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	if len(interceptors) == 0 {
+		return nil
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+
+		return next(ctx, req)
+	}
+}
+
+// chainStreamInterceptors chains the interceptors so that the first one is the outermost. It returns nil without
+// interceptors, so that the methods are called directly.
+func chainStreamInterceptors(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	if len(interceptors) == 0 {
+		return nil
+	}
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(srv any, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, inner)
+			}
+		}
+
+		return next(srv, ss)
+	}
+}
+
+// callContext creates the context of a call like the grpc server would, so that the caller can be authenticated and
+// the called method can be determined for e.g. the audit log.
+func callContext(request *http.Request, fullMethod string) context.Context {
+	ctx := grpc.NewContextWithServerTransportStream(request.Context(), &transportStream{method: fullMethod})
+
+	md := metadata.MD{}
+	if value := request.Header.Get(authorizationHeader); value != "" {
+		md.Set(authorizationHeader, value)
+	}
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	p := &peer.Peer{Addr: remoteAddr(request.RemoteAddr)}
+	if request.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{State: *request.TLS}
+	}
+
+	return peer.NewContext(ctx, p)
+}
+
+func fullMethodName(serviceName string, methodName string) string {
+	return fmt.Sprintf("/%s/%s", serviceName, methodName)
+}
+
+// remoteAddr is the address of the http client.
+type remoteAddr string
+
+// Network returns the network of the address.
+func (a remoteAddr) Network() string {
+	return "tcp"
+}
+
+// String returns the address in the form "host:port".
+func (a remoteAddr) String() string {
+	return string(a)
+}
+
+// transportStream provides the called method to grpc.Method. Headers and trailers are not supported.
+type transportStream struct {
+	method string
+}
+
+// Method returns the fully qualified called method.
+func (s *transportStream) Method() string {
+	return s.method
+}
+
+// SetHeader ignores the given metadata.
+func (s *transportStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+// SendHeader ignores the given metadata.
+func (s *transportStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+// SetTrailer ignores the given metadata.
+func (s *transportStream) SetTrailer(metadata.MD) error {
+	return nil
+}
+
+func isJSONContentType(contentType string) bool {
+	return contentType == "" || strings.HasPrefix(contentType, contentTypeJSON)
+}
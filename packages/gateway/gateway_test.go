@@ -0,0 +1,499 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudogu/k8s-ces-control/packages/auth"
+	"github.com/cloudogu/k8s-ces-control/packages/stream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var testRoutes = []route{
+	{pattern: "POST /echo/{name}", fullMethod: "/test.Service/Echo"},
+	{pattern: "GET /lines", fullMethod: "/test.Service/Lines"},
+	{pattern: "GET /download", fullMethod: "/test.Service/Download", rawContentType: contentTypeZip},
+	{pattern: "GET /unregistered", fullMethod: "/test.Service/Unregistered"},
+}
+
+// testService records the calls of the test service descriptor.
+type testService struct {
+	ctx      context.Context
+	request  *structpb.Struct
+	err      error
+	header   metadata.MD
+	messages []any
+}
+
+func (s *testService) desc() *grpc.ServiceDesc {
+	return &grpc.ServiceDesc{
+		ServiceName: "test.Service",
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Echo",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				request := &structpb.Struct{}
+				if err := dec(request); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return s.echo(ctx, request)
+				}
+				return interceptor(ctx, request, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/test.Service/Echo"}, s.echo)
+			},
+		}},
+		Streams: []grpc.StreamDesc{
+			{StreamName: "Lines", Handler: s.streamHandler, ServerStreams: true},
+			{StreamName: "Download", Handler: s.streamHandler, ServerStreams: true},
+			{StreamName: "Upload", Handler: s.streamHandler, ClientStreams: true},
+		},
+	}
+}
+
+func (s *testService) echo(ctx context.Context, request any) (any, error) {
+	s.ctx = ctx
+	s.request = request.(*structpb.Struct)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return request, nil
+}
+
+func (s *testService) streamHandler(_ any, stream grpc.ServerStream) error {
+	request := &structpb.Struct{}
+	if err := stream.RecvMsg(request); err != nil {
+		return err
+	}
+	s.ctx = stream.Context()
+	s.request = request
+
+	if s.header != nil {
+		if err := stream.SetHeader(s.header); err != nil {
+			return err
+		}
+	}
+
+	for _, message := range s.messages {
+		if err := stream.SendMsg(message); err != nil {
+			return err
+		}
+	}
+
+	return s.err
+}
+
+type testChunk []byte
+
+func (c testChunk) GetData() []byte {
+	return c
+}
+
+// testAuthenticator authenticates every caller with the same result.
+type testAuthenticator struct {
+	identity auth.Identity
+	err      error
+	ctx      context.Context
+}
+
+func (a *testAuthenticator) Authenticate(ctx context.Context) (auth.Identity, error) {
+	a.ctx = ctx
+	return a.identity, a.err
+}
+
+func newTestGateway(t *testing.T, authenticator *testAuthenticator, service *testService) *Gateway {
+	t.Helper()
+	var sut *Gateway
+	if authenticator == nil {
+		sut = newGateway(nil, nil, testRoutes)
+	} else {
+		sut = newGateway(
+			[]grpc.UnaryServerInterceptor{auth.UnaryServerInterceptor(authenticator)},
+			[]grpc.StreamServerInterceptor{auth.StreamServerInterceptor(authenticator)},
+			testRoutes,
+		)
+	}
+	sut.RegisterService(service.desc(), service)
+	return sut
+}
+
+func TestNewGateway(t *testing.T) {
+	// when
+	sut := NewGateway(nil, nil)
+
+	// then
+	require.NotNil(t, sut)
+	assert.Nil(t, sut.unaryInterceptor)
+	assert.Nil(t, sut.streamInterceptor)
+}
+
+func TestGateway_RegisterService(t *testing.T) {
+	// given
+	service := &testService{}
+	sut := newGateway(nil, nil, testRoutes)
+
+	// when
+	sut.RegisterService(service.desc(), service)
+
+	// then
+	assert.Contains(t, sut.unaryMethods, "/test.Service/Echo")
+	assert.Contains(t, sut.streamMethods, "/test.Service/Lines")
+	assert.Contains(t, sut.streamMethods, "/test.Service/Download")
+	assert.NotContains(t, sut.streamMethods, "/test.Service/Upload")
+}
+
+func TestGateway_ServeHTTP(t *testing.T) {
+	t.Run("should decode request from body, query and path", func(t *testing.T) {
+		// given
+		service := &testService{}
+		sut := newTestGateway(t, nil, service)
+		request := httptest.NewRequest(http.MethodPost, "/echo/cas?lines=10&verbose=true&tag=a&tag=b", strings.NewReader(`{"name": "ignored", "timer": 15}`))
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"name": "cas", "timer": 15, "lines": "10", "verbose": true, "tag": ["a", "b"]}`, recorder.Body.String())
+		method, ok := grpc.Method(service.ctx)
+		require.True(t, ok)
+		assert.Equal(t, "/test.Service/Echo", method)
+	})
+	t.Run("should map grpc status to http status", func(t *testing.T) {
+		// given
+		service := &testService{err: status.Error(codes.NotFound, "dogu cas not found")}
+		sut := newTestGateway(t, nil, service)
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/echo/cas", nil))
+
+		// then
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.JSONEq(t, `{"code": "NotFound", "message": "dogu cas not found"}`, recorder.Body.String())
+	})
+	t.Run("should fail for invalid body", func(t *testing.T) {
+		// given
+		service := &testService{}
+		sut := newTestGateway(t, nil, service)
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/echo/cas", strings.NewReader(`[1, 2]`)))
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "body must be a json object")
+		assert.Nil(t, service.request)
+	})
+	t.Run("should fail for unsupported content type", func(t *testing.T) {
+		// given
+		service := &testService{}
+		sut := newTestGateway(t, nil, service)
+		request := httptest.NewRequest(http.MethodPost, "/echo/cas", strings.NewReader(`name=cas`))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "unsupported content type")
+	})
+	t.Run("should fail for unregistered method", func(t *testing.T) {
+		// given
+		sut := newTestGateway(t, nil, &testService{})
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/unregistered", nil))
+
+		// then
+		assert.Equal(t, http.StatusNotImplemented, recorder.Code)
+	})
+	t.Run("should fail for unknown route", func(t *testing.T) {
+		// given
+		sut := newTestGateway(t, nil, &testService{})
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+		// then
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestGateway_ServeHTTP_auth(t *testing.T) {
+	hasBearerToken := func(ctx context.Context) bool {
+		md, ok := metadata.FromIncomingContext(ctx)
+		return ok && len(md.Get("authorization")) == 1 && md.Get("authorization")[0] == "Bearer secret"
+	}
+
+	t.Run("should reject unauthenticated caller", func(t *testing.T) {
+		// given
+		service := &testService{}
+		sut := newTestGateway(t, &testAuthenticator{err: assert.AnError}, service)
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/echo/cas", nil))
+
+		// then
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "Unauthenticated")
+		assert.Nil(t, service.request)
+	})
+	t.Run("should reject caller without required role", func(t *testing.T) {
+		// given
+		authenticator := &testAuthenticator{identity: auth.Identity{Name: "script", Role: auth.RoleViewer}}
+		service := &testService{}
+		sut := newTestGateway(t, authenticator, service)
+		request := httptest.NewRequest(http.MethodPost, "/echo/cas", nil)
+		request.Header.Set("Authorization", "Bearer secret")
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.True(t, hasBearerToken(authenticator.ctx))
+		assert.Nil(t, service.request)
+	})
+	t.Run("should pass identity of authorized caller", func(t *testing.T) {
+		// given
+		authenticator := &testAuthenticator{identity: auth.Identity{Name: "script", Role: auth.RoleAdmin}}
+		service := &testService{}
+		sut := newTestGateway(t, authenticator, service)
+		request := httptest.NewRequest(http.MethodPost, "/echo/cas", nil)
+		request.Header.Set("Authorization", "Bearer secret")
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.True(t, hasBearerToken(authenticator.ctx))
+		identity, ok := auth.IdentityFromContext(service.ctx)
+		require.True(t, ok)
+		assert.Equal(t, "script", identity.Name)
+	})
+	t.Run("should reject unauthenticated caller of a stream", func(t *testing.T) {
+		// given
+		service := &testService{}
+		sut := newTestGateway(t, &testAuthenticator{err: assert.AnError}, service)
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/lines", nil))
+
+		// then
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Nil(t, service.request)
+	})
+}
+
+func TestGateway_ServeHTTP_interceptors(t *testing.T) {
+	t.Run("should call the unary interceptors in the given order", func(t *testing.T) {
+		// given
+		var calls []string
+		interceptor := func(name string) grpc.UnaryServerInterceptor {
+			return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				calls = append(calls, name+" "+info.FullMethod)
+				return handler(ctx, req)
+			}
+		}
+		service := &testService{}
+		sut := newGateway([]grpc.UnaryServerInterceptor{interceptor("first"), interceptor("second")}, nil, testRoutes)
+		sut.RegisterService(service.desc(), service)
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/echo/cas", nil))
+
+		// then
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, []string{"first /test.Service/Echo", "second /test.Service/Echo"}, calls)
+		assert.NotNil(t, service.request)
+	})
+	t.Run("should call the stream interceptors in the given order and pass their error", func(t *testing.T) {
+		// given
+		var calls []string
+		interceptor := func(name string) grpc.StreamServerInterceptor {
+			return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				calls = append(calls, name+" "+info.FullMethod)
+				err := handler(srv, ss)
+				calls = append(calls, name+" "+status.Code(err).String())
+				return err
+			}
+		}
+		service := &testService{err: status.Error(codes.NotFound, "missing")}
+		sut := newGateway(nil, []grpc.StreamServerInterceptor{interceptor("first"), interceptor("second")}, testRoutes)
+		sut.RegisterService(service.desc(), service)
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/lines", nil))
+
+		// then
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Equal(t, []string{"first /test.Service/Lines", "second /test.Service/Lines", "second NotFound", "first NotFound"}, calls)
+	})
+}
+
+func TestGateway_ServeHTTP_stream(t *testing.T) {
+	t.Run("should write messages as json lines", func(t *testing.T) {
+		// given
+		first, _ := structpb.NewStruct(map[string]any{"message": "first"})
+		second, _ := structpb.NewStruct(map[string]any{"message": "second"})
+		service := &testService{messages: []any{first, second}}
+		sut := newTestGateway(t, nil, service)
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/lines?filter=error", nil))
+
+		// then
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.JSONEq(t, `{"message": "first"}`, lines[0])
+		assert.JSONEq(t, `{"message": "second"}`, lines[1])
+		assert.True(t, recorder.Flushed)
+		assert.Equal(t, "error", service.request.GetFields()["filter"].GetStringValue())
+	})
+	t.Run("should write raw chunks", func(t *testing.T) {
+		// given
+		service := &testService{messages: []any{testChunk("PK"), testChunk("zip")}}
+		sut := newTestGateway(t, nil, service)
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/download", nil))
+
+		// then
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/zip", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "PKzip", recorder.Body.String())
+	})
+	t.Run("should write raw chunks with the content type set by the method", func(t *testing.T) {
+		// given
+		service := &testService{header: metadata.Pairs(stream.ContentTypeHeader, "application/x-ndjson"), messages: []any{testChunk("{}\n")}}
+		sut := newTestGateway(t, nil, service)
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/download?format=NDJSON", nil))
+
+		// then
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "{}\n", recorder.Body.String())
+	})
+	t.Run("should send empty body for empty stream", func(t *testing.T) {
+		// given
+		sut := newTestGateway(t, nil, &testService{})
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/download", nil))
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
+	})
+	t.Run("should write error if stream fails before the first message", func(t *testing.T) {
+		// given
+		service := &testService{err: status.Error(codes.FailedPrecondition, "support archive is not ready yet")}
+		sut := newTestGateway(t, nil, service)
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/download", nil))
+
+		// then
+		assert.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "support archive is not ready yet")
+	})
+	t.Run("should abort response if stream fails after the first message", func(t *testing.T) {
+		// given
+		service := &testService{messages: []any{testChunk("PK")}, err: assert.AnError}
+		sut := newTestGateway(t, nil, service)
+		recorder := httptest.NewRecorder()
+
+		// when
+		serve := func() { sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/download", nil)) }
+
+		// then
+		assert.PanicsWithValue(t, http.ErrAbortHandler, serve)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+	t.Run("should fail for message without chunked data", func(t *testing.T) {
+		// given
+		service := &testService{messages: []any{&structpb.Struct{}}}
+		sut := newTestGateway(t, nil, service)
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/download", nil))
+
+		// then
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "unexpected chunk type")
+	})
+}
+
+func Test_httpStatus(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want int
+	}{
+		{codes.OK, http.StatusOK},
+		{codes.InvalidArgument, http.StatusBadRequest},
+		{codes.NotFound, http.StatusNotFound},
+		{codes.AlreadyExists, http.StatusConflict},
+		{codes.PermissionDenied, http.StatusForbidden},
+		{codes.Unauthenticated, http.StatusUnauthorized},
+		{codes.Unavailable, http.StatusServiceUnavailable},
+		{codes.Unknown, http.StatusInternalServerError},
+		{codes.Internal, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, httpStatus(tt.code))
+		})
+	}
+}
+
+func Test_pathWildcards(t *testing.T) {
+	assert.Equal(t, []string{"doguName"}, pathWildcards("POST /api/v1/dogus/{doguName}/start"))
+	assert.Equal(t, []string{"name", "path"}, pathWildcards("GET /a/{name}/b/{path...}"))
+	assert.Empty(t, pathWildcards("GET /api/v1/dogus"))
+}
+
+func Test_queryValue(t *testing.T) {
+	assert.Equal(t, "cas", queryValue([]string{"cas"}, false))
+	assert.Equal(t, []any{"cas"}, queryValue([]string{"cas"}, true))
+	assert.Equal(t, []any{"cas", "ldap"}, queryValue([]string{"cas", "ldap"}, false))
+	assert.Equal(t, true, queryValue([]string{"true"}, false))
+}
+
+func Test_isListField(t *testing.T) {
+	assert.True(t, isListField(&structpb.ListValue{}, "values"))
+	assert.False(t, isListField(&structpb.Struct{}, "fields"))
+	assert.False(t, isListField(&structpb.ListValue{}, "unknown"))
+}
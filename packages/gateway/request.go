@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxRequestBodySize limits the json body of a request. Requests only contain a few fields.
+const maxRequestBodySize = 1 << 20
+
+// decodeRequest sets the fields of the given request message from the json body, the query parameters and the path
+// wildcards of the request. Path wildcards take precedence over query parameters, which take precedence over the body.
+func decodeRequest(request *http.Request, message any) error {
+	protoMessage, ok := message.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "unexpected request type %T", message)
+	}
+
+	fields, err := readBody(request)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+	}
+
+	for name, values := range request.URL.Query() {
+		fields[name] = queryValue(values, isListField(protoMessage, name))
+	}
+
+	for _, name := range pathWildcards(request.Pattern) {
+		fields[name] = request.PathValue(name)
+	}
+
+	content, err := json.Marshal(fields)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+
+	err = protojson.Unmarshal(content, protoMessage)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+
+	return nil
+}
+
+func readBody(request *http.Request) (map[string]any, error) {
+	fields := map[string]any{}
+	if request.Body == nil {
+		return fields, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(request.Body, maxRequestBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) > maxRequestBodySize {
+		return nil, fmt.Errorf("body exceeds %d bytes", maxRequestBodySize)
+	}
+
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return fields, nil
+	}
+
+	if !isJSONContentType(request.Header.Get("Content-Type")) {
+		return nil, fmt.Errorf("unsupported content type %q", request.Header.Get("Content-Type"))
+	}
+
+	err = json.Unmarshal(body, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("body must be a json object: %w", err)
+	}
+
+	return fields, nil
+}
+
+// queryValue converts the values of a query parameter to json. Repeated parameters and the values of list fields
+// become arrays and the values "true" and "false" become booleans. Numbers are kept as strings because protojson
+// accepts them for numeric fields.
+func queryValue(values []string, list bool) any {
+	converted := make([]any, 0, len(values))
+	for _, value := range values {
+		switch value {
+		case "true":
+			converted = append(converted, true)
+		case "false":
+			converted = append(converted, false)
+		default:
+			converted = append(converted, value)
+		}
+	}
+
+	if len(converted) == 1 && !list {
+		return converted[0]
+	}
+
+	return converted
+}
+
+// isListField returns whether the message has a repeated field with the given json or proto name.
+func isListField(message proto.Message, name string) bool {
+	fields := message.ProtoReflect().Descriptor().Fields()
+	field := fields.ByJSONName(name)
+	if field == nil {
+		field = fields.ByName(protoreflect.Name(name))
+	}
+
+	return field != nil && field.IsList()
+}
+
+// pathWildcards returns the names of the wildcards of the given http.ServeMux pattern.
+func pathWildcards(pattern string) []string {
+	var names []string
+	for _, segment := range strings.Split(pattern, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			names = append(names, strings.TrimSuffix(strings.Trim(segment, "{}"), "..."))
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,68 @@
+package gateway
+
+const (
+	contentTypeJSON   = "application/json"
+	contentTypeNDJSON = "application/x-ndjson"
+	contentTypeZip    = "application/zip"
+)
+
+// route maps an http method and path to a fully qualified grpc method.
+type route struct {
+	// pattern is the http.ServeMux pattern of the route. Path wildcards are set as fields of the request message.
+	pattern string
+	// fullMethod is the fully qualified grpc method called by the route, e.g. "/backup.BackupManagement/AllBackups".
+	fullMethod string
+	// rawContentType is set for streaming methods sending chunked data. The data of the chunks is written to the
+	// response body as is instead of writing one json object per message. The method may replace it with the
+	// stream.ContentTypeHeader, e.g. for the export format of the logs.
+	rawContentType string
+}
+
+var defaultRoutes = []route{
+	// dogu administration
+	{pattern: "GET /api/v1/dogus", fullMethod: "/doguAdministration.DoguAdministration/GetDoguList"},
+	{pattern: "GET /api/v1/blueprint-id", fullMethod: "/doguAdministration.DoguAdministration/GetBlueprintId"},
+	{pattern: "POST /api/v1/dogus/{doguName}/start", fullMethod: "/doguAdministration.DoguAdministration/StartDogu"},
+	{pattern: "POST /api/v1/dogus/{doguName}/stop", fullMethod: "/doguAdministration.DoguAdministration/StopDogu"},
+	{pattern: "POST /api/v1/dogus/{doguName}/restart", fullMethod: "/doguAdministration.DoguAdministration/RestartDogu"},
+
+	// dogu health
+	{pattern: "GET /api/v1/health/dogus", fullMethod: "/health.DoguHealth/GetAll"},
+	{pattern: "POST /api/v1/health/dogus", fullMethod: "/health.DoguHealth/GetByNames"},
+	{pattern: "GET /api/v1/health/dogus/{doguName}", fullMethod: "/health.DoguHealth/GetByName"},
+
+	// logging
+	{pattern: "GET /api/v1/dogus/{doguName}/logs", fullMethod: "/logging.DoguLogMessages/GetForDogu", rawContentType: contentTypeZip},
+	{pattern: "GET /api/v1/dogus/{doguName}/logs/query", fullMethod: "/logging.DoguLogMessages/QueryForDogu"},
+	{pattern: "GET /api/v1/dogus/{doguName}/logs/follow", fullMethod: "/logging.DoguLogMessages/FollowForDogu"},
+	{pattern: "GET /api/v1/logs/query", fullMethod: "/logging.DoguLogMessages/QueryForDogu"},
+	{pattern: "GET /api/v1/dogus/{doguName}/logs/statistics", fullMethod: "/logging.DoguLogMessages/GetLogStatistics"},
+	{pattern: "GET /api/v1/logs/statistics", fullMethod: "/logging.DoguLogMessages/GetLogStatistics"},
+	{pattern: "GET /api/v1/logs/volume", fullMethod: "/logging.DoguLogMessages/GetLogVolumeReport"},
+	{pattern: "PUT /api/v1/dogus/{doguName}/log-level", fullMethod: "/logging.DoguLogMessages/ApplyLogLevelWithRestart"},
+	{pattern: "GET /api/v1/dogus/{doguName}/loggers", fullMethod: "/logging.DoguLogMessages/GetLoggers"},
+	{pattern: "PUT /api/v1/dogus/{doguName}/loggers/{logger...}", fullMethod: "/logging.DoguLogMessages/SetLoggerLevel"},
+	{pattern: "PUT /api/v1/log-levels", fullMethod: "/logging.DoguLogMessages/ApplyLogLevels"},
+
+	// debug mode
+	{pattern: "GET /api/v1/debug-mode", fullMethod: "/maintenance.DebugMode/Status"},
+	{pattern: "GET /api/v1/debug-mode/history", fullMethod: "/maintenance.DebugMode/GetHistory"},
+	{pattern: "POST /api/v1/debug-mode/enable", fullMethod: "/maintenance.DebugMode/Enable"},
+	{pattern: "POST /api/v1/debug-mode/disable", fullMethod: "/maintenance.DebugMode/Disable"},
+
+	// support archives
+	{pattern: "GET /api/v1/support-archives", fullMethod: "/maintenance.SupportArchive/AllSupportArchives"},
+	{pattern: "POST /api/v1/support-archives", fullMethod: "/maintenance.SupportArchive/Create"},
+	{pattern: "GET /api/v1/support-archives/{name}/download", fullMethod: "/maintenance.SupportArchive/DownloadSupportArchive", rawContentType: contentTypeZip},
+	{pattern: "DELETE /api/v1/support-archives/{name}", fullMethod: "/maintenance.SupportArchive/DeleteSupportArchive"},
+
+	// backup
+	{pattern: "GET /api/v1/backups", fullMethod: "/backup.BackupManagement/AllBackups"},
+	{pattern: "POST /api/v1/backups", fullMethod: "/backup.BackupManagement/CreateBackup"},
+	{pattern: "DELETE /api/v1/backups/{name}", fullMethod: "/backup.BackupManagement/DeleteBackup"},
+	{pattern: "GET /api/v1/restores", fullMethod: "/backup.BackupManagement/AllRestores"},
+	{pattern: "POST /api/v1/restores", fullMethod: "/backup.BackupManagement/CreateRestore"},
+	{pattern: "GET /api/v1/backup-schedule", fullMethod: "/backup.BackupManagement/GetSchedule"},
+	{pattern: "PUT /api/v1/backup-schedule", fullMethod: "/backup.BackupManagement/SetSchedule"},
+	{pattern: "GET /api/v1/backup-retention-policy", fullMethod: "/backup.BackupManagement/GetRetentionPolicy"},
+}
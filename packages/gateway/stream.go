@@ -0,0 +1,126 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudogu/k8s-ces-control/packages/stream"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// chunkedData is implemented by messages containing a chunk of a download, e.g. a support archive.
+type chunkedData interface {
+	GetData() []byte
+}
+
+// serverStream writes the messages of a server stream to a chunked http response body. Every message is flushed
+// immediately so that clients receive large downloads and long streams progressively.
+type serverStream struct {
+	ctx            context.Context
+	writer         http.ResponseWriter
+	controller     *http.ResponseController
+	decode         func(any) error
+	rawContentType string
+	started        bool
+}
+
+var _ grpc.ServerStream = &serverStream{}
+
+func newServerStream(ctx context.Context, writer http.ResponseWriter, decode func(any) error, rawContentType string) *serverStream {
+	return &serverStream{
+		ctx:            ctx,
+		writer:         writer,
+		controller:     http.NewResponseController(writer),
+		decode:         decode,
+		rawContentType: rawContentType,
+	}
+}
+
+// Context returns the context of the call.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// RecvMsg decodes the request message from the http request.
+func (s *serverStream) RecvMsg(message any) error {
+	return s.decode(message)
+}
+
+// SendMsg writes the given message to the response body. Chunked data is written as is if the route has a raw content
+// type, every other message is written as a single line of json.
+func (s *serverStream) SendMsg(message any) error {
+	content, err := s.encode(message)
+	if err != nil {
+		return err
+	}
+
+	s.start()
+	_, err = s.writer.Write(content)
+	if err != nil {
+		return err
+	}
+
+	return s.controller.Flush()
+}
+
+func (s *serverStream) encode(message any) ([]byte, error) {
+	if s.rawContentType != "" {
+		chunk, ok := message.(chunkedData)
+		if !ok {
+			return nil, status.Errorf(codes.Internal, "unexpected chunk type %T", message)
+		}
+		return chunk.GetData(), nil
+	}
+
+	protoMessage, ok := message.(proto.Message)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "unexpected response type %T", message)
+	}
+
+	content, err := protojson.Marshal(protoMessage)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal response: %v", err)
+	}
+
+	return append(content, '\n'), nil
+}
+
+// start sends the status code and the content type if they were not sent yet.
+func (s *serverStream) start() {
+	if s.started {
+		return
+	}
+	s.started = true
+
+	contentType := contentTypeNDJSON
+	if s.rawContentType != "" {
+		contentType = s.rawContentType
+	}
+
+	s.writer.Header().Set("Content-Type", contentType)
+	s.writer.WriteHeader(http.StatusOK)
+}
+
+// SetHeader takes the content type of raw chunks from the given metadata, e.g. for a download whose format is chosen
+// by the request. Other grpc headers are not mapped to http headers.
+func (s *serverStream) SetHeader(md metadata.MD) error {
+	contentTypes := md.Get(stream.ContentTypeHeader)
+	if s.rawContentType != "" && !s.started && len(contentTypes) > 0 {
+		s.rawContentType = contentTypes[len(contentTypes)-1]
+	}
+
+	return nil
+}
+
+// SendHeader ignores the given metadata because grpc headers are not mapped to http headers.
+func (s *serverStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+// SetTrailer ignores the given metadata because http trailers are not used.
+func (s *serverStream) SetTrailer(metadata.MD) {}
@@ -0,0 +1,39 @@
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc/health/grpc_health_v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+type prober interface {
+	// Probe returns an error if the checked dependency is not available.
+	Probe(ctx context.Context) error
+}
+
+type statusSetter interface {
+	// SetServingStatus sets the serving status of the given service.
+	SetServingStatus(service string, servingStatus grpc_health_v1.HealthCheckResponse_ServingStatus)
+}
+
+type restClientGetter interface {
+	// RESTClient returns the client which sends the requests to the kubernetes api server.
+	RESTClient() rest.Interface
+}
+
+type configMapLister interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*corev1.ConfigMapList, error)
+}
+
+type httpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type credentialsProvider interface {
+	// Authenticate adds the credentials for the loki gateway or opensearch to the request.
+	Authenticate(req *http.Request)
+}
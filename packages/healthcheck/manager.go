@@ -0,0 +1,127 @@
+package healthcheck
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ReadinessService is the service name used by the kubernetes readiness probe. It is not serving if the kubernetes
+// api server is not reachable.
+const ReadinessService = "readiness"
+
+var (
+	probeInterval = 15 * time.Second
+	probeTimeout  = 5 * time.Second
+)
+
+// Check describes a dependency and the grpc services which cannot work without it.
+type Check struct {
+	// Name identifies the dependency in logs, e.g. "loki".
+	Name string
+	// Prober checks whether the dependency is available.
+	Prober prober
+	// Services contains the names of the grpc services depending on the dependency.
+	Services []string
+}
+
+// Manager periodically probes the dependencies of the grpc services and sets the serving status of each service in
+// the grpc health server. A service is serving if all of its dependencies are available.
+type Manager struct {
+	statusSetter statusSetter
+	checks       []Check
+
+	mutex    sync.Mutex
+	statuses map[string]grpc_health_v1.HealthCheckResponse_ServingStatus
+}
+
+// NewManager creates a new Manager which sets the statuses in the given health server.
+func NewManager(statusSetter statusSetter, checks ...Check) *Manager {
+	return &Manager{
+		statusSetter: statusSetter,
+		checks:       checks,
+		statuses:     map[string]grpc_health_v1.HealthCheckResponse_ServingStatus{},
+	}
+}
+
+// Run probes all dependencies immediately and then periodically until the given context is done.
+func (m *Manager) Run(ctx context.Context) {
+	m.probeAll(ctx)
+
+	ticker := time.NewTicker(probeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.probeAll(ctx)
+		}
+	}
+}
+
+func (m *Manager) probeAll(ctx context.Context) {
+	failedChecks := map[string][]string{}
+	services := []string{}
+
+	for _, check := range m.checks {
+		err := probe(ctx, check.Prober)
+		if err != nil {
+			logrus.Warnf("health check %s failed: %v", check.Name, err)
+		}
+
+		for _, service := range check.Services {
+			if !slices.Contains(services, service) {
+				services = append(services, service)
+			}
+			if err != nil {
+				failedChecks[service] = append(failedChecks[service], check.Name)
+			}
+		}
+	}
+
+	// the context is cancelled on shutdown, the health server reports NOT_SERVING on its own then
+	if ctx.Err() != nil {
+		return
+	}
+
+	for _, service := range services {
+		status := grpc_health_v1.HealthCheckResponse_SERVING
+		if len(failedChecks[service]) > 0 {
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+
+		m.setStatus(service, status, failedChecks[service])
+	}
+}
+
+func probe(ctx context.Context, prober prober) error {
+	probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	return prober.Probe(probeCtx)
+}
+
+func (m *Manager) setStatus(service string, status grpc_health_v1.HealthCheckResponse_ServingStatus, failedChecks []string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	previous, known := m.statuses[service]
+	if known && previous == status {
+		return
+	}
+
+	if status == grpc_health_v1.HealthCheckResponse_SERVING {
+		logrus.Infof("service %q is serving", service)
+	} else {
+		logrus.Warnf("service %q is not serving because of the failed health checks %v", service, failedChecks)
+	}
+
+	m.statuses[service] = status
+	m.statusSetter.SetServingStatus(service, status)
+}
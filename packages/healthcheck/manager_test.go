@@ -0,0 +1,129 @@
+package healthcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var testCtx = context.Background()
+
+func TestNewManager(t *testing.T) {
+	// given
+	statusSetterMock := newMockStatusSetter(t)
+	check := Check{Name: "loki", Prober: newMockProber(t), Services: []string{"logging.DoguLogMessages"}}
+
+	// when
+	sut := NewManager(statusSetterMock, check)
+
+	// then
+	assert.Equal(t, statusSetterMock, sut.statusSetter)
+	assert.Equal(t, []Check{check}, sut.checks)
+	assert.NotNil(t, sut.statuses)
+}
+
+func TestManager_probeAll(t *testing.T) {
+	t.Run("should set services of failed checks to not serving", func(t *testing.T) {
+		// given
+		clusterProber := newMockProber(t)
+		clusterProber.EXPECT().Probe(mock.Anything).Return(nil)
+		lokiProber := newMockProber(t)
+		lokiProber.EXPECT().Probe(mock.Anything).Return(assert.AnError)
+
+		statusSetterMock := newMockStatusSetter(t)
+		statusSetterMock.EXPECT().SetServingStatus("backup.BackupManagement", grpc_health_v1.HealthCheckResponse_SERVING).Return()
+		statusSetterMock.EXPECT().SetServingStatus("logging.DoguLogMessages", grpc_health_v1.HealthCheckResponse_NOT_SERVING).Return()
+
+		sut := NewManager(statusSetterMock,
+			Check{Name: "cluster", Prober: clusterProber, Services: []string{"backup.BackupManagement", "logging.DoguLogMessages"}},
+			Check{Name: "loki", Prober: lokiProber, Services: []string{"logging.DoguLogMessages"}},
+		)
+
+		// when
+		sut.probeAll(testCtx)
+
+		// then
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, sut.statuses["logging.DoguLogMessages"])
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, sut.statuses["backup.BackupManagement"])
+	})
+	t.Run("should only set changed statuses", func(t *testing.T) {
+		// given
+		lokiProber := newMockProber(t)
+		lokiProber.EXPECT().Probe(mock.Anything).Return(assert.AnError).Once()
+		lokiProber.EXPECT().Probe(mock.Anything).Return(nil).Twice()
+
+		statusSetterMock := newMockStatusSetter(t)
+		statusSetterMock.EXPECT().SetServingStatus("logging.DoguLogMessages", grpc_health_v1.HealthCheckResponse_NOT_SERVING).Return().Once()
+		statusSetterMock.EXPECT().SetServingStatus("logging.DoguLogMessages", grpc_health_v1.HealthCheckResponse_SERVING).Return().Once()
+
+		sut := NewManager(statusSetterMock, Check{Name: "loki", Prober: lokiProber, Services: []string{"logging.DoguLogMessages"}})
+
+		// when
+		sut.probeAll(testCtx)
+		sut.probeAll(testCtx)
+		sut.probeAll(testCtx)
+
+		// then
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, sut.statuses["logging.DoguLogMessages"])
+	})
+	t.Run("should not set statuses after the context is cancelled", func(t *testing.T) {
+		// given
+		ctx, cancel := context.WithCancel(testCtx)
+		cancel()
+		lokiProber := newMockProber(t)
+		lokiProber.EXPECT().Probe(mock.Anything).Return(context.Canceled)
+
+		sut := NewManager(newMockStatusSetter(t), Check{Name: "loki", Prober: lokiProber, Services: []string{"logging.DoguLogMessages"}})
+
+		// when
+		sut.probeAll(ctx)
+
+		// then
+		assert.Empty(t, sut.statuses)
+	})
+}
+
+func TestManager_Run(t *testing.T) {
+	t.Run("should probe until the context is cancelled", func(t *testing.T) {
+		// given
+		oldProbeInterval := probeInterval
+		probeInterval = time.Millisecond
+		defer func() { probeInterval = oldProbeInterval }()
+
+		ctx, cancel := context.WithCancel(testCtx)
+		probed := make(chan struct{}, 10)
+		clusterProber := newMockProber(t)
+		clusterProber.EXPECT().Probe(mock.Anything).RunAndReturn(func(context.Context) error {
+			select {
+			case probed <- struct{}{}:
+			default:
+			}
+			return nil
+		})
+		statusSetterMock := newMockStatusSetter(t)
+		statusSetterMock.EXPECT().SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING).Return().Once()
+
+		sut := NewManager(statusSetterMock, Check{Name: "cluster", Prober: clusterProber, Services: []string{""}})
+
+		// when
+		done := make(chan struct{})
+		go func() {
+			sut.Run(ctx)
+			close(done)
+		}()
+		<-probed
+		<-probed
+		cancel()
+
+		// then
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("manager did not stop after the context was cancelled")
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const localDoguRegistrySelector = "app=ces,k8s.cloudogu.com/type=local-dogu-registry"
+
+// KubernetesProber checks whether the kubernetes api server is reachable.
+type KubernetesProber struct {
+	client restClientGetter
+}
+
+// NewKubernetesProber creates a new KubernetesProber. The probe is limited by the timeout of the context passed to
+// Probe.
+func NewKubernetesProber(client restClientGetter) *KubernetesProber {
+	return &KubernetesProber{client: client}
+}
+
+// Probe requests the version of the api server.
+func (p *KubernetesProber) Probe(ctx context.Context) error {
+	err := p.client.RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+	if err != nil {
+		return fmt.Errorf("kubernetes api server is not reachable: %w", err)
+	}
+
+	return nil
+}
+
+// LokiProber checks whether the loki gateway is ready to serve queries.
+type LokiProber struct {
+	gatewayUrl  string
+	credentials credentialsProvider
+	httpClient  httpClient
+}
+
+// NewLokiProber creates a new LokiProber for the given loki gateway. The probe is limited by the timeout of the
+// context passed to Probe.
+func NewLokiProber(gatewayUrl string, credentials credentialsProvider, httpClient *http.Client) *LokiProber {
+	return &LokiProber{
+		gatewayUrl:  gatewayUrl,
+		credentials: credentials,
+		httpClient:  httpClient,
+	}
+}
+
+// Probe calls the ready endpoint of loki.
+func (p *LokiProber) Probe(ctx context.Context) error {
+	readyUrl := strings.TrimSuffix(p.gatewayUrl, "/") + "/ready"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, readyUrl, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create loki ready request: %w", err)
+	}
+	p.credentials.Authenticate(req)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("loki gateway is not reachable: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("loki is not ready: status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// OpenSearchProber checks whether the OpenSearch cluster is able to serve searches.
+type OpenSearchProber struct {
+	url         string
+	credentials credentialsProvider
+	httpClient  httpClient
+}
+
+// NewOpenSearchProber creates a new OpenSearchProber for the given OpenSearch or Elasticsearch compatible endpoint.
+// The probe is limited by the timeout of the context passed to Probe.
+func NewOpenSearchProber(url string, credentials credentialsProvider, httpClient *http.Client) *OpenSearchProber {
+	return &OpenSearchProber{
+		url:         url,
+		credentials: credentials,
+		httpClient:  httpClient,
+	}
+}
+
+// Probe requests the cluster health. A red cluster fails the probe because not all indices can be searched.
+func (p *OpenSearchProber) Probe(ctx context.Context) error {
+	healthUrl := strings.TrimSuffix(p.url, "/") + "/_cluster/health"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthUrl, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create opensearch health request: %w", err)
+	}
+	p.credentials.Authenticate(req)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("opensearch is not reachable: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("opensearch is not ready: status code %d", resp.StatusCode)
+	}
+
+	health := struct {
+		Status string `json:"status"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(&health)
+	if err != nil {
+		return fmt.Errorf("failed to parse opensearch cluster health: %w", err)
+	}
+
+	if health.Status == "red" {
+		return fmt.Errorf("opensearch is not ready: cluster status %s", health.Status)
+	}
+
+	return nil
+}
+
+// RegistryProber checks whether the configmaps of the local dogu registry can be read.
+type RegistryProber struct {
+	configMaps configMapLister
+}
+
+// NewRegistryProber creates a new RegistryProber.
+func NewRegistryProber(configMaps configMapLister) *RegistryProber {
+	return &RegistryProber{configMaps: configMaps}
+}
+
+// Probe lists the configmaps of the local dogu registry. An empty registry is not an error because a system
+// without dogus is valid.
+func (p *RegistryProber) Probe(ctx context.Context) error {
+	_, err := p.configMaps.List(ctx, metav1.ListOptions{LabelSelector: localDoguRegistrySelector, Limit: 1})
+	if err != nil {
+		return fmt.Errorf("failed to read local dogu registry: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+func TestKubernetesProber_Probe(t *testing.T) {
+	restClientFor := func(t *testing.T, server *httptest.Server) *mockRestClientGetter {
+		clientMock := newMockRestClientGetter(t)
+		clientMock.EXPECT().RESTClient().Return(discovery.NewDiscoveryClientForConfigOrDie(&rest.Config{Host: server.URL}).RESTClient())
+		return clientMock
+	}
+
+	t.Run("should succeed if api server is reachable", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/version", r.URL.Path)
+			_, _ = w.Write([]byte(`{"gitVersion":"v1.35.1"}`))
+		}))
+		defer server.Close()
+
+		// when
+		err := NewKubernetesProber(restClientFor(t, server)).Probe(testCtx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail if api server is not reachable", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		// when
+		err := NewKubernetesProber(restClientFor(t, server)).Probe(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "kubernetes api server is not reachable")
+	})
+	t.Run("should fail if api server does not answer within the timeout", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+		ctx, cancel := context.WithTimeout(testCtx, 50*time.Millisecond)
+		defer cancel()
+
+		// when
+		err := NewKubernetesProber(restClientFor(t, server)).Probe(ctx)
+
+		// then
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "kubernetes api server is not reachable")
+	})
+}
+
+func TestLokiProber_Probe(t *testing.T) {
+	t.Run("should succeed if loki is ready", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "user", username)
+			assert.Equal(t, "secret", password)
+			assert.Equal(t, "/ready", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		credentialsMock := newMockCredentialsProvider(t)
+		credentialsMock.EXPECT().Authenticate(mock.Anything).Run(func(req *http.Request) {
+			req.SetBasicAuth("user", "secret")
+		})
+
+		// when
+		err := NewLokiProber(server.URL+"/", credentialsMock, http.DefaultClient).Probe(testCtx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail if loki is not ready", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		credentialsMock := newMockCredentialsProvider(t)
+		credentialsMock.EXPECT().Authenticate(mock.Anything)
+
+		// when
+		err := NewLokiProber(server.URL, credentialsMock, http.DefaultClient).Probe(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "loki is not ready: status code 503")
+	})
+	t.Run("should fail if loki gateway is not reachable", func(t *testing.T) {
+		// given
+		httpClientMock := newMockHttpClient(t)
+		httpClientMock.EXPECT().Do(mock.Anything).Return(nil, assert.AnError)
+		credentialsMock := newMockCredentialsProvider(t)
+		credentialsMock.EXPECT().Authenticate(mock.Anything)
+		sut := NewLokiProber("http://loki", credentialsMock, http.DefaultClient)
+		sut.httpClient = httpClientMock
+
+		// when
+		err := sut.Probe(testCtx)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "loki gateway is not reachable")
+	})
+}
+
+func TestOpenSearchProber_Probe(t *testing.T) {
+	t.Run("should succeed if the cluster is yellow", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "user", username)
+			assert.Equal(t, "secret", password)
+			assert.Equal(t, "/_cluster/health", r.URL.Path)
+			_, _ = w.Write([]byte(`{"cluster_name":"logs","status":"yellow"}`))
+		}))
+		defer server.Close()
+
+		credentialsMock := newMockCredentialsProvider(t)
+		credentialsMock.EXPECT().Authenticate(mock.Anything).Run(func(req *http.Request) {
+			req.SetBasicAuth("user", "secret")
+		})
+
+		// when
+		err := NewOpenSearchProber(server.URL+"/", credentialsMock, http.DefaultClient).Probe(testCtx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail if the cluster is red", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"cluster_name":"logs","status":"red"}`))
+		}))
+		defer server.Close()
+
+		credentialsMock := newMockCredentialsProvider(t)
+		credentialsMock.EXPECT().Authenticate(mock.Anything)
+
+		// when
+		err := NewOpenSearchProber(server.URL, credentialsMock, http.DefaultClient).Probe(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "opensearch is not ready: cluster status red")
+	})
+	t.Run("should fail for an error response", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		credentialsMock := newMockCredentialsProvider(t)
+		credentialsMock.EXPECT().Authenticate(mock.Anything)
+
+		// when
+		err := NewOpenSearchProber(server.URL, credentialsMock, http.DefaultClient).Probe(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "opensearch is not ready: status code 401")
+	})
+	t.Run("should fail if opensearch is not reachable", func(t *testing.T) {
+		// given
+		httpClientMock := newMockHttpClient(t)
+		httpClientMock.EXPECT().Do(mock.Anything).Return(nil, assert.AnError)
+		credentialsMock := newMockCredentialsProvider(t)
+		credentialsMock.EXPECT().Authenticate(mock.Anything)
+		sut := NewOpenSearchProber("http://opensearch:9200", credentialsMock, http.DefaultClient)
+		sut.httpClient = httpClientMock
+
+		// when
+		err := sut.Probe(testCtx)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "opensearch is not reachable")
+	})
+}
+
+func TestRegistryProber_Probe(t *testing.T) {
+	t.Run("should succeed if registry can be read", func(t *testing.T) {
+		// given
+		configMapMock := newMockConfigMapLister(t)
+		configMapMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: "app=ces,k8s.cloudogu.com/type=local-dogu-registry", Limit: 1}).Return(&corev1.ConfigMapList{}, nil)
+
+		// when
+		err := NewRegistryProber(configMapMock).Probe(testCtx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail if registry cannot be read", func(t *testing.T) {
+		// given
+		configMapMock := newMockConfigMapLister(t)
+		configMapMock.EXPECT().List(testCtx, mock.Anything).Return(nil, assert.AnError)
+
+		// when
+		err := NewRegistryProber(configMapMock).Probe(testCtx)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to read local dogu registry")
+	})
+}
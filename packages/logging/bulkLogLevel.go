@@ -0,0 +1,221 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/cloudogu/ces-control-api/generated/logging"
+	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// defaultRestartParallelism is the number of dogus restarted at the same time if the request does not specify it.
+	defaultRestartParallelism = 2
+	maxRestartParallelism     = 10
+)
+
+type doguLogMessagesApplyLogLevelsServer interface {
+	pb.DoguLogMessages_ApplyLogLevelsServer
+}
+
+// ApplyLogLevels sets the log level of several or all dogus and restarts the dogus whose level was changed. The dogus
+// are restarted in the order of their dependencies, so that a dogu is restarted after the dogus it depends on, and at
+// most the requested number of dogus is restarted at the same time. The progress of every dogu is sent to the client.
+// Errors of single dogus are sent as progress and do not abort the other dogus. Dogus which cannot be ordered by their
+// dependencies are not restarted and sent as failed.
+func (s *loggingService) ApplyLogLevels(request *pb.BulkLogLevelRequest, server pb.DoguLogMessages_ApplyLogLevelsServer) (err error) {
+	ctx := server.Context()
+
+	lLevel, err := CreateLogLevelFromProto(request.GetLogLevel())
+	if err != nil {
+		return createInternalErr(fmt.Errorf("unable to map log level from proto message: %w", err), codes.InvalidArgument)
+	}
+
+	parallelism, err := restartParallelismFromProto(request.GetParallelism())
+	if err != nil {
+		return createInternalErr(err, codes.InvalidArgument)
+	}
+
+	doguNames, err := s.queryDoguNames(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	started := time.Now()
+	progress := &logLevelProgress{server: server}
+	defer func() {
+		s.auditLogger.Record(ctx, audit.Entry{
+			Action:   "ApplyLogLevels",
+			Resource: audit.Resource{Kind: audit.KindDogu},
+			Parameters: map[string]string{
+				"dogus":       strings.Join(doguNames, ","),
+				"logLevel":    lLevel.String(),
+				"parallelism": strconv.Itoa(parallelism),
+			},
+			Started: started,
+			Err:     errors.Join(progress.failures, err),
+		})
+	}()
+
+	var changedDogus []string
+	for _, doguName := range doguNames {
+		change, lErr := s.setLoggerLevel(ctx, doguName, rootLoggerName, lLevel.String())
+		if lErr != nil {
+			progress.fail(doguName, fmt.Errorf("unable to set log level of dogu %s: %w", doguName, lErr))
+			continue
+		}
+
+		switch change {
+		case levelUnchanged:
+			progress.send(doguName, pb.LogLevelProgressState_UNCHANGED)
+		case levelChangedStopped:
+			progress.send(doguName, pb.LogLevelProgressState_STOPPED)
+		default:
+			progress.send(doguName, pb.LogLevelProgressState_CHANGED)
+			changedDogus = append(changedDogus, doguName)
+		}
+	}
+
+	for _, wave := range s.restartWaves(ctx, changedDogus, progress) {
+		s.restartDogus(ctx, wave, parallelism, progress)
+	}
+
+	if progress.sendErr != nil {
+		err = fmt.Errorf("failed to send log level progress: %w", progress.sendErr)
+		return createInternalErr(err, codes.Internal)
+	}
+
+	return nil
+}
+
+func restartParallelismFromProto(parallelism int32) (int, error) {
+	if parallelism == 0 {
+		return defaultRestartParallelism, nil
+	}
+
+	if parallelism < 0 || parallelism > maxRestartParallelism {
+		return 0, fmt.Errorf("parallelism %d must be between 0 and %d", parallelism, maxRestartParallelism)
+	}
+
+	return int(parallelism), nil
+}
+
+// restartWaves groups the dogus into waves which are restarted one after another. Every dogu is in the wave after the
+// last wave containing one of its dependencies, so that the dogus of a wave can be restarted at the same time. Dogus
+// which cannot be ordered by their dependencies are reported as failed instead of being restarted out of order.
+func (s *loggingService) restartWaves(ctx context.Context, doguNames []string, progress *logLevelProgress) [][]string {
+	dogus := make([]*core.Dogu, 0, len(doguNames))
+	for _, doguName := range doguNames {
+		dogu, err := s.doguDescriptorGetter.GetCurrent(ctx, doguName)
+		if err != nil {
+			progress.fail(doguName, fmt.Errorf("unable to get dogu description of dogu %s to restart it after its dependencies: %w", doguName, err))
+			continue
+		}
+		dogus = append(dogus, dogu)
+	}
+
+	waves, err := groupRestartWaves(dogus)
+	if err != nil {
+		for _, dogu := range dogus {
+			progress.fail(dogu.GetSimpleName(), fmt.Errorf("unable to restart dogu %s after its dependencies: %w", dogu.GetSimpleName(), err))
+		}
+		return nil
+	}
+
+	return waves
+}
+
+func groupRestartWaves(dogus []*core.Dogu) ([][]string, error) {
+	sortedDogus, err := core.SortDogusByDependencyWithError(dogus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sort dogus by dependency: %w", err)
+	}
+
+	var waves [][]string
+	doguWaves := map[string]int{}
+	for _, dogu := range sortedDogus {
+		wave := 0
+		for _, dependency := range dogu.GetAllDependenciesOfType(core.DependencyTypeDogu) {
+			if dependencyWave, ok := doguWaves[dependency.Name]; ok {
+				wave = max(wave, dependencyWave+1)
+			}
+		}
+
+		// the dependencies are sorted before the dogu, so the wave is at most one after the last wave
+		if wave == len(waves) {
+			waves = append(waves, nil)
+		}
+		waves[wave] = append(waves[wave], dogu.GetSimpleName())
+		doguWaves[dogu.GetSimpleName()] = wave
+	}
+
+	return waves, nil
+}
+
+// restartDogus restarts the dogus with at most parallelism restarts at the same time and waits until all are restarted.
+// The restarts are not cancelled if the client cancels the call, because the new log levels are written already.
+func (s *loggingService) restartDogus(ctx context.Context, doguNames []string, parallelism int, progress *logLevelProgress) {
+	semaphore := make(chan struct{}, parallelism)
+	var waitGroup sync.WaitGroup
+	for _, doguName := range doguNames {
+		semaphore <- struct{}{}
+		waitGroup.Go(func() {
+			defer func() { <-semaphore }()
+
+			progress.send(doguName, pb.LogLevelProgressState_RESTARTING)
+			err := s.doguRestarter.RestartDoguWithWait(context.WithoutCancel(ctx), doguName, true)
+			if err != nil {
+				progress.fail(doguName, fmt.Errorf("unable to restart dogu %s after setting new log level: %w", doguName, err))
+				return
+			}
+
+			logrus.Debugf("Restarted dogu %s", doguName)
+			progress.send(doguName, pb.LogLevelProgressState_RESTARTED)
+		})
+	}
+	waitGroup.Wait()
+}
+
+// logLevelProgress sends the progress of the dogus to the client. The dogus are restarted concurrently, so sending is
+// synchronized. After the first error sending, nothing is sent anymore but the remaining dogus are still processed.
+type logLevelProgress struct {
+	mutex  sync.Mutex
+	server pb.DoguLogMessages_ApplyLogLevelsServer
+	// sendErr is the first error sending the progress.
+	sendErr error
+	// failures contains the errors of all dogus which failed.
+	failures error
+}
+
+func (p *logLevelProgress) send(doguName string, state pb.LogLevelProgressState) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.sendLocked(&pb.LogLevelProgress{DoguName: doguName, State: state})
+}
+
+func (p *logLevelProgress) fail(doguName string, err error) {
+	logrus.Error(err)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.failures = errors.Join(p.failures, err)
+	p.sendLocked(&pb.LogLevelProgress{DoguName: doguName, State: pb.LogLevelProgressState_FAILED, Error: err.Error()})
+}
+
+func (p *logLevelProgress) sendLocked(progress *pb.LogLevelProgress) {
+	if p.sendErr != nil {
+		return
+	}
+
+	p.sendErr = p.server.Send(progress)
+}
@@ -0,0 +1,345 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	common "github.com/cloudogu/ces-commons-lib/dogu"
+	pb "github.com/cloudogu/ces-control-api/generated/logging"
+	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func bulkTestDogu(name string, dependencies ...string) *core.Dogu {
+	dogu := &core.Dogu{Name: "official/" + name}
+	for _, dependency := range dependencies {
+		dogu.Dependencies = append(dogu.Dependencies, core.Dependency{Type: core.DependencyTypeDogu, Name: dependency})
+	}
+
+	return dogu
+}
+
+// collectProgress expects the progress to be sent to the server and returns the sent progress as "dogu STATE".
+func collectProgress(server *mockDoguLogMessagesApplyLogLevelsServer, sendErr error) *[]string {
+	sent := &[]string{}
+	server.EXPECT().Send(mock.Anything).RunAndReturn(func(progress *pb.LogLevelProgress) error {
+		*sent = append(*sent, progress.GetDoguName()+" "+progress.GetState().String())
+		return sendErr
+	})
+
+	return sent
+}
+
+func TestLoggingService_ApplyLogLevels(t *testing.T) {
+	t.Run("should restart the changed dogus after their dependencies", func(t *testing.T) {
+		// given
+		mockedServer := newMockDoguLogMessagesApplyLogLevelsServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		mockedServer.EXPECT().Context().Return(context.TODO())
+		sent := collectProgress(mockedServer, nil)
+		for _, dogu := range []*core.Dogu{bulkTestDogu("redmine", "postgresql"), bulkTestDogu("postgresql")} {
+			doguName := dogu.GetSimpleName()
+			mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName(doguName)).Return(config.CreateDoguConfig(common.SimpleName(doguName), config.Entries{}), nil)
+			mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), doguName).Return(dogu, nil)
+			mockedDoguGetter.EXPECT().Get(context.TODO(), doguName, metav1.GetOptions{}).Return(&v2.Dogu{}, nil)
+			mockedDoguRestarter.EXPECT().RestartDoguWithWait(mock.Anything, doguName, true).Return(nil)
+		}
+		mockedDoguConfigRepository.EXPECT().Update(context.TODO(), mock.Anything).RunAndReturn(func(_ context.Context, doguConfig config.DoguConfig) (config.DoguConfig, error) {
+			level, ok := doguConfig.Get("logging/root")
+			require.True(t, ok)
+			assert.Equal(t, "DEBUG", level.String())
+
+			return doguConfig, nil
+		}).Times(2)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "ApplyLogLevels" && entry.Parameters["dogus"] == "postgresql,redmine" &&
+				entry.Parameters["logLevel"] == "DEBUG" && entry.Parameters["parallelism"] == "1" && entry.Err == nil
+		})).Return()
+
+		sut := NewLoggingService(nil, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		err := sut.ApplyLogLevels(&pb.BulkLogLevelRequest{DoguNames: []string{"redmine", "postgresql"}, LogLevel: pb.LogLevel_DEBUG, Parallelism: 1}, mockedServer)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"postgresql CHANGED",
+			"redmine CHANGED",
+			"postgresql RESTARTING",
+			"postgresql RESTARTED",
+			"redmine RESTARTING",
+			"redmine RESTARTED",
+		}, *sent)
+	})
+	t.Run("should report unchanged, stopped and failed dogus without restarting them", func(t *testing.T) {
+		// given
+		mockedServer := newMockDoguLogMessagesApplyLogLevelsServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		mockedServer.EXPECT().Context().Return(context.TODO())
+		sent := collectProgress(mockedServer, nil)
+		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("cas")).Return(config.DoguConfig{}, assert.AnError)
+		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("ldap")).Return(config.CreateDoguConfig("ldap", config.Entries{"logging/root": "DEBUG"}), nil)
+		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "ldap").Return(bulkTestDogu("ldap"), nil)
+		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("scm")).Return(config.CreateDoguConfig("scm", config.Entries{}), nil)
+		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "scm").Return(bulkTestDogu("scm"), nil)
+		mockedDoguConfigRepository.EXPECT().Update(context.TODO(), mock.Anything).RunAndReturn(func(_ context.Context, doguConfig config.DoguConfig) (config.DoguConfig, error) {
+			return doguConfig, nil
+		})
+		mockedDoguGetter.EXPECT().Get(context.TODO(), "scm", metav1.GetOptions{}).Return(&v2.Dogu{Spec: v2.DoguSpec{Stopped: true}}, nil)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "ApplyLogLevels" && errors.Is(entry.Err, assert.AnError)
+		})).Return()
+
+		sut := NewLoggingService(nil, mockedDoguConfigRepository, newMockDoguRestarter(t), mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		err := sut.ApplyLogLevels(&pb.BulkLogLevelRequest{DoguName: "scm", DoguNames: []string{"ldap", "cas"}, LogLevel: pb.LogLevel_DEBUG}, mockedServer)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cas FAILED", "ldap UNCHANGED", "scm STOPPED"}, *sent)
+	})
+	t.Run("should report failed restarts and restart the other dogus", func(t *testing.T) {
+		// given
+		mockedServer := newMockDoguLogMessagesApplyLogLevelsServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		mockedServer.EXPECT().Context().Return(context.TODO())
+		var failure *pb.LogLevelProgress
+		mockedServer.EXPECT().Send(mock.Anything).RunAndReturn(func(progress *pb.LogLevelProgress) error {
+			if progress.GetState() == pb.LogLevelProgressState_FAILED {
+				failure = progress
+			}
+			return nil
+		})
+		for _, doguName := range []string{"ldap", "scm"} {
+			mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName(doguName)).Return(config.CreateDoguConfig(common.SimpleName(doguName), config.Entries{}), nil)
+			mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), doguName).Return(bulkTestDogu(doguName), nil)
+			mockedDoguGetter.EXPECT().Get(context.TODO(), doguName, metav1.GetOptions{}).Return(&v2.Dogu{}, nil)
+		}
+		mockedDoguConfigRepository.EXPECT().Update(context.TODO(), mock.Anything).RunAndReturn(func(_ context.Context, doguConfig config.DoguConfig) (config.DoguConfig, error) {
+			return doguConfig, nil
+		})
+		mockedDoguRestarter.EXPECT().RestartDoguWithWait(mock.Anything, "ldap", true).Return(assert.AnError)
+		mockedDoguRestarter.EXPECT().RestartDoguWithWait(mock.Anything, "scm", true).Return(nil)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.MatchedBy(func(entry audit.Entry) bool {
+			return errors.Is(entry.Err, assert.AnError)
+		})).Return()
+
+		sut := NewLoggingService(nil, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		err := sut.ApplyLogLevels(&pb.BulkLogLevelRequest{DoguNames: []string{"ldap", "scm"}, LogLevel: pb.LogLevel_WARN}, mockedServer)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, failure)
+		assert.Equal(t, "ldap", failure.GetDoguName())
+		assert.Contains(t, failure.GetError(), "unable to restart dogu ldap after setting new log level")
+	})
+	t.Run("should report dogus as failed instead of restarting them out of order", func(t *testing.T) {
+		// given
+		mockedServer := newMockDoguLogMessagesApplyLogLevelsServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		mockedServer.EXPECT().Context().Return(context.TODO())
+		var failures []*pb.LogLevelProgress
+		mockedServer.EXPECT().Send(mock.Anything).RunAndReturn(func(progress *pb.LogLevelProgress) error {
+			if progress.GetState() == pb.LogLevelProgressState_FAILED {
+				failures = append(failures, progress)
+			}
+			return nil
+		})
+		for _, doguName := range []string{"ldap", "scm"} {
+			mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName(doguName)).Return(config.CreateDoguConfig(common.SimpleName(doguName), config.Entries{}), nil)
+			mockedDoguGetter.EXPECT().Get(context.TODO(), doguName, metav1.GetOptions{}).Return(&v2.Dogu{}, nil)
+		}
+		mockedDoguConfigRepository.EXPECT().Update(context.TODO(), mock.Anything).RunAndReturn(func(_ context.Context, doguConfig config.DoguConfig) (config.DoguConfig, error) {
+			return doguConfig, nil
+		})
+		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "ldap").Return(nil, assert.AnError)
+		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "scm").Return(bulkTestDogu("scm"), nil)
+		mockedDoguRestarter.EXPECT().RestartDoguWithWait(mock.Anything, "scm", true).Return(nil)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.MatchedBy(func(entry audit.Entry) bool {
+			return errors.Is(entry.Err, assert.AnError)
+		})).Return()
+
+		sut := NewLoggingService(nil, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		err := sut.ApplyLogLevels(&pb.BulkLogLevelRequest{DoguNames: []string{"ldap", "scm"}, LogLevel: pb.LogLevel_WARN}, mockedServer)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, failures, 1)
+		assert.Equal(t, "ldap", failures[0].GetDoguName())
+		assert.Contains(t, failures[0].GetError(), "unable to get dogu description of dogu ldap")
+	})
+	t.Run("should set the level of all dogus and finish them if sending fails", func(t *testing.T) {
+		// given
+		mockedServer := newMockDoguLogMessagesApplyLogLevelsServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		mockedServer.EXPECT().Context().Return(context.TODO())
+		sent := collectProgress(mockedServer, assert.AnError)
+		mockedDoguGetter.EXPECT().List(context.TODO(), metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{{ObjectMeta: metav1.ObjectMeta{Name: "ldap"}}, {ObjectMeta: metav1.ObjectMeta{Name: "scm"}}}}, nil)
+		for _, doguName := range []string{"ldap", "scm"} {
+			mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName(doguName)).Return(config.CreateDoguConfig(common.SimpleName(doguName), config.Entries{}), nil)
+			mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), doguName).Return(bulkTestDogu(doguName), nil)
+			mockedDoguGetter.EXPECT().Get(context.TODO(), doguName, metav1.GetOptions{}).Return(&v2.Dogu{}, nil)
+			mockedDoguRestarter.EXPECT().RestartDoguWithWait(mock.Anything, doguName, true).Return(nil)
+		}
+		mockedDoguConfigRepository.EXPECT().Update(context.TODO(), mock.Anything).RunAndReturn(func(_ context.Context, doguConfig config.DoguConfig) (config.DoguConfig, error) {
+			return doguConfig, nil
+		}).Times(2)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Err != nil && strings.Contains(entry.Err.Error(), "failed to send log level progress")
+		})).Return()
+
+		sut := NewLoggingService(nil, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		err := sut.ApplyLogLevels(&pb.BulkLogLevelRequest{AllDogus: true, LogLevel: pb.LogLevel_ERROR}, mockedServer)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, []string{"ldap CHANGED"}, *sent)
+	})
+	t.Run("should fail for an invalid parallelism", func(t *testing.T) {
+		// given
+		mockedServer := newMockDoguLogMessagesApplyLogLevelsServer(t)
+		mockedServer.EXPECT().Context().Return(context.TODO())
+
+		sut := NewLoggingService(nil, nil, nil, nil, nil, nil)
+
+		// when
+		err := sut.ApplyLogLevels(&pb.BulkLogLevelRequest{DoguName: "ldap", LogLevel: pb.LogLevel_ERROR, Parallelism: 11}, mockedServer)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, "parallelism 11 must be between 0 and 10")
+	})
+	t.Run("should fail without dogus", func(t *testing.T) {
+		// given
+		mockedServer := newMockDoguLogMessagesApplyLogLevelsServer(t)
+		mockedServer.EXPECT().Context().Return(context.TODO())
+
+		sut := NewLoggingService(nil, nil, nil, nil, nil, nil)
+
+		// when
+		err := sut.ApplyLogLevels(&pb.BulkLogLevelRequest{LogLevel: pb.LogLevel_ERROR}, mockedServer)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func Test_groupRestartWaves(t *testing.T) {
+	t.Run("should restart dogus after their dependencies", func(t *testing.T) {
+		// given
+		dogus := []*core.Dogu{
+			bulkTestDogu("redmine", "postgresql", "cas"),
+			bulkTestDogu("cas", "ldap"),
+			bulkTestDogu("scm", "cas", "nginx"),
+			bulkTestDogu("postgresql"),
+			bulkTestDogu("ldap"),
+		}
+
+		// when
+		actual, err := groupRestartWaves(dogus)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, actual, 3)
+		assert.ElementsMatch(t, []string{"postgresql", "ldap"}, actual[0])
+		assert.Equal(t, []string{"cas"}, actual[1])
+		assert.ElementsMatch(t, []string{"redmine", "scm"}, actual[2])
+	})
+	t.Run("should fail if the dependencies are cyclic", func(t *testing.T) {
+		// given
+		dogus := []*core.Dogu{
+			bulkTestDogu("cas", "ldap"),
+			bulkTestDogu("ldap", "cas"),
+		}
+
+		// when
+		actual, err := groupRestartWaves(dogus)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to sort dogus by dependency")
+		assert.Nil(t, actual)
+	})
+	t.Run("should return no waves without dogus", func(t *testing.T) {
+		// when
+		actual, err := groupRestartWaves(nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
+
+func Test_restartParallelismFromProto(t *testing.T) {
+	tests := []struct {
+		name        string
+		parallelism int32
+		want        int
+		wantErr     bool
+	}{
+		{name: "default", parallelism: 0, want: defaultRestartParallelism},
+		{name: "sequential", parallelism: 1, want: 1},
+		{name: "maximum", parallelism: maxRestartParallelism, want: maxRestartParallelism},
+		{name: "negative", parallelism: -1, wantErr: true},
+		{name: "too high", parallelism: maxRestartParallelism + 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := restartParallelismFromProto(tt.parallelism)
+
+			// then
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+}
@@ -2,11 +2,33 @@ package logging
 
 import (
 	"context"
+	"net/http"
+
 	common "github.com/cloudogu/ces-commons-lib/dogu"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
 	"github.com/cloudogu/k8s-registry-lib/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
 )
 
 type doguConfigRepository interface {
 	Get(context.Context, common.SimpleName) (config.DoguConfig, error)
 	Update(context.Context, config.DoguConfig) (config.DoguConfig, error)
 }
+
+type auditLogger interface {
+	// Record writes the given entry to the audit log.
+	Record(ctx context.Context, entry audit.Entry)
+}
+
+type credentialsProvider interface {
+	// Authenticate adds the credentials for the log backend to the request.
+	Authenticate(req *http.Request)
+}
+
+type podClient interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*corev1.PodList, error)
+	// GetLogs returns the request for the logs of a container of the pod.
+	GetLogs(name string, opts *corev1.PodLogOptions) *rest.Request
+}
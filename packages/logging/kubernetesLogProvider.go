@@ -0,0 +1,227 @@
+package logging
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// doguPodLabel is the label of the pods of a dogu which contains the name of the dogu.
+const doguPodLabel = "dogu.name"
+
+// maxKubernetesLogLineSize is the size of the longest log line read from the pod log api. Longer lines fail the query.
+const maxKubernetesLogLineSize = 1024 * 1024
+
+// KubernetesLogProvider reads the logs of dogus from the pod log api of kubernetes, so that no log stack is required.
+// Only the logs of existing pods are available, including the logs of the previous instance of restarted containers.
+type KubernetesLogProvider struct {
+	pods  podClient
+	clock nowClock
+}
+
+// NewKubernetesLogProvider creates a new KubernetesLogProvider reading the logs of the pods of the given client.
+func NewKubernetesLogProvider(pods podClient) *KubernetesLogProvider {
+	return &KubernetesLogProvider{
+		pods:  pods,
+		clock: &realClock{},
+	}
+}
+
+// getLogs sends the newest log lines of the dogu. A linesCount of 0 or less sends all lines. The pod log api cannot
+// be read backwards, so the lines are read completely before they are sent.
+func (klp *KubernetesLogProvider) getLogs(doguName string, linesCount int, send func(page []logLine) error) error {
+	lines, err := klp.readLogs(newDoguQuery(doguName, ""), time.Time{}, klp.clock.Now(), linesCount)
+	if err != nil {
+		return fmt.Errorf("failed to read logs from kubernetes: %w", err)
+	}
+
+	if linesCount > 0 && len(lines) > linesCount {
+		lines = lines[len(lines)-linesCount:]
+	}
+
+	return sendPages(lines, send)
+}
+
+// queryLogs returns the log lines selected by the query between the start date and the end date. Like for loki, the
+// end date defaults to now and the start date to 30 days before the end date.
+func (klp *KubernetesLogProvider) queryLogs(query logQuery, startDate time.Time, endDate time.Time) ([]logLine, error) {
+	if endDate.IsZero() {
+		endDate = klp.clock.Now()
+	}
+
+	if startDate.IsZero() {
+		startDate = createQueryStartDateFromEndDate(endDate)
+	}
+
+	lines, err := klp.readLogs(query, startDate, endDate, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs from kubernetes: %w", err)
+	}
+
+	return lines, nil
+}
+
+// queryLogsPage selects the page from all log lines within the time window of the cursor.
+func (klp *KubernetesLogProvider) queryLogsPage(query logQuery, cursor logCursor, size int) ([]logLine, error) {
+	return queryPage(klp.queryLogs, query, cursor, size)
+}
+
+// queryStatistics is not supported because the kubernetes log backend has no metric queries.
+func (klp *KubernetesLogProvider) queryStatistics(logStatisticsQuery) ([]logSeries, error) {
+	return nil, fmt.Errorf("log statistics of the kubernetes log backend: %w", errors.ErrUnsupported)
+}
+
+// queryVolume is not supported because the kubernetes log backend has no volume statistics.
+func (klp *KubernetesLogProvider) queryVolume([]string, time.Time, time.Time) ([]logVolume, error) {
+	return nil, fmt.Errorf("log volume of the kubernetes log backend: %w", errors.ErrUnsupported)
+}
+
+// followLogs polls the pod log api for new log lines, so that pods started while following are included.
+func (klp *KubernetesLogProvider) followLogs(ctx context.Context, query logQuery, send func(logLine) error) error {
+	return pollLogs(ctx, query, klp.clock, klp.queryLogs, send)
+}
+
+// readLogs reads the lines of all containers of the pods of the dogus and returns the lines selected by the query
+// sorted by their timestamp. A tailLines greater than 0 limits the lines read per container.
+func (klp *KubernetesLogProvider) readLogs(query logQuery, startDate time.Time, endDate time.Time, tailLines int) ([]logLine, error) {
+	matcher, err := query.newMatcher()
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	selector, err := doguPodSelector(query.doguNames)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), currentQueryTimeout())
+	defer cancel()
+
+	pods, err := klp.pods.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of dogus %v: %w", query.doguNames, err)
+	}
+
+	reader := containerLogReader{pods: klp.pods, matcher: matcher, startDate: startDate, endDate: endDate, tailLines: int64(tailLines)}
+	result := make([]logLine, 0)
+	var errs []error
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.RestartCount > 0 {
+				lines, err := reader.read(ctx, pod, status.Name, true)
+				if err != nil {
+					// the logs of the previous container may already be removed by the kubelet
+					logrus.Debugf("skipping logs of previous container %s of pod %s: %v", status.Name, pod.Name, err)
+				}
+				result = append(result, lines...)
+			}
+
+			if status.State.Waiting != nil {
+				// the container has not been started yet or waits to be restarted, so it has no current logs
+				continue
+			}
+
+			lines, err := reader.read(ctx, pod, status.Name, false)
+			errs = append(errs, err)
+			result = append(result, lines...)
+		}
+	}
+
+	err = errors.Join(errs...)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(result, func(a, b logLine) int {
+		return cmp.Compare(a.timestamp.UnixNano(), b.timestamp.UnixNano())
+	})
+
+	logrus.Debugf("finished reading logs of %d pod(s); got %d logLines", len(pods.Items), len(result))
+
+	return result, nil
+}
+
+// doguPodSelector returns the label selector of the pods of the dogus.
+func doguPodSelector(doguNames []string) (string, error) {
+	requirement, err := labels.NewRequirement(doguPodLabel, selection.In, doguNames)
+	if err != nil {
+		return "", err
+	}
+
+	return labels.NewSelector().Add(*requirement).String(), nil
+}
+
+// containerLogReader reads the log lines of single containers which are selected by the matcher and lie between the
+// start date and the end date.
+type containerLogReader struct {
+	pods      podClient
+	matcher   *logMatcher
+	startDate time.Time
+	endDate   time.Time
+	tailLines int64
+}
+
+func (r containerLogReader) read(ctx context.Context, pod corev1.Pod, container string, previous bool) ([]logLine, error) {
+	options := &corev1.PodLogOptions{
+		Container:  container,
+		Previous:   previous,
+		Timestamps: true,
+	}
+	if !r.startDate.IsZero() {
+		// the api only supports seconds, so the lines before the start date are skipped below
+		sinceTime := metav1.NewTime(r.startDate)
+		options.SinceTime = &sinceTime
+	}
+	if r.tailLines > 0 {
+		options.TailLines = &r.tailLines
+	}
+
+	stream, err := r.pods.GetLogs(pod.Name, options).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs of container %s of pod %s: %w", container, pod.Name, err)
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	lines := make([]logLine, 0)
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(nil, maxKubernetesLogLineSize)
+	for scanner.Scan() {
+		timestampValue, value, _ := strings.Cut(scanner.Text(), " ")
+		timestamp, err := time.Parse(time.RFC3339Nano, timestampValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse log timestamp of container %s of pod %s: %w", container, pod.Name, err)
+		}
+
+		if timestamp.Before(r.startDate) || !timestamp.Before(r.endDate) || !r.matcher.matches(value) {
+			continue
+		}
+
+		lines = append(lines, logLine{
+			timestamp: timestamp,
+			value:     value,
+			dogu:      pod.Labels[doguPodLabel],
+			container: container,
+			pod:       pod.Name,
+		})
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs of container %s of pod %s: %w", container, pod.Name, err)
+	}
+
+	return lines, nil
+}
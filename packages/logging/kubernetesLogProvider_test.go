@@ -0,0 +1,331 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestKubernetesLogProvider_contract(t *testing.T) {
+	testLogProviderContract(t, func(t *testing.T, clock nowClock) (logProvider, logBackend) {
+		backend := newFakePodLogs(t)
+		return &KubernetesLogProvider{pods: backend.podClient(), clock: clock}, backend
+	})
+}
+
+func TestNewKubernetesLogProvider(t *testing.T) {
+	t.Run("should create KubernetesLogProvider", func(t *testing.T) {
+		// given
+		pods := newFakePodLogs(t).podClient()
+
+		// when
+		actual := NewKubernetesLogProvider(pods)
+
+		// then
+		require.NotNil(t, actual)
+		assert.Same(t, pods, actual.pods)
+		assert.IsType(t, &realClock{}, actual.clock)
+	})
+}
+
+func TestKubernetesLogProvider_queryLogs(t *testing.T) {
+	t.Run("should select the pods by the dogu label", func(t *testing.T) {
+		// given
+		backend := newFakePodLogs(t)
+		sut := &KubernetesLogProvider{pods: backend.podClient(), clock: &testClock{contractNow}}
+
+		// when
+		_, err := sut.queryLogs(logQuery{doguNames: []string{"cas", "ldap"}}, time.Time{}, time.Time{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "dogu.name in (cas,ldap)", backend.selectors[0])
+	})
+	t.Run("should include the logs of previous containers", func(t *testing.T) {
+		// given
+		backend := newFakePodLogs(t)
+		backend.addPrevious(casLine(-30, "out of memory"))
+		backend.add(casLine(-20, "cas started"))
+		sut := &KubernetesLogProvider{pods: backend.podClient(), clock: &testClock{contractNow}}
+
+		// when
+		actual, err := sut.queryLogs(newDoguQuery("cas", ""), time.Time{}, time.Time{})
+
+		// then
+		require.NoError(t, err)
+		assertLogLines(t, []logLine{casLine(-30, "out of memory"), casLine(-20, "cas started")}, actual)
+	})
+	t.Run("should skip waiting containers and missing previous logs", func(t *testing.T) {
+		// given
+		backend := newFakePodLogs(t)
+		backend.add(casLine(-20, "cas started"), ldapLine(-10, "ldap started"))
+		backend.restarted = map[string]bool{contractCasPod: true}
+		backend.waiting = map[string]bool{contractLdapPod: true}
+		sut := &KubernetesLogProvider{pods: backend.podClient(), clock: &testClock{contractNow}}
+
+		// when
+		actual, err := sut.queryLogs(logQuery{doguNames: []string{"cas", "ldap"}}, time.Time{}, time.Time{})
+
+		// then
+		require.NoError(t, err)
+		assertLogLines(t, []logLine{casLine(-20, "cas started")}, actual)
+	})
+	t.Run("should apply field filters", func(t *testing.T) {
+		// given
+		backend := newFakePodLogs(t)
+		backend.add(casLine(-20, `{"level":"info","msg":"login"}`), casLine(-10, `{"level":"error","msg":"login failed"}`))
+		sut := &KubernetesLogProvider{pods: backend.podClient(), clock: &testClock{contractNow}}
+		query := logQuery{
+			doguNames:    []string{"cas"},
+			fieldFilters: []fieldFilter{{format: logFormatJSON, field: "level", logFilter: logFilter{value: "error"}}},
+		}
+
+		// when
+		actual, err := sut.queryLogs(query, time.Time{}, time.Time{})
+
+		// then
+		require.NoError(t, err)
+		assertLogLines(t, []logLine{casLine(-10, `{"level":"error","msg":"login failed"}`)}, actual)
+	})
+	t.Run("should fail if the logs cannot be read", func(t *testing.T) {
+		// given
+		backend := newFakePodLogs(t)
+		backend.add(casLine(-20, "cas started"))
+		backend.forbidden = true
+		sut := &KubernetesLogProvider{pods: backend.podClient(), clock: &testClock{contractNow}}
+
+		// when
+		_, err := sut.queryLogs(newDoguQuery("cas", ""), time.Time{}, time.Time{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read logs of container cas of pod "+contractCasPod)
+	})
+	t.Run("should fail for an invalid dogu name", func(t *testing.T) {
+		// given
+		sut := &KubernetesLogProvider{pods: newFakePodLogs(t).podClient(), clock: &testClock{contractNow}}
+
+		// when
+		_, err := sut.queryLogs(newDoguQuery("invalid name!", ""), time.Time{}, time.Time{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid query")
+	})
+}
+
+func TestKubernetesLogProvider_getLogs(t *testing.T) {
+	t.Run("should tail the logs of every container", func(t *testing.T) {
+		// given
+		backend := newFakePodLogs(t)
+		backend.addPrevious(casLine(-40, "first"), casLine(-30, "out of memory"))
+		backend.add(casLine(-20, "cas started"), casLine(-10, "request handled"))
+		sut := &KubernetesLogProvider{pods: backend.podClient(), clock: &testClock{contractNow}}
+
+		// when
+		actual, err := collectLogs(t, sut, "cas", 3)
+
+		// then
+		require.NoError(t, err)
+		assertLogLines(t, []logLine{casLine(-30, "out of memory"), casLine(-20, "cas started"), casLine(-10, "request handled")}, actual)
+		assert.Equal(t, []string{"3", "3"}, backend.tailLines)
+	})
+}
+
+// fakePodLogs serves the pod list and pod log api of kubernetes for the pods of the stored lines. Every pod has the
+// dogu label and a container for each container of its lines.
+type fakePodLogs struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mutex     sync.Mutex
+	lines     []logLine
+	previous  []logLine
+	selectors []string
+	tailLines []string
+	// restarted and waiting contain the names of the pods whose containers were restarted or wait to be started.
+	restarted map[string]bool
+	waiting   map[string]bool
+	forbidden bool
+}
+
+func newFakePodLogs(t *testing.T) *fakePodLogs {
+	f := &fakePodLogs{t: t}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/namespaces/ecosystem/pods", f.listPods)
+	mux.HandleFunc("GET /api/v1/namespaces/ecosystem/pods/{pod}/log", f.getLogs)
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+func (f *fakePodLogs) podClient() podClient {
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: f.server.URL})
+	require.NoError(f.t, err)
+
+	return client.CoreV1().Pods("ecosystem")
+}
+
+func (f *fakePodLogs) add(lines ...logLine) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.lines = append(f.lines, lines...)
+}
+
+// addPrevious stores lines of the previous instance of the containers.
+func (f *fakePodLogs) addPrevious(lines ...logLine) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.previous = append(f.previous, lines...)
+}
+
+func (f *fakePodLogs) listPods(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	selectorValue := r.URL.Query().Get("labelSelector")
+	f.selectors = append(f.selectors, selectorValue)
+	selector, err := labels.Parse(selectorValue)
+	require.NoError(f.t, err)
+
+	podList := corev1.PodList{TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"}}
+	for _, line := range slices.Concat(f.lines, f.previous) {
+		podLabels := labels.Set{doguPodLabel: line.dogu}
+		if !selector.Matches(podLabels) {
+			continue
+		}
+
+		index := slices.IndexFunc(podList.Items, func(pod corev1.Pod) bool { return pod.Name == line.pod })
+		if index < 0 {
+			podList.Items = append(podList.Items, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: line.pod, Labels: podLabels}})
+			index = len(podList.Items) - 1
+		}
+		pod := &podList.Items[index]
+		if slices.ContainsFunc(pod.Status.ContainerStatuses, func(status corev1.ContainerStatus) bool { return status.Name == line.container }) {
+			continue
+		}
+
+		status := corev1.ContainerStatus{Name: line.container}
+		if f.restarted[line.pod] || slices.ContainsFunc(f.previous, func(previous logLine) bool { return previous.pod == line.pod }) {
+			status.RestartCount = 1
+		}
+		if f.waiting[line.pod] {
+			status.State.Waiting = &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}
+		} else {
+			status.State.Running = &corev1.ContainerStateRunning{}
+		}
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, status)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	assert.NoError(f.t, json.NewEncoder(w).Encode(podList))
+}
+
+func (f *fakePodLogs) getLogs(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.forbidden {
+		writeStatus(w, http.StatusForbidden, "pods/log is forbidden")
+		return
+	}
+
+	params := r.URL.Query()
+	assert.Equal(f.t, "true", params.Get("timestamps"))
+	lines := f.lines
+	if params.Get("previous") == "true" {
+		lines = f.previous
+	}
+
+	sinceTime := time.Time{}
+	if params.Has("sinceTime") {
+		var err error
+		sinceTime, err = time.Parse(time.RFC3339, params.Get("sinceTime"))
+		require.NoError(f.t, err)
+	}
+
+	selected := make([]logLine, 0)
+	for _, line := range lines {
+		if line.pod == r.PathValue("pod") && line.container == params.Get("container") && !line.timestamp.Before(sinceTime) {
+			selected = append(selected, line)
+		}
+	}
+
+	if params.Get("previous") == "true" && len(selected) == 0 {
+		writeStatus(w, http.StatusBadRequest, "previous terminated container not found")
+		return
+	}
+
+	selected = f.tail(selected, params)
+	for _, line := range selected {
+		_, _ = fmt.Fprintf(w, "%s %s\n", line.timestamp.Format(time.RFC3339Nano), line.value)
+	}
+}
+
+func (f *fakePodLogs) tail(lines []logLine, params url.Values) []logLine {
+	if !params.Has("tailLines") {
+		return lines
+	}
+	f.tailLines = append(f.tailLines, params.Get("tailLines"))
+
+	tailLines, err := strconv.Atoi(params.Get("tailLines"))
+	require.NoError(f.t, err)
+
+	return lines[max(0, len(lines)-tailLines):]
+}
+
+func writeStatus(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  message,
+		Code:     int32(code),
+	})
+}
+
+func TestKubernetesLogProvider_queryStatistics(t *testing.T) {
+	t.Run("should not support statistics", func(t *testing.T) {
+		// given
+		sut := &KubernetesLogProvider{}
+
+		// when
+		_, err := sut.queryStatistics(statisticsQuery(newDoguQuery("cas", "")))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errors.ErrUnsupported)
+	})
+}
+
+func TestKubernetesLogProvider_queryVolume(t *testing.T) {
+	t.Run("should not support the log volume", func(t *testing.T) {
+		// given
+		sut := &KubernetesLogProvider{}
+
+		// when
+		_, err := sut.queryVolume([]string{"cas"}, contractNow.Add(-time.Hour), contractNow)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errors.ErrUnsupported)
+	})
+}
@@ -15,11 +15,14 @@ const (
 	LevelWarn
 	LevelInfo
 	LevelDebug
+	LevelTrace
 )
 
 // String converts LogLevel type to a string
 func (l LogLevel) String() string {
 	switch l {
+	case LevelTrace:
+		return "TRACE"
 	case LevelDebug:
 		return "DEBUG"
 	case LevelInfo:
@@ -36,6 +39,8 @@ func (l LogLevel) String() string {
 // CreateLogLevelFromProto maps protobuf log level to an internal log level used in application
 func CreateLogLevelFromProto(pLevel pb.LogLevel) (LogLevel, error) {
 	switch pLevel {
+	case pb.LogLevel_TRACE:
+		return LevelTrace, nil
 	case pb.LogLevel_DEBUG:
 		return LevelDebug, nil
 	case pb.LogLevel_INFO:
@@ -62,6 +67,8 @@ func CreateLogLevelFromString(sLevel string) (LogLevel, error) {
 		return LevelInfo, nil
 	case LevelDebug.String():
 		return LevelDebug, nil
+	case LevelTrace.String():
+		return LevelTrace, nil
 	default:
 		return LevelErrorUnspecified, errors.New("unknown log level")
 	}
@@ -14,6 +14,7 @@ func TestCreateLogLevelFromProto(t *testing.T) {
 		input    pbLogging.LogLevel
 		err      error
 	}{
+		{"Trace", LevelTrace, pbLogging.LogLevel_TRACE, nil},
 		{"Debug", LevelDebug, pbLogging.LogLevel_DEBUG, nil},
 		{"Info", LevelInfo, pbLogging.LogLevel_INFO, nil},
 		{"Warn", LevelWarn, pbLogging.LogLevel_WARN, nil},
@@ -50,6 +51,7 @@ func TestCreateLevelFromString(t *testing.T) {
 		expected LogLevel
 		err      error
 	}{
+		{"Trace", "TRACE", LevelTrace, nil},
 		{"Debug", "DEBUG", LevelDebug, nil},
 		{"Info", "INFO", LevelInfo, nil},
 		{"Warn", "WARN", LevelWarn, nil},
@@ -86,6 +88,7 @@ func TestLogLevel_String(t *testing.T) {
 		input    LogLevel
 		expected string
 	}{
+		{"Trace", LevelTrace, "TRACE"},
 		{"Debug", LevelDebug, "DEBUG"},
 		{"Info", LevelInfo, "INFO"},
 		{"Warn", LevelWarn, "WARN"},
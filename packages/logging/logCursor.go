@@ -0,0 +1,143 @@
+package logging
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"time"
+)
+
+// defaultPageSize is the number of lines of a page if a page token is given without a page size.
+const defaultPageSize = defaultQueryLimit
+
+// maxPageSize is the maximum number of lines of a page. It is half of the query limit of loki, so that the lines at the
+// boundary which were sent already can be queried additionally.
+const maxPageSize = maxQueryLimit / 2
+
+var errInvalidPageToken = errors.New("invalid page token")
+
+// logCursor is the state of paging through the logs of a query. It is passed to the client as opaque page token.
+// Every page continues within the remaining time window: backward pages end at the oldest line of the previous page,
+// forward pages start at its newest line. Lines with the timestamp of this boundary may be split between pages, so the
+// hashes of the boundary lines already sent are kept to skip them on the following page.
+type logCursor struct {
+	Forward bool `json:"f,omitempty"`
+	// StartDate is the inclusive start of the time window.
+	StartDate time.Time `json:"s"`
+	// EndDate is the exclusive end of the time window.
+	EndDate time.Time `json:"e"`
+	// Sent contains the hashes of the lines at the boundary of the time window sent on previous pages.
+	Sent []uint64 `json:"k,omitempty"`
+	// Query is the hash of the query, so that a page token cannot be used for another query.
+	Query uint64 `json:"q"`
+}
+
+// newLogCursor creates a cursor for the first page of the query within the time window.
+func newLogCursor(query logQuery, startDate time.Time, endDate time.Time, forward bool) logCursor {
+	return logCursor{
+		Forward:   forward,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Query:     hashQuery(query),
+	}
+}
+
+// decodeLogCursor decodes the page token of the query.
+func decodeLogCursor(token string, query logQuery) (logCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return logCursor{}, fmt.Errorf("%w: %w", errInvalidPageToken, err)
+	}
+
+	cursor := logCursor{}
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		return logCursor{}, fmt.Errorf("%w: %w", errInvalidPageToken, err)
+	}
+
+	if cursor.Query != hashQuery(query) {
+		return logCursor{}, fmt.Errorf("%w: the token belongs to another query", errInvalidPageToken)
+	}
+
+	if !cursor.StartDate.Before(cursor.EndDate) {
+		return logCursor{}, fmt.Errorf("%w: empty time window", errInvalidPageToken)
+	}
+
+	return cursor, nil
+}
+
+// encode returns the cursor as page token.
+func (c logCursor) encode() string {
+	// the cursor contains only timestamps and numbers, so marshalling cannot fail
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// selectPage returns the lines of the page from the lines sorted by their timestamp. These are the newest lines for
+// backward pages and the oldest lines for forward pages within the time window which were not sent yet.
+func (c logCursor) selectPage(lines []logLine, size int) []logLine {
+	page := make([]logLine, 0, min(len(lines), size))
+	for _, line := range lines {
+		if line.timestamp.Before(c.StartDate) || !line.timestamp.Before(c.EndDate) || slices.Contains(c.Sent, hashLogLine(line)) {
+			continue
+		}
+		page = append(page, line)
+	}
+
+	if len(page) <= size {
+		return page
+	}
+
+	if c.Forward {
+		return page[:size]
+	}
+
+	return page[len(page)-size:]
+}
+
+// next returns the cursor of the page following the given non-empty page.
+func (c logCursor) next(page []logLine) logCursor {
+	next := logCursor{Forward: c.Forward, StartDate: c.StartDate, EndDate: c.EndDate, Query: c.Query}
+
+	var boundary, previousBoundary time.Time
+	if c.Forward {
+		boundary = page[len(page)-1].timestamp
+		previousBoundary = c.StartDate
+		next.StartDate = boundary
+	} else {
+		boundary = page[0].timestamp
+		previousBoundary = c.EndDate.Add(-time.Nanosecond)
+		next.EndDate = boundary.Add(time.Nanosecond)
+	}
+
+	if boundary.Equal(previousBoundary) {
+		// the page did not leave the timestamp of the previous boundary
+		next.Sent = slices.Clone(c.Sent)
+	}
+
+	for _, line := range page {
+		if line.timestamp.Equal(boundary) {
+			next.Sent = append(next.Sent, hashLogLine(line))
+		}
+	}
+
+	return next
+}
+
+func hashQuery(query logQuery) uint64 {
+	hash := fnv.New64a()
+	// the query is validated before, so it can be built
+	logQL, _ := query.build()
+	_, _ = hash.Write([]byte(logQL))
+	return hash.Sum64()
+}
+
+// hashLogLine hashes the same fields which identify a line in deduplicateLogLines.
+func hashLogLine(line logLine) uint64 {
+	hash := fnv.New64a()
+	_, _ = fmt.Fprintf(hash, "%d_%s_%s", line.timestamp.UnixNano(), line.pod, line.value)
+	return hash.Sum64()
+}
@@ -0,0 +1,134 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_decodeLogCursor(t *testing.T) {
+	query := newDoguQuery("cas", "failed")
+
+	t.Run("should decode an encoded cursor", func(t *testing.T) {
+		// given
+		cursor := newLogCursor(query, contractNow.Add(-time.Hour), contractNow, true)
+		cursor.Sent = []uint64{hashLogLine(casLine(-10, "request handled"))}
+
+		// when
+		actual, err := decodeLogCursor(cursor.encode(), query)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, cursor, actual)
+	})
+	t.Run("should fail for a malformed token", func(t *testing.T) {
+		// when
+		_, err := decodeLogCursor("not a token", query)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errInvalidPageToken)
+	})
+	t.Run("should fail for a token of another query", func(t *testing.T) {
+		// given
+		token := newLogCursor(newDoguQuery("cas", ""), contractNow.Add(-time.Hour), contractNow, false).encode()
+
+		// when
+		_, err := decodeLogCursor(token, query)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errInvalidPageToken)
+		assert.ErrorContains(t, err, "the token belongs to another query")
+	})
+	t.Run("should fail for an empty time window", func(t *testing.T) {
+		// given
+		token := newLogCursor(query, contractNow, contractNow, false).encode()
+
+		// when
+		_, err := decodeLogCursor(token, query)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid page token: empty time window")
+	})
+}
+
+func Test_logCursor_selectPage(t *testing.T) {
+	lines := []logLine{
+		casLine(-60, "before the window"),
+		casLine(-40, "cas started"),
+		casLine(-30, "login failed for admin"),
+		casLine(-20, "login succeeded for admin"),
+		casLine(-10, "at the end of the window"),
+	}
+
+	t.Run("should select the newest lines of the window for a backward page", func(t *testing.T) {
+		// given
+		cursor := newLogCursor(newDoguQuery("cas", ""), contractNow.Add(-50*time.Second), contractNow.Add(-10*time.Second), false)
+
+		// when
+		actual := cursor.selectPage(lines, 2)
+
+		// then
+		assert.Equal(t, []logLine{casLine(-30, "login failed for admin"), casLine(-20, "login succeeded for admin")}, actual)
+	})
+	t.Run("should select the oldest lines of the window which were not sent for a forward page", func(t *testing.T) {
+		// given
+		cursor := newLogCursor(newDoguQuery("cas", ""), contractNow.Add(-40*time.Second), contractNow, true)
+		cursor.Sent = []uint64{hashLogLine(casLine(-40, "cas started"))}
+
+		// when
+		actual := cursor.selectPage(lines, 2)
+
+		// then
+		assert.Equal(t, []logLine{casLine(-30, "login failed for admin"), casLine(-20, "login succeeded for admin")}, actual)
+	})
+}
+
+func Test_logCursor_next(t *testing.T) {
+	query := newDoguQuery("cas", "")
+
+	t.Run("should end the next backward page after the oldest line of the page", func(t *testing.T) {
+		// given
+		cursor := newLogCursor(query, contractNow.Add(-time.Hour), contractNow, false)
+		page := []logLine{casLine(-20, "first"), casLine(-20, "second"), casLine(-10, "third")}
+
+		// when
+		actual := cursor.next(page)
+
+		// then
+		assert.Equal(t, contractNow.Add(-time.Hour), actual.StartDate)
+		assert.Equal(t, contractNow.Add(-20*time.Second+time.Nanosecond), actual.EndDate)
+		assert.Equal(t, []uint64{hashLogLine(page[0]), hashLogLine(page[1])}, actual.Sent)
+	})
+	t.Run("should start the next forward page at the newest line of the page", func(t *testing.T) {
+		// given
+		cursor := newLogCursor(query, contractNow.Add(-time.Hour), contractNow, true)
+		page := []logLine{casLine(-30, "first"), casLine(-20, "second")}
+
+		// when
+		actual := cursor.next(page)
+
+		// then
+		assert.Equal(t, contractNow.Add(-20*time.Second), actual.StartDate)
+		assert.Equal(t, contractNow, actual.EndDate)
+		assert.Equal(t, []uint64{hashLogLine(page[1])}, actual.Sent)
+	})
+	t.Run("should keep the sent lines if the page did not leave the boundary", func(t *testing.T) {
+		// given
+		cursor := newLogCursor(query, contractNow.Add(-20*time.Second), contractNow, true)
+		cursor.Sent = []uint64{hashLogLine(casLine(-20, "first"))}
+		page := []logLine{casLine(-20, "second")}
+
+		// when
+		actual := cursor.next(page)
+
+		// then
+		assert.Equal(t, contractNow.Add(-20*time.Second), actual.StartDate)
+		assert.Equal(t, []uint64{hashLogLine(casLine(-20, "first")), hashLogLine(casLine(-20, "second"))}, actual.Sent)
+		assert.Len(t, cursor.Sent, 1)
+	})
+}
@@ -0,0 +1,159 @@
+package logging
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// logExportFormat is the format in which the logs of a dogu are exported.
+type logExportFormat string
+
+const (
+	// logExportFormatZip is a zip archive containing the lines of the dogu as file named like the dogu.
+	logExportFormatZip logExportFormat = "zip"
+	// logExportFormatGzip is the gzip compressed plain text.
+	logExportFormatGzip logExportFormat = "gzip"
+	// logExportFormatText is plain text with one log line per line.
+	logExportFormatText logExportFormat = "text"
+	// logExportFormatNDJSON is a json object per line with the timestamp and the labels of the log line.
+	logExportFormatNDJSON logExportFormat = "ndjson"
+)
+
+// contentType returns the media type of the exported data.
+func (f logExportFormat) contentType() string {
+	switch f {
+	case logExportFormatGzip:
+		return "application/gzip"
+	case logExportFormatText:
+		return "text/plain; charset=utf-8"
+	case logExportFormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/zip"
+	}
+}
+
+// exportedLogLine is a log line of the ndjson export.
+type exportedLogLine struct {
+	Timestamp time.Time `json:"timestamp"`
+	Dogu      string    `json:"dogu,omitempty"`
+	Container string    `json:"container,omitempty"`
+	Pod       string    `json:"pod,omitempty"`
+	Message   string    `json:"message"`
+}
+
+// logExporter encodes the log lines of a dogu in the export format and writes them to the output while they are read,
+// so that only the buffers of the compression are held in memory. Nothing is written if there are no lines.
+type logExporter struct {
+	doguName string
+	format   logExportFormat
+	output   io.WriteCloser
+	// writer is nil until the first line is written. The compression writers write their headers when they are
+	// created.
+	writer       io.Writer
+	compression  io.Closer
+	writtenBytes int
+}
+
+func newLogExporter(doguName string, format logExportFormat, output io.WriteCloser) *logExporter {
+	return &logExporter{
+		doguName: doguName,
+		format:   format,
+		output:   output,
+	}
+}
+
+// write encodes the lines and writes them to the output.
+func (e *logExporter) write(lines []logLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	if e.writer == nil {
+		err := e.start()
+		if err != nil {
+			return fmt.Errorf("failed to start %s export: %w", e.format, err)
+		}
+	}
+
+	for _, line := range lines {
+		content, err := e.encode(line)
+		if err != nil {
+			return fmt.Errorf("failed to encode log line: %w", err)
+		}
+
+		n, err := e.writer.Write(content)
+		e.writtenBytes += n
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *logExporter) start() error {
+	fileName := fmt.Sprintf("%s.log", e.doguName)
+	switch e.format {
+	case logExportFormatZip:
+		zipWriter := zip.NewWriter(e.output)
+		writer, err := zipWriter.CreateHeader(&zip.FileHeader{
+			Name:     fileName,
+			Modified: time.Now(),
+			Method:   zip.Deflate,
+		})
+		if err != nil {
+			return err
+		}
+		e.writer = writer
+		e.compression = zipWriter
+	case logExportFormatGzip:
+		gzipWriter := gzip.NewWriter(e.output)
+		gzipWriter.Name = fileName
+		gzipWriter.ModTime = time.Now()
+		e.writer = gzipWriter
+		e.compression = gzipWriter
+	default:
+		e.writer = e.output
+	}
+
+	return nil
+}
+
+func (e *logExporter) encode(line logLine) ([]byte, error) {
+	if e.format != logExportFormatNDJSON {
+		return []byte(line.value + "\n"), nil
+	}
+
+	content, err := json.Marshal(exportedLogLine{
+		Timestamp: line.timestamp,
+		Dogu:      line.dogu,
+		Container: line.container,
+		Pod:       line.pod,
+		Message:   line.value,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(content, '\n'), nil
+}
+
+// close finishes the compression and writes the remaining data to the output.
+func (e *logExporter) close() error {
+	if e.compression != nil {
+		err := e.compression.Close()
+		if err != nil {
+			return fmt.Errorf("failed to finish %s export: %w", e.format, err)
+		}
+	}
+
+	logrus.Debugf("exported %d byte(s) of logs of dogu %s as %s", e.writtenBytes, e.doguName, e.format)
+	return e.output.Close()
+}
@@ -0,0 +1,110 @@
+package logging
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportOutput collects the exported data and records whether it was closed.
+type exportOutput struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (o *exportOutput) Close() error {
+	o.closed = true
+	return nil
+}
+
+func exportTestPages() [][]logLine {
+	return [][]logLine{
+		{casLine(-30, "cas started"), casLine(-20, "login failed for admin")},
+		{casLine(-10, "request handled")},
+	}
+}
+
+func exportLines(t *testing.T, format logExportFormat, pages [][]logLine) *exportOutput {
+	t.Helper()
+	output := &exportOutput{}
+	sut := newLogExporter("cas", format, output)
+
+	for _, page := range pages {
+		require.NoError(t, sut.write(page))
+	}
+	require.NoError(t, sut.close())
+	assert.True(t, output.closed)
+
+	return output
+}
+
+func Test_logExporter(t *testing.T) {
+	expectedText := "cas started\nlogin failed for admin\nrequest handled\n"
+
+	t.Run("should export a zip archive with a file named like the dogu", func(t *testing.T) {
+		// when
+		output := exportLines(t, logExportFormatZip, exportTestPages())
+
+		// then
+		reader, err := zip.NewReader(bytes.NewReader(output.Bytes()), int64(output.Len()))
+		require.NoError(t, err)
+		require.Len(t, reader.File, 1)
+		assert.Equal(t, "cas.log", reader.File[0].Name)
+
+		file, err := reader.File[0].Open()
+		require.NoError(t, err)
+		defer func() { _ = file.Close() }()
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, expectedText, string(content))
+	})
+	t.Run("should export gzip compressed text", func(t *testing.T) {
+		// when
+		output := exportLines(t, logExportFormatGzip, exportTestPages())
+
+		// then
+		reader, err := gzip.NewReader(&output.Buffer)
+		require.NoError(t, err)
+		assert.Equal(t, "cas.log", reader.Name)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, expectedText, string(content))
+	})
+	t.Run("should export plain text", func(t *testing.T) {
+		// when
+		output := exportLines(t, logExportFormatText, exportTestPages())
+
+		// then
+		assert.Equal(t, expectedText, output.String())
+	})
+	t.Run("should export ndjson with timestamps and labels", func(t *testing.T) {
+		// when
+		output := exportLines(t, logExportFormatNDJSON, [][]logLine{{casLine(-10, "request handled"), {timestamp: contractNow, value: "unlabeled"}}})
+
+		// then
+		assert.Equal(t, `{"timestamp":"2026-03-04T11:59:50Z","dogu":"cas","container":"cas","pod":"cas-6d4b8c7f9-x2x4z","message":"request handled"}
+{"timestamp":"2026-03-04T12:00:00Z","message":"unlabeled"}
+`, output.String())
+	})
+	t.Run("should export nothing without lines", func(t *testing.T) {
+		for _, format := range []logExportFormat{logExportFormatZip, logExportFormatGzip, logExportFormatText, logExportFormatNDJSON} {
+			// when
+			output := exportLines(t, format, [][]logLine{{}})
+
+			// then
+			assert.Zero(t, output.Len(), format)
+		}
+	})
+}
+
+func Test_logExportFormat_contentType(t *testing.T) {
+	assert.Equal(t, "application/zip", logExportFormatZip.contentType())
+	assert.Equal(t, "application/gzip", logExportFormatGzip.contentType())
+	assert.Equal(t, "text/plain; charset=utf-8", logExportFormatText.contentType())
+	assert.Equal(t, "application/x-ndjson", logExportFormatNDJSON.contentType())
+}
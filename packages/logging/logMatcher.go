@@ -0,0 +1,201 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// logMatcher evaluates the filters of a query for log backends which cannot filter the lines themselves. It matches
+// the same lines as the LogQL rendered by logQuery.build.
+type logMatcher struct {
+	lineFilters  []func(string) bool
+	format       logFormat
+	fieldFilters []fieldMatcher
+}
+
+type fieldMatcher struct {
+	field   string
+	matches func(string) bool
+}
+
+// newMatcher validates the query and compiles its filters.
+func (q logQuery) newMatcher() (*logMatcher, error) {
+	err := q.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	matcher := &logMatcher{}
+	for _, filter := range q.lineFilters {
+		matcher.lineFilters = append(matcher.lineFilters, filter.compile(false))
+	}
+
+	for _, filter := range q.fieldFilters {
+		matcher.format = filter.format
+		// like the label filters of loki, field filters compare the whole value
+		matcher.fieldFilters = append(matcher.fieldFilters, fieldMatcher{field: filter.field, matches: filter.compile(true)})
+	}
+
+	return matcher, nil
+}
+
+// compile returns a function which reports whether a value matches the filter. The filter must be valid.
+func (f logFilter) compile(whole bool) func(string) bool {
+	operator, value := f.expression()
+	exclude := operator == "!=" || operator == "!~"
+
+	var matches func(string) bool
+	switch {
+	case operator == "|~" || operator == "!~":
+		if whole {
+			value = "^(?:" + value + ")$"
+		}
+		expression := regexp.MustCompile(value)
+		matches = expression.MatchString
+	case whole:
+		matches = func(s string) bool { return s == value }
+	default:
+		matches = func(s string) bool { return strings.Contains(s, value) }
+	}
+
+	return func(s string) bool {
+		return matches(s) != exclude
+	}
+}
+
+// matches reports whether the line matches all filters. Lines which cannot be parsed for the field filters do not
+// match.
+func (m *logMatcher) matches(line string) bool {
+	for _, matches := range m.lineFilters {
+		if !matches(line) {
+			return false
+		}
+	}
+
+	if len(m.fieldFilters) == 0 {
+		return true
+	}
+
+	fields, err := parseFields(m.format, line)
+	if err != nil {
+		return false
+	}
+
+	for _, filter := range m.fieldFilters {
+		// missing fields are empty like missing labels in loki
+		if !filter.matches(fields[filter.field]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseFields(format logFormat, line string) (map[string]string, error) {
+	switch format {
+	case logFormatJSON:
+		return parseJSONFields(line)
+	case logFormatLogfmt:
+		return parseLogfmtFields(line)
+	default:
+		return nil, fmt.Errorf("unsupported log format %q", format)
+	}
+}
+
+// parseJSONFields returns the fields of a json object. Like the json parser of loki, the keys of nested objects are
+// joined with an underscore and arrays are skipped.
+func parseJSONFields(line string) (map[string]string, error) {
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+
+	document := map[string]any{}
+	err := decoder.Decode(&document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse json: %w", err)
+	}
+
+	fields := map[string]string{}
+	flattenJSONFields("", document, fields)
+
+	return fields, nil
+}
+
+func flattenJSONFields(prefix string, document map[string]any, fields map[string]string) {
+	for key, value := range document {
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+
+		switch value := value.(type) {
+		case map[string]any:
+			flattenJSONFields(key, value, fields)
+		case []any:
+			continue
+		case nil:
+			fields[key] = ""
+		default:
+			fields[key] = fmt.Sprint(value)
+		}
+	}
+}
+
+// parseLogfmtFields returns the fields of a logfmt line like `level=info msg="user logged in"`. Keys without a value
+// are empty.
+func parseLogfmtFields(line string) (map[string]string, error) {
+	fields := map[string]string{}
+	rest := []byte(strings.TrimSpace(line))
+	for len(rest) > 0 {
+		end := bytes.IndexAny(rest, "= ")
+		if end < 0 {
+			end = len(rest)
+		}
+
+		key := string(rest[:end])
+		if key == "" {
+			return nil, errors.New("failed to parse logfmt: missing key")
+		}
+		rest = rest[end:]
+
+		value := ""
+		if len(rest) > 0 && rest[0] == '=' {
+			var err error
+			value, rest, err = parseLogfmtValue(rest[1:])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse logfmt value of key %q: %w", key, err)
+			}
+		}
+
+		fields[key] = value
+		rest = bytes.TrimLeft(rest, " ")
+	}
+
+	return fields, nil
+}
+
+func parseLogfmtValue(rest []byte) (string, []byte, error) {
+	if len(rest) == 0 || rest[0] != '"' {
+		end := bytes.IndexByte(rest, ' ')
+		if end < 0 {
+			end = len(rest)
+		}
+
+		return string(rest[:end]), rest[end:], nil
+	}
+
+	for i := 1; i < len(rest); i++ {
+		switch rest[i] {
+		case '\\':
+			i++
+		case '"':
+			value, err := strconv.Unquote(string(rest[:i+1]))
+			return value, rest[i+1:], err
+		}
+	}
+
+	return "", nil, errors.New("missing closing quote")
+}
@@ -0,0 +1,168 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_logMatcher_matches(t *testing.T) {
+	tests := []struct {
+		name  string
+		query logQuery
+		line  string
+		want  bool
+	}{
+		{
+			name:  "no filters",
+			query: newDoguQuery("cas", ""),
+			line:  "anything",
+			want:  true,
+		},
+		{
+			name:  "substring filter",
+			query: newDoguQuery("cas", "failed"),
+			line:  "login failed for admin",
+			want:  true,
+		},
+		{
+			name:  "substring filter is case-sensitive",
+			query: newDoguQuery("cas", "failed"),
+			line:  "login FAILED for admin",
+			want:  false,
+		},
+		{
+			name:  "case-insensitive substring filter",
+			query: logQuery{doguNames: []string{"cas"}, lineFilters: []logFilter{{value: "a.b", caseInsensitive: true}}},
+			line:  "found A.B",
+			want:  true,
+		},
+		{
+			name:  "case-insensitive substring filter does not interpret the value as regex",
+			query: logQuery{doguNames: []string{"cas"}, lineFilters: []logFilter{{value: "a.b", caseInsensitive: true}}},
+			line:  "found AXB",
+			want:  false,
+		},
+		{
+			name:  "excluded substring filter",
+			query: logQuery{doguNames: []string{"cas"}, lineFilters: []logFilter{{value: "failed"}, {value: "bind", exclude: true}}},
+			line:  "bind failed for admin",
+			want:  false,
+		},
+		{
+			name:  "regex filter",
+			query: logQuery{doguNames: []string{"cas"}, lineFilters: []logFilter{{value: "err(or)?", regex: true}}},
+			line:  "an error occurred",
+			want:  true,
+		},
+		{
+			name:  "excluded regex filter",
+			query: logQuery{doguNames: []string{"cas"}, lineFilters: []logFilter{{value: "^GET", regex: true, exclude: true}}},
+			line:  "GET /cas/login",
+			want:  false,
+		},
+		{
+			name:  "json field filter compares the whole value",
+			query: jsonFieldQuery("level", logFilter{value: "err"}),
+			line:  `{"level":"error"}`,
+			want:  false,
+		},
+		{
+			name:  "json field filter of nested object",
+			query: jsonFieldQuery("user_name", logFilter{value: "admin"}),
+			line:  `{"user":{"name":"admin","roles":["admin"]}}`,
+			want:  true,
+		},
+		{
+			name:  "json field filter of number",
+			query: jsonFieldQuery("status", logFilter{value: "5..", regex: true}),
+			line:  `{"status":503}`,
+			want:  true,
+		},
+		{
+			name:  "excluded json field filter matches missing field",
+			query: jsonFieldQuery("user", logFilter{value: "admin", exclude: true}),
+			line:  `{"level":"info"}`,
+			want:  true,
+		},
+		{
+			name:  "json field filter does not match invalid json",
+			query: jsonFieldQuery("user", logFilter{value: "admin", exclude: true}),
+			line:  `level=info`,
+			want:  false,
+		},
+		{
+			name:  "case-insensitive json field filter",
+			query: jsonFieldQuery("level", logFilter{value: "error", caseInsensitive: true}),
+			line:  `{"level":"ERROR"}`,
+			want:  true,
+		},
+		{
+			name:  "logfmt field filter of quoted value",
+			query: logfmtFieldQuery("msg", logFilter{value: `user "admin" logged in`}),
+			line:  `level=info msg="user \"admin\" logged in" debug`,
+			want:  true,
+		},
+		{
+			name:  "logfmt field filter does not match unterminated quote",
+			query: logfmtFieldQuery("level", logFilter{value: "info"}),
+			line:  `level=info msg="user logged in`,
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			matcher, err := tt.query.newMatcher()
+			require.NoError(t, err)
+
+			// when
+			actual := matcher.matches(tt.line)
+
+			// then
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+}
+
+func Test_logQuery_newMatcher(t *testing.T) {
+	t.Run("should fail for an invalid query", func(t *testing.T) {
+		// given
+		query := logQuery{doguNames: []string{"cas"}, lineFilters: []logFilter{{value: "(", regex: true}}}
+
+		// when
+		_, err := query.newMatcher()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid regular expression")
+	})
+}
+
+func Test_parseLogfmtFields(t *testing.T) {
+	t.Run("should parse keys with plain, quoted and missing values", func(t *testing.T) {
+		// when
+		actual, err := parseLogfmtFields(` level=warn msg="disk \"data\" full"  retry empty= `)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"level": "warn", "msg": `disk "data" full`, "retry": "", "empty": ""}, actual)
+	})
+	t.Run("should fail for a missing key", func(t *testing.T) {
+		// when
+		_, err := parseLogfmtFields(`level=info =value`)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "missing key")
+	})
+}
+
+func jsonFieldQuery(field string, filter logFilter) logQuery {
+	return logQuery{doguNames: []string{"cas"}, fieldFilters: []fieldFilter{{format: logFormatJSON, field: field, logFilter: filter}}}
+}
+
+func logfmtFieldQuery(field string, filter logFilter) logQuery {
+	return logQuery{doguNames: []string{"cas"}, fieldFilters: []fieldFilter{{format: logFormatLogfmt, field: field, logFilter: filter}}}
+}
@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// followPollInterval is the interval in which log backends without a tail api are queried for new log lines.
+var followPollInterval = 2 * time.Second
+
+type logQueryFunc func(query logQuery, startDate time.Time, endDate time.Time) ([]logLine, error)
+
+// pollLogs follows the logs of backends which cannot stream new log lines. It queries the lines since the newest sent
+// line repeatedly until the context is done. Failed queries are retried in the next interval and lines which were
+// already sent are skipped.
+func pollLogs(ctx context.Context, query logQuery, clock nowClock, queryLogs logQueryFunc, send func(logLine) error) error {
+	err := query.validate()
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	lines := newFollowedLines(clock.Now())
+	ticker := time.NewTicker(followPollInterval)
+	defer ticker.Stop()
+
+	for {
+		newLines, err := queryLogs(query, lines.resumeFrom(), clock.Now())
+		if err != nil {
+			logrus.Warnf("failed to poll logs of dogus %v, retrying in %s: %v", query.doguNames, followPollInterval, err)
+		} else {
+			err = sendNewLines(lines, newLines, send)
+			var permanentErr *permanentFollowError
+			if errors.As(err, &permanentErr) {
+				return fmt.Errorf("failed to follow logs of dogus %v: %w", query.doguNames, permanentErr.err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			logrus.Debugf("stopped following logs of dogus %v", query.doguNames)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
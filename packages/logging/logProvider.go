@@ -1,13 +1,62 @@
 package logging
 
-import "time"
+import (
+	"context"
+	"slices"
+	"time"
+)
 
 type logLine struct {
 	timestamp time.Time
 	value     string
+	// dogu, container and pod identify the source of the line. They are empty if the log backend did not return them.
+	dogu      string
+	container string
+	pod       string
 }
 
+// LogProvider reads the logs of dogus from a log backend, e.g. from a LokiLogProvider, a KubernetesLogProvider or an
+// OpenSearchLogProvider.
+type LogProvider = logProvider
+
 type logProvider interface {
-	getLogs(doguName string, linesCount int) ([]logLine, error)
-	queryLogs(doguName string, startDate time.Time, endDate time.Time, filter string) ([]logLine, error)
+	// getLogs sends the newest log lines of the dogu page by page in order of their timestamps, so that the lines
+	// can be exported without holding all of them in memory. A linesCount of 0 or less sends all lines.
+	getLogs(doguName string, linesCount int, send func(page []logLine) error) error
+	// queryLogs returns the logs selected by the query merged in order of their timestamps.
+	queryLogs(query logQuery, startDate time.Time, endDate time.Time) ([]logLine, error)
+	// queryLogsPage returns at most size log lines selected by the query within the time window of the cursor in order
+	// of their timestamps, skipping the lines sent on previous pages.
+	queryLogsPage(query logQuery, cursor logCursor, size int) ([]logLine, error)
+	// queryStatistics returns the series of the statistics query. Backends without metric queries return an error
+	// wrapping errors.ErrUnsupported.
+	queryStatistics(query logStatisticsQuery) ([]logSeries, error)
+	// queryVolume returns the bytes and lines of every dogu ingested within the time window in the order of the dogus.
+	// Backends without volume statistics return an error wrapping errors.ErrUnsupported.
+	queryVolume(doguNames []string, startDate time.Time, endDate time.Time) ([]logVolume, error)
+	// followLogs sends every new log line selected by the query until the context is done.
+	followLogs(ctx context.Context, query logQuery, send func(logLine) error) error
+}
+
+// sendPages sends the lines in pages of the default query limit. It is used by the providers which read all lines at
+// once.
+func sendPages(lines []logLine, send func(page []logLine) error) error {
+	for page := range slices.Chunk(lines, defaultQueryLimit) {
+		if err := send(page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queryPage selects the page from all lines within the time window of the cursor. It is used by the providers which
+// cannot query a limited number of lines in both directions.
+func queryPage(queryLogs logQueryFunc, query logQuery, cursor logCursor, size int) ([]logLine, error) {
+	lines, err := queryLogs(query, cursor.StartDate, cursor.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return cursor.selectPage(lines, size), nil
 }
@@ -0,0 +1,327 @@
+package logging
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// contractNow is the time at which the provider contract queries the logs.
+var contractNow = time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+const (
+	contractCasPod       = "cas-6d4b8c7f9-x2x4z"
+	contractLdapPod      = "ldap-5c4b8d79f-qwrtz"
+	contractCassandraPod = "cassandra-7c9b5d4f8-qwrtz"
+)
+
+func casLine(seconds int, value string) logLine {
+	return contractLine("cas", contractCasPod, seconds, value)
+}
+
+func ldapLine(seconds int, value string) logLine {
+	return contractLine("ldap", contractLdapPod, seconds, value)
+}
+
+func cassandraLine(seconds int, value string) logLine {
+	return contractLine("cassandra", contractCassandraPod, seconds, value)
+}
+
+// contractLine returns a line of the container named like the dogu, logged the given seconds after contractNow.
+func contractLine(dogu string, pod string, seconds int, value string) logLine {
+	return logLine{
+		timestamp: contractNow.Add(time.Duration(seconds) * time.Second),
+		value:     value,
+		dogu:      dogu,
+		container: dogu,
+		pod:       pod,
+	}
+}
+
+// logBackend is a fake log backend which is filled with log lines by the provider contract.
+type logBackend interface {
+	// add stores the lines with their dogu, container and pod.
+	add(lines ...logLine)
+}
+
+// newContractProvider creates a provider reading the logs of a new fake backend.
+type newContractProvider func(t *testing.T, clock nowClock) (logProvider, logBackend)
+
+// contractClock can be advanced while the provider follows the logs.
+type contractClock struct {
+	mutex sync.Mutex
+	now   time.Time
+	// read is closed when the time is read the first time, e.g. as start of following the logs.
+	read     chan struct{}
+	readOnce sync.Once
+}
+
+func newContractClock(now time.Time) *contractClock {
+	return &contractClock{now: now, read: make(chan struct{})}
+}
+
+func (c *contractClock) Now() time.Time {
+	c.readOnce.Do(func() { close(c.read) })
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *contractClock) set(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = now
+}
+
+// assertLogLines compares the lines independent of the location of their timestamps.
+func assertLogLines(t *testing.T, expected []logLine, actual []logLine) {
+	t.Helper()
+	utc := func(lines []logLine) []logLine {
+		result := make([]logLine, 0, len(lines))
+		for _, line := range lines {
+			line.timestamp = line.timestamp.UTC()
+			result = append(result, line)
+		}
+		return result
+	}
+
+	assert.Equal(t, utc(expected), utc(actual))
+}
+
+// collectLogs returns the lines sent by getLogs in the order of their pages.
+func collectLogs(t *testing.T, sut logProvider, doguName string, linesCount int) ([]logLine, error) {
+	t.Helper()
+	lines := make([]logLine, 0)
+	err := sut.getLogs(doguName, linesCount, func(page []logLine) error {
+		assert.NotEmpty(t, page)
+		lines = append(lines, page...)
+		return nil
+	})
+
+	return lines, err
+}
+
+// collectPages pages through the lines of the query and returns the pages in the order they were queried.
+func collectPages(t *testing.T, sut logProvider, query logQuery, cursor logCursor, size int) [][]logLine {
+	t.Helper()
+	var pages [][]logLine
+	for {
+		page, err := sut.queryLogsPage(query, cursor, size)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page), size)
+		if len(page) > 0 {
+			pages = append(pages, page)
+		}
+		if len(page) < size {
+			return pages
+		}
+
+		cursor = cursor.next(page)
+	}
+}
+
+// sortLogLines sorts the lines by their timestamp and value, so that the order of lines logged at the same time does
+// not matter.
+func sortLogLines(lines []logLine) []logLine {
+	sorted := slices.Clone(lines)
+	slices.SortFunc(sorted, func(a, b logLine) int {
+		return cmp.Or(a.timestamp.Compare(b.timestamp), strings.Compare(a.value, b.value))
+	})
+	return sorted
+}
+
+// testLogProviderContract verifies the behavior every logProvider must have, independent of its log backend.
+func testLogProviderContract(t *testing.T, newProvider newContractProvider) {
+	storedLines := []logLine{
+		casLine(-50, "cas started"),
+		ldapLine(-45, "ldap started"),
+		cassandraLine(-42, "cassandra started"),
+		casLine(-40, "login failed for admin"),
+		ldapLine(-30, "bind failed for admin"),
+		casLine(-20, "login succeeded for admin"),
+		casLine(-10, "request handled"),
+	}
+	setup := func(t *testing.T) (logProvider, logBackend, *contractClock) {
+		clock := newContractClock(contractNow)
+		sut, backend := newProvider(t, clock)
+		backend.add(storedLines...)
+
+		return sut, backend, clock
+	}
+
+	t.Run("getLogs should return the newest lines of the dogu in order", func(t *testing.T) {
+		// given
+		sut, _, _ := setup(t)
+
+		// when
+		actual, err := collectLogs(t, sut, "cas", 2)
+
+		// then
+		require.NoError(t, err)
+		assertLogLines(t, []logLine{casLine(-20, "login succeeded for admin"), casLine(-10, "request handled")}, actual)
+	})
+	t.Run("getLogs should return all lines of exactly the dogu", func(t *testing.T) {
+		// given
+		sut, _, _ := setup(t)
+
+		// when
+		actual, err := collectLogs(t, sut, "cas", 0)
+
+		// then
+		require.NoError(t, err)
+		assertLogLines(t, []logLine{
+			casLine(-50, "cas started"),
+			casLine(-40, "login failed for admin"),
+			casLine(-20, "login succeeded for admin"),
+			casLine(-10, "request handled"),
+		}, actual)
+	})
+	t.Run("queryLogs should merge the lines of several dogus within the time range by time", func(t *testing.T) {
+		// given
+		sut, _, _ := setup(t)
+		query := logQuery{doguNames: []string{"cas", "ldap"}}
+
+		// when
+		actual, err := sut.queryLogs(query, contractNow.Add(-45*time.Second), contractNow.Add(-15*time.Second))
+
+		// then
+		require.NoError(t, err)
+		assertLogLines(t, []logLine{
+			ldapLine(-45, "ldap started"),
+			casLine(-40, "login failed for admin"),
+			ldapLine(-30, "bind failed for admin"),
+			casLine(-20, "login succeeded for admin"),
+		}, actual)
+	})
+	t.Run("queryLogs should apply the line filters", func(t *testing.T) {
+		// given
+		sut, _, _ := setup(t)
+		query := logQuery{
+			doguNames:   []string{"cas", "ldap"},
+			lineFilters: []logFilter{{value: "failed"}, {value: "bind", exclude: true}},
+		}
+
+		// when
+		actual, err := sut.queryLogs(query, time.Time{}, time.Time{})
+
+		// then
+		require.NoError(t, err)
+		assertLogLines(t, []logLine{casLine(-40, "login failed for admin")}, actual)
+	})
+	t.Run("queryLogs should query the last 30 days by default", func(t *testing.T) {
+		// given
+		sut, backend, _ := setup(t)
+		backend.add(ldapLine(-31*24*60*60, "too old"))
+
+		// when
+		actual, err := sut.queryLogs(newDoguQuery("ldap", ""), time.Time{}, time.Time{})
+
+		// then
+		require.NoError(t, err)
+		assertLogLines(t, []logLine{ldapLine(-45, "ldap started"), ldapLine(-30, "bind failed for admin")}, actual)
+	})
+	t.Run("queryLogs should fail for an invalid query", func(t *testing.T) {
+		// given
+		sut, _, _ := setup(t)
+
+		// when
+		_, err := sut.queryLogs(logQuery{}, time.Time{}, time.Time{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errMissingDoguName)
+	})
+	t.Run("queryLogsPage should page backward through the lines without duplicates at the page boundaries", func(t *testing.T) {
+		// given
+		sut, backend, _ := setup(t)
+		backend.add(casLine(-20, "session created for admin"))
+		query := newDoguQuery("cas", "")
+		cursor := newLogCursor(query, contractNow.Add(-time.Hour), contractNow, false)
+
+		// when
+		pages := collectPages(t, sut, query, cursor, 2)
+
+		// then
+		require.Len(t, pages, 3)
+		assertLogLines(t, []logLine{casLine(-50, "cas started")}, pages[2])
+		var actual []logLine
+		for _, page := range pages {
+			assert.True(t, slices.IsSortedFunc(page, func(a, b logLine) int { return a.timestamp.Compare(b.timestamp) }))
+			actual = append(actual, page...)
+		}
+		assertLogLines(t, sortLogLines([]logLine{
+			casLine(-50, "cas started"),
+			casLine(-40, "login failed for admin"),
+			casLine(-20, "login succeeded for admin"),
+			casLine(-20, "session created for admin"),
+			casLine(-10, "request handled"),
+		}), sortLogLines(actual))
+	})
+	t.Run("queryLogsPage should page forward through the lines of the time window", func(t *testing.T) {
+		// given
+		sut, _, _ := setup(t)
+		query := logQuery{doguNames: []string{"cas", "ldap"}}
+		cursor := newLogCursor(query, contractNow.Add(-45*time.Second), contractNow.Add(-10*time.Second), true)
+
+		// when
+		pages := collectPages(t, sut, query, cursor, 2)
+
+		// then
+		require.Len(t, pages, 2)
+		assertLogLines(t, []logLine{ldapLine(-45, "ldap started"), casLine(-40, "login failed for admin")}, pages[0])
+		assertLogLines(t, []logLine{ldapLine(-30, "bind failed for admin"), casLine(-20, "login succeeded for admin")}, pages[1])
+	})
+	t.Run("followLogs should send new lines of the dogu until the context is done", func(t *testing.T) {
+		// given
+		setFollowBackoff(t, time.Millisecond)
+		previousPollInterval := followPollInterval
+		t.Cleanup(func() { followPollInterval = previousPollInterval })
+		followPollInterval = 10 * time.Millisecond
+
+		sut, backend, clock := setup(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		received := make(chan logLine, 10)
+		followed := make(chan error, 1)
+		go func() {
+			followed <- sut.followLogs(ctx, newDoguQuery("cas", ""), func(line logLine) error {
+				received <- line
+				return nil
+			})
+		}()
+
+		// when
+		<-clock.read
+		backend.add(casLine(1, "logout of admin"), cassandraLine(1, "compaction finished"), casLine(2, "cas stopped"))
+		clock.set(contractNow.Add(3 * time.Second))
+
+		// then
+		var actual []logLine
+		for len(actual) < 2 {
+			select {
+			case line := <-received:
+				actual = append(actual, line)
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "timed out waiting for followed lines", "received %v", actual)
+			}
+		}
+		assertLogLines(t, []logLine{casLine(1, "logout of admin"), casLine(2, "cas stopped")}, actual)
+
+		cancel()
+		select {
+		case err := <-followed:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "followLogs did not return after the context was done")
+		}
+		assert.Empty(t, received)
+	})
+}
@@ -0,0 +1,200 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// podNameSuffixCharacters are the characters kubernetes uses for generated names, e.g. the pod-template-hash of a
+// deployment and the random suffix of its pods. They contain no vowels, so that no word of a dogu name matches.
+const podNameSuffixCharacters = "[bcdfghjklmnpqrstvwxz2456789]"
+
+// podNameSuffixPattern matches the part of a pod name following the name of the dogu deployment, e.g. "-6d4b8c7f9-x2x4z".
+var podNameSuffixPattern = fmt.Sprintf("-%s{1,10}-%s{5}", podNameSuffixCharacters, podNameSuffixCharacters)
+
+var podNameSuffixRegexp = regexp.MustCompile("^" + podNameSuffixPattern + "$")
+
+// fieldNameRegexp matches the names of labels extracted by the json and logfmt parsers of loki.
+var fieldNameRegexp = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+// logFormat is the format of structured log lines whose fields can be filtered.
+type logFormat string
+
+const (
+	logFormatJSON   logFormat = "json"
+	logFormatLogfmt logFormat = "logfmt"
+)
+
+// logQuery selects the logs of dogus which match all of its filters. It is rendered to LogQL with every value
+// escaped, so that user input can neither break the query nor select the logs of other pods.
+type logQuery struct {
+	doguNames    []string
+	lineFilters  []logFilter
+	fieldFilters []fieldFilter
+}
+
+// logFilter matches a value either as substring or as regular expression.
+type logFilter struct {
+	value string
+	// regex interprets the value as regular expression in RE2 syntax instead of as substring.
+	regex bool
+	// exclude selects the lines which do not match the value.
+	exclude         bool
+	caseInsensitive bool
+}
+
+// fieldFilter matches the value of a field of structured log lines. Lines which cannot be parsed in the format are
+// not selected.
+type fieldFilter struct {
+	format logFormat
+	field  string
+	logFilter
+}
+
+// newDoguQuery creates a query for the logs of the dogu, optionally containing the given substring.
+func newDoguQuery(doguName string, contains string) logQuery {
+	query := logQuery{doguNames: []string{doguName}}
+	if strings.TrimSpace(contains) != "" {
+		query.lineFilters = []logFilter{{value: contains}}
+	}
+
+	return query
+}
+
+// build validates the query and returns its LogQL representation.
+func (q logQuery) build() (string, error) {
+	err := q.validate()
+	if err != nil {
+		return "", err
+	}
+
+	builder := &strings.Builder{}
+	builder.WriteString(q.selector())
+
+	for _, filter := range q.lineFilters {
+		operator, value := filter.expression()
+		fmt.Fprintf(builder, " %s %s", operator, strconv.Quote(value))
+	}
+
+	if len(q.fieldFilters) > 0 {
+		fmt.Fprintf(builder, " | %s | __error__=\"\"", q.fieldFilters[0].format)
+	}
+	for _, filter := range q.fieldFilters {
+		operator, value := filter.expression()
+		// label filters use the operators of the label matchers instead of the line filter operators
+		operator = strings.NewReplacer("|=", "=", "|~", "=~").Replace(operator)
+		fmt.Fprintf(builder, " | %s%s%s", filter.field, operator, strconv.Quote(value))
+	}
+
+	return builder.String(), nil
+}
+
+// selector returns the stream selector matching exactly the pods of the dogus.
+func (q logQuery) selector() string {
+	return fmt.Sprintf("{pod=~%s}", strconv.Quote(q.podPattern()))
+}
+
+// podPattern returns the regular expression matching exactly the names of the pods of the dogus.
+func (q logQuery) podPattern() string {
+	names := make([]string, 0, len(q.doguNames))
+	for _, doguName := range q.doguNames {
+		names = append(names, regexp.QuoteMeta(doguName))
+	}
+
+	podPrefix := strings.Join(names, "|")
+	if len(names) > 1 {
+		podPrefix = fmt.Sprintf("(%s)", podPrefix)
+	}
+
+	return podPrefix + podNameSuffixPattern
+}
+
+func (q logQuery) validate() error {
+	var errs []error
+	if len(q.doguNames) == 0 {
+		errs = append(errs, errMissingDoguName)
+	}
+	for _, doguName := range q.doguNames {
+		if strings.TrimSpace(doguName) == "" {
+			errs = append(errs, errMissingDoguName)
+		}
+	}
+
+	for _, filter := range q.lineFilters {
+		errs = append(errs, filter.validate())
+	}
+
+	for _, filter := range q.fieldFilters {
+		if filter.format != q.fieldFilters[0].format {
+			errs = append(errs, fmt.Errorf("field filters must use the same format, got %s and %s", q.fieldFilters[0].format, filter.format))
+		}
+		if !slices.Contains([]logFormat{logFormatJSON, logFormatLogfmt}, filter.format) {
+			errs = append(errs, fmt.Errorf("unsupported log format %q for field %q", filter.format, filter.field))
+		}
+		if !fieldNameRegexp.MatchString(filter.field) {
+			errs = append(errs, fmt.Errorf("invalid field name %q; field names must match %s", filter.field, fieldNameRegexp))
+		}
+		errs = append(errs, filter.validate())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (f logFilter) validate() error {
+	if f.value == "" {
+		return errors.New("filter value must not be empty")
+	}
+
+	if !f.regex {
+		return nil
+	}
+
+	_, err := regexp.Compile(f.value)
+	if err != nil {
+		return fmt.Errorf("invalid regular expression %q: %w", f.value, err)
+	}
+
+	return nil
+}
+
+// expression returns the LogQL line filter operator and the unquoted value of the filter. Case-insensitive filters are
+// expressed as regular expressions.
+func (f logFilter) expression() (string, string) {
+	value := f.value
+	regex := f.regex
+	if f.caseInsensitive {
+		if !regex {
+			value = regexp.QuoteMeta(value)
+		}
+		value = "(?i)" + value
+		regex = true
+	}
+
+	switch {
+	case regex && f.exclude:
+		return "!~", value
+	case regex:
+		return "|~", value
+	case f.exclude:
+		return "!=", value
+	default:
+		return "|=", value
+	}
+}
+
+// doguOfPod returns the dogu of the given dogus which the pod belongs to or an empty string if the pod belongs to none
+// of them.
+func doguOfPod(doguNames []string, pod string) string {
+	for _, doguName := range doguNames {
+		suffix, found := strings.CutPrefix(pod, doguName)
+		if found && podNameSuffixRegexp.MatchString(suffix) {
+			return doguName
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,136 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_logQuery_build(t *testing.T) {
+	tests := []struct {
+		name  string
+		query logQuery
+		want  string
+	}{
+		{
+			name:  "single dogu",
+			query: newDoguQuery("cas", ""),
+			want:  `{pod=~"cas` + podNameSuffixPattern + `"}`,
+		},
+		{
+			name:  "several dogus",
+			query: logQuery{doguNames: []string{"cas", "ldap"}},
+			want:  `{pod=~"(cas|ldap)` + podNameSuffixPattern + `"}`,
+		},
+		{
+			name:  "escape regex meta characters of dogu names",
+			query: newDoguQuery("my.dogu", ""),
+			want:  `{pod=~"my\\.dogu` + podNameSuffixPattern + `"}`,
+		},
+		{
+			name:  "substring filter with quotes",
+			query: newDoguQuery("cas", `user="admin" \ foo`),
+			want:  `{pod=~"cas` + podNameSuffixPattern + `"} |= "user=\"admin\" \\ foo"`,
+		},
+		{
+			name: "excluded substring and regex filters",
+			query: logQuery{doguNames: []string{"cas"}, lineFilters: []logFilter{
+				{value: "health"},
+				{value: "debug", exclude: true},
+				{value: "err(or)?", regex: true},
+				{value: "^GET", regex: true, exclude: true},
+			}},
+			want: `{pod=~"cas` + podNameSuffixPattern + `"} |= "health" != "debug" |~ "err(or)?" !~ "^GET"`,
+		},
+		{
+			name: "case-insensitive filters",
+			query: logQuery{doguNames: []string{"cas"}, lineFilters: []logFilter{
+				{value: "a.b", caseInsensitive: true},
+				{value: "warn|error", regex: true, exclude: true, caseInsensitive: true},
+			}},
+			want: `{pod=~"cas` + podNameSuffixPattern + `"} |~ "(?i)a\\.b" !~ "(?i)warn|error"`,
+		},
+		{
+			name: "json field filters",
+			query: logQuery{doguNames: []string{"cas"}, fieldFilters: []fieldFilter{
+				{format: logFormatJSON, field: "level", logFilter: logFilter{value: "error"}},
+				{format: logFormatJSON, field: "user", logFilter: logFilter{value: "admin.*", regex: true, exclude: true}},
+			}},
+			want: `{pod=~"cas` + podNameSuffixPattern + `"} | json | __error__="" | level="error" | user!~"admin.*"`,
+		},
+		{
+			name: "logfmt field filters after line filters",
+			query: logQuery{
+				doguNames:    []string{"cas"},
+				lineFilters:  []logFilter{{value: "login"}},
+				fieldFilters: []fieldFilter{{format: logFormatLogfmt, field: "status", logFilter: logFilter{value: "ok", exclude: true, caseInsensitive: true}}},
+			},
+			want: `{pod=~"cas` + podNameSuffixPattern + `"} |= "login" | logfmt | __error__="" | status!~"(?i)ok"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			actual, err := tt.query.build()
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+}
+
+func Test_logQuery_validate(t *testing.T) {
+	t.Run("should fail without dogu", func(t *testing.T) {
+		// when
+		_, err := logQuery{}.build()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errMissingDoguName)
+	})
+	t.Run("should report every invalid filter", func(t *testing.T) {
+		// given
+		query := logQuery{
+			doguNames:   []string{"cas"},
+			lineFilters: []logFilter{{value: ""}, {value: "(", regex: true}},
+			fieldFilters: []fieldFilter{
+				{format: logFormatJSON, field: "level", logFilter: logFilter{value: "error"}},
+				{format: logFormatLogfmt, field: "le-vel", logFilter: logFilter{value: "error"}},
+				{format: "xml", field: "level", logFilter: logFilter{value: "error"}},
+			},
+		}
+
+		// when
+		err := query.validate()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "filter value must not be empty")
+		assert.ErrorContains(t, err, "invalid regular expression \"(\"")
+		assert.ErrorContains(t, err, "field filters must use the same format, got json and logfmt")
+		assert.ErrorContains(t, err, "invalid field name \"le-vel\"")
+		assert.ErrorContains(t, err, "unsupported log format \"xml\" for field \"level\"")
+	})
+}
+
+func Test_doguOfPod(t *testing.T) {
+	doguNames := []string{"cas", "nginx-ingress", "cassandra"}
+	tests := []struct {
+		pod  string
+		want string
+	}{
+		{pod: "cas-6d4b8c7f9-x2x4z", want: "cas"},
+		{pod: "nginx-ingress-6b7c9d8f5-x2x4z", want: "nginx-ingress"},
+		{pod: "cassandra-6d4b8c7f9-x2x4z", want: "cassandra"},
+		{pod: "cassandra-0", want: ""},
+		{pod: "cas-helper-6d4b8c7f9-x2x4z", want: ""},
+		{pod: "ldap-6d4b8c7f9-x2x4z", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pod, func(t *testing.T) {
+			assert.Equal(t, tt.want, doguOfPod(doguNames, tt.pod))
+		})
+	}
+}
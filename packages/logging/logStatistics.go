@@ -0,0 +1,139 @@
+package logging
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxStatisticsPoints is the maximum number of points of a series loki returns for a metric query.
+const maxStatisticsPoints = 11000
+
+// maxStatisticsRange is the maximum time range of a metric query allowed by loki.
+const maxStatisticsRange = 24 * 30 * time.Hour
+
+// detectedLevelLabel is the label which loki sets to the level detected in a log line, e.g. "error" or "unknown".
+const detectedLevelLabel = "detected_level"
+
+// logStatisticsFunction is the LogQL range function aggregating the log lines of every step.
+type logStatisticsFunction string
+
+const (
+	// logStatisticsCount counts the log lines of every step.
+	logStatisticsCount logStatisticsFunction = "count_over_time"
+	// logStatisticsRate is the number of log lines per second of every step.
+	logStatisticsRate logStatisticsFunction = "rate"
+)
+
+// logStatisticsQuery aggregates the log lines selected by the log query at every step within the time range. Every
+// step covers the log lines of the preceding step duration.
+type logStatisticsQuery struct {
+	logQuery
+	function  logStatisticsFunction
+	startDate time.Time
+	endDate   time.Time
+	step      time.Duration
+	// byLevel returns a series per dogu and detected level instead of a series per dogu.
+	byLevel bool
+}
+
+// logSeries contains the aggregated values of a dogu, optionally of a single level.
+type logSeries struct {
+	dogu    string
+	level   string
+	samples []logSample
+}
+
+type logSample struct {
+	timestamp time.Time
+	value     float64
+}
+
+// build validates the statistics query and returns its LogQL representation. The series are summed by pod, so that
+// the labels extracted by field filters do not split them.
+func (q logStatisticsQuery) build() (string, error) {
+	err := q.validate()
+	if err != nil {
+		return "", err
+	}
+
+	logQL, err := q.logQuery.build()
+	if err != nil {
+		return "", err
+	}
+
+	labels := "pod"
+	if q.byLevel {
+		labels += ", " + detectedLevelLabel
+	}
+
+	return fmt.Sprintf("sum by (%s) (%s(%s [%dms]))", labels, q.function, logQL, q.step.Milliseconds()), nil
+}
+
+func (q logStatisticsQuery) validate() error {
+	var errs []error
+	if !slices.Contains([]logStatisticsFunction{logStatisticsCount, logStatisticsRate}, q.function) {
+		errs = append(errs, fmt.Errorf("unsupported statistics function %q", q.function))
+	}
+
+	if q.step < time.Second || q.step%time.Millisecond != 0 {
+		errs = append(errs, fmt.Errorf("step %s must be at least 1s and a multiple of 1ms", q.step))
+	}
+
+	timeRange := q.endDate.Sub(q.startDate)
+	switch {
+	case timeRange <= 0:
+		errs = append(errs, fmt.Errorf("end date %s must be after start date %s", q.endDate.Format(time.RFC3339), q.startDate.Format(time.RFC3339)))
+	case timeRange > maxStatisticsRange:
+		errs = append(errs, fmt.Errorf("time range %s exceeds the maximum of %s", timeRange, maxStatisticsRange))
+	case q.step > 0 && timeRange/q.step >= maxStatisticsPoints:
+		errs = append(errs, fmt.Errorf("time range %s with step %s exceeds the maximum of %d points", timeRange, q.step, maxStatisticsPoints))
+	}
+
+	return errors.Join(errs...)
+}
+
+// aggregateSeries sums the series of the pods of every dogu and level and returns them sorted by dogu and level.
+// Series of pods not belonging to one of the dogus are ignored.
+func aggregateSeries(doguNames []string, results []lokiSeriesResult) []logSeries {
+	type seriesKey struct {
+		dogu  string
+		level string
+	}
+
+	sums := map[seriesKey]map[time.Time]float64{}
+	for _, result := range results {
+		dogu := doguOfPod(doguNames, result.Metric["pod"])
+		if dogu == "" {
+			continue
+		}
+
+		key := seriesKey{dogu: dogu, level: result.Metric[detectedLevelLabel]}
+		if sums[key] == nil {
+			sums[key] = map[time.Time]float64{}
+		}
+		for _, sample := range result.Values {
+			sums[key][sample.timestamp] += sample.value
+		}
+	}
+
+	series := make([]logSeries, 0, len(sums))
+	for key, values := range sums {
+		samples := make([]logSample, 0, len(values))
+		for timestamp, value := range values {
+			samples = append(samples, logSample{timestamp: timestamp, value: value})
+		}
+		slices.SortFunc(samples, func(a, b logSample) int { return a.timestamp.Compare(b.timestamp) })
+
+		series = append(series, logSeries{dogu: key.dogu, level: key.level, samples: samples})
+	}
+
+	slices.SortFunc(series, func(a, b logSeries) int {
+		return cmp.Or(strings.Compare(a.dogu, b.dogu), strings.Compare(a.level, b.level))
+	})
+
+	return series
+}
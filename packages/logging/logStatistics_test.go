@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func statisticsQuery(query logQuery) logStatisticsQuery {
+	return logStatisticsQuery{
+		logQuery:  query,
+		function:  logStatisticsCount,
+		startDate: contractNow.Add(-time.Hour),
+		endDate:   contractNow,
+		step:      time.Minute,
+	}
+}
+
+func Test_logStatisticsQuery_build(t *testing.T) {
+	t.Run("should sum the counts by pod", func(t *testing.T) {
+		// when
+		actual, err := statisticsQuery(logQuery{doguNames: []string{"cas", "ldap"}}).build()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, `sum by (pod) (count_over_time({pod=~"(cas|ldap)`+podNameSuffixPattern+`"} [60000ms]))`, actual)
+	})
+	t.Run("should sum the rates of the filtered lines by pod and level", func(t *testing.T) {
+		// given
+		query := statisticsQuery(newDoguQuery("ldap", "bind"))
+		query.function = logStatisticsRate
+		query.step = 1500 * time.Millisecond
+		query.byLevel = true
+
+		// when
+		actual, err := query.build()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, `sum by (pod, detected_level) (rate({pod=~"ldap`+podNameSuffixPattern+`"} |= "bind" [1500ms]))`, actual)
+	})
+	t.Run("should fail for an invalid log query", func(t *testing.T) {
+		// when
+		_, err := statisticsQuery(logQuery{}).build()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errMissingDoguName)
+	})
+}
+
+func Test_logStatisticsQuery_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(query *logStatisticsQuery)
+		wantErr string
+	}{
+		{
+			name:    "unsupported function",
+			modify:  func(query *logStatisticsQuery) { query.function = "bytes_over_time" },
+			wantErr: `unsupported statistics function "bytes_over_time"`,
+		},
+		{
+			name:    "step below one second",
+			modify:  func(query *logStatisticsQuery) { query.step = 500 * time.Millisecond },
+			wantErr: "step 500ms must be at least 1s and a multiple of 1ms",
+		},
+		{
+			name:    "end before start",
+			modify:  func(query *logStatisticsQuery) { query.startDate = contractNow.Add(time.Minute) },
+			wantErr: "end date 2026-03-04T12:00:00Z must be after start date 2026-03-04T12:01:00Z",
+		},
+		{
+			name:    "time range above 30 days",
+			modify:  func(query *logStatisticsQuery) { query.startDate = contractNow.Add(-31 * 24 * time.Hour) },
+			wantErr: "time range 744h0m0s exceeds the maximum of 720h0m0s",
+		},
+		{
+			name: "too many points",
+			modify: func(query *logStatisticsQuery) {
+				query.step = time.Second
+				query.startDate = contractNow.Add(-4 * time.Hour)
+			},
+			wantErr: "time range 4h0m0s with step 1s exceeds the maximum of 11000 points",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			query := statisticsQuery(newDoguQuery("cas", ""))
+			tt.modify(&query)
+
+			// when
+			err := query.validate()
+
+			// then
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_aggregateSeries(t *testing.T) {
+	t.Run("should sum the series of the pods of every dogu and level", func(t *testing.T) {
+		// given
+		minute := contractNow.Add(-time.Minute)
+		results := []lokiSeriesResult{
+			{Metric: map[string]string{"pod": contractLdapPod}, Values: []lokiSample{{timestamp: contractNow, value: 1}}},
+			{Metric: map[string]string{"pod": contractCasPod}, Values: []lokiSample{{timestamp: contractNow, value: 2}, {timestamp: minute, value: 3}}},
+			{Metric: map[string]string{"pod": "cas-6d4b8c7f9-bcdfg"}, Values: []lokiSample{{timestamp: contractNow, value: 4}}},
+			{Metric: map[string]string{"pod": contractCassandraPod}, Values: []lokiSample{{timestamp: contractNow, value: 5}}},
+		}
+
+		// when
+		actual := aggregateSeries([]string{"cas", "ldap"}, results)
+
+		// then
+		assert.Equal(t, []logSeries{
+			{dogu: "cas", samples: []logSample{{timestamp: minute, value: 3}, {timestamp: contractNow, value: 6}}},
+			{dogu: "ldap", samples: []logSample{{timestamp: contractNow, value: 1}}},
+		}, actual)
+	})
+}
@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultVolumeWindow is the window of a volume report which does not specify windows.
+	defaultVolumeWindow = 24 * time.Hour
+	// minVolumeWindow is the smallest window of a volume report. Loki computes the volume from its index, which is not
+	// more precise for shorter windows.
+	minVolumeWindow = time.Minute
+	// maxVolumeWindows is the maximum number of windows of a volume report, so that a report does not run too many
+	// queries.
+	maxVolumeWindows = 5
+)
+
+// logVolume is the amount of logs of a dogu ingested within a time window.
+type logVolume struct {
+	dogu  string
+	bytes uint64
+	lines uint64
+}
+
+// logVolumeSort is the value by which the dogus of a volume report are sorted.
+type logVolumeSort int
+
+const (
+	logVolumeByBytes logVolumeSort = iota
+	logVolumeByLines
+)
+
+// doguVolumeReport contains the volumes of a dogu in every window of the report in the order of the windows.
+type doguVolumeReport struct {
+	dogu    string
+	windows []logVolume
+	// rootLogger contains the current level of the dogu. Its name is empty if the level could not be read.
+	rootLogger doguLogger
+}
+
+// validateVolumeWindows checks that the windows can be queried. A window covers the logs of the given duration before
+// the time of the report.
+func validateVolumeWindows(windows []time.Duration) error {
+	if len(windows) > maxVolumeWindows {
+		return fmt.Errorf("%d windows exceed the maximum of %d", len(windows), maxVolumeWindows)
+	}
+
+	var errs []error
+	for _, window := range windows {
+		if window < minVolumeWindow || window > maxStatisticsRange {
+			errs = append(errs, fmt.Errorf("window %s must be between %s and %s", window, minVolumeWindow, maxStatisticsRange))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sortVolumeReports sorts the reports descending by the bytes or lines of their first window, so that the noisiest
+// dogus come first. Dogus with the same volume are sorted by name.
+func sortVolumeReports(reports []doguVolumeReport, by logVolumeSort) {
+	volumeOf := func(report doguVolumeReport) uint64 {
+		if len(report.windows) == 0 {
+			return 0
+		}
+		if by == logVolumeByLines {
+			return report.windows[0].lines
+		}
+		return report.windows[0].bytes
+	}
+
+	slices.SortFunc(reports, func(a, b doguVolumeReport) int {
+		return cmp.Or(cmp.Compare(volumeOf(b), volumeOf(a)), strings.Compare(a.dogu, b.dogu))
+	})
+}
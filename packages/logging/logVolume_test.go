@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateVolumeWindows(t *testing.T) {
+	t.Run("should accept windows between a minute and 30 days", func(t *testing.T) {
+		// when
+		err := validateVolumeWindows([]time.Duration{time.Minute, time.Hour, 24 * time.Hour, maxStatisticsRange})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should reject too short and too long windows", func(t *testing.T) {
+		// when
+		err := validateVolumeWindows([]time.Duration{time.Second, 31 * 24 * time.Hour})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "window 1s must be between 1m0s and 720h0m0s")
+		assert.ErrorContains(t, err, "window 744h0m0s must be between 1m0s and 720h0m0s")
+	})
+	t.Run("should reject too many windows", func(t *testing.T) {
+		// when
+		err := validateVolumeWindows([]time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour, 4 * time.Hour, 5 * time.Hour, 6 * time.Hour})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "6 windows exceed the maximum of 5")
+	})
+}
+
+func Test_sortVolumeReports(t *testing.T) {
+	reports := func() []doguVolumeReport {
+		return []doguVolumeReport{
+			{dogu: "cas", windows: []logVolume{{dogu: "cas", bytes: 100, lines: 30}, {dogu: "cas", bytes: 9000, lines: 900}}},
+			{dogu: "ldap", windows: []logVolume{{dogu: "ldap", bytes: 500, lines: 10}}},
+			{dogu: "nexus", windows: []logVolume{{dogu: "nexus", bytes: 100, lines: 20}}},
+			{dogu: "jenkins"},
+		}
+	}
+	names := func(reports []doguVolumeReport) []string {
+		var result []string
+		for _, report := range reports {
+			result = append(result, report.dogu)
+		}
+		return result
+	}
+
+	t.Run("should sort descending by the bytes of the first window and then by name", func(t *testing.T) {
+		// given
+		actual := reports()
+
+		// when
+		sortVolumeReports(actual, logVolumeByBytes)
+
+		// then
+		assert.Equal(t, []string{"ldap", "cas", "nexus", "jenkins"}, names(actual))
+	})
+	t.Run("should sort descending by the lines of the first window", func(t *testing.T) {
+		// given
+		actual := reports()
+
+		// when
+		sortVolumeReports(actual, logVolumeByLines)
+
+		// then
+		assert.Equal(t, []string{"cas", "nexus", "ldap", "jenkins"}, names(actual))
+	})
+}
@@ -0,0 +1,148 @@
+package logging
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-registry-lib/config"
+)
+
+const (
+	// loggingKeyPrefix is the prefix of the configuration keys of the log levels of a dogu, e.g. logging/root.
+	loggingKeyPrefix = "logging/"
+	rootLoggerName   = "root"
+	// oneOfValidation is the validation type of configuration fields allowing a fixed set of values.
+	oneOfValidation = "ONE_OF"
+)
+
+var (
+	errUnknownLogger      = errors.New("unknown logger")
+	errInvalidLoggerLevel = errors.New("invalid log level")
+)
+
+// loggerLevelSource tells where the effective level of a logger comes from.
+type loggerLevelSource int
+
+const (
+	// loggerLevelUnset is the source of loggers without a configured level and without a default level.
+	loggerLevelUnset loggerLevelSource = iota
+	// loggerLevelFromConfig is the source of levels set in the dogu config.
+	loggerLevelFromConfig
+	// loggerLevelFromDescriptor is the source of the default levels of the dogu descriptor.
+	loggerLevelFromDescriptor
+)
+
+// doguLogger is a logger of a dogu whose level is set with a configuration key below logging/.
+type doguLogger struct {
+	// name is the configuration key without the prefix, e.g. root for logging/root.
+	name        string
+	description string
+	// level is the effective level of the logger; it is empty if the source is loggerLevelUnset.
+	level  string
+	source loggerLevelSource
+	// allowedLevels are the values allowed by the descriptor. All known log levels are allowed if it is empty.
+	allowedLevels []string
+}
+
+// key returns the configuration key of the logger.
+func (l doguLogger) key() string {
+	return loggingKeyPrefix + l.name
+}
+
+// validate returns the level in the spelling of the allowed levels or an error if the logger does not allow it.
+func (l doguLogger) validate(level string) (string, error) {
+	if len(l.allowedLevels) == 0 {
+		logLevel, err := CreateLogLevelFromString(level)
+		if err != nil {
+			return "", fmt.Errorf("%w %q for logger %s", errInvalidLoggerLevel, level, l.name)
+		}
+
+		return logLevel.String(), nil
+	}
+
+	index := slices.IndexFunc(l.allowedLevels, func(allowed string) bool { return strings.EqualFold(allowed, level) })
+	if index < 0 {
+		return "", fmt.Errorf("%w %q for logger %s: allowed are %s", errInvalidLoggerLevel, level, l.name, strings.Join(l.allowedLevels, ", "))
+	}
+
+	return l.allowedLevels[index], nil
+}
+
+// discoverLoggers returns the loggers which are defined in the configuration of the dogu descriptor, sorted by name
+// with the root logger first. The root logger is always returned because every dogu is expected to support it.
+func discoverLoggers(descriptor *core.Dogu, doguConfig config.DoguConfig) []doguLogger {
+	var loggers []doguLogger
+	for _, field := range descriptor.Configuration {
+		name, ok := strings.CutPrefix(field.Name, loggingKeyPrefix)
+		if !ok || name == "" {
+			continue
+		}
+
+		logger := doguLogger{name: name, description: field.Description}
+		if strings.EqualFold(field.Validation.Type, oneOfValidation) {
+			logger.allowedLevels = field.Validation.Values
+		}
+		if field.Default != "" {
+			logger.level = field.Default
+			logger.source = loggerLevelFromDescriptor
+		}
+		loggers = append(loggers, logger)
+	}
+
+	if !slices.ContainsFunc(loggers, func(logger doguLogger) bool { return logger.name == rootLoggerName }) {
+		loggers = append(loggers, doguLogger{name: rootLoggerName})
+	}
+
+	for i := range loggers {
+		level, _ := doguConfig.Get(config.Key(loggers[i].key()))
+		if level != "" {
+			loggers[i].level = string(level)
+			loggers[i].source = loggerLevelFromConfig
+		}
+	}
+
+	isNotRoot := func(logger doguLogger) bool { return logger.name != rootLoggerName }
+	slices.SortFunc(loggers, func(a, b doguLogger) int {
+		return cmp.Or(compareBool(isNotRoot(a), isNotRoot(b)), strings.Compare(a.name, b.name))
+	})
+
+	return loggers
+}
+
+// findLogger returns the logger with the given name from the loggers of the dogu.
+func findLogger(loggers []doguLogger, name string) (doguLogger, error) {
+	index := slices.IndexFunc(loggers, func(logger doguLogger) bool { return logger.name == name })
+	if index < 0 {
+		return doguLogger{}, fmt.Errorf("%w %q", errUnknownLogger, name)
+	}
+
+	return loggers[index], nil
+}
+
+// ValidateRootLogLevel returns an error if the root logger of the dogu does not allow the level according to its
+// descriptor.
+func ValidateRootLogLevel(descriptor *core.Dogu, level string) error {
+	rootLogger, err := findLogger(discoverLoggers(descriptor, config.DoguConfig{}), rootLoggerName)
+	if err != nil {
+		return err
+	}
+
+	_, err = rootLogger.validate(level)
+	return err
+}
+
+// compareBool sorts false before true.
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,136 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loggerTestDescriptor() *core.Dogu {
+	return &core.Dogu{
+		Name: "official/redmine",
+		Configuration: []core.ConfigurationField{
+			{Name: "container_config/memory_limit", Default: "1g"},
+			{Name: "logging/sql", Description: "Level of the SQL logger"},
+			{
+				Name:       "logging/root",
+				Default:    "INFO",
+				Validation: core.ValidationDescriptor{Type: "ONE_OF", Values: []string{"ERROR", "WARN", "INFO", "DEBUG", "TRACE"}},
+			},
+			{Name: "logging/mail", Default: "WARN", Validation: core.ValidationDescriptor{Type: "ONE_OF", Values: []string{"OFF", "WARN"}}},
+		},
+	}
+}
+
+func Test_discoverLoggers(t *testing.T) {
+	t.Run("should return the loggers of the descriptor with the root logger first", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("redmine", config.Entries{"logging/sql": "DEBUG", "logging/other": "TRACE"})
+
+		// when
+		actual := discoverLoggers(loggerTestDescriptor(), doguConfig)
+
+		// then
+		assert.Equal(t, []doguLogger{
+			{name: "root", level: "INFO", source: loggerLevelFromDescriptor, allowedLevels: []string{"ERROR", "WARN", "INFO", "DEBUG", "TRACE"}},
+			{name: "mail", level: "WARN", source: loggerLevelFromDescriptor, allowedLevels: []string{"OFF", "WARN"}},
+			{name: "sql", description: "Level of the SQL logger", level: "DEBUG", source: loggerLevelFromConfig},
+		}, actual)
+	})
+	t.Run("should return the root logger if the descriptor does not define it", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("redmine", config.Entries{"logging/root": "WARN"})
+
+		// when
+		actual := discoverLoggers(&core.Dogu{Name: "official/redmine"}, doguConfig)
+
+		// then
+		assert.Equal(t, []doguLogger{{name: "root", level: "WARN", source: loggerLevelFromConfig}}, actual)
+	})
+}
+
+func Test_doguLogger_validate(t *testing.T) {
+	t.Run("should return an allowed level in the spelling of the descriptor", func(t *testing.T) {
+		// given
+		logger := doguLogger{name: "mail", allowedLevels: []string{"OFF", "WARN"}}
+
+		// when
+		actual, err := logger.validate("off")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "OFF", actual)
+	})
+	t.Run("should fail for a level not allowed by the descriptor", func(t *testing.T) {
+		// given
+		logger := doguLogger{name: "mail", allowedLevels: []string{"OFF", "WARN"}}
+
+		// when
+		_, err := logger.validate("TRACE")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errInvalidLoggerLevel)
+		assert.ErrorContains(t, err, `invalid log level "TRACE" for logger mail: allowed are OFF, WARN`)
+	})
+	t.Run("should allow the known levels without validation in the descriptor", func(t *testing.T) {
+		// given
+		logger := doguLogger{name: "sql"}
+
+		// when
+		actual, err := logger.validate("trace")
+		_, unknownErr := logger.validate("OFF")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "TRACE", actual)
+		assert.ErrorIs(t, unknownErr, errInvalidLoggerLevel)
+	})
+}
+
+func Test_findLogger(t *testing.T) {
+	// when
+	_, err := findLogger([]doguLogger{{name: "root"}}, "sql")
+
+	// then
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errUnknownLogger)
+	assert.ErrorContains(t, err, `unknown logger "sql"`)
+}
+
+func TestValidateRootLogLevel(t *testing.T) {
+	t.Run("should allow a level allowed by the root logger", func(t *testing.T) {
+		// when
+		err := ValidateRootLogLevel(loggerTestDescriptor(), "trace")
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail for a level not allowed by the root logger", func(t *testing.T) {
+		// given
+		descriptor := &core.Dogu{
+			Name: "official/cas",
+			Configuration: []core.ConfigurationField{
+				{Name: "logging/root", Validation: core.ValidationDescriptor{Type: "ONE_OF", Values: []string{"ERROR", "WARN", "INFO", "DEBUG"}}},
+			},
+		}
+
+		// when
+		err := ValidateRootLogLevel(descriptor, "TRACE")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errInvalidLoggerLevel)
+		assert.ErrorContains(t, err, `invalid log level "TRACE" for logger root: allowed are ERROR, WARN, INFO, DEBUG`)
+	})
+	t.Run("should allow the known levels if the descriptor does not define the root logger", func(t *testing.T) {
+		// when
+		err := ValidateRootLogLevel(&core.Dogu{Name: "official/cas"}, "TRACE")
+
+		// then
+		require.NoError(t, err)
+	})
+}
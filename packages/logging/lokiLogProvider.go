@@ -2,22 +2,52 @@ package logging
 
 import (
 	"cmp"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"slices"
 	"strconv"
-	"strings"
+	"sync"
 	"time"
 
+	"github.com/cloudogu/k8s-ces-control/packages/metrics"
 	"github.com/sirupsen/logrus"
 )
 
 const defaultQueryLimit = 1000
 const maxQueryLimit = 5000 // the max query limit for Loki
 
+// lokiDirection is the order in which loki returns the lines of a query; the limit cuts off the lines at its end.
+type lokiDirection string
+
+const (
+	lokiBackward lokiDirection = "backward"
+	lokiForward  lokiDirection = "forward"
+)
+
+var (
+	queryTimeout      = time.Second * 30
+	queryTimeoutMutex sync.RWMutex
+)
+
+// SetQueryTimeout sets the time a single query to loki may take. It may be called while queries are running and
+// affects only the subsequent queries.
+func SetQueryTimeout(timeout time.Duration) {
+	queryTimeoutMutex.Lock()
+	defer queryTimeoutMutex.Unlock()
+	queryTimeout = timeout
+}
+
+func currentQueryTimeout() time.Duration {
+	queryTimeoutMutex.RLock()
+	defer queryTimeoutMutex.RUnlock()
+	return queryTimeout
+}
+
 type nowClock interface {
 	Now() time.Time
 }
@@ -29,40 +59,87 @@ func (r *realClock) Now() time.Time {
 }
 
 type LokiLogProvider struct {
-	gatewayUrl string
-	username   string
-	password   string
-	clock      nowClock
-	httpClient *http.Client
+	gatewayUrl  string
+	credentials credentialsProvider
+	clock       nowClock
+	httpClient  *http.Client
 }
 
-func NewLokiLogProvider(gatewayUrl string, username string, password string) *LokiLogProvider {
+// NewLokiLogProvider creates a new LokiLogProvider. The httpClient must not have a timeout because the query timeout
+// is set per request so that it can be changed at runtime.
+func NewLokiLogProvider(gatewayUrl string, credentials credentialsProvider, httpClient *http.Client) *LokiLogProvider {
 	return &LokiLogProvider{
-		gatewayUrl: gatewayUrl,
-		username:   username,
-		password:   password,
-		clock:      &realClock{},
-		httpClient: &http.Client{
-			Timeout: time.Second * 30,
-		},
+		gatewayUrl:  gatewayUrl,
+		credentials: credentials,
+		clock:       &realClock{},
+		httpClient:  httpClient,
+	}
+}
+
+// getLogs sends the newest logs of the dogu from loki until the given lineCount is reached, or no more logs are
+// available. Since loki requires a max time-window of 30 days and has a limit of max 5000 log-lines per request,
+// the logs are read in pages. Loki returns the pages from the newest to the oldest, so the pages are located first
+// and only the oldest one is kept. The newer pages are queried again one after another when they are sent, so that
+// only a single page is held in memory.
+func (llp *LokiLogProvider) getLogs(doguName string, linesCount int, send func(page []logLine) error) error {
+	query := newDoguQuery(doguName, "")
+	pages, oldestLines, err := llp.findPages(query, linesCount)
+	if err != nil {
+		return fmt.Errorf("failed to query logs from loki: %w", err)
+	}
+
+	if len(pages) == 0 {
+		return nil
+	}
+
+	sender := &lokiPageSender{send: send, linesCount: linesCount}
+	err = sender.sendPage(oldestLines)
+	if err != nil {
+		return err
+	}
+
+	for _, page := range slices.Backward(pages[:len(pages)-1]) {
+		logLines, err := llp.queryLogsFromLoki(query, page.startDate, page.endDate, page.limit, lokiBackward)
+		if err != nil {
+			return fmt.Errorf("failed to query logs from loki: %w", err)
+		}
+
+		err = sender.sendPage(logLines)
+		if err != nil {
+			return err
+		}
 	}
+
+	logrus.Debugf("finished loading logs for linecount; sent %d logLines", sender.sent)
+
+	return nil
+}
+
+// lokiPage is the time-window and limit of a single loki query.
+type lokiPage struct {
+	startDate time.Time
+	endDate   time.Time
+	limit     int
 }
 
-// getLogs queries logs from loki until the given lineCount is reached, or no more logs are available.
-// Since loki requires a max time-window of 30 days and has a limit of max 5000 log-lines per request,
-// a query can result in multiple request for the loki backend.
-// The time-window is adjusted accordingly to get all logs even if they are older than 30 days.
-func (llp *LokiLogProvider) getLogs(doguName string, linesCount int) ([]logLine, error) {
+// findPages returns the pages containing the newest logs from the newest to the oldest and the lines of the oldest
+// page.
+func (llp *LokiLogProvider) findPages(query logQuery, linesCount int) ([]lokiPage, []logLine, error) {
 	endDate := llp.clock.Now()
-	startDate := createQueryStartDateFromEndDate(endDate)
 
-	result := make([]logLine, 0)
+	var pages []lokiPage
+	var oldestLines []logLine
+	count := 0
 	for {
-		limit := calculateQueryLimit(linesCount, len(result))
+		page := lokiPage{
+			startDate: createQueryStartDateFromEndDate(endDate),
+			endDate:   endDate,
+			limit:     calculateQueryLimit(linesCount, count),
+		}
 
-		logLines, err := llp.queryLogsFromLoki(doguName, startDate, endDate, "", limit)
+		logLines, err := llp.queryLogsFromLoki(query, page.startDate, page.endDate, page.limit, lokiBackward)
 		if err != nil {
-			return nil, fmt.Errorf("failed to query logs from loki: %w", err)
+			return nil, nil, err
 		}
 
 		if len(logLines) <= 0 {
@@ -70,42 +147,67 @@ func (llp *LokiLogProvider) getLogs(doguName string, linesCount int) ([]logLine,
 			break
 		}
 
-		// prepend logs
-		result = append(logLines, result...)
+		pages = append(pages, page)
+		oldestLines = logLines
+		count += len(logLines)
 
-		if len(logLines) < limit {
+		if len(logLines) < page.limit {
 			// the query returned fewer lines than requested => nothing is left
 			break
 		}
 
-		if linesCount > 0 && len(result) >= linesCount {
+		if linesCount > 0 && count >= linesCount {
 			// we reached the maximum lines count
 			break
 		}
 
 		// there are still logs to read -> start with the newest log timestamp from the last response
 		endDate = logLines[0].timestamp
-		startDate = createQueryStartDateFromEndDate(endDate)
 	}
 
-	// because multiple logs can happen at the exact same timestamp and the query is batched over time,
-	// it is possible that consecutive batches contain the same logLines. These need to be removed.
-	result = deduplicateLogLines(result)
+	return pages, oldestLines, nil
+}
+
+// lokiPageSender sends the pages of getLogs from the oldest to the newest.
+type lokiPageSender struct {
+	send func(page []logLine) error
+	// linesCount limits the number of sent lines. All lines are sent if it is 0 or less.
+	linesCount int
+	sent       int
+	// previousKeys identify the lines of the previously sent page. Because multiple logs can happen at the exact same
+	// timestamp and the pages are batched over time, consecutive pages can contain the same lines.
+	previousKeys map[logLineKey]bool
+}
+
+func (s *lokiPageSender) sendPage(logLines []logLine) error {
+	keys := make(map[logLineKey]bool, len(logLines))
+	page := make([]logLine, 0, len(logLines))
+	for _, ll := range logLines {
+		key := newLogLineKey(ll)
+		keys[key] = true
+		if !s.previousKeys[key] {
+			page = append(page, ll)
+		}
+	}
+	s.previousKeys = keys
 
 	// truncate log-lines if we got more than requested
-	if linesCount > 0 && len(result) > linesCount {
-		result = result[:linesCount]
+	if s.linesCount > 0 {
+		page = page[:min(len(page), s.linesCount-s.sent)]
 	}
 
-	logrus.Debugf("finished loading logs for linecount; got %d logLines", len(result))
+	if len(page) == 0 {
+		return nil
+	}
 
-	return result, nil
+	s.sent += len(page)
+	return s.send(page)
 }
 
-// queryLogs queries logs from loki for the given time-window (startDate and endDate).
+// queryLogs queries the logs selected by the query from loki for the given time-window (startDate and endDate).
 // The allowed time-window is max 30 days (limited by loki).
 // Since loki also has a limit of max 5000 log-lines per request, a query can result in multiple request for the loki backend.
-func (llp *LokiLogProvider) queryLogs(doguName string, startDate time.Time, endDate time.Time, filter string) ([]logLine, error) {
+func (llp *LokiLogProvider) queryLogs(query logQuery, startDate time.Time, endDate time.Time) ([]logLine, error) {
 	if endDate.IsZero() {
 		endDate = llp.clock.Now()
 	}
@@ -118,7 +220,7 @@ func (llp *LokiLogProvider) queryLogs(doguName string, startDate time.Time, endD
 	limit := defaultQueryLimit
 	for {
 
-		logLines, err := llp.queryLogsFromLoki(doguName, startDate, endDate, filter, limit)
+		logLines, err := llp.queryLogsFromLoki(query, startDate, endDate, limit, lokiBackward)
 		if err != nil {
 			return nil, fmt.Errorf("failed to query logs from loki: %w", err)
 		}
@@ -149,7 +251,7 @@ func (llp *LokiLogProvider) queryLogs(doguName string, startDate time.Time, endD
 	return result, nil
 }
 
-func (llp *LokiLogProvider) queryLogsFromLoki(doguName string, startDate time.Time, endDate time.Time, filter string, limit int) ([]logLine, error) {
+func (llp *LokiLogProvider) queryLogsFromLoki(query logQuery, startDate time.Time, endDate time.Time, limit int, direction lokiDirection) ([]logLine, error) {
 	if limit <= 0 {
 		limit = defaultQueryLimit
 	}
@@ -158,14 +260,13 @@ func (llp *LokiLogProvider) queryLogsFromLoki(doguName string, startDate time.Ti
 		return nil, fmt.Errorf("the given limit of %d exceeds the maximum limit of %d", limit, maxQueryLimit)
 	}
 
-	query := fmt.Sprintf("{pod=~\"%s.*\"}", doguName)
-
-	if len(strings.TrimSpace(filter)) != 0 {
-		query = fmt.Sprintf("%s |= \"%s\" ", query, filter)
+	logQL, err := query.build()
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
 	}
 
-	logrus.Debugf("running loki query for '%s' from %s to %s with limit %d", doguName, startDate.Format(time.RFC3339), endDate.Format(time.RFC3339), limit)
-	lokiQueryUrl, err := buildLokiQueryUrl(llp.gatewayUrl, query, startDate, endDate, limit)
+	logrus.Debugf("running loki query for %v from %s to %s with limit %d", query.doguNames, startDate.Format(time.RFC3339), endDate.Format(time.RFC3339), limit)
+	lokiQueryUrl, err := buildLokiQueryUrl(llp.gatewayUrl, logQL, startDate, endDate, limit, direction)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build loki-query: %w", err)
 	}
@@ -180,9 +281,120 @@ func (llp *LokiLogProvider) queryLogsFromLoki(doguName string, startDate time.Ti
 		return nil, fmt.Errorf("failed to extract logs from loki response: %w", err)
 	}
 
+	for i := range logLines {
+		logLines[i].dogu = doguOfPod(query.doguNames, logLines[i].pod)
+	}
+
 	return logLines, nil
 }
 
+// queryLogsPage queries a page of the logs within the time window of the cursor. Loki returns the lines from the end
+// of the window backwards or from its start forwards, the lines at the boundary which were already sent are queried
+// additionally and skipped.
+func (llp *LokiLogProvider) queryLogsPage(query logQuery, cursor logCursor, size int) ([]logLine, error) {
+	direction := lokiBackward
+	if cursor.Forward {
+		direction = lokiForward
+	}
+
+	logLines, err := llp.queryLogsFromLoki(query, cursor.StartDate, cursor.EndDate, min(size+len(cursor.Sent), maxQueryLimit), direction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logs from loki: %w", err)
+	}
+
+	return cursor.selectPage(logLines, size), nil
+}
+
+// queryStatistics runs the metric query of the statistics over its time range and sums the series of the pods of
+// every dogu.
+func (llp *LokiLogProvider) queryStatistics(query logStatisticsQuery) ([]logSeries, error) {
+	logQL, err := query.build()
+	if err != nil {
+		return nil, fmt.Errorf("invalid statistics query: %w", err)
+	}
+
+	logrus.Debugf("running loki metric query for %v from %s to %s with step %s", query.doguNames, query.startDate.Format(time.RFC3339), query.endDate.Format(time.RFC3339), query.step)
+	lokiQueryUrl, err := buildLokiMetricQueryUrl(llp.gatewayUrl, logQL, query.startDate, query.endDate, query.step)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build loki-query: %w", err)
+	}
+
+	lokiResp, err := llp.doLokiHttpQuery(lokiQueryUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query statistics from loki: %w", err)
+	}
+
+	if lokiResp.Data.ResultType == lokiResultTypeStreams {
+		return nil, fmt.Errorf("failed to query statistics from loki: unexpected resultType %s", lokiResp.Data.ResultType)
+	}
+
+	return aggregateSeries(query.doguNames, lokiResp.Data.Series), nil
+}
+
+// queryVolume reads the bytes of the dogus from the volume api and their lines from the index stats api of loki. Both
+// are computed from the index of loki, so they are estimates at the granularity of chunks.
+func (llp *LokiLogProvider) queryVolume(doguNames []string, startDate time.Time, endDate time.Time) ([]logVolume, error) {
+	query := logQuery{doguNames: doguNames}
+	err := query.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid volume query: %w", err)
+	}
+
+	logrus.Debugf("querying loki log volume for %v from %s to %s", doguNames, startDate.Format(time.RFC3339), endDate.Format(time.RFC3339))
+	volumeUrl, err := buildLokiVolumeUrl(llp.gatewayUrl, query.selector(), startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build loki-query: %w", err)
+	}
+
+	lokiResp, err := llp.doLokiHttpQuery(volumeUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query log volume from loki: %w", err)
+	}
+
+	if lokiResp.Data.ResultType == lokiResultTypeStreams {
+		return nil, fmt.Errorf("failed to query log volume from loki: unexpected resultType %s", lokiResp.Data.ResultType)
+	}
+
+	doguBytes := map[string]uint64{}
+	for _, series := range lokiResp.Data.Series {
+		dogu := doguOfPod(doguNames, series.Metric["pod"])
+		for _, sample := range series.Values {
+			doguBytes[dogu] += uint64(sample.value)
+		}
+	}
+
+	volumes := make([]logVolume, 0, len(doguNames))
+	for _, doguName := range doguNames {
+		// the index stats cannot be grouped, so they are queried for every dogu
+		stats, sErr := llp.queryIndexStats(newDoguQuery(doguName, "").selector(), startDate, endDate)
+		if sErr != nil {
+			return nil, fmt.Errorf("failed to query log lines of dogu %s from loki: %w", doguName, sErr)
+		}
+
+		volumes = append(volumes, logVolume{dogu: doguName, bytes: doguBytes[doguName], lines: stats.Entries})
+	}
+
+	return volumes, nil
+}
+
+func (llp *LokiLogProvider) queryIndexStats(selector string, startDate time.Time, endDate time.Time) (lokiIndexStats, error) {
+	statsUrl, err := buildLokiIndexStatsUrl(llp.gatewayUrl, selector, startDate, endDate)
+	if err != nil {
+		return lokiIndexStats{}, fmt.Errorf("failed to build loki-query: %w", err)
+	}
+
+	stats := lokiIndexStats{}
+	err = llp.doLokiHttpRequest(statsUrl, func(body io.Reader) error {
+		dErr := json.NewDecoder(body).Decode(&stats)
+		if dErr != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", dErr)
+		}
+		return nil
+	})
+
+	return stats, err
+}
+
 func calculateQueryLimit(linesCount int, resultCount int) int {
 	if linesCount <= 0 {
 		return defaultQueryLimit
@@ -207,9 +419,9 @@ func createQueryStartDateFromEndDate(endDate time.Time) time.Time {
 	return endDate.Add(-24 * 30 * time.Hour)
 }
 
-// buildLokiQueryUrl returns a Loki query over a range of time using the given query regexp part, a start date, an end date and the maximum number of
-// results being returned.
-func buildLokiQueryUrl(lokiBaseUrl string, query string, startDate time.Time, endDate time.Time, limit int) (string, error) {
+// buildLokiQueryUrl returns a Loki query over a range of time using the given query regexp part, a start date, an end date, the maximum number of
+// results being returned and the direction in which they are returned.
+func buildLokiQueryUrl(lokiBaseUrl string, query string, startDate time.Time, endDate time.Time, limit int, direction lokiDirection) (string, error) {
 	baseUrl, err := url.Parse(lokiBaseUrl)
 	if err != nil {
 		return "", err
@@ -219,7 +431,7 @@ func buildLokiQueryUrl(lokiBaseUrl string, query string, startDate time.Time, en
 
 	params := baseUrl.Query()
 	params.Set("query", query)
-	params.Set("direction", "backward")
+	params.Set("direction", string(direction))
 	params.Set("limit", fmt.Sprintf("%d", limit))
 	params.Set("start", fmt.Sprintf("%d", startDate.UnixNano()))
 	params.Set("end", fmt.Sprintf("%d", endDate.UnixNano()))
@@ -229,16 +441,96 @@ func buildLokiQueryUrl(lokiBaseUrl string, query string, startDate time.Time, en
 	return baseUrl.String(), nil
 }
 
+// buildLokiMetricQueryUrl returns a Loki metric query over a range of time evaluated at every step.
+func buildLokiMetricQueryUrl(lokiBaseUrl string, query string, startDate time.Time, endDate time.Time, step time.Duration) (string, error) {
+	baseUrl, err := url.Parse(lokiBaseUrl)
+	if err != nil {
+		return "", err
+	}
+
+	baseUrl = baseUrl.JoinPath("/loki/api/v1/query_range")
+
+	params := baseUrl.Query()
+	params.Set("query", query)
+	params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+	params.Set("start", fmt.Sprintf("%d", startDate.UnixNano()))
+	params.Set("end", fmt.Sprintf("%d", endDate.UnixNano()))
+
+	baseUrl.RawQuery = params.Encode()
+
+	return baseUrl.String(), nil
+}
+
+// buildLokiVolumeUrl returns a Loki volume query over a range of time which returns the bytes of every pod selected by
+// the stream selector.
+func buildLokiVolumeUrl(lokiBaseUrl string, selector string, startDate time.Time, endDate time.Time) (string, error) {
+	baseUrl, err := url.Parse(lokiBaseUrl)
+	if err != nil {
+		return "", err
+	}
+
+	baseUrl = baseUrl.JoinPath("/loki/api/v1/index/volume")
+
+	params := baseUrl.Query()
+	params.Set("query", selector)
+	params.Set("targetLabels", "pod")
+	params.Set("aggregateBy", "series")
+	params.Set("limit", fmt.Sprintf("%d", maxQueryLimit))
+	params.Set("start", fmt.Sprintf("%d", startDate.UnixNano()))
+	params.Set("end", fmt.Sprintf("%d", endDate.UnixNano()))
+
+	baseUrl.RawQuery = params.Encode()
+
+	return baseUrl.String(), nil
+}
+
+// buildLokiIndexStatsUrl returns a Loki index stats query over a range of time for the streams selected by the stream
+// selector.
+func buildLokiIndexStatsUrl(lokiBaseUrl string, selector string, startDate time.Time, endDate time.Time) (string, error) {
+	baseUrl, err := url.Parse(lokiBaseUrl)
+	if err != nil {
+		return "", err
+	}
+
+	baseUrl = baseUrl.JoinPath("/loki/api/v1/index/stats")
+
+	params := baseUrl.Query()
+	params.Set("query", selector)
+	params.Set("start", fmt.Sprintf("%d", startDate.UnixNano()))
+	params.Set("end", fmt.Sprintf("%d", endDate.UnixNano()))
+
+	baseUrl.RawQuery = params.Encode()
+
+	return baseUrl.String(), nil
+}
+
 func (llp *LokiLogProvider) doLokiHttpQuery(lokiUrl string) (*lokiResponse, error) {
+	var result *lokiResponse
+	err := llp.doLokiHttpRequest(lokiUrl, func(body io.Reader) (err error) {
+		result, err = parseLokiResponse(body)
+		return err
+	})
+
+	return result, err
+}
+
+// doLokiHttpRequest sends a get request to loki and decodes the body of a successful response.
+func (llp *LokiLogProvider) doLokiHttpRequest(lokiUrl string, decode func(body io.Reader) error) (err error) {
+	started := time.Now()
+	defer func() { metrics.ObserveLokiQuery(started, err) }()
+
 	logrus.Debugf("running loki query with URL: %s", lokiUrl)
-	req, err := http.NewRequest(http.MethodGet, lokiUrl, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), currentQueryTimeout())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, lokiUrl, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request with url [%s]: %w", lokiUrl, err)
+		return fmt.Errorf("failed to create request with url [%s]: %w", lokiUrl, err)
 	}
-	req.SetBasicAuth(llp.username, llp.password)
+	llp.credentials.Authenticate(req)
 	resp, err := llp.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request with url [%s]: %w", lokiUrl, err)
+		return fmt.Errorf("failed to execute request with url [%s]: %w", lokiUrl, err)
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
@@ -254,10 +546,10 @@ func (llp *LokiLogProvider) doLokiHttpQuery(lokiUrl string) (*lokiResponse, erro
 			responseData = []byte(fmt.Sprintf("faild to read error response: %v", err))
 		}
 
-		return nil, fmt.Errorf("loki http error: status: %s, code: %d; response-body: %s", resp.Status, resp.StatusCode, responseData)
+		return fmt.Errorf("loki http error: status: %s, code: %d; response-body: %s", resp.Status, resp.StatusCode, responseData)
 	}
 
-	return parseLokiResponse(resp.Body)
+	return decode(resp.Body)
 }
 
 func parseLokiResponse(lokiResult io.Reader) (*lokiResponse, error) {
@@ -271,17 +563,28 @@ func parseLokiResponse(lokiResult io.Reader) (*lokiResponse, error) {
 		return lokiResp, fmt.Errorf("loki response status is not successful; status is %s", lokiResp.Status)
 	}
 
-	if lokiResp.Data.ResultType != "streams" {
-		return lokiResp, fmt.Errorf("loki response data aren't streams; resultType is %s", lokiResp.Data.ResultType)
+	switch lokiResp.Data.ResultType {
+	case lokiResultTypeStreams, lokiResultTypeMatrix, lokiResultTypeVector:
+	default:
+		return lokiResp, fmt.Errorf("loki response data aren't streams, a matrix or a vector; resultType is %s", lokiResp.Data.ResultType)
 	}
 
 	return lokiResp, nil
 }
 
 func extractLogLinesFromLokiResponse(lokiResponse *lokiResponse) ([]logLine, error) {
+	if lokiResponse.Data.ResultType != lokiResultTypeStreams {
+		return nil, fmt.Errorf("loki response data aren't streams; resultType is %s", lokiResponse.Data.ResultType)
+	}
+
 	logrus.Debugf("response contains %d streams", len(lokiResponse.Data.Result))
+	return extractLogLines(lokiResponse.Data.Result)
+}
+
+// extractLogLines returns the log lines of all streams sorted by their timestamp.
+func extractLogLines(streams []lokiStreamResult) ([]logLine, error) {
 	var logLines = make([]logLine, 0)
-	for _, lokiStream := range lokiResponse.Data.Result {
+	for _, lokiStream := range streams {
 		for _, value := range lokiStream.Values {
 			nanos, err := strconv.ParseInt(value[0], 10, 64)
 			if err != nil {
@@ -290,6 +593,8 @@ func extractLogLinesFromLokiResponse(lokiResponse *lokiResponse) ([]logLine, err
 			logLines = append(logLines, logLine{
 				timestamp: time.Unix(0, nanos),
 				value:     value[1],
+				container: lokiStream.Stream.Container,
+				pod:       lokiStream.Stream.Pod,
 			})
 		}
 	}
@@ -305,7 +610,8 @@ func deduplicateLogLines(logLines []logLine) []logLine {
 	result := make([]logLine, 0)
 	uniqueMap := make(map[string]bool)
 	for _, ll := range logLines {
-		uniqueKey := fmt.Sprintf("%d_%s", ll.timestamp.UnixNano(), ll.value)
+		// lines of different pods are only equal by chance
+		uniqueKey := fmt.Sprintf("%d_%s_%s", ll.timestamp.UnixNano(), ll.pod, ll.value)
 		_, exists := uniqueMap[uniqueKey]
 		if !exists {
 			uniqueMap[uniqueKey] = true
@@ -317,17 +623,121 @@ func deduplicateLogLines(logLines []logLine) []logLine {
 	return result
 }
 
+// lokiIndexStats is the response of the index stats api. It is not wrapped like the responses of queries.
+type lokiIndexStats struct {
+	Streams uint64 `json:"streams"`
+	Chunks  uint64 `json:"chunks"`
+	Bytes   uint64 `json:"bytes"`
+	Entries uint64 `json:"entries"`
+}
+
 // lokiResponse represents the root structure of a query response.
 type lokiResponse struct {
 	Status string           `json:"status"`
 	Data   lokiResponseData `json:"data"`
 }
 
-// lokiResponseData contains log stream results and metadata ResultType. ResultType could be "stream" oder "vector".
+const (
+	lokiResultTypeStreams = "streams"
+	lokiResultTypeMatrix  = "matrix"
+	lokiResultTypeVector  = "vector"
+)
+
+// lokiResponseData contains the results of a query and metadata ResultType. Log queries result in "streams", metric
+// queries in a "matrix" or a "vector".
 type lokiResponseData struct {
-	// ResultType contains the type of the response data. May be one "stream", "matrix", "vector".
-	ResultType string             `json:"resultType"`
-	Result     []lokiStreamResult `json:"result"`
+	// ResultType contains the type of the response data. May be one "streams", "matrix", "vector".
+	ResultType string `json:"resultType"`
+	// Result contains the log streams of a "streams" result.
+	Result []lokiStreamResult `json:"result"`
+	// Series contains the series of a "matrix" result or the single samples of a "vector" result.
+	Series []lokiSeriesResult `json:"-"`
+}
+
+// UnmarshalJSON decodes the result depending on its type. Results of unknown types are ignored.
+func (d *lokiResponseData) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	}{}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	*d = lokiResponseData{ResultType: raw.ResultType}
+	if len(raw.Result) == 0 {
+		return nil
+	}
+
+	switch raw.ResultType {
+	case lokiResultTypeStreams:
+		return json.Unmarshal(raw.Result, &d.Result)
+	case lokiResultTypeMatrix:
+		return json.Unmarshal(raw.Result, &d.Series)
+	case lokiResultTypeVector:
+		var vector []lokiVectorResult
+		err = json.Unmarshal(raw.Result, &vector)
+		if err != nil {
+			return err
+		}
+		for _, result := range vector {
+			d.Series = append(d.Series, lokiSeriesResult{Metric: result.Metric, Values: []lokiSample{result.Value}})
+		}
+	}
+
+	return nil
+}
+
+// lokiSeriesResult contains the labels and the samples of a series of a metric query.
+type lokiSeriesResult struct {
+	Metric map[string]string `json:"metric"`
+	Values []lokiSample      `json:"values"`
+}
+
+// lokiVectorResult contains the labels and the single sample of a series of an instant metric query.
+type lokiVectorResult struct {
+	Metric map[string]string `json:"metric"`
+	Value  lokiSample        `json:"value"`
+}
+
+// lokiSample is a sample of a metric query. Loki sends it as array of the timestamp in seconds and the value as
+// string.
+type lokiSample struct {
+	timestamp time.Time
+	value     float64
+}
+
+func (s *lokiSample) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	if len(raw) != 2 {
+		return fmt.Errorf("sample must contain a timestamp and a value: %s", data)
+	}
+
+	var seconds float64
+	err = json.Unmarshal(raw[0], &seconds)
+	if err != nil {
+		return fmt.Errorf("failed to parse sample timestamp: %w", err)
+	}
+
+	var value string
+	err = json.Unmarshal(raw[1], &value)
+	if err != nil {
+		return fmt.Errorf("failed to parse sample value: %w", err)
+	}
+
+	s.value, err = strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse sample value: %w", err)
+	}
+
+	s.timestamp = time.UnixMilli(int64(math.Round(seconds * 1000))).UTC()
+	return nil
 }
 
 // lokiStreamResult the stream and the log values.
@@ -2,10 +2,17 @@ package logging
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -31,6 +38,12 @@ var lokiResponseTestDataError []byte
 //go:embed testdata/loki-non-stream-response.json
 var lokiResponseTestDataNoStream []byte
 
+//go:embed testdata/loki-matrix-response.json
+var lokiResponseTestDataMatrix []byte
+
+//go:embed testdata/loki-vector-response.json
+var lokiResponseTestDataVector []byte
+
 func Test_extractLogLinesFromLokiResponse(t *testing.T) {
 	t.Run("should return the response as list of log lines", func(t *testing.T) {
 		// given
@@ -84,6 +97,17 @@ func Test_extractLogLinesFromLokiResponse(t *testing.T) {
 		assert.Error(t, err)
 		assert.ErrorContains(t, err, "failed to parse log timestamp: strconv.ParseInt: parsing \"time has run out...\": invalid syntax")
 	})
+	t.Run("should fail for metric response", func(t *testing.T) {
+		// given
+		lr := &lokiResponse{Data: lokiResponseData{ResultType: "matrix"}}
+
+		// when
+		_, err := extractLogLinesFromLokiResponse(lr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "loki response data aren't streams; resultType is matrix")
+	})
 }
 
 func Test_deduplicateLogLines(t *testing.T) {
@@ -155,6 +179,17 @@ func Test_deduplicateLogLines(t *testing.T) {
 				{timestamp: time.Unix(0, 1234), value: "a"},
 			},
 		},
+		{
+			name: "remove no duplicates for different pods",
+			logLines: []logLine{
+				{timestamp: time.Unix(0, 1234), value: "a", pod: "cas-1"},
+				{timestamp: time.Unix(0, 1234), value: "a", pod: "ldap-1"},
+			},
+			want: []logLine{
+				{timestamp: time.Unix(0, 1234), value: "a", pod: "cas-1"},
+				{timestamp: time.Unix(0, 1234), value: "a", pod: "ldap-1"},
+			},
+		},
 		{
 			name:     "remove none for emtpy slice",
 			logLines: []logLine{},
@@ -196,6 +231,7 @@ func Test_buildLokiQueryUrl(t *testing.T) {
 		startDate   time.Time
 		endDate     time.Time
 		limit       int
+		direction   lokiDirection
 		want        string
 		wantErr     assert.ErrorAssertionFunc
 	}{
@@ -206,9 +242,21 @@ func Test_buildLokiQueryUrl(t *testing.T) {
 			startDate:   time.Unix(0, 1701697208000046548),
 			endDate:     time.Unix(0, 1696419608000094634),
 			limit:       500,
+			direction:   lokiBackward,
 			want:        "http://loki:8001/loki/api/v1/query_range?direction=backward&end=1696419608000094634&limit=500&query=%7Bpod%3D~%22test.%2A%22%7D&start=1701697208000046548",
 			wantErr:     assert.NoError,
 		},
+		{
+			name:        "should set the forward direction",
+			lokiBaseUrl: "http://loki:8001",
+			query:       "{pod=~\"test.*\"}",
+			startDate:   time.Unix(0, 1696419608000094634),
+			endDate:     time.Unix(0, 1701697208000046548),
+			limit:       500,
+			direction:   lokiForward,
+			want:        "http://loki:8001/loki/api/v1/query_range?direction=forward&end=1701697208000046548&limit=500&query=%7Bpod%3D~%22test.%2A%22%7D&start=1696419608000094634",
+			wantErr:     assert.NoError,
+		},
 		{
 			name:        "should fail for wrong url",
 			lokiBaseUrl: "t:/\\\foo/bar",
@@ -216,17 +264,18 @@ func Test_buildLokiQueryUrl(t *testing.T) {
 			startDate:   time.Unix(0, 1701697208000046548),
 			endDate:     time.Unix(0, 1696419608000094634),
 			limit:       500,
+			direction:   lokiBackward,
 			want:        "",
 			wantErr:     assert.Error,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := buildLokiQueryUrl(tt.lokiBaseUrl, tt.query, tt.startDate, tt.endDate, tt.limit)
-			if !tt.wantErr(t, err, fmt.Sprintf("buildLokiQueryUrl(%v, %v, %v, %v, %v)", tt.lokiBaseUrl, tt.query, tt.startDate, tt.endDate, tt.limit)) {
+			got, err := buildLokiQueryUrl(tt.lokiBaseUrl, tt.query, tt.startDate, tt.endDate, tt.limit, tt.direction)
+			if !tt.wantErr(t, err, fmt.Sprintf("buildLokiQueryUrl(%v, %v, %v, %v, %v, %v)", tt.lokiBaseUrl, tt.query, tt.startDate, tt.endDate, tt.limit, tt.direction)) {
 				return
 			}
-			assert.Equalf(t, tt.want, got, "buildLokiQueryUrl(%v, %v, %v, %v, %v)", tt.lokiBaseUrl, tt.query, tt.startDate, tt.endDate, tt.limit)
+			assert.Equalf(t, tt.want, got, "buildLokiQueryUrl(%v, %v, %v, %v, %v, %v)", tt.lokiBaseUrl, tt.query, tt.startDate, tt.endDate, tt.limit, tt.direction)
 		})
 	}
 }
@@ -237,7 +286,7 @@ func TestLokiLogProvider_getLogs(t *testing.T) {
 	t.Run("should get logs", func(t *testing.T) {
 		// given
 		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=1000&query=%7Bpod%3D~%22test.%2A%22%7D&start=1653130130600667903", r.URL.String())
+			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=1000&query=%7Bpod%3D~%22test-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B1%2C10%7D-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B5%7D%22%7D&start=1653130130600667903", r.URL.String())
 			username, password, ok := r.BasicAuth()
 			assert.True(t, ok)
 			assert.Equal(t, "admin", username)
@@ -248,15 +297,14 @@ func TestLokiLogProvider_getLogs(t *testing.T) {
 		defer svr.Close()
 
 		sut := &LokiLogProvider{
-			gatewayUrl: svr.URL,
-			username:   "admin",
-			password:   "admin123",
-			clock:      &testClock{time.Unix(0, 1655722130600667903)},
-			httpClient: httpClient,
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
 		}
 
 		// when
-		actual, err := sut.getLogs("test", 0)
+		actual, err := collectLogs(t, sut, "test", 0)
 
 		// then
 		require.NoError(t, err)
@@ -267,10 +315,36 @@ func TestLokiLogProvider_getLogs(t *testing.T) {
 		assert.Equal(t, expectedLogLines, actual)
 	})
 
+	t.Run("should fail if the query exceeds the query timeout", func(t *testing.T) {
+		// given
+		previousTimeout := currentQueryTimeout()
+		defer SetQueryTimeout(previousTimeout)
+		SetQueryTimeout(10 * time.Millisecond)
+
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
+		}
+
+		// when
+		_, err := collectLogs(t, sut, "test", 0)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
 	t.Run("should get logs with limit", func(t *testing.T) {
 		// given
 		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=11&query=%7Bpod%3D~%22test.%2A%22%7D&start=1653130130600667903", r.URL.String())
+			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=11&query=%7Bpod%3D~%22test-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B1%2C10%7D-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B5%7D%22%7D&start=1653130130600667903", r.URL.String())
 			username, password, ok := r.BasicAuth()
 			assert.True(t, ok)
 			assert.Equal(t, "admin", username)
@@ -281,15 +355,14 @@ func TestLokiLogProvider_getLogs(t *testing.T) {
 		defer svr.Close()
 
 		sut := &LokiLogProvider{
-			gatewayUrl: svr.URL,
-			username:   "admin",
-			password:   "admin123",
-			clock:      &testClock{time.Unix(0, 1655722130600667903)},
-			httpClient: httpClient,
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
 		}
 
 		// when
-		actual, err := sut.getLogs("test", 11)
+		actual, err := collectLogs(t, sut, "test", 11)
 
 		// then
 		require.NoError(t, err)
@@ -299,7 +372,7 @@ func TestLokiLogProvider_getLogs(t *testing.T) {
 	t.Run("should truncate logs with limit", func(t *testing.T) {
 		// given
 		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=10&query=%7Bpod%3D~%22test.%2A%22%7D&start=1653130130600667903", r.URL.String())
+			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=10&query=%7Bpod%3D~%22test-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B1%2C10%7D-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B5%7D%22%7D&start=1653130130600667903", r.URL.String())
 			username, password, ok := r.BasicAuth()
 			assert.True(t, ok)
 			assert.Equal(t, "admin", username)
@@ -310,15 +383,14 @@ func TestLokiLogProvider_getLogs(t *testing.T) {
 		defer svr.Close()
 
 		sut := &LokiLogProvider{
-			gatewayUrl: svr.URL,
-			username:   "admin",
-			password:   "admin123",
-			clock:      &testClock{time.Unix(0, 1655722130600667903)},
-			httpClient: httpClient,
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
 		}
 
 		// when
-		actual, err := sut.getLogs("test", 10)
+		actual, err := collectLogs(t, sut, "test", 10)
 
 		// then
 		require.NoError(t, err)
@@ -328,7 +400,7 @@ func TestLokiLogProvider_getLogs(t *testing.T) {
 	t.Run("should get no logs with empty response", func(t *testing.T) {
 		// given
 		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=10&query=%7Bpod%3D~%22test.%2A%22%7D&start=1653130130600667903", r.URL.String())
+			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=10&query=%7Bpod%3D~%22test-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B1%2C10%7D-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B5%7D%22%7D&start=1653130130600667903", r.URL.String())
 			username, password, ok := r.BasicAuth()
 			assert.True(t, ok)
 			assert.Equal(t, "admin", username)
@@ -339,15 +411,14 @@ func TestLokiLogProvider_getLogs(t *testing.T) {
 		defer svr.Close()
 
 		sut := &LokiLogProvider{
-			gatewayUrl: svr.URL,
-			username:   "admin",
-			password:   "admin123",
-			clock:      &testClock{time.Unix(0, 1655722130600667903)},
-			httpClient: httpClient,
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
 		}
 
 		// when
-		actual, err := sut.getLogs("test", 10)
+		actual, err := collectLogs(t, sut, "test", 10)
 
 		// then
 		require.NoError(t, err)
@@ -357,15 +428,14 @@ func TestLokiLogProvider_getLogs(t *testing.T) {
 	t.Run("should fail on building query", func(t *testing.T) {
 		// given
 		sut := &LokiLogProvider{
-			gatewayUrl: "t:/\\\foo/bar",
-			username:   "admin",
-			password:   "admin123",
-			clock:      &testClock{time.Unix(0, 1655722130600667903)},
-			httpClient: httpClient,
+			gatewayUrl:  "t:/\\\foo/bar",
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
 		}
 
 		// when
-		_, err := sut.getLogs("test", 0)
+		_, err := collectLogs(t, sut, "test", 0)
 
 		// then
 		require.Error(t, err)
@@ -375,7 +445,7 @@ func TestLokiLogProvider_getLogs(t *testing.T) {
 	t.Run("should fail to do query", func(t *testing.T) {
 		// given
 		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=1000&query=%7Bpod%3D~%22test.%2A%22%7D&start=1653130130600667903", r.URL.String())
+			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=1000&query=%7Bpod%3D~%22test-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B1%2C10%7D-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B5%7D%22%7D&start=1653130130600667903", r.URL.String())
 			username, password, ok := r.BasicAuth()
 			assert.True(t, ok)
 			assert.Equal(t, "admin", username)
@@ -386,15 +456,14 @@ func TestLokiLogProvider_getLogs(t *testing.T) {
 		defer svr.Close()
 
 		sut := &LokiLogProvider{
-			gatewayUrl: svr.URL,
-			username:   "admin",
-			password:   "admin123",
-			clock:      &testClock{time.Unix(0, 1655722130600667903)},
-			httpClient: httpClient,
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
 		}
 
 		// when
-		_, err := sut.getLogs("test", 0)
+		_, err := collectLogs(t, sut, "test", 0)
 
 		// then
 		require.Error(t, err)
@@ -404,7 +473,7 @@ func TestLokiLogProvider_getLogs(t *testing.T) {
 	t.Run("should fail to do extract log lines", func(t *testing.T) {
 		// given
 		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=1000&query=%7Bpod%3D~%22test.%2A%22%7D&start=1653130130600667903", r.URL.String())
+			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=1000&query=%7Bpod%3D~%22test-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B1%2C10%7D-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B5%7D%22%7D&start=1653130130600667903", r.URL.String())
 			username, password, ok := r.BasicAuth()
 			assert.True(t, ok)
 			assert.Equal(t, "admin", username)
@@ -415,30 +484,139 @@ func TestLokiLogProvider_getLogs(t *testing.T) {
 		defer svr.Close()
 
 		sut := &LokiLogProvider{
-			gatewayUrl: svr.URL,
-			username:   "admin",
-			password:   "admin123",
-			clock:      &testClock{time.Unix(0, 1655722130600667903)},
-			httpClient: httpClient,
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
 		}
 
 		// when
-		_, err := sut.getLogs("test", 0)
+		_, err := collectLogs(t, sut, "test", 0)
 
 		// then
 		require.Error(t, err)
 		assert.ErrorContains(t, err, "failed to extract logs from loki response: failed to parse log timestamp")
 	})
 
+	t.Run("should send the pages from the oldest to the newest", func(t *testing.T) {
+		// given
+		backend := &fakeLoki{t: t}
+		svr := httptest.NewServer(backend)
+		defer svr.Close()
+
+		var stored []logLine
+		for i := range 1500 {
+			stored = append(stored, casLine(i-1500, fmt.Sprintf("line %d", i)))
+		}
+		backend.add(stored...)
+
+		sut := &LokiLogProvider{
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{},
+			clock:       &testClock{contractNow},
+			httpClient:  httpClient,
+		}
+
+		for _, tt := range []struct {
+			linesCount int
+			pageSizes  []int
+		}{
+			{linesCount: 0, pageSizes: []int{500, 1000}},
+			{linesCount: 1200, pageSizes: []int{200, 1000}},
+			{linesCount: 800, pageSizes: []int{800}},
+		} {
+			var pageSizes []int
+			var actual []logLine
+
+			// when
+			err := sut.getLogs("cas", tt.linesCount, func(page []logLine) error {
+				pageSizes = append(pageSizes, len(page))
+				actual = append(actual, page...)
+				return nil
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tt.pageSizes, pageSizes)
+			expected := stored
+			if tt.linesCount > 0 {
+				expected = stored[len(stored)-tt.linesCount:]
+			}
+			assertLogLines(t, expected, actual)
+		}
+	})
+
+	t.Run("should stop at an error of sending a page", func(t *testing.T) {
+		// given
+		backend := &fakeLoki{t: t}
+		svr := httptest.NewServer(backend)
+		defer svr.Close()
+
+		for i := range 1500 {
+			backend.add(casLine(i-1500, fmt.Sprintf("line %d", i)))
+		}
+
+		sut := &LokiLogProvider{
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{},
+			clock:       &testClock{contractNow},
+			httpClient:  httpClient,
+		}
+
+		sentPages := 0
+
+		// when
+		err := sut.getLogs("cas", 0, func(page []logLine) error {
+			sentPages++
+			return assert.AnError
+		})
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, sentPages)
+	})
+
 }
 
 func TestLokiLogProvider_queryLogs(t *testing.T) {
 	httpClient := http.DefaultClient
 
+	t.Run("should merge the logs of several dogus and label them", func(t *testing.T) {
+		// given
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "{pod=~\"(cas|ldap)"+podNameSuffixPattern+"\"}", r.URL.Query().Get("query"))
+			_, err := w.Write([]byte(`{"status":"success","data":{"resultType":"streams","result":[
+				{"stream":{"pod":"cas-7d9f8b6c4-x2x4z","container":"cas"},"values":[["3","cas second"],["1","cas first"]]},
+				{"stream":{"pod":"ldap-5c4b8d79f-qwrtz","container":"ldap"},"values":[["2","ldap first"]]}
+			]}}`))
+			require.NoError(t, err)
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
+		}
+
+		// when
+		actual, err := sut.queryLogs(logQuery{doguNames: []string{"cas", "ldap"}}, time.Time{}, time.Time{})
+
+		// then
+		require.NoError(t, err)
+		expectedLogLines := []logLine{
+			{timestamp: time.Unix(0, 1), value: "cas first", dogu: "cas", container: "cas", pod: "cas-7d9f8b6c4-x2x4z"},
+			{timestamp: time.Unix(0, 2), value: "ldap first", dogu: "ldap", container: "ldap", pod: "ldap-5c4b8d79f-qwrtz"},
+			{timestamp: time.Unix(0, 3), value: "cas second", dogu: "cas", container: "cas", pod: "cas-7d9f8b6c4-x2x4z"},
+		}
+		assert.Equal(t, expectedLogLines, actual)
+	})
+
 	t.Run("should query logs", func(t *testing.T) {
 		// given
 		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=1000&query=%7Bpod%3D~%22test.%2A%22%7D&start=1653130130600667903", r.URL.String())
+			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1655722130600667903&limit=1000&query=%7Bpod%3D~%22test-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B1%2C10%7D-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B5%7D%22%7D&start=1653130130600667903", r.URL.String())
 			username, password, ok := r.BasicAuth()
 			assert.True(t, ok)
 			assert.Equal(t, "admin", username)
@@ -449,15 +627,14 @@ func TestLokiLogProvider_queryLogs(t *testing.T) {
 		defer svr.Close()
 
 		sut := &LokiLogProvider{
-			gatewayUrl: svr.URL,
-			username:   "admin",
-			password:   "admin123",
-			clock:      &testClock{time.Unix(0, 1655722130600667903)},
-			httpClient: httpClient,
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
 		}
 
 		// when
-		actual, err := sut.queryLogs("test", time.Time{}, time.Time{}, "")
+		actual, err := sut.queryLogs(newDoguQuery("test", ""), time.Time{}, time.Time{})
 
 		// then
 		require.NoError(t, err)
@@ -471,7 +648,7 @@ func TestLokiLogProvider_queryLogs(t *testing.T) {
 	t.Run("should query logs with dates and filter", func(t *testing.T) {
 		// given
 		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1712131304000000000&limit=1000&query=%7Bpod%3D~%22test.%2A%22%7D+%7C%3D+%22foo%3Dbar%22+&start=1711616504000000000", r.URL.String())
+			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1712131304000000000&limit=1000&query=%7Bpod%3D~%22test-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B1%2C10%7D-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B5%7D%22%7D+%7C%3D+%22foo%3Dbar%22&start=1711616504000000000", r.URL.String())
 			username, password, ok := r.BasicAuth()
 			assert.True(t, ok)
 			assert.Equal(t, "admin", username)
@@ -485,15 +662,14 @@ func TestLokiLogProvider_queryLogs(t *testing.T) {
 		end := time.Unix(1712131304, 0)
 
 		sut := &LokiLogProvider{
-			gatewayUrl: svr.URL,
-			username:   "admin",
-			password:   "admin123",
-			clock:      &testClock{time.Unix(0, 1655722130600667903)},
-			httpClient: httpClient,
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
 		}
 
 		// when
-		actual, err := sut.queryLogs("test", start, end, "foo=bar")
+		actual, err := sut.queryLogs(newDoguQuery("test", "foo=bar"), start, end)
 
 		// then
 		require.NoError(t, err)
@@ -507,7 +683,7 @@ func TestLokiLogProvider_queryLogs(t *testing.T) {
 	t.Run("should query logs with empty response", func(t *testing.T) {
 		// given
 		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1712131304000000000&limit=1000&query=%7Bpod%3D~%22test.%2A%22%7D+%7C%3D+%22foo%3Dbar%22+&start=1711616504000000000", r.URL.String())
+			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1712131304000000000&limit=1000&query=%7Bpod%3D~%22test-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B1%2C10%7D-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B5%7D%22%7D+%7C%3D+%22foo%3Dbar%22&start=1711616504000000000", r.URL.String())
 			username, password, ok := r.BasicAuth()
 			assert.True(t, ok)
 			assert.Equal(t, "admin", username)
@@ -521,15 +697,14 @@ func TestLokiLogProvider_queryLogs(t *testing.T) {
 		end := time.Unix(1712131304, 0)
 
 		sut := &LokiLogProvider{
-			gatewayUrl: svr.URL,
-			username:   "admin",
-			password:   "admin123",
-			clock:      &testClock{time.Unix(0, 1655722130600667903)},
-			httpClient: httpClient,
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
 		}
 
 		// when
-		actual, err := sut.queryLogs("test", start, end, "foo=bar")
+		actual, err := sut.queryLogs(newDoguQuery("test", "foo=bar"), start, end)
 
 		// then
 		require.NoError(t, err)
@@ -539,7 +714,7 @@ func TestLokiLogProvider_queryLogs(t *testing.T) {
 	t.Run("should fail to query logs for error in loki", func(t *testing.T) {
 		// given
 		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1712131304000000000&limit=1000&query=%7Bpod%3D~%22test.%2A%22%7D+%7C%3D+%22foo%3Dbar%22+&start=1711616504000000000", r.URL.String())
+			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1712131304000000000&limit=1000&query=%7Bpod%3D~%22test-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B1%2C10%7D-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B5%7D%22%7D+%7C%3D+%22foo%3Dbar%22&start=1711616504000000000", r.URL.String())
 			username, password, ok := r.BasicAuth()
 			assert.True(t, ok)
 			assert.Equal(t, "admin", username)
@@ -554,15 +729,14 @@ func TestLokiLogProvider_queryLogs(t *testing.T) {
 		end := time.Unix(1712131304, 0)
 
 		sut := &LokiLogProvider{
-			gatewayUrl: svr.URL,
-			username:   "admin",
-			password:   "admin123",
-			clock:      &testClock{time.Unix(0, 1655722130600667903)},
-			httpClient: httpClient,
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
 		}
 
 		// when
-		_, err := sut.queryLogs("test", start, end, "foo=bar")
+		_, err := sut.queryLogs(newDoguQuery("test", "foo=bar"), start, end)
 
 		// then
 		require.Error(t, err)
@@ -575,7 +749,7 @@ func TestLokiLogProvider_queryLogsFromLoki(t *testing.T) {
 	t.Run("should query logs", func(t *testing.T) {
 		// given
 		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1712131304000000000&limit=1000&query=%7Bpod%3D~%22test.%2A%22%7D&start=1711616504000000000", r.URL.String())
+			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1712131304000000000&limit=1000&query=%7Bpod%3D~%22test-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B1%2C10%7D-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B5%7D%22%7D&start=1711616504000000000", r.URL.String())
 			username, password, ok := r.BasicAuth()
 			assert.True(t, ok)
 			assert.Equal(t, "admin", username)
@@ -589,15 +763,14 @@ func TestLokiLogProvider_queryLogsFromLoki(t *testing.T) {
 		end := time.Unix(1712131304, 0)
 
 		sut := &LokiLogProvider{
-			gatewayUrl: svr.URL,
-			username:   "admin",
-			password:   "admin123",
-			clock:      &testClock{time.Unix(0, 1655722130600667903)},
-			httpClient: httpClient,
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
 		}
 
 		// when
-		actual, err := sut.queryLogsFromLoki("test", start, end, "", 0)
+		actual, err := sut.queryLogsFromLoki(newDoguQuery("test", ""), start, end, 0, lokiBackward)
 
 		// then
 		require.NoError(t, err)
@@ -611,7 +784,7 @@ func TestLokiLogProvider_queryLogsFromLoki(t *testing.T) {
 	t.Run("should query logs with filter", func(t *testing.T) {
 		// given
 		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1712131304000000000&limit=200&query=%7Bpod%3D~%22test.%2A%22%7D+%7C%3D+%22level%3Derror%22+&start=1711616504000000000", r.URL.String())
+			assert.Equal(t, "/loki/api/v1/query_range?direction=backward&end=1712131304000000000&limit=200&query=%7Bpod%3D~%22test-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B1%2C10%7D-%5Bbcdfghjklmnpqrstvwxz2456789%5D%7B5%7D%22%7D+%7C%3D+%22level%3Derror%22&start=1711616504000000000", r.URL.String())
 			username, password, ok := r.BasicAuth()
 			assert.True(t, ok)
 			assert.Equal(t, "admin", username)
@@ -622,18 +795,17 @@ func TestLokiLogProvider_queryLogsFromLoki(t *testing.T) {
 		defer svr.Close()
 
 		sut := &LokiLogProvider{
-			gatewayUrl: svr.URL,
-			username:   "admin",
-			password:   "admin123",
-			clock:      &testClock{time.Unix(0, 1655722130600667903)},
-			httpClient: httpClient,
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{time.Unix(0, 1655722130600667903)},
+			httpClient:  httpClient,
 		}
 
 		start := time.Unix(1711616504, 0)
 		end := time.Unix(1712131304, 0)
 
 		// when
-		actual, err := sut.queryLogsFromLoki("test", start, end, "level=error", 200)
+		actual, err := sut.queryLogsFromLoki(newDoguQuery("test", "level=error"), start, end, 200, lokiBackward)
 
 		// then
 		require.NoError(t, err)
@@ -655,7 +827,7 @@ func TestLokiLogProvider_queryLogsFromLoki(t *testing.T) {
 		end := time.Unix(1712131304, 0)
 
 		// when
-		_, err := sut.queryLogsFromLoki("test", start, end, "level=error", 8000)
+		_, err := sut.queryLogsFromLoki(newDoguQuery("test", "level=error"), start, end, 8000, lokiBackward)
 
 		// then
 		require.Error(t, err)
@@ -663,6 +835,64 @@ func TestLokiLogProvider_queryLogsFromLoki(t *testing.T) {
 	})
 }
 
+func TestLokiLogProvider_queryLogsPage(t *testing.T) {
+	t.Run("should query the sent lines at the boundary additionally and skip them", func(t *testing.T) {
+		// given
+		query := newDoguQuery("cas", "")
+		sent := casLine(-20, "login succeeded for admin")
+		cursor := newLogCursor(query, contractNow.Add(-20*time.Second), contractNow, true)
+		cursor.Sent = []uint64{hashLogLine(sent)}
+
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "forward", r.URL.Query().Get("direction"))
+			assert.Equal(t, "3", r.URL.Query().Get("limit"))
+			response := lokiResponse{Status: "success", Data: lokiResponseData{ResultType: "streams", Result: fakeLokiStreams([]logLine{
+				sent,
+				casLine(-10, "request handled"),
+				casLine(-5, "cas stopped"),
+			})}}
+			assert.NoError(t, json.NewEncoder(w).Encode(response))
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{contractNow},
+			httpClient:  http.DefaultClient,
+		}
+
+		// when
+		actual, err := sut.queryLogsPage(query, cursor, 2)
+
+		// then
+		require.NoError(t, err)
+		assertLogLines(t, []logLine{casLine(-10, "request handled"), casLine(-5, "cas stopped")}, actual)
+	})
+	t.Run("should fail for an error of loki", func(t *testing.T) {
+		// given
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{contractNow},
+			httpClient:  http.DefaultClient,
+		}
+		query := newDoguQuery("cas", "")
+
+		// when
+		_, err := sut.queryLogsPage(query, newLogCursor(query, contractNow.Add(-time.Hour), contractNow, false), 2)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to query logs from loki")
+	})
+}
+
 func TestLokiLogProvider_doLokiHttpQuery(t *testing.T) {
 	httpClient := http.DefaultClient
 
@@ -680,9 +910,8 @@ func TestLokiLogProvider_doLokiHttpQuery(t *testing.T) {
 		defer svr.Close()
 
 		sut := &LokiLogProvider{
-			username:   "admin",
-			password:   "admin123",
-			httpClient: httpClient,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			httpClient:  httpClient,
 		}
 
 		// when
@@ -726,9 +955,8 @@ func TestLokiLogProvider_doLokiHttpQuery(t *testing.T) {
 	t.Run("should fail to execute request", func(t *testing.T) {
 		// given
 		sut := &LokiLogProvider{
-			username:   "admin",
-			password:   "admin123",
-			httpClient: httpClient,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			httpClient:  httpClient,
 		}
 
 		// when
@@ -754,9 +982,8 @@ func TestLokiLogProvider_doLokiHttpQuery(t *testing.T) {
 		defer svr.Close()
 
 		sut := &LokiLogProvider{
-			username:   "admin",
-			password:   "admin123",
-			httpClient: httpClient,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			httpClient:  httpClient,
 		}
 
 		// when
@@ -779,9 +1006,8 @@ func TestLokiLogProvider_doLokiHttpQuery(t *testing.T) {
 		defer svr.Close()
 
 		sut := &LokiLogProvider{
-			username:   "admin",
-			password:   "admin123",
-			httpClient: httpClient,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			httpClient:  httpClient,
 		}
 
 		// when
@@ -834,7 +1060,7 @@ func TestLokiLogProvider_parseLokiResponse(t *testing.T) {
 		assert.ErrorContains(t, err, "loki response status is not successful; status is error")
 	})
 
-	t.Run("should fail on non stream response", func(t *testing.T) {
+	t.Run("should fail on unsupported response", func(t *testing.T) {
 		// given
 
 		// when
@@ -842,22 +1068,233 @@ func TestLokiLogProvider_parseLokiResponse(t *testing.T) {
 
 		// then
 		require.Error(t, err)
-		assert.ErrorContains(t, err, "loki response data aren't streams; resultType is not a stream")
+		assert.ErrorContains(t, err, "loki response data aren't streams, a matrix or a vector; resultType is not a stream")
+	})
+
+	t.Run("should parse matrix response", func(t *testing.T) {
+		// when
+		actual, err := parseLokiResponse(bytes.NewReader(lokiResponseTestDataMatrix))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, lokiResponseData{
+			ResultType: "matrix",
+			Series: []lokiSeriesResult{{
+				Metric: map[string]string{"pod": contractLdapPod, "detected_level": "error"},
+				Values: []lokiSample{
+					{timestamp: contractNow.Add(-time.Minute), value: 2},
+					{timestamp: contractNow.Add(500 * time.Millisecond), value: 7},
+				},
+			}},
+		}, actual.Data)
+	})
+
+	t.Run("should parse vector response", func(t *testing.T) {
+		// when
+		actual, err := parseLokiResponse(bytes.NewReader(lokiResponseTestDataVector))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, lokiResponseData{
+			ResultType: "vector",
+			Series: []lokiSeriesResult{{
+				Metric: map[string]string{"pod": contractLdapPod},
+				Values: []lokiSample{{timestamp: contractNow, value: 42}},
+			}},
+		}, actual.Data)
+	})
+
+	t.Run("should fail on invalid sample", func(t *testing.T) {
+		// when
+		_, err := parseLokiResponse(strings.NewReader(`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1772625600,"many"]]}]}}`))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse sample value")
+	})
+}
+
+func TestLokiLogProvider_queryStatistics(t *testing.T) {
+	t.Run("should run the metric query and sum the series of every dogu", func(t *testing.T) {
+		// given
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/loki/api/v1/query_range", r.URL.Path)
+			assert.Equal(t, `sum by (pod, detected_level) (count_over_time({pod=~"ldap`+podNameSuffixPattern+`"} [60000ms]))`, r.URL.Query().Get("query"))
+			assert.Equal(t, "60", r.URL.Query().Get("step"))
+			assert.Equal(t, strconv.FormatInt(contractNow.Add(-time.Hour).UnixNano(), 10), r.URL.Query().Get("start"))
+			assert.Equal(t, strconv.FormatInt(contractNow.UnixNano(), 10), r.URL.Query().Get("end"))
+			assert.Empty(t, r.URL.Query().Get("direction"))
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+				{"metric":{"pod":"ldap-5c4b8d79f-qwrtz","detected_level":"error"},"values":[[1772625540,"2"],[1772625600,"1"]]},
+				{"metric":{"pod":"ldap-5c4b8d79f-bcdfg","detected_level":"error"},"values":[[1772625600,"3"]]},
+				{"metric":{"pod":"ldap-5c4b8d79f-qwrtz","detected_level":"info"},"values":[[1772625600,"10"]]}
+			]}}`))
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{gatewayUrl: svr.URL, credentials: basicAuth{}, clock: &testClock{contractNow}, httpClient: http.DefaultClient}
+		query := logStatisticsQuery{
+			logQuery:  newDoguQuery("ldap", ""),
+			function:  logStatisticsCount,
+			startDate: contractNow.Add(-time.Hour),
+			endDate:   contractNow,
+			step:      time.Minute,
+			byLevel:   true,
+		}
+
+		// when
+		actual, err := sut.queryStatistics(query)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []logSeries{
+			{dogu: "ldap", level: "error", samples: []logSample{{timestamp: contractNow.Add(-time.Minute), value: 2}, {timestamp: contractNow, value: 4}}},
+			{dogu: "ldap", level: "info", samples: []logSample{{timestamp: contractNow, value: 10}}},
+		}, actual)
+	})
+
+	t.Run("should fail for an invalid query", func(t *testing.T) {
+		// given
+		sut := &LokiLogProvider{gatewayUrl: "http://loki", credentials: basicAuth{}, clock: &testClock{contractNow}, httpClient: http.DefaultClient}
+
+		// when
+		_, err := sut.queryStatistics(logStatisticsQuery{logQuery: newDoguQuery("ldap", ""), function: logStatisticsRate, startDate: contractNow, endDate: contractNow, step: time.Minute})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid statistics query")
+	})
+
+	t.Run("should fail for a log response", func(t *testing.T) {
+		// given
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(lokiResponseTestData)
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{gatewayUrl: svr.URL, credentials: basicAuth{}, clock: &testClock{contractNow}, httpClient: http.DefaultClient}
+
+		// when
+		_, err := sut.queryStatistics(logStatisticsQuery{logQuery: newDoguQuery("ldap", ""), function: logStatisticsRate, startDate: contractNow.Add(-time.Hour), endDate: contractNow, step: time.Minute})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to query statistics from loki: unexpected resultType streams")
+	})
+}
+
+func TestLokiLogProvider_queryVolume(t *testing.T) {
+	t.Run("should sum the bytes of the pods and query the lines of every dogu", func(t *testing.T) {
+		// given
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, strconv.FormatInt(contractNow.Add(-time.Hour).UnixNano(), 10), r.URL.Query().Get("start"))
+			assert.Equal(t, strconv.FormatInt(contractNow.UnixNano(), 10), r.URL.Query().Get("end"))
+
+			switch r.URL.Path {
+			case "/loki/api/v1/index/volume":
+				assert.Equal(t, `{pod=~"(cas|ldap)`+podNameSuffixPattern+`"}`, r.URL.Query().Get("query"))
+				assert.Equal(t, "pod", r.URL.Query().Get("targetLabels"))
+				assert.Equal(t, "series", r.URL.Query().Get("aggregateBy"))
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+					{"metric":{"pod":"ldap-5c4b8d79f-qwrtz"},"value":[1772625600,"2048"]},
+					{"metric":{"pod":"ldap-5c4b8d79f-bcdfg"},"value":[1772625600,"1024"]},
+					{"metric":{"pod":"postfix-5c4b8d79f-bcdfg"},"value":[1772625600,"512"]}
+				]}}`))
+			case "/loki/api/v1/index/stats":
+				switch r.URL.Query().Get("query") {
+				case `{pod=~"ldap` + podNameSuffixPattern + `"}`:
+					_, _ = w.Write([]byte(`{"streams":2,"chunks":3,"bytes":3000,"entries":42}`))
+				case `{pod=~"cas` + podNameSuffixPattern + `"}`:
+					_, _ = w.Write([]byte(`{"streams":0,"chunks":0,"bytes":0,"entries":0}`))
+				default:
+					t.Errorf("unexpected stats query %s", r.URL.Query().Get("query"))
+				}
+			default:
+				t.Errorf("unexpected path %s", r.URL.Path)
+			}
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{gatewayUrl: svr.URL, credentials: basicAuth{}, clock: &testClock{contractNow}, httpClient: http.DefaultClient}
+
+		// when
+		actual, err := sut.queryVolume([]string{"cas", "ldap"}, contractNow.Add(-time.Hour), contractNow)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []logVolume{
+			{dogu: "cas"},
+			{dogu: "ldap", bytes: 3072, lines: 42},
+		}, actual)
+	})
+
+	t.Run("should fail without dogus", func(t *testing.T) {
+		// given
+		sut := &LokiLogProvider{gatewayUrl: "http://loki", credentials: basicAuth{}, clock: &testClock{contractNow}, httpClient: http.DefaultClient}
+
+		// when
+		_, err := sut.queryVolume(nil, contractNow.Add(-time.Hour), contractNow)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid volume query")
+	})
+
+	t.Run("should fail if the volume cannot be queried", func(t *testing.T) {
+		// given
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("volume api disabled"))
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{gatewayUrl: svr.URL, credentials: basicAuth{}, clock: &testClock{contractNow}, httpClient: http.DefaultClient}
+
+		// when
+		_, err := sut.queryVolume([]string{"ldap"}, contractNow.Add(-time.Hour), contractNow)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to query log volume from loki")
+		assert.ErrorContains(t, err, "volume api disabled")
+	})
+
+	t.Run("should fail for an invalid stats response", func(t *testing.T) {
+		// given
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/loki/api/v1/index/volume" {
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"entries":"many"}`))
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{gatewayUrl: svr.URL, credentials: basicAuth{}, clock: &testClock{contractNow}, httpClient: http.DefaultClient}
+
+		// when
+		_, err := sut.queryVolume([]string{"ldap"}, contractNow.Add(-time.Hour), contractNow)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to query log lines of dogu ldap from loki: failed to unmarshal response")
 	})
 }
 
 func TestNewLokiLogProvider(t *testing.T) {
 	t.Run("should create LokiLogProvider", func(t *testing.T) {
 		// given
+		credentials := basicAuth{username: "user", password: "password"}
+		httpClient := &http.Client{}
 
 		// when
-		llp := NewLokiLogProvider("gatewayUrl", "user", "password")
+		llp := NewLokiLogProvider("gatewayUrl", credentials, httpClient)
 
 		// then
 		require.NotNil(t, llp)
 		assert.Equal(t, "gatewayUrl", llp.gatewayUrl)
-		assert.Equal(t, "user", llp.username)
-		assert.Equal(t, "password", llp.password)
+		assert.Equal(t, credentials, llp.credentials)
+		assert.Same(t, httpClient, llp.httpClient)
 		assert.IsType(t, &realClock{}, llp.clock)
 	})
 }
@@ -868,6 +1305,16 @@ func Test_realClock_Now(t *testing.T) {
 	assert.IsType(t, actual, time.Now())
 }
 
+// basicAuth authenticates the requests to the test server with fixed credentials.
+type basicAuth struct {
+	username string
+	password string
+}
+
+func (b basicAuth) Authenticate(req *http.Request) {
+	req.SetBasicAuth(b.username, b.password)
+}
+
 type testClock struct {
 	time time.Time
 }
@@ -875,3 +1322,175 @@ type testClock struct {
 func (tc *testClock) Now() time.Time {
 	return tc.time
 }
+
+func TestLokiLogProvider_contract(t *testing.T) {
+	testLogProviderContract(t, func(t *testing.T, clock nowClock) (logProvider, logBackend) {
+		backend := &fakeLoki{t: t}
+		svr := httptest.NewServer(backend)
+		t.Cleanup(svr.Close)
+
+		return &LokiLogProvider{
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       clock,
+			httpClient:  http.DefaultClient,
+		}, backend
+	})
+}
+
+var (
+	fakeLokiSelectorRegexp   = regexp.MustCompile(`^\{pod=~("(?:[^"\\]|\\.)*")\}`)
+	fakeLokiLineFilterRegexp = regexp.MustCompile(`^ (\|=|!=) ("(?:[^"\\]|\\.)*")`)
+)
+
+// fakeLoki serves the query range api and the tail api of loki for stream selectors on the pod label and substring
+// line filters.
+type fakeLoki struct {
+	t     *testing.T
+	mutex sync.Mutex
+	lines []logLine
+}
+
+func (f *fakeLoki) add(lines ...logLine) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.lines = append(f.lines, lines...)
+}
+
+// linesSince returns the stored lines from the given index on and the index of the next line.
+func (f *fakeLoki) linesSince(index int) ([]logLine, int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return slices.Clone(f.lines[index:]), len(f.lines)
+}
+
+func (f *fakeLoki) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	matches, err := parseFakeLokiQuery(r.URL.Query().Get("query"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	start := parseFakeLokiTime(f.t, r.URL.Query().Get("start"))
+	switch r.URL.Path {
+	case "/loki/api/v1/query_range":
+		end := parseFakeLokiTime(f.t, r.URL.Query().Get("end"))
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		require.NoError(f.t, err)
+
+		lines, _ := f.linesSince(0)
+		selected := make([]logLine, 0)
+		for _, line := range lines {
+			if matches(line) && !line.timestamp.Before(start) && line.timestamp.Before(end) {
+				selected = append(selected, line)
+			}
+		}
+		// the lines are returned backward from the end or forward from the start
+		slices.SortFunc(selected, func(a, b logLine) int { return b.timestamp.Compare(a.timestamp) })
+		if r.URL.Query().Get("direction") == string(lokiForward) {
+			slices.Reverse(selected)
+		}
+		selected = selected[:min(limit, len(selected))]
+
+		response := lokiResponse{Status: "success", Data: lokiResponseData{ResultType: "streams", Result: fakeLokiStreams(selected)}}
+		assert.NoError(f.t, json.NewEncoder(w).Encode(response))
+	case "/loki/api/v1/tail":
+		conn := upgradeTail(f.t, w, r)
+		defer conn.Close()
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			_, _, _ = conn.ReadMessage()
+		}()
+
+		next := 0
+		for {
+			var lines []logLine
+			lines, next = f.linesSince(next)
+			selected := make([]logLine, 0)
+			for _, line := range lines {
+				if matches(line) && !line.timestamp.Before(start) {
+					selected = append(selected, line)
+				}
+			}
+			if len(selected) > 0 && conn.WriteJSON(lokiTailResponse{Streams: fakeLokiStreams(selected)}) != nil {
+				return
+			}
+
+			select {
+			case <-closed:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// parseFakeLokiQuery returns a function which reports whether a line is selected by the query.
+func parseFakeLokiQuery(query string) (func(logLine) bool, error) {
+	selector := fakeLokiSelectorRegexp.FindStringSubmatch(query)
+	if selector == nil {
+		return nil, fmt.Errorf("unsupported stream selector in query %s", query)
+	}
+	podPattern, err := strconv.Unquote(selector[1])
+	if err != nil {
+		return nil, err
+	}
+	podRegexp, err := regexp.Compile("^(?:" + podPattern + ")$")
+	if err != nil {
+		return nil, err
+	}
+
+	var lineFilters []logFilter
+	rest := query[len(selector[0]):]
+	for rest != "" {
+		filter := fakeLokiLineFilterRegexp.FindStringSubmatch(rest)
+		if filter == nil {
+			return nil, fmt.Errorf("unsupported expression %q in query %s", rest, query)
+		}
+		value, err := strconv.Unquote(filter[2])
+		if err != nil {
+			return nil, err
+		}
+		lineFilters = append(lineFilters, logFilter{value: value, exclude: filter[1] == "!="})
+		rest = rest[len(filter[0]):]
+	}
+
+	return func(line logLine) bool {
+		if !podRegexp.MatchString(line.pod) {
+			return false
+		}
+		for _, filter := range lineFilters {
+			if strings.Contains(line.value, filter.value) == filter.exclude {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func parseFakeLokiTime(t *testing.T, value string) time.Time {
+	nanos, err := strconv.ParseInt(value, 10, 64)
+	require.NoError(t, err)
+	return time.Unix(0, nanos).UTC()
+}
+
+// fakeLokiStreams groups the lines into one stream per container.
+func fakeLokiStreams(lines []logLine) []lokiStreamResult {
+	streams := make([]lokiStreamResult, 0)
+	for _, line := range lines {
+		index := slices.IndexFunc(streams, func(stream lokiStreamResult) bool {
+			return stream.Stream.Pod == line.pod && stream.Stream.Container == line.container
+		})
+		if index < 0 {
+			streams = append(streams, lokiStreamResult{Stream: lokiStream{Pod: line.pod, Container: line.container}})
+			index = len(streams) - 1
+		}
+		streams[index].Values = append(streams[index].Values, []string{strconv.FormatInt(line.timestamp.UnixNano(), 10), line.value})
+	}
+
+	return streams
+}
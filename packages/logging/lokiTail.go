@@ -0,0 +1,381 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// followOverlap is the time before the newest sent log line of a stream from which the logs of the stream are
+	// requested again after a reconnect. Lines of a stream may arrive slightly out of order, so the overlap ensures that
+	// none is missed. Lines of the overlap which were already sent are skipped.
+	followOverlap = 5 * time.Second
+	// followMaxDelay is the time by which the lines of a stream may arrive after newer lines of other streams, e.g.
+	// if the log collector of a pod lags behind. Streams without lines within this time are forgotten and lines which
+	// arrive even later are skipped.
+	followMaxDelay = 10 * time.Minute
+)
+
+var (
+	followMinBackoff = time.Second
+	followMaxBackoff = 30 * time.Second
+)
+
+// permanentFollowError is returned for errors which cannot be resolved by reconnecting, e.g. a closed client stream.
+type permanentFollowError struct {
+	err error
+}
+
+func (e *permanentFollowError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentFollowError) Unwrap() error {
+	return e.err
+}
+
+// followLogs sends every new log line selected by the query until the context is done. It uses the tail
+// websocket of loki and reconnects with an exponential backoff if the connection fails. Lines logged while the
+// connection was lost or which loki dropped while tailing are queried and lines which were already sent are skipped.
+func (llp *LokiLogProvider) followLogs(ctx context.Context, query logQuery, send func(logLine) error) error {
+	logQL, err := query.build()
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	lines := newFollowedLines(llp.clock.Now())
+	backoff := followMinBackoff
+	reconnect := false
+	for {
+		connected, err := llp.tailLogs(ctx, query, logQL, lines, send, reconnect)
+		if ctx.Err() != nil {
+			logrus.Debugf("stopped following logs of dogus %v", query.doguNames)
+			return nil
+		}
+
+		var permanentErr *permanentFollowError
+		if errors.As(err, &permanentErr) {
+			return fmt.Errorf("failed to follow logs of dogus %v: %w", query.doguNames, permanentErr.err)
+		}
+
+		if connected {
+			backoff = followMinBackoff
+		}
+		logrus.Warnf("lost loki tail connection for dogus %v, reconnecting in %s: %v", query.doguNames, backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, followMaxBackoff)
+		reconnect = true
+	}
+}
+
+// tailLogs sends the log lines of a single tail connection until it fails. On a reconnect the lines logged while the
+// connection was lost are queried and sent first. It returns whether the connection was established.
+func (llp *LokiLogProvider) tailLogs(ctx context.Context, query logQuery, logQL string, lines *followedLines, send func(logLine) error, reconnect bool) (bool, error) {
+	if reconnect {
+		missedLines, err := llp.queryLogs(query, lines.resumeFrom(), llp.clock.Now())
+		if err != nil {
+			return false, fmt.Errorf("failed to query logs missed while disconnected: %w", err)
+		}
+
+		err = sendNewLines(lines, missedLines, send)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	conn, err := llp.dialTail(ctx, logQL, lines.resumeFrom())
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// reading from the connection does not support a context, so the connection is closed to stop reading
+	stopClosing := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stopClosing()
+
+	for {
+		message := &lokiTailResponse{}
+		err = conn.ReadJSON(message)
+		if err != nil {
+			return true, fmt.Errorf("failed to read from loki tail: %w", err)
+		}
+
+		tailedLines, err := extractLogLines(message.Streams)
+		if err != nil {
+			return true, fmt.Errorf("failed to extract logs from loki tail response: %w", err)
+		}
+		for i := range tailedLines {
+			tailedLines[i].dogu = doguOfPod(query.doguNames, tailedLines[i].pod)
+		}
+
+		if len(message.DroppedEntries) > 0 {
+			err = llp.sendDroppedLines(query, lines, message.DroppedEntries, tailedLines, send)
+			if err != nil {
+				return true, err
+			}
+		}
+
+		err = sendNewLines(lines, tailedLines, send)
+		if err != nil {
+			return true, err
+		}
+	}
+}
+
+// sendDroppedLines queries the lines which loki dropped while tailing because the client was too slow and sends the
+// ones which were not sent yet. They are sent before the tailed lines of the same message, which are newer. The
+// queried range starts with the oldest dropped line and ends with the tail position, the newest dropped or tailed line.
+func (llp *LokiLogProvider) sendDroppedLines(query logQuery, lines *followedLines, dropped []lokiDroppedEntry, tailedLines []logLine, send func(logLine) error) error {
+	var oldest, newest time.Time
+	for _, entry := range dropped {
+		nanos, err := strconv.ParseInt(entry.Timestamp, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse timestamp of log line dropped by loki tail: %w", err)
+		}
+
+		timestamp := time.Unix(0, nanos)
+		if oldest.IsZero() || timestamp.Before(oldest) {
+			oldest = timestamp
+		}
+		if timestamp.After(newest) {
+			newest = timestamp
+		}
+	}
+	if len(tailedLines) > 0 && tailedLines[len(tailedLines)-1].timestamp.After(newest) {
+		newest = tailedLines[len(tailedLines)-1].timestamp
+	}
+
+	logrus.Infof("loki dropped %d log line(s) of dogus %v while tailing, querying them since %s", len(dropped), query.doguNames, oldest.Format(time.RFC3339Nano))
+	// loki returns the lines before the end of the query, so it ends right after the tail position
+	droppedLines, err := llp.queryLogs(query, oldest, newest.Add(time.Nanosecond))
+	if err != nil {
+		return fmt.Errorf("failed to query logs dropped by loki tail: %w", err)
+	}
+
+	return sendNewLines(lines, droppedLines, send)
+}
+
+func sendNewLines(lines *followedLines, candidates []logLine, send func(logLine) error) error {
+	for _, line := range lines.filterNew(candidates) {
+		err := send(line)
+		if err != nil {
+			return &permanentFollowError{err: fmt.Errorf("failed to send log line: %w", err)}
+		}
+	}
+
+	return nil
+}
+
+func (llp *LokiLogProvider) dialTail(ctx context.Context, logQL string, start time.Time) (*websocket.Conn, error) {
+	tailUrl, err := buildLokiTailUrl(llp.gatewayUrl, logQL, start, maxQueryLimit)
+	if err != nil {
+		return nil, &permanentFollowError{err: fmt.Errorf("failed to build loki tail url: %w", err)}
+	}
+
+	// the credentials may be rotated, so they are added again for every connection
+	authRequest := &http.Request{Header: http.Header{}}
+	llp.credentials.Authenticate(authRequest)
+
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: currentQueryTimeout(),
+	}
+	if transport, ok := llp.httpClient.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+		dialer.TLSClientConfig = transport.TLSClientConfig.Clone()
+	}
+
+	logrus.Debugf("connecting to loki tail with URL: %s", tailUrl)
+	conn, resp, err := dialer.DialContext(ctx, tailUrl, authRequest.Header)
+	if err != nil {
+		if resp == nil {
+			return nil, fmt.Errorf("failed to connect to loki tail with url [%s]: %w", tailUrl, err)
+		}
+
+		responseData, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if readErr != nil || len(responseData) == 0 {
+			responseData = []byte(fmt.Sprintf("failed to read error response: %v", readErr))
+		}
+
+		err = fmt.Errorf("loki tail http error: status: %s, code: %d; response-body: %s", resp.Status, resp.StatusCode, responseData)
+		if resp.StatusCode == http.StatusBadRequest {
+			// an invalid query stays invalid
+			return nil, &permanentFollowError{err: err}
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// buildLokiTailUrl returns the url of the loki tail websocket for the given query. Loki sends the logs since the start
+// date up to the limit first and afterward every new log line.
+func buildLokiTailUrl(lokiBaseUrl string, query string, start time.Time, limit int) (string, error) {
+	baseUrl, err := url.Parse(lokiBaseUrl)
+	if err != nil {
+		return "", err
+	}
+
+	switch baseUrl.Scheme {
+	case "http":
+		baseUrl.Scheme = "ws"
+	case "https":
+		baseUrl.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("unsupported scheme %q", baseUrl.Scheme)
+	}
+
+	baseUrl = baseUrl.JoinPath("/loki/api/v1/tail")
+
+	params := baseUrl.Query()
+	params.Set("query", query)
+	params.Set("limit", fmt.Sprintf("%d", limit))
+	params.Set("start", fmt.Sprintf("%d", start.UnixNano()))
+
+	baseUrl.RawQuery = params.Encode()
+
+	return baseUrl.String(), nil
+}
+
+// followedLines remembers the log lines sent while following the logs, so that lines received again after a
+// reconnect are not sent twice. It tracks every stream, i.e. every container of a pod, on its own, because the lines of
+// different streams may arrive out of order while the lines of a stream arrive nearly in order.
+type followedLines struct {
+	start time.Time
+	// latest is the timestamp of the newest sent line of all streams.
+	latest time.Time
+	// newest contains the timestamp of the newest sent line of every stream with lines within followMaxDelay.
+	newest map[logStream]time.Time
+	// sent contains the lines sent within the overlap before the newest line of their stream, older lines are never
+	// sent again.
+	sent map[logLineKey]struct{}
+}
+
+type logStream struct {
+	pod       string
+	container string
+}
+
+// logLineKey identifies a log line. Lines of different streams are only equal by chance.
+type logLineKey struct {
+	stream    logStream
+	timestamp int64
+	value     string
+}
+
+func newLogLineKey(line logLine) logLineKey {
+	return logLineKey{
+		stream:    logStream{pod: line.pod, container: line.container},
+		timestamp: line.timestamp.UnixNano(),
+		value:     line.value,
+	}
+}
+
+func newFollowedLines(start time.Time) *followedLines {
+	return &followedLines{
+		start:  start,
+		newest: map[logStream]time.Time{},
+		sent:   map[logLineKey]struct{}{},
+	}
+}
+
+// resumeFrom returns the time from which the logs must be requested to receive every line which was not sent yet.
+func (f *followedLines) resumeFrom() time.Time {
+	if f.latest.IsZero() {
+		return f.start
+	}
+
+	resumeFrom := f.latest.Add(-followOverlap)
+	for stream := range f.newest {
+		if floor := f.floor(stream); floor.Before(resumeFrom) {
+			resumeFrom = floor
+		}
+	}
+	if resumeFrom.Before(f.start) {
+		return f.start
+	}
+
+	return resumeFrom
+}
+
+// floor returns the time before which the lines of the stream are not sent anymore, either because they were sent
+// already or because they arrive too late.
+func (f *followedLines) floor(stream logStream) time.Time {
+	floor := f.start
+	if maxDelayed := f.latest.Add(-followMaxDelay); maxDelayed.After(floor) {
+		floor = maxDelayed
+	}
+	if newest, ok := f.newest[stream]; ok && newest.Add(-followOverlap).After(floor) {
+		floor = newest.Add(-followOverlap)
+	}
+
+	return floor
+}
+
+// filterNew returns the lines which were not sent yet and remembers them as sent. The lines of every stream must be
+// sorted by their timestamp.
+func (f *followedLines) filterNew(lines []logLine) []logLine {
+	result := make([]logLine, 0, len(lines))
+	for _, line := range lines {
+		key := newLogLineKey(line)
+		if line.timestamp.Before(f.floor(key.stream)) {
+			continue
+		}
+		if _, sent := f.sent[key]; sent {
+			continue
+		}
+
+		f.sent[key] = struct{}{}
+		if line.timestamp.After(f.newest[key.stream]) {
+			f.newest[key.stream] = line.timestamp
+		}
+		if line.timestamp.After(f.latest) {
+			f.latest = line.timestamp
+		}
+		result = append(result, line)
+	}
+
+	maxDelayed := f.latest.Add(-followMaxDelay)
+	for stream, newest := range f.newest {
+		if newest.Before(maxDelayed) {
+			delete(f.newest, stream)
+		}
+	}
+	for key := range f.sent {
+		if key.timestamp < f.floor(key.stream).UnixNano() {
+			delete(f.sent, key)
+		}
+	}
+
+	return result
+}
+
+// lokiTailResponse is a single message of the loki tail websocket.
+type lokiTailResponse struct {
+	Streams []lokiStreamResult `json:"streams"`
+	// DroppedEntries contains lines which loki could not send because the client was too slow.
+	DroppedEntries []lokiDroppedEntry `json:"dropped_entries"`
+}
+
+// lokiDroppedEntry identifies a log line which was dropped by loki.
+type lokiDroppedEntry struct {
+	Labels    map[string]string `json:"labels"`
+	Timestamp string            `json:"timestamp"`
+}
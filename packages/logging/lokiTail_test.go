@@ -0,0 +1,388 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var followStart = time.Unix(1655722130, 0)
+
+func lineAt(seconds int, value string) logLine {
+	return logLine{timestamp: followStart.Add(time.Duration(seconds) * time.Second), value: value}
+}
+
+// casLineAt returns a line of another stream than the lines of lineAt.
+func casLineAt(seconds int, value string) logLine {
+	line := lineAt(seconds, value)
+	line.pod = "cas-5c8b7d9f4-x2x7p"
+	return line
+}
+
+func lokiStreams(lines ...logLine) []lokiStreamResult {
+	values := make([][]string, 0, len(lines))
+	for _, line := range lines {
+		values = append(values, []string{strconv.FormatInt(line.timestamp.UnixNano(), 10), line.value})
+	}
+
+	return []lokiStreamResult{{Values: values}}
+}
+
+func writeTail(t *testing.T, conn *websocket.Conn, lines ...logLine) {
+	t.Helper()
+	err := conn.WriteJSON(lokiTailResponse{Streams: lokiStreams(lines...)})
+	require.NoError(t, err)
+}
+
+func upgradeTail(t *testing.T, w http.ResponseWriter, r *http.Request) *websocket.Conn {
+	t.Helper()
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	require.NoError(t, err)
+
+	return conn
+}
+
+func setFollowBackoff(t *testing.T, backoff time.Duration) {
+	t.Helper()
+	previousMin, previousMax := followMinBackoff, followMaxBackoff
+	t.Cleanup(func() { followMinBackoff, followMaxBackoff = previousMin, previousMax })
+	followMinBackoff, followMaxBackoff = backoff, backoff
+}
+
+func TestLokiLogProvider_followLogs(t *testing.T) {
+	t.Run("should send tailed lines until the context is done", func(t *testing.T) {
+		// given
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/loki/api/v1/tail", r.URL.Path)
+			assert.Equal(t, "{pod=~\"test"+podNameSuffixPattern+"\"} |= \"error\"", r.URL.Query().Get("query"))
+			assert.Equal(t, "5000", r.URL.Query().Get("limit"))
+			assert.Equal(t, strconv.FormatInt(followStart.UnixNano(), 10), r.URL.Query().Get("start"))
+			username, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "admin", username)
+			assert.Equal(t, "admin123", password)
+
+			conn := upgradeTail(t, w, r)
+			defer conn.Close()
+			writeTail(t, conn, lineAt(1, "first"), lineAt(2, "second"))
+			writeTail(t, conn, lineAt(3, "third"))
+			_, _, _ = conn.ReadMessage()
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{followStart},
+			httpClient:  http.DefaultClient,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var actual []logLine
+
+		// when
+		err := sut.followLogs(ctx, newDoguQuery("test", "error"), func(line logLine) error {
+			actual = append(actual, line)
+			if len(actual) == 3 {
+				cancel()
+			}
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []logLine{lineAt(1, "first"), lineAt(2, "second"), lineAt(3, "third")}, actual)
+	})
+	t.Run("should reconnect without dropping or duplicating lines", func(t *testing.T) {
+		// given
+		setFollowBackoff(t, time.Millisecond)
+		var connections atomic.Int32
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/loki/api/v1/query_range" {
+				// the lines logged while disconnected
+				assert.Equal(t, strconv.FormatInt(followStart.UnixNano(), 10), r.URL.Query().Get("start"))
+				response := lokiResponse{Status: "success", Data: lokiResponseData{
+					ResultType: "streams",
+					Result:     lokiStreams(lineAt(2, "second"), lineAt(3, "third")),
+				}}
+				assert.NoError(t, json.NewEncoder(w).Encode(response))
+				return
+			}
+
+			conn := upgradeTail(t, w, r)
+			defer conn.Close()
+			switch connections.Add(1) {
+			case 1:
+				writeTail(t, conn, lineAt(1, "first"), lineAt(2, "second"))
+				// the connection is lost
+			case 2:
+				// the tail resumes within the overlap before the newest line
+				assert.Equal(t, strconv.FormatInt(followStart.UnixNano(), 10), r.URL.Query().Get("start"))
+				writeTail(t, conn, lineAt(2, "second"), lineAt(3, "third"))
+				writeTail(t, conn, lineAt(4, "fourth"))
+				_, _, _ = conn.ReadMessage()
+			}
+		}))
+		defer svr.Close()
+
+		clock := &testClock{followStart}
+		sut := &LokiLogProvider{
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       clock,
+			httpClient:  http.DefaultClient,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var actual []logLine
+
+		// when
+		err := sut.followLogs(ctx, newDoguQuery("test", ""), func(line logLine) error {
+			clock.time = line.timestamp
+			actual = append(actual, line)
+			if line.value == "fourth" {
+				cancel()
+			}
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []logLine{lineAt(1, "first"), lineAt(2, "second"), lineAt(3, "third"), lineAt(4, "fourth")}, actual)
+		assert.Equal(t, int32(2), connections.Load())
+	})
+	t.Run("should query and send the lines dropped by loki before the tailed lines", func(t *testing.T) {
+		// given
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/loki/api/v1/query_range" {
+				// from the oldest dropped line to the tail position
+				assert.Equal(t, strconv.FormatInt(followStart.Add(2*time.Second).UnixNano(), 10), r.URL.Query().Get("start"))
+				assert.Equal(t, strconv.FormatInt(followStart.Add(4*time.Second).UnixNano()+1, 10), r.URL.Query().Get("end"))
+				response := lokiResponse{Status: "success", Data: lokiResponseData{
+					ResultType: "streams",
+					Result:     lokiStreams(lineAt(2, "second"), lineAt(3, "third")),
+				}}
+				assert.NoError(t, json.NewEncoder(w).Encode(response))
+				return
+			}
+
+			conn := upgradeTail(t, w, r)
+			defer conn.Close()
+			writeTail(t, conn, lineAt(1, "first"))
+			err := conn.WriteJSON(lokiTailResponse{
+				Streams: lokiStreams(lineAt(4, "fourth")),
+				DroppedEntries: []lokiDroppedEntry{
+					{Timestamp: strconv.FormatInt(followStart.Add(3*time.Second).UnixNano(), 10)},
+					{Timestamp: strconv.FormatInt(followStart.Add(2*time.Second).UnixNano(), 10)},
+				},
+			})
+			assert.NoError(t, err)
+			_, _, _ = conn.ReadMessage()
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{followStart},
+			httpClient:  http.DefaultClient,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var actual []logLine
+
+		// when
+		err := sut.followLogs(ctx, newDoguQuery("test", ""), func(line logLine) error {
+			actual = append(actual, line)
+			if line.value == "fourth" {
+				cancel()
+			}
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []logLine{lineAt(1, "first"), lineAt(2, "second"), lineAt(3, "third"), lineAt(4, "fourth")}, actual)
+	})
+	t.Run("should retry failed connections until the context is done", func(t *testing.T) {
+		// given
+		setFollowBackoff(t, time.Millisecond)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var attempts atomic.Int32
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) == 3 {
+				cancel()
+			}
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{followStart},
+			httpClient:  http.DefaultClient,
+		}
+
+		// when
+		err := sut.followLogs(ctx, newDoguQuery("test", ""), func(line logLine) error {
+			t.Error("unexpected log line")
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, attempts.Load(), int32(3))
+	})
+	t.Run("should fail for an invalid query", func(t *testing.T) {
+		// given
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("parse error"))
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{followStart},
+			httpClient:  http.DefaultClient,
+		}
+
+		// when
+		err := sut.followLogs(context.Background(), newDoguQuery("test", ""), func(line logLine) error {
+			return nil
+		})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to follow logs of dogus [test]")
+		assert.ErrorContains(t, err, "code: 400; response-body: parse error")
+	})
+	t.Run("should fail if a line cannot be sent", func(t *testing.T) {
+		// given
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn := upgradeTail(t, w, r)
+			defer conn.Close()
+			writeTail(t, conn, lineAt(1, "first"))
+			_, _, _ = conn.ReadMessage()
+		}))
+		defer svr.Close()
+
+		sut := &LokiLogProvider{
+			gatewayUrl:  svr.URL,
+			credentials: basicAuth{username: "admin", password: "admin123"},
+			clock:       &testClock{followStart},
+			httpClient:  http.DefaultClient,
+		}
+		assertErr := errors.New("assert error")
+
+		// when
+		err := sut.followLogs(context.Background(), newDoguQuery("test", ""), func(line logLine) error {
+			return assertErr
+		})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assertErr)
+		assert.ErrorContains(t, err, "failed to send log line")
+	})
+}
+
+func Test_buildLokiTailUrl(t *testing.T) {
+	t.Run("should use websocket schemes", func(t *testing.T) {
+		// when
+		insecure, err := buildLokiTailUrl("http://loki:8080", "{pod=~\"cas.*\"}", followStart, 100)
+		require.NoError(t, err)
+		secure, err := buildLokiTailUrl("https://loki", "{pod=~\"cas.*\"}", followStart, 100)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, "ws://loki:8080/loki/api/v1/tail?limit=100&query=%7Bpod%3D~%22cas.%2A%22%7D&start=1655722130000000000", insecure)
+		assert.Equal(t, "wss://loki/loki/api/v1/tail?limit=100&query=%7Bpod%3D~%22cas.%2A%22%7D&start=1655722130000000000", secure)
+	})
+	t.Run("should fail for unsupported scheme", func(t *testing.T) {
+		// when
+		_, err := buildLokiTailUrl("ftp://loki", "{}", followStart, 100)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unsupported scheme \"ftp\"")
+	})
+}
+
+func Test_followedLines_filterNew(t *testing.T) {
+	t.Run("should skip lines before the start", func(t *testing.T) {
+		// given
+		sut := newFollowedLines(followStart)
+
+		// when
+		actual := sut.filterNew([]logLine{lineAt(-1, "old"), lineAt(0, "start"), lineAt(1, "new")})
+
+		// then
+		assert.Equal(t, []logLine{lineAt(0, "start"), lineAt(1, "new")}, actual)
+	})
+	t.Run("should skip sent lines and keep lines with the same timestamp", func(t *testing.T) {
+		// given
+		sut := newFollowedLines(followStart)
+		sut.filterNew([]logLine{lineAt(1, "a"), lineAt(2, "b")})
+
+		// when
+		actual := sut.filterNew([]logLine{lineAt(1, "a"), lineAt(2, "b"), lineAt(2, "c"), lineAt(3, "d")})
+
+		// then
+		assert.Equal(t, []logLine{lineAt(2, "c"), lineAt(3, "d")}, actual)
+	})
+	t.Run("should forget lines before the overlap", func(t *testing.T) {
+		// given
+		sut := newFollowedLines(followStart)
+
+		// when
+		actual := sut.filterNew([]logLine{lineAt(1, "a"), lineAt(10, "b"), lineAt(2, "late")})
+
+		// then
+		assert.Equal(t, []logLine{lineAt(1, "a"), lineAt(10, "b")}, actual)
+		assert.Equal(t, followStart.Add(5*time.Second), sut.resumeFrom())
+		assert.Len(t, sut.sent, 1)
+	})
+	t.Run("should keep late lines of other streams", func(t *testing.T) {
+		// given
+		sut := newFollowedLines(followStart)
+		sut.filterNew([]logLine{lineAt(1, "a"), lineAt(60, "b")})
+
+		// when
+		actual := sut.filterNew([]logLine{casLineAt(2, "late"), lineAt(3, "too late")})
+
+		// then
+		assert.Equal(t, []logLine{casLineAt(2, "late")}, actual)
+		assert.Equal(t, followStart, sut.resumeFrom())
+	})
+	t.Run("should forget streams without lines within the maximum delay", func(t *testing.T) {
+		// given
+		sut := newFollowedLines(followStart)
+		maxDelay := int(followMaxDelay.Seconds())
+
+		// when
+		actual := sut.filterNew([]logLine{casLineAt(1, "a"), lineAt(maxDelay+10, "b"), casLineAt(5, "too late")})
+
+		// then
+		assert.Equal(t, []logLine{casLineAt(1, "a"), lineAt(maxDelay+10, "b")}, actual)
+		assert.Len(t, sut.newest, 1)
+		assert.Len(t, sut.sent, 1)
+		assert.Equal(t, lineAt(maxDelay+5, "").timestamp, sut.resumeFrom())
+	})
+}
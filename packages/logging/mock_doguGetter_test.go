@@ -84,6 +84,65 @@ func (_c *mockDoguGetter_Get_Call) RunAndReturn(run func(context.Context, string
 	return _c
 }
 
+// List provides a mock function with given fields: ctx, opts
+func (_m *mockDoguGetter) List(ctx context.Context, opts v1.ListOptions) (*v2.DoguList, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *v2.DoguList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, v1.ListOptions) (*v2.DoguList, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, v1.ListOptions) *v2.DoguList); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v2.DoguList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, v1.ListOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockDoguGetter_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type mockDoguGetter_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts v1.ListOptions
+func (_e *mockDoguGetter_Expecter) List(ctx interface{}, opts interface{}) *mockDoguGetter_List_Call {
+	return &mockDoguGetter_List_Call{Call: _e.mock.On("List", ctx, opts)}
+}
+
+func (_c *mockDoguGetter_List_Call) Run(run func(ctx context.Context, opts v1.ListOptions)) *mockDoguGetter_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(v1.ListOptions))
+	})
+	return _c
+}
+
+func (_c *mockDoguGetter_List_Call) Return(_a0 *v2.DoguList, _a1 error) *mockDoguGetter_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockDoguGetter_List_Call) RunAndReturn(run func(context.Context, v1.ListOptions) (*v2.DoguList, error)) *mockDoguGetter_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // newMockDoguGetter creates a new instance of mockDoguGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockDoguGetter(t interface {
@@ -68,6 +68,54 @@ func (_c *mockDoguRestarter_RestartDogu_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// RestartDoguWithWait provides a mock function with given fields: ctx, doguName, waitForRollout
+func (_m *mockDoguRestarter) RestartDoguWithWait(ctx context.Context, doguName string, waitForRollout bool) error {
+	ret := _m.Called(ctx, doguName, waitForRollout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestartDoguWithWait")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, doguName, waitForRollout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockDoguRestarter_RestartDoguWithWait_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestartDoguWithWait'
+type mockDoguRestarter_RestartDoguWithWait_Call struct {
+	*mock.Call
+}
+
+// RestartDoguWithWait is a helper method to define mock.On call
+//   - ctx context.Context
+//   - doguName string
+//   - waitForRollout bool
+func (_e *mockDoguRestarter_Expecter) RestartDoguWithWait(ctx interface{}, doguName interface{}, waitForRollout interface{}) *mockDoguRestarter_RestartDoguWithWait_Call {
+	return &mockDoguRestarter_RestartDoguWithWait_Call{Call: _e.mock.On("RestartDoguWithWait", ctx, doguName, waitForRollout)}
+}
+
+func (_c *mockDoguRestarter_RestartDoguWithWait_Call) Run(run func(ctx context.Context, doguName string, waitForRollout bool)) *mockDoguRestarter_RestartDoguWithWait_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *mockDoguRestarter_RestartDoguWithWait_Call) Return(_a0 error) *mockDoguRestarter_RestartDoguWithWait_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDoguRestarter_RestartDoguWithWait_Call) RunAndReturn(run func(context.Context, string, bool) error) *mockDoguRestarter_RestartDoguWithWait_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // newMockDoguRestarter creates a new instance of mockDoguRestarter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockDoguRestarter(t interface {
@@ -3,6 +3,8 @@
 package logging
 
 import (
+	context "context"
+
 	time "time"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,34 +23,70 @@ func (_m *mockLogProvider) EXPECT() *mockLogProvider_Expecter {
 	return &mockLogProvider_Expecter{mock: &_m.Mock}
 }
 
-// getLogs provides a mock function with given fields: doguName, linesCount
-func (_m *mockLogProvider) getLogs(doguName string, linesCount int) ([]logLine, error) {
-	ret := _m.Called(doguName, linesCount)
+// followLogs provides a mock function with given fields: ctx, query, send
+func (_m *mockLogProvider) followLogs(ctx context.Context, query logQuery, send func(logLine) error) error {
+	ret := _m.Called(ctx, query, send)
 
 	if len(ret) == 0 {
-		panic("no return value specified for getLogs")
+		panic("no return value specified for followLogs")
 	}
 
-	var r0 []logLine
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, int) ([]logLine, error)); ok {
-		return rf(doguName, linesCount)
-	}
-	if rf, ok := ret.Get(0).(func(string, int) []logLine); ok {
-		r0 = rf(doguName, linesCount)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, logQuery, func(logLine) error) error); ok {
+		r0 = rf(ctx, query, send)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]logLine)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int) error); ok {
-		r1 = rf(doguName, linesCount)
+	return r0
+}
+
+// mockLogProvider_followLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'followLogs'
+type mockLogProvider_followLogs_Call struct {
+	*mock.Call
+}
+
+// followLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query logQuery
+//   - send func(logLine) error
+func (_e *mockLogProvider_Expecter) followLogs(ctx interface{}, query interface{}, send interface{}) *mockLogProvider_followLogs_Call {
+	return &mockLogProvider_followLogs_Call{Call: _e.mock.On("followLogs", ctx, query, send)}
+}
+
+func (_c *mockLogProvider_followLogs_Call) Run(run func(ctx context.Context, query logQuery, send func(logLine) error)) *mockLogProvider_followLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(logQuery), args[2].(func(logLine) error))
+	})
+	return _c
+}
+
+func (_c *mockLogProvider_followLogs_Call) Return(_a0 error) *mockLogProvider_followLogs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockLogProvider_followLogs_Call) RunAndReturn(run func(context.Context, logQuery, func(logLine) error) error) *mockLogProvider_followLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// getLogs provides a mock function with given fields: doguName, linesCount, send
+func (_m *mockLogProvider) getLogs(doguName string, linesCount int, send func(page []logLine) error) error {
+	ret := _m.Called(doguName, linesCount, send)
+
+	if len(ret) == 0 {
+		panic("no return value specified for getLogs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int, func(page []logLine) error) error); ok {
+		r0 = rf(doguName, linesCount, send)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 // mockLogProvider_getLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'getLogs'
@@ -59,30 +97,31 @@ type mockLogProvider_getLogs_Call struct {
 // getLogs is a helper method to define mock.On call
 //   - doguName string
 //   - linesCount int
-func (_e *mockLogProvider_Expecter) getLogs(doguName interface{}, linesCount interface{}) *mockLogProvider_getLogs_Call {
-	return &mockLogProvider_getLogs_Call{Call: _e.mock.On("getLogs", doguName, linesCount)}
+//   - send func(page []logLine) error
+func (_e *mockLogProvider_Expecter) getLogs(doguName interface{}, linesCount interface{}, send interface{}) *mockLogProvider_getLogs_Call {
+	return &mockLogProvider_getLogs_Call{Call: _e.mock.On("getLogs", doguName, linesCount, send)}
 }
 
-func (_c *mockLogProvider_getLogs_Call) Run(run func(doguName string, linesCount int)) *mockLogProvider_getLogs_Call {
+func (_c *mockLogProvider_getLogs_Call) Run(run func(doguName string, linesCount int, send func(page []logLine) error)) *mockLogProvider_getLogs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(int))
+		run(args[0].(string), args[1].(int), args[2].(func(page []logLine) error))
 	})
 	return _c
 }
 
-func (_c *mockLogProvider_getLogs_Call) Return(_a0 []logLine, _a1 error) *mockLogProvider_getLogs_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *mockLogProvider_getLogs_Call) Return(_a0 error) *mockLogProvider_getLogs_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *mockLogProvider_getLogs_Call) RunAndReturn(run func(string, int) ([]logLine, error)) *mockLogProvider_getLogs_Call {
+func (_c *mockLogProvider_getLogs_Call) RunAndReturn(run func(string, int, func(page []logLine) error) error) *mockLogProvider_getLogs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// queryLogs provides a mock function with given fields: doguName, startDate, endDate, filter
-func (_m *mockLogProvider) queryLogs(doguName string, startDate time.Time, endDate time.Time, filter string) ([]logLine, error) {
-	ret := _m.Called(doguName, startDate, endDate, filter)
+// queryLogs provides a mock function with given fields: query, startDate, endDate
+func (_m *mockLogProvider) queryLogs(query logQuery, startDate time.Time, endDate time.Time) ([]logLine, error) {
+	ret := _m.Called(query, startDate, endDate)
 
 	if len(ret) == 0 {
 		panic("no return value specified for queryLogs")
@@ -90,19 +129,19 @@ func (_m *mockLogProvider) queryLogs(doguName string, startDate time.Time, endDa
 
 	var r0 []logLine
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time, string) ([]logLine, error)); ok {
-		return rf(doguName, startDate, endDate, filter)
+	if rf, ok := ret.Get(0).(func(logQuery, time.Time, time.Time) ([]logLine, error)); ok {
+		return rf(query, startDate, endDate)
 	}
-	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time, string) []logLine); ok {
-		r0 = rf(doguName, startDate, endDate, filter)
+	if rf, ok := ret.Get(0).(func(logQuery, time.Time, time.Time) []logLine); ok {
+		r0 = rf(query, startDate, endDate)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]logLine)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time, string) error); ok {
-		r1 = rf(doguName, startDate, endDate, filter)
+	if rf, ok := ret.Get(1).(func(logQuery, time.Time, time.Time) error); ok {
+		r1 = rf(query, startDate, endDate)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -116,17 +155,16 @@ type mockLogProvider_queryLogs_Call struct {
 }
 
 // queryLogs is a helper method to define mock.On call
-//   - doguName string
+//   - query logQuery
 //   - startDate time.Time
 //   - endDate time.Time
-//   - filter string
-func (_e *mockLogProvider_Expecter) queryLogs(doguName interface{}, startDate interface{}, endDate interface{}, filter interface{}) *mockLogProvider_queryLogs_Call {
-	return &mockLogProvider_queryLogs_Call{Call: _e.mock.On("queryLogs", doguName, startDate, endDate, filter)}
+func (_e *mockLogProvider_Expecter) queryLogs(query interface{}, startDate interface{}, endDate interface{}) *mockLogProvider_queryLogs_Call {
+	return &mockLogProvider_queryLogs_Call{Call: _e.mock.On("queryLogs", query, startDate, endDate)}
 }
 
-func (_c *mockLogProvider_queryLogs_Call) Run(run func(doguName string, startDate time.Time, endDate time.Time, filter string)) *mockLogProvider_queryLogs_Call {
+func (_c *mockLogProvider_queryLogs_Call) Run(run func(query logQuery, startDate time.Time, endDate time.Time)) *mockLogProvider_queryLogs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(time.Time), args[2].(time.Time), args[3].(string))
+		run(args[0].(logQuery), args[1].(time.Time), args[2].(time.Time))
 	})
 	return _c
 }
@@ -136,7 +174,185 @@ func (_c *mockLogProvider_queryLogs_Call) Return(_a0 []logLine, _a1 error) *mock
 	return _c
 }
 
-func (_c *mockLogProvider_queryLogs_Call) RunAndReturn(run func(string, time.Time, time.Time, string) ([]logLine, error)) *mockLogProvider_queryLogs_Call {
+func (_c *mockLogProvider_queryLogs_Call) RunAndReturn(run func(logQuery, time.Time, time.Time) ([]logLine, error)) *mockLogProvider_queryLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// queryLogsPage provides a mock function with given fields: query, cursor, size
+func (_m *mockLogProvider) queryLogsPage(query logQuery, cursor logCursor, size int) ([]logLine, error) {
+	ret := _m.Called(query, cursor, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for queryLogsPage")
+	}
+
+	var r0 []logLine
+	var r1 error
+	if rf, ok := ret.Get(0).(func(logQuery, logCursor, int) ([]logLine, error)); ok {
+		return rf(query, cursor, size)
+	}
+	if rf, ok := ret.Get(0).(func(logQuery, logCursor, int) []logLine); ok {
+		r0 = rf(query, cursor, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]logLine)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(logQuery, logCursor, int) error); ok {
+		r1 = rf(query, cursor, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockLogProvider_queryLogsPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'queryLogsPage'
+type mockLogProvider_queryLogsPage_Call struct {
+	*mock.Call
+}
+
+// queryLogsPage is a helper method to define mock.On call
+//   - query logQuery
+//   - cursor logCursor
+//   - size int
+func (_e *mockLogProvider_Expecter) queryLogsPage(query interface{}, cursor interface{}, size interface{}) *mockLogProvider_queryLogsPage_Call {
+	return &mockLogProvider_queryLogsPage_Call{Call: _e.mock.On("queryLogsPage", query, cursor, size)}
+}
+
+func (_c *mockLogProvider_queryLogsPage_Call) Run(run func(query logQuery, cursor logCursor, size int)) *mockLogProvider_queryLogsPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(logQuery), args[1].(logCursor), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *mockLogProvider_queryLogsPage_Call) Return(_a0 []logLine, _a1 error) *mockLogProvider_queryLogsPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockLogProvider_queryLogsPage_Call) RunAndReturn(run func(logQuery, logCursor, int) ([]logLine, error)) *mockLogProvider_queryLogsPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// queryStatistics provides a mock function with given fields: query
+func (_m *mockLogProvider) queryStatistics(query logStatisticsQuery) ([]logSeries, error) {
+	ret := _m.Called(query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for queryStatistics")
+	}
+
+	var r0 []logSeries
+	var r1 error
+	if rf, ok := ret.Get(0).(func(logStatisticsQuery) ([]logSeries, error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(logStatisticsQuery) []logSeries); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]logSeries)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(logStatisticsQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockLogProvider_queryStatistics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'queryStatistics'
+type mockLogProvider_queryStatistics_Call struct {
+	*mock.Call
+}
+
+// queryStatistics is a helper method to define mock.On call
+//   - query logStatisticsQuery
+func (_e *mockLogProvider_Expecter) queryStatistics(query interface{}) *mockLogProvider_queryStatistics_Call {
+	return &mockLogProvider_queryStatistics_Call{Call: _e.mock.On("queryStatistics", query)}
+}
+
+func (_c *mockLogProvider_queryStatistics_Call) Run(run func(query logStatisticsQuery)) *mockLogProvider_queryStatistics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(logStatisticsQuery))
+	})
+	return _c
+}
+
+func (_c *mockLogProvider_queryStatistics_Call) Return(_a0 []logSeries, _a1 error) *mockLogProvider_queryStatistics_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockLogProvider_queryStatistics_Call) RunAndReturn(run func(logStatisticsQuery) ([]logSeries, error)) *mockLogProvider_queryStatistics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// queryVolume provides a mock function with given fields: doguNames, startDate, endDate
+func (_m *mockLogProvider) queryVolume(doguNames []string, startDate time.Time, endDate time.Time) ([]logVolume, error) {
+	ret := _m.Called(doguNames, startDate, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for queryVolume")
+	}
+
+	var r0 []logVolume
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string, time.Time, time.Time) ([]logVolume, error)); ok {
+		return rf(doguNames, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func([]string, time.Time, time.Time) []logVolume); ok {
+		r0 = rf(doguNames, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]logVolume)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string, time.Time, time.Time) error); ok {
+		r1 = rf(doguNames, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockLogProvider_queryVolume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'queryVolume'
+type mockLogProvider_queryVolume_Call struct {
+	*mock.Call
+}
+
+// queryVolume is a helper method to define mock.On call
+//   - doguNames []string
+//   - startDate time.Time
+//   - endDate time.Time
+func (_e *mockLogProvider_Expecter) queryVolume(doguNames interface{}, startDate interface{}, endDate interface{}) *mockLogProvider_queryVolume_Call {
+	return &mockLogProvider_queryVolume_Call{Call: _e.mock.On("queryVolume", doguNames, startDate, endDate)}
+}
+
+func (_c *mockLogProvider_queryVolume_Call) Run(run func(doguNames []string, startDate time.Time, endDate time.Time)) *mockLogProvider_queryVolume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *mockLogProvider_queryVolume_Call) Return(_a0 []logVolume, _a1 error) *mockLogProvider_queryVolume_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockLogProvider_queryVolume_Call) RunAndReturn(run func([]string, time.Time, time.Time) ([]logVolume, error)) *mockLogProvider_queryVolume_Call {
 	_c.Call.Return(run)
 	return _c
 }
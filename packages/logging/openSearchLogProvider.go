@@ -0,0 +1,324 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The fields of the log documents as written by the kubernetes filter of fluent-bit. The pod name must be mapped as
+// keyword so that it can be matched by a regular expression.
+const (
+	openSearchTimestampField = "@timestamp"
+	openSearchLogField       = "log"
+	openSearchPodField       = "kubernetes.pod_name"
+	openSearchContainerField = "kubernetes.container_name"
+)
+
+// openSearchPageSize is the number of documents requested at once.
+var openSearchPageSize = defaultQueryLimit
+
+// OpenSearchLogProvider reads the logs of dogus from an OpenSearch or Elasticsearch compatible search endpoint. The
+// documents are selected by the pod name and the time, the filters of the queries are evaluated on the returned lines.
+type OpenSearchLogProvider struct {
+	url         string
+	index       string
+	credentials credentialsProvider
+	clock       nowClock
+	httpClient  *http.Client
+}
+
+// NewOpenSearchLogProvider creates a new OpenSearchLogProvider searching the documents of the given index pattern. The
+// httpClient must not have a timeout because the query timeout is set per request so that it can be changed at runtime.
+func NewOpenSearchLogProvider(url string, index string, credentials credentialsProvider, httpClient *http.Client) *OpenSearchLogProvider {
+	return &OpenSearchLogProvider{
+		url:         url,
+		index:       index,
+		credentials: credentials,
+		clock:       &realClock{},
+		httpClient:  httpClient,
+	}
+}
+
+// getLogs sends the newest log lines of the dogu. A linesCount of 0 or less sends all lines. The lines are searched
+// from the newest to the oldest, so they are read completely before they are sent in order.
+func (osp *OpenSearchLogProvider) getLogs(doguName string, linesCount int, send func(page []logLine) error) error {
+	lines, err := osp.searchLogs(newDoguQuery(doguName, ""), time.Time{}, osp.clock.Now(), linesCount)
+	if err != nil {
+		return fmt.Errorf("failed to query logs from opensearch: %w", err)
+	}
+
+	return sendPages(lines, send)
+}
+
+// queryLogs returns the log lines selected by the query between the start date and the end date. Like for loki, the
+// end date defaults to now and the start date to 30 days before the end date.
+func (osp *OpenSearchLogProvider) queryLogs(query logQuery, startDate time.Time, endDate time.Time) ([]logLine, error) {
+	if endDate.IsZero() {
+		endDate = osp.clock.Now()
+	}
+
+	if startDate.IsZero() {
+		startDate = createQueryStartDateFromEndDate(endDate)
+	}
+
+	lines, err := osp.searchLogs(query, startDate, endDate, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logs from opensearch: %w", err)
+	}
+
+	return lines, nil
+}
+
+// queryLogsPage selects the page from all log lines within the time window of the cursor.
+func (osp *OpenSearchLogProvider) queryLogsPage(query logQuery, cursor logCursor, size int) ([]logLine, error) {
+	return queryPage(osp.queryLogs, query, cursor, size)
+}
+
+// queryStatistics is not supported because the opensearch log backend has no metric queries.
+func (osp *OpenSearchLogProvider) queryStatistics(logStatisticsQuery) ([]logSeries, error) {
+	return nil, fmt.Errorf("log statistics of the opensearch log backend: %w", errors.ErrUnsupported)
+}
+
+// queryVolume is not supported because the opensearch log backend has no volume statistics.
+func (osp *OpenSearchLogProvider) queryVolume([]string, time.Time, time.Time) ([]logVolume, error) {
+	return nil, fmt.Errorf("log volume of the opensearch log backend: %w", errors.ErrUnsupported)
+}
+
+// followLogs polls the search endpoint for new log lines because it has no tail api.
+func (osp *OpenSearchLogProvider) followLogs(ctx context.Context, query logQuery, send func(logLine) error) error {
+	return pollLogs(ctx, query, osp.clock, osp.queryLogs, send)
+}
+
+// searchLogs returns the newest log lines selected by the query between the start date and the end date sorted by
+// their timestamp. The documents are requested page by page backwards in time until the linesCount is reached or no
+// documents are left. A linesCount of 0 or less returns all lines.
+func (osp *OpenSearchLogProvider) searchLogs(query logQuery, startDate time.Time, endDate time.Time, linesCount int) ([]logLine, error) {
+	matcher, err := query.newMatcher()
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	// the lines are collected from the newest to the oldest
+	result := make([]logLine, 0)
+	page := openSearchPage{endDate: endDate}
+	for {
+		lines, err := osp.search(query, startDate, page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, line := range lines {
+			if !matcher.matches(line.value) {
+				continue
+			}
+
+			result = append(result, line)
+			if linesCount > 0 && len(result) >= linesCount {
+				slices.Reverse(result)
+				return result, nil
+			}
+		}
+
+		if len(lines) < openSearchPageSize {
+			break
+		}
+
+		page = page.next(lines)
+	}
+
+	slices.Reverse(result)
+	logrus.Debugf("finished querying logs from opensearch; got %d logLines", len(result))
+
+	return result, nil
+}
+
+// openSearchPage selects the documents of a page. Following pages end with the timestamp of the oldest line of the
+// previous page, inclusively, because more lines may have this timestamp. The lines with this timestamp which were
+// already returned are skipped.
+type openSearchPage struct {
+	endDate      time.Time
+	endInclusive bool
+	from         int
+}
+
+// next returns the page following the page with the given lines, which are sorted from the newest to the oldest.
+func (p openSearchPage) next(lines []logLine) openSearchPage {
+	oldest := lines[len(lines)-1].timestamp
+	if p.endInclusive && oldest.Equal(p.endDate) {
+		// all lines of the page have the timestamp of the end date
+		return openSearchPage{endDate: p.endDate, endInclusive: true, from: p.from + len(lines)}
+	}
+
+	from := 0
+	for _, line := range lines {
+		if line.timestamp.Equal(oldest) {
+			from++
+		}
+	}
+
+	return openSearchPage{endDate: oldest, endInclusive: true, from: from}
+}
+
+// search returns a page of the lines of the pods of the dogus sorted from the newest to the oldest.
+func (osp *OpenSearchLogProvider) search(query logQuery, startDate time.Time, page openSearchPage) ([]logLine, error) {
+	timeRange := openSearchRange{}
+	if !startDate.IsZero() {
+		timeRange.Gte = startDate.Format(time.RFC3339Nano)
+	}
+	if page.endInclusive {
+		timeRange.Lte = page.endDate.Format(time.RFC3339Nano)
+	} else {
+		timeRange.Lt = page.endDate.Format(time.RFC3339Nano)
+	}
+
+	request := openSearchRequest{
+		From:   page.from,
+		Size:   openSearchPageSize,
+		Sort:   []map[string]string{{openSearchTimestampField: "desc"}},
+		Source: []string{openSearchTimestampField, openSearchLogField, openSearchPodField, openSearchContainerField},
+		Query: openSearchQuery{Bool: openSearchBoolQuery{Filter: []openSearchFilter{
+			{Regexp: map[string]openSearchRegexp{openSearchPodField: {Value: query.podPattern()}}},
+			{Range: map[string]openSearchRange{openSearchTimestampField: timeRange}},
+		}}},
+	}
+
+	response, err := osp.doSearch(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute opensearch query: %w", err)
+	}
+
+	lines := make([]logLine, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		timestamp, err := time.Parse(time.RFC3339Nano, hit.Source.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse log timestamp: %w", err)
+		}
+
+		lines = append(lines, logLine{
+			timestamp: timestamp,
+			value:     hit.Source.Log,
+			dogu:      doguOfPod(query.doguNames, hit.Source.Kubernetes.PodName),
+			container: hit.Source.Kubernetes.ContainerName,
+			pod:       hit.Source.Kubernetes.PodName,
+		})
+	}
+
+	return lines, nil
+}
+
+func (osp *OpenSearchLogProvider) doSearch(request openSearchRequest) (*openSearchResponse, error) {
+	searchUrl, err := url.JoinPath(osp.url, osp.index, "_search")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build opensearch url: %w", err)
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal opensearch query: %w", err)
+	}
+
+	logrus.Debugf("running opensearch query with URL %s: %s", searchUrl, body)
+	ctx, cancel := context.WithTimeout(context.Background(), currentQueryTimeout())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, searchUrl, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request with url [%s]: %w", searchUrl, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	osp.credentials.Authenticate(req)
+
+	resp, err := osp.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request with url [%s]: %w", searchUrl, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		responseData, err := io.ReadAll(resp.Body)
+		if err != nil || len(responseData) == 0 {
+			responseData = []byte(fmt.Sprintf("failed to read error response: %v", err))
+		}
+
+		return nil, fmt.Errorf("opensearch http error: status: %s, code: %d; response-body: %s", resp.Status, resp.StatusCode, responseData)
+	}
+
+	response := &openSearchResponse{}
+	err = json.NewDecoder(resp.Body).Decode(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return response, nil
+}
+
+// openSearchRequest is the body of a search request.
+type openSearchRequest struct {
+	From   int                 `json:"from,omitempty"`
+	Size   int                 `json:"size"`
+	Sort   []map[string]string `json:"sort"`
+	Source []string            `json:"_source"`
+	Query  openSearchQuery     `json:"query"`
+}
+
+type openSearchQuery struct {
+	Bool openSearchBoolQuery `json:"bool"`
+}
+
+// openSearchBoolQuery selects the documents matching all filters.
+type openSearchBoolQuery struct {
+	Filter []openSearchFilter `json:"filter"`
+}
+
+// openSearchFilter contains either a regexp or a range query by the name of the field.
+type openSearchFilter struct {
+	Regexp map[string]openSearchRegexp `json:"regexp,omitempty"`
+	Range  map[string]openSearchRange  `json:"range,omitempty"`
+}
+
+type openSearchRegexp struct {
+	Value string `json:"value"`
+}
+
+type openSearchRange struct {
+	Gte string `json:"gte,omitempty"`
+	Lt  string `json:"lt,omitempty"`
+	Lte string `json:"lte,omitempty"`
+}
+
+// openSearchResponse is the body of a search response.
+type openSearchResponse struct {
+	Hits openSearchHits `json:"hits"`
+}
+
+type openSearchHits struct {
+	Hits []openSearchHit `json:"hits"`
+}
+
+type openSearchHit struct {
+	Source openSearchDocument `json:"_source"`
+}
+
+// openSearchDocument is a log line with the metadata added by the kubernetes filter of fluent-bit.
+type openSearchDocument struct {
+	Timestamp  string                       `json:"@timestamp"`
+	Log        string                       `json:"log"`
+	Kubernetes openSearchKubernetesMetadata `json:"kubernetes"`
+}
+
+type openSearchKubernetesMetadata struct {
+	PodName       string `json:"pod_name"`
+	ContainerName string `json:"container_name"`
+}
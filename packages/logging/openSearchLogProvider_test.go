@@ -0,0 +1,207 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenSearchLogProvider_contract(t *testing.T) {
+	testLogProviderContract(t, func(t *testing.T, clock nowClock) (logProvider, logBackend) {
+		backend := newFakeOpenSearch(t)
+		sut := NewOpenSearchLogProvider(backend.server.URL, "fluent-bit", basicAuth{username: "admin", password: "admin123"}, http.DefaultClient)
+		sut.clock = clock
+
+		return sut, backend
+	})
+}
+
+func TestNewOpenSearchLogProvider(t *testing.T) {
+	t.Run("should create OpenSearchLogProvider", func(t *testing.T) {
+		// given
+		credentials := basicAuth{username: "user", password: "password"}
+		httpClient := &http.Client{}
+
+		// when
+		actual := NewOpenSearchLogProvider("https://opensearch:9200", "logstash-*", credentials, httpClient)
+
+		// then
+		require.NotNil(t, actual)
+		assert.Equal(t, "https://opensearch:9200", actual.url)
+		assert.Equal(t, "logstash-*", actual.index)
+		assert.Equal(t, credentials, actual.credentials)
+		assert.Same(t, httpClient, actual.httpClient)
+		assert.IsType(t, &realClock{}, actual.clock)
+	})
+}
+
+func TestOpenSearchLogProvider_queryLogs(t *testing.T) {
+	t.Run("should search the pods of the dogus within the time range", func(t *testing.T) {
+		// given
+		backend := newFakeOpenSearch(t)
+		sut := &OpenSearchLogProvider{url: backend.server.URL, index: "fluent-bit", credentials: basicAuth{username: "admin", password: "admin123"}, clock: &testClock{contractNow}, httpClient: http.DefaultClient}
+
+		// when
+		_, err := sut.queryLogs(logQuery{doguNames: []string{"cas", "ldap"}}, contractNow.Add(-time.Minute), contractNow)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, backend.requests, 1)
+		assert.Equal(t, openSearchRequest{
+			Size:   defaultQueryLimit,
+			Sort:   []map[string]string{{"@timestamp": "desc"}},
+			Source: []string{"@timestamp", "log", "kubernetes.pod_name", "kubernetes.container_name"},
+			Query: openSearchQuery{Bool: openSearchBoolQuery{Filter: []openSearchFilter{
+				{Regexp: map[string]openSearchRegexp{"kubernetes.pod_name": {Value: "(cas|ldap)" + podNameSuffixPattern}}},
+				{Range: map[string]openSearchRange{"@timestamp": {Gte: "2026-03-04T11:59:00Z", Lt: "2026-03-04T12:00:00Z"}}},
+			}}},
+		}, backend.requests[0])
+	})
+	t.Run("should request the pages until no lines are left", func(t *testing.T) {
+		// given
+		previousPageSize := openSearchPageSize
+		t.Cleanup(func() { openSearchPageSize = previousPageSize })
+		openSearchPageSize = 2
+
+		backend := newFakeOpenSearch(t)
+		backend.add(casLine(-40, "first"), casLine(-30, "second"), casLine(-30, "third"), casLine(-20, "fourth"), casLine(-10, "fifth"))
+		sut := &OpenSearchLogProvider{url: backend.server.URL, index: "fluent-bit", credentials: basicAuth{}, clock: &testClock{contractNow}, httpClient: http.DefaultClient}
+
+		// when
+		actual, err := sut.queryLogs(newDoguQuery("cas", ""), time.Time{}, time.Time{})
+
+		// then
+		require.NoError(t, err)
+		assertLogLines(t, []logLine{casLine(-40, "first"), casLine(-30, "second"), casLine(-30, "third"), casLine(-20, "fourth"), casLine(-10, "fifth")}, actual)
+		require.Len(t, backend.requests, 3)
+		assert.Equal(t, openSearchRange{Gte: "2026-02-02T12:00:00Z", Lte: "2026-03-04T11:59:30Z"}, backend.requests[2].Query.Bool.Filter[1].Range["@timestamp"])
+		assert.Equal(t, 2, backend.requests[2].From)
+	})
+	t.Run("should fail for an error response", func(t *testing.T) {
+		// given
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("no such index"))
+		}))
+		defer svr.Close()
+		sut := &OpenSearchLogProvider{url: svr.URL, index: "fluent-bit", credentials: basicAuth{}, clock: &testClock{contractNow}, httpClient: http.DefaultClient}
+
+		// when
+		_, err := sut.queryLogs(newDoguQuery("cas", ""), time.Time{}, time.Time{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to query logs from opensearch")
+		assert.ErrorContains(t, err, "code: 400; response-body: no such index")
+	})
+}
+
+// fakeOpenSearch serves the search api for the regexp and range queries of the OpenSearchLogProvider.
+type fakeOpenSearch struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mutex    sync.Mutex
+	lines    []logLine
+	requests []openSearchRequest
+}
+
+func newFakeOpenSearch(t *testing.T) *fakeOpenSearch {
+	f := &fakeOpenSearch{t: t}
+	f.server = httptest.NewServer(http.HandlerFunc(f.search))
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+func (f *fakeOpenSearch) add(lines ...logLine) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.lines = append(f.lines, lines...)
+}
+
+func (f *fakeOpenSearch) search(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	assert.Equal(f.t, http.MethodPost, r.Method)
+	assert.Equal(f.t, "/fluent-bit/_search", r.URL.Path)
+	assert.Equal(f.t, "application/json", r.Header.Get("Content-Type"))
+
+	request := openSearchRequest{}
+	require.NoError(f.t, json.NewDecoder(r.Body).Decode(&request))
+	f.requests = append(f.requests, request)
+
+	podRegexp := regexp.MustCompile("^(?:" + request.Query.Bool.Filter[0].Regexp[openSearchPodField].Value + ")$")
+	timeRange := request.Query.Bool.Filter[1].Range[openSearchTimestampField]
+	// the documents are sorted from the newest to the oldest, the latest added first for the same timestamp
+	selected := make([]logLine, 0)
+	for _, line := range slices.Backward(f.lines) {
+		if podRegexp.MatchString(line.pod) && inFakeRange(f.t, line.timestamp, timeRange) {
+			selected = append(selected, line)
+		}
+	}
+	slices.SortStableFunc(selected, func(a, b logLine) int { return b.timestamp.Compare(a.timestamp) })
+	selected = selected[min(request.From, len(selected)):]
+	selected = selected[:min(request.Size, len(selected))]
+
+	response := openSearchResponse{Hits: openSearchHits{Hits: make([]openSearchHit, 0)}}
+	for _, line := range selected {
+		response.Hits.Hits = append(response.Hits.Hits, openSearchHit{Source: openSearchDocument{
+			Timestamp:  line.timestamp.Format(time.RFC3339Nano),
+			Log:        line.value,
+			Kubernetes: openSearchKubernetesMetadata{PodName: line.pod, ContainerName: line.container},
+		}})
+	}
+
+	assert.NoError(f.t, json.NewEncoder(w).Encode(response))
+}
+
+func inFakeRange(t *testing.T, timestamp time.Time, timeRange openSearchRange) bool {
+	parse := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		require.NoError(t, err)
+		return parsed
+	}
+
+	return (timeRange.Gte == "" || !timestamp.Before(parse(timeRange.Gte))) &&
+		(timeRange.Lt == "" || timestamp.Before(parse(timeRange.Lt))) &&
+		(timeRange.Lte == "" || !timestamp.After(parse(timeRange.Lte)))
+}
+
+func TestOpenSearchLogProvider_queryStatistics(t *testing.T) {
+	t.Run("should not support statistics", func(t *testing.T) {
+		// given
+		sut := &OpenSearchLogProvider{}
+
+		// when
+		_, err := sut.queryStatistics(statisticsQuery(newDoguQuery("cas", "")))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errors.ErrUnsupported)
+	})
+}
+
+func TestOpenSearchLogProvider_queryVolume(t *testing.T) {
+	t.Run("should not support the log volume", func(t *testing.T) {
+		// given
+		sut := &OpenSearchLogProvider{}
+
+		// when
+		_, err := sut.queryVolume([]string{"cas"}, contractNow.Add(-time.Hour), contractNow)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errors.ErrUnsupported)
+	})
+}
@@ -1,8 +1,6 @@
 package logging
 
 import (
-	"archive/zip"
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -10,16 +8,20 @@ import (
 	"github.com/cloudogu/cesapp-lib/core"
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-registry-lib/config"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"slices"
 	"strings"
 	"time"
 
 	pb "github.com/cloudogu/ces-control-api/generated/logging"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
 	"github.com/cloudogu/k8s-ces-control/packages/stream"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -37,8 +39,28 @@ type doguLogMessagesServer interface {
 	pb.DoguLogMessages_GetForDoguServer
 }
 
+type doguLogMessagesFollowServer interface {
+	pb.DoguLogMessages_FollowForDoguServer
+}
+
+// doguSelection is implemented by the requests selecting the logs of several or all dogus.
+type doguSelection interface {
+	GetDoguName() string
+	GetDoguNames() []string
+	GetAllDogus() bool
+}
+
+// logFilterRequest is implemented by the requests filtering the log lines.
+type logFilterRequest interface {
+	GetFilter() string
+	GetLineFilters() []*pb.LogLineFilter
+	GetFieldFilters() []*pb.LogFieldFilter
+}
+
 type doguRestarter interface {
 	RestartDogu(ctx context.Context, doguName string) error
+	// RestartDoguWithWait restarts the dogu and waits until it is started again if waitForRollout is true.
+	RestartDoguWithWait(ctx context.Context, doguName string, waitForRollout bool) error
 }
 
 type doguDescriptorGetter interface {
@@ -47,16 +69,18 @@ type doguDescriptorGetter interface {
 
 type doguGetter interface {
 	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v2.Dogu, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v2.DoguList, error)
 }
 
 // NewLoggingService creates a new logging service.
-func NewLoggingService(provider logProvider, doguConfigRepository doguConfigRepository, restarter doguRestarter, doguDescriptorGetter doguDescriptorGetter, doguGetter doguGetter) *loggingService {
+func NewLoggingService(provider logProvider, doguConfigRepository doguConfigRepository, restarter doguRestarter, doguDescriptorGetter doguDescriptorGetter, doguGetter doguGetter, auditLogger auditLogger) *loggingService {
 	return &loggingService{
 		logProvider:          provider,
 		doguConfigRepository: doguConfigRepository,
 		doguRestarter:        restarter,
 		doguDescriptorGetter: doguDescriptorGetter,
 		doguGetter:           doguGetter,
+		auditLogger:          auditLogger,
 	}
 }
 
@@ -67,18 +91,27 @@ type loggingService struct {
 	doguRestarter        doguRestarter
 	doguDescriptorGetter doguDescriptorGetter
 	doguGetter           doguGetter
+	auditLogger          auditLogger
 }
 
-// QueryForDogu writes dogu log messages into the stream of the given server.
+// QueryForDogu writes the log messages of the requested dogus into the stream of the given server. The messages of
+// several dogus are merged in order of their timestamps and labeled with the dogu, container and pod which logged them.
+// If the request has a page size or a page token, only a page of the messages is written and the last message carries
+// the token of the next page.
 func (s *loggingService) QueryForDogu(request *pb.DoguLogMessageQueryRequest, server pb.DoguLogMessages_QueryForDoguServer) error {
-	doguName := request.DoguName
-	if doguName == "" {
-		return createInternalErr(errMissingDoguName, codes.InvalidArgument)
+	doguNames, err := s.queryDoguNames(server.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	if len(doguNames) == 0 {
+		logrus.Debug("no dogus installed, there are no log messages to query")
+		return nil
 	}
 
-	var filter = ""
-	if request.Filter != nil {
-		filter = request.GetFilter()
+	query, err := createLogQueryFromProto(doguNames, request)
+	if err != nil {
+		return createInternalErr(fmt.Errorf("invalid log query: %w", err), codes.InvalidArgument)
 	}
 
 	var startDate time.Time
@@ -91,19 +124,26 @@ func (s *loggingService) QueryForDogu(request *pb.DoguLogMessageQueryRequest, se
 		endDate = request.GetEndDate().AsTime()
 	}
 
-	logrus.Debugf("retrieving log messages from %s to %s for dogu '%s' with filter %s", startDate, endDate, doguName, filter)
+	logrus.Debugf("retrieving log messages from %s to %s for dogus %v", startDate, endDate, doguNames)
 
-	logLines, err := s.logProvider.queryLogs(doguName, startDate, endDate, filter)
+	logLines, nextPageToken, err := s.queryLogLines(query, request, startDate, endDate)
 	if err != nil {
-		logrus.Errorf("error reading logs: %v", err)
-		return createInternalErr(err, codes.InvalidArgument)
+		return err
 	}
 
-	for _, line := range logLines {
-		err := server.Send(&pb.DoguLogMessage{
+	for i, line := range logLines {
+		message := &pb.DoguLogMessage{
 			Timestamp: timestamppb.New(line.timestamp),
 			Message:   line.value,
-		})
+			DoguName:  line.dogu,
+			Container: line.container,
+			Pod:       line.pod,
+		}
+		if i == len(logLines)-1 {
+			message.NextPageToken = nextPageToken
+		}
+
+		err := server.Send(message)
 		if err != nil {
 			logrus.Errorf("error writing log-lines to stream: %v", err)
 			return createInternalErr(err, codes.InvalidArgument)
@@ -113,13 +153,353 @@ func (s *loggingService) QueryForDogu(request *pb.DoguLogMessageQueryRequest, se
 	return nil
 }
 
-// GetForDogu writes dogu log messages into the stream of the given server.
+// queryLogLines returns all log lines of the query or a page of them and the token of the next page. There is no next
+// page token if the page is not full.
+func (s *loggingService) queryLogLines(query logQuery, request *pb.DoguLogMessageQueryRequest, startDate time.Time, endDate time.Time) ([]logLine, string, error) {
+	if request.GetPageSize() == 0 && request.GetPageToken() == "" {
+		logLines, err := s.logProvider.queryLogs(query, startDate, endDate)
+		if err != nil {
+			logrus.Errorf("error reading logs: %v", err)
+			return nil, "", createInternalErr(err, codes.InvalidArgument)
+		}
+
+		return logLines, "", nil
+	}
+
+	cursor, pageSize, err := createLogCursorFromProto(query, request, time.Now())
+	if err != nil {
+		return nil, "", createInternalErr(fmt.Errorf("invalid log query: %w", err), codes.InvalidArgument)
+	}
+
+	logLines, err := s.logProvider.queryLogsPage(query, cursor, pageSize)
+	if err != nil {
+		logrus.Errorf("error reading logs: %v", err)
+		return nil, "", createInternalErr(err, codes.InvalidArgument)
+	}
+
+	if len(logLines) < pageSize {
+		return logLines, "", nil
+	}
+
+	return logLines, cursor.next(logLines).encode(), nil
+}
+
+// createLogCursorFromProto returns the cursor of the requested page and the page size. A page token continues the
+// time window and direction of the first page, so the dates and the direction of the request are ignored then. The
+// end date of the first page defaults to now and the start date to 30 days before the end date.
+func createLogCursorFromProto(query logQuery, request *pb.DoguLogMessageQueryRequest, now time.Time) (logCursor, int, error) {
+	pageSize := int(request.GetPageSize())
+	if pageSize < 0 || pageSize > maxPageSize {
+		return logCursor{}, 0, fmt.Errorf("page size %d must be between 0 and %d", pageSize, maxPageSize)
+	}
+
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	if request.GetPageToken() != "" {
+		cursor, err := decodeLogCursor(request.GetPageToken(), query)
+		return cursor, pageSize, err
+	}
+
+	endDate := now
+	if request.GetEndDate() != nil {
+		endDate = request.GetEndDate().AsTime()
+	}
+
+	startDate := createQueryStartDateFromEndDate(endDate)
+	if request.GetStartDate() != nil {
+		startDate = request.GetStartDate().AsTime()
+	}
+
+	if !startDate.Before(endDate) {
+		return logCursor{}, 0, fmt.Errorf("end date %s must be after start date %s", endDate.Format(time.RFC3339), startDate.Format(time.RFC3339))
+	}
+
+	return newLogCursor(query, startDate, endDate, request.GetDirection() == pb.LogQueryDirection_FORWARD), pageSize, nil
+}
+
+// queryDoguNames returns the sorted names of the dogus whose logs are queried. These are all installed dogus or the
+// dogu and the additional dogus of the request.
+func (s *loggingService) queryDoguNames(ctx context.Context, request doguSelection) ([]string, error) {
+	var doguNames []string
+	if request.GetAllDogus() {
+		dogus, err := s.doguGetter.List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, createInternalErr(fmt.Errorf("failed to list dogus: %w", err), codes.Internal)
+		}
+
+		for _, dogu := range dogus.Items {
+			doguNames = append(doguNames, dogu.Name)
+		}
+	} else {
+		for _, doguName := range append([]string{request.GetDoguName()}, request.GetDoguNames()...) {
+			if strings.TrimSpace(doguName) != "" {
+				doguNames = append(doguNames, doguName)
+			}
+		}
+
+		if len(doguNames) == 0 {
+			return nil, createInternalErr(errMissingDoguName, codes.InvalidArgument)
+		}
+	}
+
+	slices.Sort(doguNames)
+	return slices.Compact(doguNames), nil
+}
+
+// createLogQueryFromProto creates a validated query for the logs of the dogus with the filters of the request. The
+// filter field of the request is an additional substring which must be contained.
+func createLogQueryFromProto(doguNames []string, request logFilterRequest) (logQuery, error) {
+	query := logQuery{doguNames: doguNames}
+	if request.GetFilter() != "" {
+		query.lineFilters = append(query.lineFilters, logFilter{value: request.GetFilter()})
+	}
+
+	for _, filter := range request.GetLineFilters() {
+		query.lineFilters = append(query.lineFilters, logFilter{
+			value:           filter.GetValue(),
+			regex:           filter.GetRegex(),
+			exclude:         filter.GetExclude(),
+			caseInsensitive: filter.GetCaseInsensitive(),
+		})
+	}
+
+	for _, filter := range request.GetFieldFilters() {
+		format := logFormatJSON
+		if filter.GetFormat() == pb.LogFormat_LOGFMT {
+			format = logFormatLogfmt
+		}
+
+		query.fieldFilters = append(query.fieldFilters, fieldFilter{
+			format: format,
+			field:  filter.GetField(),
+			logFilter: logFilter{
+				value:           filter.GetValue(),
+				regex:           filter.GetRegex(),
+				exclude:         filter.GetExclude(),
+				caseInsensitive: filter.GetCaseInsensitive(),
+			},
+		})
+	}
+
+	return query, query.validate()
+}
+
+// GetLogStatistics counts the log lines of the requested dogus at every step within the time range, optionally
+// grouped by the level detected by loki. The lines of all pods of a dogu are summed up.
+func (s *loggingService) GetLogStatistics(ctx context.Context, request *pb.LogStatisticsRequest) (*pb.LogStatisticsResponse, error) {
+	doguNames, err := s.queryDoguNames(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(doguNames) == 0 {
+		logrus.Debug("no dogus installed, there are no log statistics to query")
+		return &pb.LogStatisticsResponse{}, nil
+	}
+
+	query, err := createLogStatisticsQueryFromProto(doguNames, request, time.Now())
+	if err != nil {
+		return nil, createInternalErr(fmt.Errorf("invalid log statistics query: %w", err), codes.InvalidArgument)
+	}
+
+	logrus.Debugf("querying log statistics from %s to %s with step %s for dogus %v", query.startDate, query.endDate, query.step, doguNames)
+
+	series, err := s.logProvider.queryStatistics(query)
+	if errors.Is(err, errors.ErrUnsupported) {
+		return nil, createInternalErr(err, codes.Unimplemented)
+	}
+	if err != nil {
+		logrus.Errorf("error querying log statistics: %v", err)
+		return nil, createInternalErr(err, codes.InvalidArgument)
+	}
+
+	response := &pb.LogStatisticsResponse{}
+	for _, entry := range series {
+		protoSeries := &pb.LogStatisticsSeries{DoguName: entry.dogu, Level: entry.level}
+		for _, sample := range entry.samples {
+			protoSeries.Samples = append(protoSeries.Samples, &pb.LogStatisticsSample{
+				Timestamp: timestamppb.New(sample.timestamp),
+				Value:     sample.value,
+			})
+		}
+		response.Series = append(response.Series, protoSeries)
+	}
+
+	return response, nil
+}
+
+// GetLogVolumeReport returns the bytes and lines of logs ingested by the requested dogus within every window before
+// now together with their current log level. The dogus are sorted descending by the volume of the first window, so
+// that noisy dogus and dogus left on a verbose log level stand out.
+func (s *loggingService) GetLogVolumeReport(ctx context.Context, request *pb.LogVolumeReportRequest) (*pb.LogVolumeReportResponse, error) {
+	windows, sortBy, err := createLogVolumeReportFromProto(request)
+	if err != nil {
+		return nil, createInternalErr(fmt.Errorf("invalid log volume report: %w", err), codes.InvalidArgument)
+	}
+
+	doguNames, err := s.queryDoguNames(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(doguNames) == 0 {
+		logrus.Debug("no dogus installed, there is no log volume to report")
+		return &pb.LogVolumeReportResponse{}, nil
+	}
+
+	reports := make([]doguVolumeReport, len(doguNames))
+	for i, doguName := range doguNames {
+		reports[i].dogu = doguName
+	}
+
+	now := time.Now()
+	for _, window := range windows {
+		volumes, lErr := s.logProvider.queryVolume(doguNames, now.Add(-window), now)
+		if errors.Is(lErr, errors.ErrUnsupported) {
+			return nil, createInternalErr(lErr, codes.Unimplemented)
+		}
+		if lErr != nil {
+			logrus.Errorf("error querying log volume: %v", lErr)
+			return nil, createInternalErr(lErr, codes.Internal)
+		}
+
+		for i := range reports {
+			reports[i].windows = append(reports[i].windows, volumes[i])
+		}
+	}
+
+	for i := range reports {
+		reports[i].rootLogger, err = s.getRootLogger(ctx, reports[i].dogu)
+		if err != nil {
+			logrus.Warnf("could not get log level of dogu %s for the log volume report: %v", reports[i].dogu, err)
+		}
+	}
+
+	sortVolumeReports(reports, sortBy)
+
+	response := &pb.LogVolumeReportResponse{}
+	for _, report := range reports {
+		protoReport := &pb.DoguLogVolume{
+			DoguName:       report.dogu,
+			LogLevel:       report.rootLogger.level,
+			LogLevelSource: loggerLevelSourceToProto(report.rootLogger.source),
+		}
+		for i, volume := range report.windows {
+			protoReport.Windows = append(protoReport.Windows, &pb.LogVolumeWindow{
+				Window: durationpb.New(windows[i]),
+				Bytes:  volume.bytes,
+				Lines:  volume.lines,
+			})
+		}
+		response.Dogus = append(response.Dogus, protoReport)
+	}
+
+	return response, nil
+}
+
+// createLogVolumeReportFromProto returns the validated windows of the report, which default to the last 24 hours, and
+// the value to sort by.
+func createLogVolumeReportFromProto(request *pb.LogVolumeReportRequest) ([]time.Duration, logVolumeSort, error) {
+	var sortBy logVolumeSort
+	switch request.GetSortBy() {
+	case pb.LogVolumeSortField_BYTES:
+		sortBy = logVolumeByBytes
+	case pb.LogVolumeSortField_LINES:
+		sortBy = logVolumeByLines
+	default:
+		return nil, 0, fmt.Errorf("unknown sort field %s", request.GetSortBy())
+	}
+
+	windows := []time.Duration{defaultVolumeWindow}
+	if len(request.GetWindows()) > 0 {
+		windows = make([]time.Duration, 0, len(request.GetWindows()))
+		for _, window := range request.GetWindows() {
+			windows = append(windows, window.AsDuration())
+		}
+	}
+
+	return windows, sortBy, validateVolumeWindows(windows)
+}
+
+// createLogStatisticsQueryFromProto creates a validated statistics query. The end date defaults to now, the start
+// date to one hour before the end date and the step to a sixtieth of the time range, but at least one second.
+func createLogStatisticsQueryFromProto(doguNames []string, request *pb.LogStatisticsRequest, now time.Time) (logStatisticsQuery, error) {
+	filters, err := createLogQueryFromProto(doguNames, request)
+	if err != nil {
+		return logStatisticsQuery{}, err
+	}
+
+	query := logStatisticsQuery{
+		logQuery: filters,
+		function: logStatisticsCount,
+		endDate:  now,
+		byLevel:  request.GetGroupByLevel(),
+	}
+
+	switch request.GetFunction() {
+	case pb.LogStatisticsFunction_COUNT_OVER_TIME:
+	case pb.LogStatisticsFunction_RATE:
+		query.function = logStatisticsRate
+	default:
+		return logStatisticsQuery{}, fmt.Errorf("unknown statistics function %s", request.GetFunction())
+	}
+
+	if request.GetEndDate() != nil {
+		query.endDate = request.GetEndDate().AsTime()
+	}
+
+	query.startDate = query.endDate.Add(-time.Hour)
+	if request.GetStartDate() != nil {
+		query.startDate = request.GetStartDate().AsTime()
+	}
+
+	query.step = max(query.endDate.Sub(query.startDate)/60, time.Second).Truncate(time.Second)
+	if request.GetStep() != nil {
+		query.step = request.GetStep().AsDuration()
+	}
+
+	return query, query.validate()
+}
+
+// FollowForDogu writes new dogu log messages into the stream of the given server until the client cancels the call.
+func (s *loggingService) FollowForDogu(request *pb.DoguLogMessageFollowRequest, server pb.DoguLogMessages_FollowForDoguServer) error {
+	doguName := request.DoguName
+	if doguName == "" {
+		return createInternalErr(errMissingDoguName, codes.InvalidArgument)
+	}
+
+	filter := request.GetFilter()
+
+	logrus.Debugf("following log messages for dogu '%s' with filter %s", doguName, filter)
+
+	err := s.logProvider.followLogs(server.Context(), newDoguQuery(doguName, filter), func(line logLine) error {
+		return server.Send(&pb.DoguLogMessage{
+			Timestamp: timestamppb.New(line.timestamp),
+			Message:   line.value,
+		})
+	})
+	if err != nil {
+		logrus.Errorf("error following logs: %v", err)
+		return createInternalErr(err, codes.Internal)
+	}
+
+	return nil
+}
+
+// GetForDogu writes dogu log messages into the stream of the given server in the requested export format.
 func (s *loggingService) GetForDogu(request *pb.DoguLogMessageRequest, server pb.DoguLogMessages_GetForDoguServer) error {
 	linesCount := int(request.LineCount)
 	doguName := request.DoguName
+	format, err := logExportFormatFromProto(request.GetFormat())
+	if err != nil {
+		return createInternalErr(err, codes.InvalidArgument)
+	}
+
 	// delegate to an orderly named method because GetForDogu is misleading but cannot be renamed due to the
 	// distributed nature of GRPC definitions
-	return writeLogLinesToStream(s.logProvider, doguName, linesCount, server)
+	return writeLogLinesToStream(s.logProvider, doguName, linesCount, format, server)
 }
 
 // ApplyLogLevelWithRestart sets the log level for a specific dogu and restarts the dogu if the log level was changed.
@@ -137,58 +517,201 @@ func (s *loggingService) ApplyLogLevelWithRestart(ctx context.Context, req *pb.L
 		return nil, createInternalErrWithCtx(fmt.Errorf("unable to map log level from proto message: %w", err), codes.InvalidArgument)
 	}
 
-	restart, err := s.setLogLevel(ctx, doguName, lLevel)
+	started := time.Now()
+	defer func() {
+		s.auditLogger.Record(ctx, audit.Entry{
+			Action:     "ApplyLogLevelWithRestart",
+			Resource:   audit.DoguResource(doguName),
+			Parameters: map[string]string{"logLevel": lLevel.String()},
+			Started:    started,
+			Err:        err,
+		})
+	}()
+
+	err = s.applyLoggerLevel(ctx, doguName, rootLoggerName, lLevel.String())
 	if err != nil {
-		return nil, createInternalErrWithCtx(fmt.Errorf("unable to set log level: %w", err), codes.Internal)
+		return nil, createInternalErrWithCtx(err, loggerLevelErrCode(err))
 	}
 
-	logrus.Debugf("restart needed for log level change: %v", restart)
+	return &emptypb.Empty{}, nil
+}
 
-	if !restart {
-		return &emptypb.Empty{}, nil
+// GetLoggers lists the loggers of a dogu defined in its descriptor with their effective levels and where these come
+// from.
+func (s *loggingService) GetLoggers(ctx context.Context, req *pb.DoguLoggersRequest) (*pb.DoguLoggersResponse, error) {
+	doguName := req.GetDoguName()
+	createInternalErrWithCtx := wrapCreateInternalErrWithContext(fmt.Sprintf("error occurred in GetLoggers for dogu \"%s\"", doguName))
+
+	if strings.TrimSpace(doguName) == "" {
+		return nil, createInternalErrWithCtx(errMissingDoguName, codes.InvalidArgument)
+	}
+
+	loggers, _, err := s.getLoggers(ctx, doguName)
+	if err != nil {
+		return nil, createInternalErrWithCtx(err, codes.Internal)
+	}
+
+	response := &pb.DoguLoggersResponse{}
+	for _, logger := range loggers {
+		response.Loggers = append(response.Loggers, &pb.DoguLogger{
+			Name:          logger.name,
+			Description:   logger.description,
+			Level:         logger.level,
+			Source:        loggerLevelSourceToProto(logger.source),
+			AllowedLevels: logger.allowedLevels,
+		})
+	}
+
+	return response, nil
+}
+
+// SetLoggerLevel sets the level of a logger of a dogu and restarts the dogu if the level was changed. The level must
+// be allowed by the dogu descriptor.
+func (s *loggingService) SetLoggerLevel(ctx context.Context, req *pb.LoggerLevelRequest) (res *emptypb.Empty, err error) {
+	doguName := req.GetDoguName()
+	createInternalErrWithCtx := wrapCreateInternalErrWithContext(fmt.Sprintf("error occurred in SetLoggerLevel for dogu \"%s\"", doguName))
+
+	if strings.TrimSpace(doguName) == "" {
+		return nil, createInternalErrWithCtx(errMissingDoguName, codes.InvalidArgument)
+	}
+
+	started := time.Now()
+	defer func() {
+		s.auditLogger.Record(ctx, audit.Entry{
+			Action:     "SetLoggerLevel",
+			Resource:   audit.DoguResource(doguName),
+			Parameters: map[string]string{"logger": req.GetLogger(), "logLevel": req.GetLevel()},
+			Started:    started,
+			Err:        err,
+		})
+	}()
+
+	err = s.applyLoggerLevel(ctx, doguName, req.GetLogger(), req.GetLevel())
+	if err != nil {
+		return nil, createInternalErrWithCtx(err, loggerLevelErrCode(err))
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// applyLoggerLevel sets the level of the logger and restarts the dogu if the level was changed.
+func (s *loggingService) applyLoggerLevel(ctx context.Context, doguName string, loggerName string, level string) error {
+	change, err := s.setLoggerLevel(ctx, doguName, loggerName, level)
+	if err != nil {
+		return fmt.Errorf("unable to set log level: %w", err)
+	}
+
+	logrus.Debugf("restart needed for log level change: %v", change == levelChanged)
+
+	if change != levelChanged {
+		return nil
 	}
 
 	if lErr := s.doguRestarter.RestartDogu(context.WithoutCancel(ctx), doguName); lErr != nil {
-		return nil, createInternalErrWithCtx(fmt.Errorf("unable to restart dogu %s after setting new log level: %w", doguName, lErr), codes.Internal)
+		return fmt.Errorf("unable to restart dogu %s after setting new log level: %w", doguName, lErr)
 	}
 
 	logrus.Debugf("Restarted dogu %s", doguName)
 
-	return &emptypb.Empty{}, nil
+	return nil
 }
 
-func (s *loggingService) setLogLevel(ctx context.Context, doguName string, l LogLevel) (bool, error) {
+// loggerLevelErrCode returns InvalidArgument for unknown loggers and levels not allowed by the dogu descriptor.
+func loggerLevelErrCode(err error) codes.Code {
+	if errors.Is(err, errUnknownLogger) || errors.Is(err, errInvalidLoggerLevel) {
+		return codes.InvalidArgument
+	}
+
+	return codes.Internal
+}
+
+func loggerLevelSourceToProto(source loggerLevelSource) pb.LogLevelSource {
+	switch source {
+	case loggerLevelFromConfig:
+		return pb.LogLevelSource_CONFIG
+	case loggerLevelFromDescriptor:
+		return pb.LogLevelSource_DESCRIPTOR_DEFAULT
+	default:
+		return pb.LogLevelSource_UNSET
+	}
+}
+
+// getLoggers returns the loggers of the dogu and its config.
+func (s *loggingService) getLoggers(ctx context.Context, doguName string) ([]doguLogger, config.DoguConfig, error) {
 	doguConfig, err := s.doguConfigRepository.Get(ctx, common.SimpleName(doguName))
 	if err != nil {
-		return false, logLevelNotFoundError(err)
+		return nil, config.DoguConfig{}, logLevelNotFoundError(err)
 	}
 
-	currentLogLevel, err := s.getLogLevel(ctx, doguName, doguConfig)
+	doguDescription, err := s.doguDescriptorGetter.GetCurrent(ctx, doguName)
 	if err != nil {
-		return false, logLevelNotFoundError(err)
+		return nil, config.DoguConfig{}, fmt.Errorf("could not get dogu description for dogu %s: %w", doguName, err)
 	}
 
-	if currentLogLevel == l {
-		return false, nil
+	return discoverLoggers(doguDescription, doguConfig), doguConfig, nil
+}
+
+// getRootLogger returns the root logger of the dogu with its current level.
+func (s *loggingService) getRootLogger(ctx context.Context, doguName string) (doguLogger, error) {
+	loggers, _, err := s.getLoggers(ctx, doguName)
+	if err != nil {
+		return doguLogger{}, err
 	}
 
-	if lErr := s.writeLogLevel(ctx, doguConfig, l); lErr != nil {
-		return false, fmt.Errorf("could not change log level from %s to %s: %w", currentLogLevel, l.String(), err)
+	return findLogger(loggers, rootLoggerName)
+}
+
+// levelChange tells how setting the level of a logger changed the dogu.
+type levelChange int
+
+const (
+	// levelUnchanged means that the effective level already was the requested level.
+	levelUnchanged levelChange = iota
+	// levelChangedStopped means that the level was written but the dogu is stopped and applies it on its next start.
+	levelChangedStopped
+	// levelChanged means that the level was written and the dogu has to be restarted to apply it.
+	levelChanged
+)
+
+// setLoggerLevel writes the validated level of the logger to the dogu config and reports whether the dogu has to be
+// restarted. No restart is needed if the effective level does not change or the dogu is stopped.
+func (s *loggingService) setLoggerLevel(ctx context.Context, doguName string, loggerName string, level string) (levelChange, error) {
+	loggers, doguConfig, err := s.getLoggers(ctx, doguName)
+	if err != nil {
+		return levelUnchanged, err
+	}
+
+	logger, err := findLogger(loggers, loggerName)
+	if err != nil {
+		return levelUnchanged, err
 	}
 
-	logrus.Debugf("written new log level %s for dogu %s", l.String(), doguName)
+	level, err = logger.validate(level)
+	if err != nil {
+		return levelUnchanged, err
+	}
+
+	if strings.EqualFold(logger.level, level) {
+		return levelUnchanged, nil
+	}
+
+	if lErr := s.writeLoggerLevel(ctx, doguConfig, logger, level); lErr != nil {
+		return levelUnchanged, fmt.Errorf("could not change log level of logger %s from %s to %s: %w", logger.name, logger.level, level, lErr)
+	}
+
+	logrus.Debugf("written new log level %s of logger %s for dogu %s", level, logger.name, doguName)
 
 	dogu, err := s.doguGetter.Get(ctx, doguName, metav1.GetOptions{})
 	if err != nil {
-		return false, fmt.Errorf("could not get dogu to check status: %w", err)
+		return levelUnchanged, fmt.Errorf("could not get dogu to check status: %w", err)
 	}
 
 	if dogu.Spec.Stopped {
 		logrus.Debugf("dogu %s dogu is stopped", doguName)
-		return false, nil
+		return levelChangedStopped, nil
 	}
 
-	return true, nil
+	return levelChanged, nil
 }
 
 // GetLogLevel provides the log level currently set for a specific dogu.
@@ -261,8 +784,8 @@ func (s *loggingService) getDefaultLogLevel(ctx context.Context, doguName string
 	return defaultLevelStr, nil
 }
 
-func (s *loggingService) writeLogLevel(ctx context.Context, dConfig config.DoguConfig, l LogLevel) error {
-	doguConfig, err := dConfig.Set(loggingKey, config.Value(l.String()))
+func (s *loggingService) writeLoggerLevel(ctx context.Context, dConfig config.DoguConfig, logger doguLogger, level string) error {
+	doguConfig, err := dConfig.Set(config.Key(logger.key()), config.Value(level))
 	if err != nil {
 		return fmt.Errorf("could not write to dogu config: %w", err)
 	}
@@ -275,25 +798,36 @@ func (s *loggingService) writeLogLevel(ctx context.Context, dConfig config.DoguC
 	return nil
 }
 
-func writeLogLinesToStream(logProvider logProvider, doguName string, linesCount int, server doguLogMessagesServer) error {
+// writeLogLinesToStream exports the log lines in the given format while they are read page by page, so that the
+// memory used is independent of the number of lines.
+func writeLogLinesToStream(logProvider logProvider, doguName string, linesCount int, format logExportFormat, server doguLogMessagesServer) error {
 	if doguName == "" {
 		return createInternalErr(errMissingDoguName, codes.InvalidArgument)
 	}
-	logrus.Debugf("retrieving %d line(s) of log messages for dogu '%s'", linesCount, doguName)
+	logrus.Debugf("retrieving %d line(s) of log messages for dogu '%s' as %s", linesCount, doguName, format)
 
-	logLines, err := logProvider.getLogs(doguName, linesCount)
+	err := server.SetHeader(metadata.Pairs(stream.ContentTypeHeader, format.contentType()))
 	if err != nil {
-		logrus.Errorf("error reading logs: %v", err)
-		return createInternalErr(err, codes.InvalidArgument)
+		return createInternalErr(fmt.Errorf("failed to set content type: %w", err), codes.Internal)
 	}
 
-	compressedMessagesBytes, err := compressMessages(doguName, logLines)
+	exporter := newLogExporter(doguName, format, stream.NewChunkWriter(server))
+
+	var writeErr error
+	err = logProvider.getLogs(doguName, linesCount, func(page []logLine) error {
+		writeErr = exporter.write(page)
+		return writeErr
+	})
+	if writeErr != nil {
+		logrus.Errorf("error writing logs to stream: %v", writeErr)
+		return createInternalErr(writeErr, codes.Internal)
+	}
 	if err != nil {
-		logrus.Errorf("error compressing message: %v", err)
-		return createInternalErr(err, codes.Internal)
+		logrus.Errorf("error reading logs: %v", err)
+		return createInternalErr(err, codes.InvalidArgument)
 	}
 
-	err = stream.WriteToStream(compressedMessagesBytes, server)
+	err = exporter.close()
 	if err != nil {
 		logrus.Errorf("error writing logs to stream: %v", err)
 		return createInternalErr(err, codes.Internal)
@@ -302,36 +836,20 @@ func writeLogLinesToStream(logProvider logProvider, doguName string, linesCount
 	return nil
 }
 
-func compressMessages(doguName string, logLines []logLine) ([]byte, error) {
-	if len(logLines) <= 0 {
-		return []byte{}, nil
+// logExportFormatFromProto maps the export format of a request. The zip archive is the default format.
+func logExportFormatFromProto(format pb.LogExportFormat) (logExportFormat, error) {
+	switch format {
+	case pb.LogExportFormat_ZIP:
+		return logExportFormatZip, nil
+	case pb.LogExportFormat_GZIP:
+		return logExportFormatGzip, nil
+	case pb.LogExportFormat_TEXT:
+		return logExportFormatText, nil
+	case pb.LogExportFormat_NDJSON:
+		return logExportFormatNDJSON, nil
+	default:
+		return "", fmt.Errorf("unknown log export format %s", format)
 	}
-
-	compressedMessages := bytes.NewBuffer(nil)
-	zipWriter := zip.NewWriter(compressedMessages)
-	logFileMetadata := zip.FileHeader{
-		Name:     fmt.Sprintf("%s.log", doguName),
-		Modified: time.Now(),
-		Method:   zip.Deflate,
-	}
-
-	writer, err := zipWriter.CreateHeader(&logFileMetadata)
-	if err != nil {
-		return nil, err
-	}
-
-	totalWrittenBytes := 0
-	for _, line := range logLines {
-		writtenBytes, err := writer.Write([]byte(line.value + "\n"))
-		if err != nil {
-			return nil, err
-		}
-		totalWrittenBytes += writtenBytes
-	}
-
-	_ = zipWriter.Close()
-	logrus.Debugf("wrote %d byte(s) to archive", totalWrittenBytes)
-	return compressedMessages.Bytes(), nil
 }
 
 func createInternalErr(err error, code codes.Code) error {
@@ -5,18 +5,25 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	common "github.com/cloudogu/ces-commons-lib/dogu"
 	pb "github.com/cloudogu/ces-control-api/generated/logging"
+	"github.com/cloudogu/ces-control-api/generated/types"
 	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
+	"github.com/cloudogu/k8s-ces-control/packages/stream"
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-registry-lib/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"testing"
 	"time"
 )
@@ -27,7 +34,7 @@ func TestNewLoggingService(t *testing.T) {
 		llp := &LokiLogProvider{}
 
 		// when
-		sut := NewLoggingService(llp, newMockDoguConfigRepository(t), newMockDoguRestarter(t), newMockDoguDescriptionGetter(t), newMockDoguGetter(t))
+		sut := NewLoggingService(llp, newMockDoguConfigRepository(t), newMockDoguRestarter(t), newMockDoguDescriptionGetter(t), newMockDoguGetter(t), newMockAuditLogger(t))
 
 		// then
 		require.NotNil(t, sut)
@@ -47,15 +54,48 @@ func Test_writeLogLinesToStream(t *testing.T) {
 			{timestamp: time.Unix(0, 1655722130600667919), value: `{"log":"Mon Jun 20 10:48:51 UTC 2022 -- Logging2\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
 			{timestamp: time.Unix(0, 1655722130600667934), value: `{"log":"Mon Jun 20 10:48:52 UTC 2022 -- Logging3\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
 		}
-		mockedLogProvider.EXPECT().getLogs("my-dogu", 222).Return(logLines, nil)
+		mockedLogProvider.EXPECT().getLogs("my-dogu", 222, mock.Anything).RunAndReturn(sendLogPages(logLines[:1], logLines[1:]))
 
-		mockedDoguLogServer.EXPECT().Send(mock.Anything).Return(nil)
+		mockedDoguLogServer.EXPECT().SetHeader(metadata.Pairs(stream.ContentTypeHeader, "application/zip")).Return(nil)
+		var sentData []byte
+		mockedDoguLogServer.EXPECT().Send(mock.Anything).Run(func(response *types.ChunkedDataResponse) {
+			sentData = append(sentData, response.GetData()...)
+		}).Return(nil)
 
 		// when
-		err := writeLogLinesToStream(mockedLogProvider, "my-dogu", 222, mockedDoguLogServer)
+		err := writeLogLinesToStream(mockedLogProvider, "my-dogu", 222, logExportFormatZip, mockedDoguLogServer)
 
 		// then
 		require.NoError(t, err)
+
+		// test text equality
+		zipreader, err := zip.NewReader(bytes.NewReader(sentData), int64(len(sentData)))
+		require.NoError(t, err)
+		require.Len(t, zipreader.File, 1)
+		assert.Equal(t, "my-dogu.log", zipreader.File[0].Name)
+
+		fc, err := zipreader.File[0].Open()
+		require.NoError(t, err)
+		defer func() { _ = fc.Close() }()
+		actualFileContent, err := io.ReadAll(fc)
+		require.NoError(t, err)
+		assert.Len(t, actualFileContent, 333)
+	})
+
+	t.Run("should write nothing for empty log lines", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesServer(t)
+
+		mockedLogProvider.EXPECT().getLogs("my-dogu", 222, mock.Anything).Return(nil)
+		mockedDoguLogServer.EXPECT().SetHeader(mock.Anything).Return(nil)
+
+		// when
+		err := writeLogLinesToStream(mockedLogProvider, "my-dogu", 222, logExportFormatZip, mockedDoguLogServer)
+
+		// then
+		require.NoError(t, err)
+		mockedDoguLogServer.AssertNotCalled(t, "Send", mock.Anything)
 	})
 
 	t.Run("should fail for empty dogu-name", func(t *testing.T) {
@@ -64,7 +104,7 @@ func Test_writeLogLinesToStream(t *testing.T) {
 		mockedDoguLogServer := newMockDoguLogMessagesServer(t)
 
 		// when
-		err := writeLogLinesToStream(mockedLogProvider, "", 222, mockedDoguLogServer)
+		err := writeLogLinesToStream(mockedLogProvider, "", 222, logExportFormatZip, mockedDoguLogServer)
 
 		// then
 		require.Error(t, err)
@@ -76,10 +116,11 @@ func Test_writeLogLinesToStream(t *testing.T) {
 		mockedLogProvider := newMockLogProvider(t)
 		mockedDoguLogServer := newMockDoguLogMessagesServer(t)
 
-		mockedLogProvider.EXPECT().getLogs("my-dogu", 222).Return(nil, assert.AnError)
+		mockedDoguLogServer.EXPECT().SetHeader(mock.Anything).Return(nil)
+		mockedLogProvider.EXPECT().getLogs("my-dogu", 222, mock.Anything).Return(assert.AnError)
 
 		// when
-		err := writeLogLinesToStream(mockedLogProvider, "my-dogu", 222, mockedDoguLogServer)
+		err := writeLogLinesToStream(mockedLogProvider, "my-dogu", 222, logExportFormatZip, mockedDoguLogServer)
 
 		// then
 		require.Error(t, err)
@@ -96,263 +137,987 @@ func Test_writeLogLinesToStream(t *testing.T) {
 			{timestamp: time.Unix(0, 1655722130600667919), value: `{"log":"Mon Jun 20 10:48:51 UTC 2022 -- Logging2\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
 			{timestamp: time.Unix(0, 1655722130600667934), value: `{"log":"Mon Jun 20 10:48:52 UTC 2022 -- Logging3\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
 		}
-		mockedLogProvider.EXPECT().getLogs("my-dogu", 222).Return(logLines, nil)
+		mockedLogProvider.EXPECT().getLogs("my-dogu", 222, mock.Anything).RunAndReturn(sendLogPages(logLines))
 
+		mockedDoguLogServer.EXPECT().SetHeader(mock.Anything).Return(nil)
 		mockedDoguLogServer.EXPECT().Send(mock.Anything).Return(assert.AnError)
 
 		// when
-		err := writeLogLinesToStream(mockedLogProvider, "my-dogu", 222, mockedDoguLogServer)
+		err := writeLogLinesToStream(mockedLogProvider, "my-dogu", 222, logExportFormatText, mockedDoguLogServer)
 
 		// then
 		require.Error(t, err)
 		assert.ErrorContains(t, err, "rpc error: code = Internal desc = assert.AnError general error for testing")
 	})
+
+	t.Run("should fail for error in setting the content type", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesServer(t)
+
+		mockedDoguLogServer.EXPECT().SetHeader(mock.Anything).Return(assert.AnError)
+
+		// when
+		err := writeLogLinesToStream(mockedLogProvider, "my-dogu", 222, logExportFormatZip, mockedDoguLogServer)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "rpc error: code = Internal desc = failed to set content type")
+	})
 }
 
-func Test_compressMessages(t *testing.T) {
-	t.Run("should compress log lines", func(t *testing.T) {
+// sendLogPages returns a getLogs implementation which sends the given pages.
+func sendLogPages(pages ...[]logLine) func(string, int, func([]logLine) error) error {
+	return func(_ string, _ int, send func([]logLine) error) error {
+		for _, page := range pages {
+			if err := send(page); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func Test_logExportFormatFromProto(t *testing.T) {
+	tests := []struct {
+		format  pb.LogExportFormat
+		want    logExportFormat
+		wantErr bool
+	}{
+		{format: pb.LogExportFormat_ZIP, want: logExportFormatZip},
+		{format: pb.LogExportFormat_GZIP, want: logExportFormatGzip},
+		{format: pb.LogExportFormat_TEXT, want: logExportFormatText},
+		{format: pb.LogExportFormat_NDJSON, want: logExportFormatNDJSON},
+		{format: pb.LogExportFormat(42), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			// when
+			actual, err := logExportFormatFromProto(tt.format)
+
+			// then
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, "unknown log export format")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+}
+
+func Test_GetForDogu(t *testing.T) {
+	t.Run("should get logs for Dogu", func(t *testing.T) {
 		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
 		logLines := []logLine{
 			{timestamp: time.Unix(0, 1655722130600667903), value: `{"log":"Mon Jun 20 10:48:50 UTC 2022 -- Logging1\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
 			{timestamp: time.Unix(0, 1655722130600667919), value: `{"log":"Mon Jun 20 10:48:51 UTC 2022 -- Logging2\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
 			{timestamp: time.Unix(0, 1655722130600667934), value: `{"log":"Mon Jun 20 10:48:52 UTC 2022 -- Logging3\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
 		}
+		mockedLogProvider.EXPECT().getLogs("my-dogu", 333, mock.Anything).RunAndReturn(sendLogPages(logLines))
+
+		mockedDoguLogServer.EXPECT().SetHeader(metadata.Pairs(stream.ContentTypeHeader, "application/x-ndjson")).Return(nil)
+		mockedDoguLogServer.EXPECT().Send(mock.Anything).Return(nil)
+
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
 
 		// when
-		actualMyDoguLogZip, err := compressMessages("my-dogu", logLines)
+		request := &pb.DoguLogMessageRequest{
+			DoguName:  "my-dogu",
+			LineCount: 333,
+			Format:    pb.LogExportFormat_NDJSON,
+		}
+		err := sut.GetForDogu(request, mockedDoguLogServer)
 
 		// then
 		require.NoError(t, err)
-		assert.NotNil(t, actualMyDoguLogZip)
+	})
+	t.Run("should fail for an unknown format", func(t *testing.T) {
+		// given
+		sut := NewLoggingService(newMockLogProvider(t), newMockDoguConfigRepository(t), newMockDoguRestarter(t), newMockDoguDescriptionGetter(t), newMockDoguGetter(t), newMockAuditLogger(t))
 
-		// test text equality
-		zipreader, err := zip.NewReader(bytes.NewReader(actualMyDoguLogZip), int64(len(actualMyDoguLogZip)))
+		// when
+		err := sut.GetForDogu(&pb.DoguLogMessageRequest{DoguName: "my-dogu", Format: pb.LogExportFormat(42)}, newMockDoguLogMessagesServer(t))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "rpc error: code = InvalidArgument desc = unknown log export format")
+	})
+}
+
+func Test_QueryForDogu(t *testing.T) {
+	t.Run("should get logs for Dogu", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		start := time.Unix(1711616504, 0).UTC()
+		end := time.Unix(1712131304, 0).UTC()
+		filter := "foo=bar"
+
+		logLines := []logLine{
+			{timestamp: time.Unix(0, 1655722130600667903), value: `{"log":"Mon Jun 20 10:48:50 UTC 2022 -- Logging1\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
+			{timestamp: time.Unix(0, 1655722130600667919), value: `{"log":"Mon Jun 20 10:48:51 UTC 2022 -- Logging2\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
+			{timestamp: time.Unix(0, 1655722130600667934), value: `{"log":"Mon Jun 20 10:48:52 UTC 2022 -- Logging3\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
+		}
+		mockedLogProvider.EXPECT().queryLogs(logQuery{doguNames: []string{"my-dogu"}, lineFilters: []logFilter{{value: filter}}}, start, end).Return(logLines, nil)
+
+		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[0].timestamp), Message: logLines[0].value}).Return(nil)
+		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[1].timestamp), Message: logLines[1].value}).Return(nil)
+		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[2].timestamp), Message: logLines[2].value}).Return(nil)
+
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		request := &pb.DoguLogMessageQueryRequest{
+			DoguName:  "my-dogu",
+			StartDate: timestamppb.New(start),
+			EndDate:   timestamppb.New(end),
+			Filter:    &filter,
+		}
+		err := sut.QueryForDogu(request, mockedDoguLogServer)
+
+		// then
 		require.NoError(t, err)
-		assert.Len(t, zipreader.File, 1)
+	})
 
-		for _, zipfile := range zipreader.File {
-			assert.Equal(t, "my-dogu.log", zipfile.Name)
+	t.Run("should get logs for Dogu without optional parameters", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
 
-			fc, err := zipfile.Open()
-			require.NoError(t, err)
-			defer func() { _ = fc.Close() }()
-			actualFileContent, err := io.ReadAll(fc)
-			require.NoError(t, err)
+		logLines := []logLine{
+			{timestamp: time.Unix(0, 1655722130600667903), value: `{"log":"Mon Jun 20 10:48:50 UTC 2022 -- Logging1\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
+			{timestamp: time.Unix(0, 1655722130600667919), value: `{"log":"Mon Jun 20 10:48:51 UTC 2022 -- Logging2\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
+			{timestamp: time.Unix(0, 1655722130600667934), value: `{"log":"Mon Jun 20 10:48:52 UTC 2022 -- Logging3\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
+		}
+		mockedLogProvider.EXPECT().queryLogs(logQuery{doguNames: []string{"my-dogu"}}, time.Time{}, time.Time{}).Return(logLines, nil)
 
-			assert.Len(t, actualFileContent, 333)
+		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[0].timestamp), Message: logLines[0].value}).Return(nil)
+		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[1].timestamp), Message: logLines[1].value}).Return(nil)
+		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[2].timestamp), Message: logLines[2].value}).Return(nil)
+
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		request := &pb.DoguLogMessageQueryRequest{
+			DoguName:  "my-dogu",
+			StartDate: nil,
+			EndDate:   nil,
+			Filter:    nil,
 		}
+		err := sut.QueryForDogu(request, mockedDoguLogServer)
+
+		// then
+		require.NoError(t, err)
 	})
 
-	t.Run("should not compress for empty log lines", func(t *testing.T) {
+	t.Run("should fail to get logs for empty doguname", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		request := &pb.DoguLogMessageQueryRequest{
+			DoguName: "",
+		}
+		err := sut.QueryForDogu(request, mockedDoguLogServer)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "rpc error: code = InvalidArgument desc = dogu name should not be empty")
+	})
+
+	t.Run("should fail to get logs for error querying the log provider", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		start := time.Unix(1711616504, 0).UTC()
+		end := time.Unix(1712131304, 0).UTC()
+		filter := "foo=bar"
+
+		mockedLogProvider.EXPECT().queryLogs(logQuery{doguNames: []string{"my-dogu"}, lineFilters: []logFilter{{value: filter}}}, start, end).Return(nil, assert.AnError)
+
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		request := &pb.DoguLogMessageQueryRequest{
+			DoguName:  "my-dogu",
+			StartDate: timestamppb.New(start),
+			EndDate:   timestamppb.New(end),
+			Filter:    &filter,
+		}
+		err := sut.QueryForDogu(request, mockedDoguLogServer)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, assert.AnError.Error())
+	})
+
+	t.Run("should fail to get logs for error while sending", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		start := time.Unix(1711616504, 0).UTC()
+		end := time.Unix(1712131304, 0).UTC()
+		filter := "foo=bar"
+
+		logLines := []logLine{
+			{timestamp: time.Unix(0, 1655722130600667903), value: `{"log":"Mon Jun 20 10:48:50 UTC 2022 -- Logging1\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
+			{timestamp: time.Unix(0, 1655722130600667919), value: `{"log":"Mon Jun 20 10:48:51 UTC 2022 -- Logging2\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
+			{timestamp: time.Unix(0, 1655722130600667934), value: `{"log":"Mon Jun 20 10:48:52 UTC 2022 -- Logging3\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
+		}
+		mockedLogProvider.EXPECT().queryLogs(logQuery{doguNames: []string{"my-dogu"}, lineFilters: []logFilter{{value: filter}}}, start, end).Return(logLines, nil)
+
+		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[0].timestamp), Message: logLines[0].value}).Return(nil)
+		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[1].timestamp), Message: logLines[1].value}).Return(assert.AnError)
+
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		request := &pb.DoguLogMessageQueryRequest{
+			DoguName:  "my-dogu",
+			StartDate: timestamppb.New(start),
+			EndDate:   timestamppb.New(end),
+			Filter:    &filter,
+		}
+		err := sut.QueryForDogu(request, mockedDoguLogServer)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, assert.AnError.Error())
+	})
+
+	t.Run("should merge logs of several dogus with labels", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		logLines := []logLine{
+			{timestamp: time.Unix(0, 1), value: "cas first", dogu: "cas", container: "cas", pod: "cas-7d9f"},
+			{timestamp: time.Unix(0, 2), value: "ldap first", dogu: "ldap", container: "ldap", pod: "ldap-5c4b"},
+		}
+		mockedLogProvider.EXPECT().queryLogs(logQuery{doguNames: []string{"cas", "ldap", "postfix"}}, time.Time{}, time.Time{}).Return(logLines, nil)
+
+		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[0].timestamp), Message: "cas first", DoguName: "cas", Container: "cas", Pod: "cas-7d9f"}).Return(nil)
+		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[1].timestamp), Message: "ldap first", DoguName: "ldap", Container: "ldap", Pod: "ldap-5c4b"}).Return(nil)
+
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		request := &pb.DoguLogMessageQueryRequest{
+			DoguName:  "postfix",
+			DoguNames: []string{"ldap", "cas", "postfix"},
+		}
+		err := sut.QueryForDogu(request, mockedDoguLogServer)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should query logs of all dogus", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		ctx := context.Background()
+		mockedDoguLogServer.EXPECT().Context().Return(ctx)
+		dogus := &v2.DoguList{Items: []v2.Dogu{
+			{ObjectMeta: metav1.ObjectMeta{Name: "ldap"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "cas"}},
+		}}
+		mockedDoguGetter.EXPECT().List(ctx, metav1.ListOptions{}).Return(dogus, nil)
+		mockedLogProvider.EXPECT().queryLogs(logQuery{doguNames: []string{"cas", "ldap"}}, time.Time{}, time.Time{}).Return([]logLine{}, nil)
+
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		err := sut.QueryForDogu(&pb.DoguLogMessageQueryRequest{AllDogus: true}, mockedDoguLogServer)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should query nothing if no dogu is installed", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		ctx := context.Background()
+		mockedDoguLogServer.EXPECT().Context().Return(ctx)
+		mockedDoguGetter.EXPECT().List(ctx, metav1.ListOptions{}).Return(&v2.DoguList{}, nil)
+
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		err := sut.QueryForDogu(&pb.DoguLogMessageQueryRequest{AllDogus: true}, mockedDoguLogServer)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should fail to query logs of all dogus if listing the dogus fails", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		ctx := context.Background()
+		mockedDoguLogServer.EXPECT().Context().Return(ctx)
+		mockedDoguGetter.EXPECT().List(ctx, metav1.ListOptions{}).Return(nil, assert.AnError)
+
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		err := sut.QueryForDogu(&pb.DoguLogMessageQueryRequest{AllDogus: true}, mockedDoguLogServer)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.ErrorContains(t, err, "failed to list dogus")
+	})
+
+	t.Run("should query logs with typed filters", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		expectedQuery := logQuery{
+			doguNames: []string{"cas"},
+			lineFilters: []logFilter{
+				{value: "login"},
+				{value: "health", exclude: true, caseInsensitive: true},
+				{value: "user=[a-z]+", regex: true},
+			},
+			fieldFilters: []fieldFilter{
+				{format: logFormatLogfmt, field: "level", logFilter: logFilter{value: "error|warn", regex: true}},
+			},
+		}
+		mockedLogProvider.EXPECT().queryLogs(expectedQuery, time.Time{}, time.Time{}).Return([]logLine{}, nil)
+
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+		filter := "login"
+
+		// when
+		request := &pb.DoguLogMessageQueryRequest{
+			DoguName: "cas",
+			Filter:   &filter,
+			LineFilters: []*pb.LogLineFilter{
+				{Value: "health", Exclude: true, CaseInsensitive: true},
+				{Value: "user=[a-z]+", Regex: true},
+			},
+			FieldFilters: []*pb.LogFieldFilter{
+				{Format: pb.LogFormat_LOGFMT, Field: "level", Value: "error|warn", Regex: true},
+			},
+		}
+		err := sut.QueryForDogu(request, mockedDoguLogServer)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should fail to query logs for invalid filters", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		request := &pb.DoguLogMessageQueryRequest{
+			DoguName:     "cas",
+			LineFilters:  []*pb.LogLineFilter{{Value: "user=(", Regex: true}},
+			FieldFilters: []*pb.LogFieldFilter{{Field: "level.name", Value: "error"}},
+		}
+		err := sut.QueryForDogu(request, mockedDoguLogServer)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, "invalid regular expression \"user=(\"")
+		assert.ErrorContains(t, err, "invalid field name \"level.name\"")
+	})
+
+	t.Run("should send a page with the token of the next page on the last message", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+
+		start := contractNow.Add(-time.Hour)
+		query := newDoguQuery("cas", "")
+		cursor := newLogCursor(query, start, contractNow, true)
+		logLines := []logLine{casLine(-30, "cas started"), casLine(-20, "request handled")}
+		mockedLogProvider.EXPECT().queryLogsPage(query, cursor, 2).Return(logLines, nil)
+
+		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[0].timestamp), Message: "cas started", DoguName: "cas", Container: "cas", Pod: contractCasPod}).Return(nil)
+		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[1].timestamp), Message: "request handled", DoguName: "cas", Container: "cas", Pod: contractCasPod, NextPageToken: cursor.next(logLines).encode()}).Return(nil)
+
+		sut := NewLoggingService(mockedLogProvider, nil, nil, nil, nil, nil)
+
+		// when
+		request := &pb.DoguLogMessageQueryRequest{
+			DoguName:  "cas",
+			StartDate: timestamppb.New(start),
+			EndDate:   timestamppb.New(contractNow),
+			PageSize:  2,
+			Direction: pb.LogQueryDirection_FORWARD,
+		}
+		err := sut.QueryForDogu(request, mockedDoguLogServer)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should continue with the page token and send no token for the last page", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+
+		query := newDoguQuery("cas", "")
+		cursor := newLogCursor(query, contractNow.Add(-time.Hour), contractNow, false).next([]logLine{casLine(-10, "request handled")})
+		logLines := []logLine{casLine(-30, "cas started")}
+		mockedLogProvider.EXPECT().queryLogsPage(query, cursor, defaultPageSize).Return(logLines, nil)
+
+		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[0].timestamp), Message: "cas started", DoguName: "cas", Container: "cas", Pod: contractCasPod}).Return(nil)
+
+		sut := NewLoggingService(mockedLogProvider, nil, nil, nil, nil, nil)
+
+		// when
+		request := &pb.DoguLogMessageQueryRequest{
+			DoguName:  "cas",
+			PageToken: cursor.encode(),
+		}
+		err := sut.QueryForDogu(request, mockedDoguLogServer)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should fail to query a page for a token of another query", func(t *testing.T) {
+		// given
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		token := newLogCursor(newDoguQuery("ldap", ""), contractNow.Add(-time.Hour), contractNow, false).encode()
+
+		sut := NewLoggingService(newMockLogProvider(t), nil, nil, nil, nil, nil)
+
+		// when
+		err := sut.QueryForDogu(&pb.DoguLogMessageQueryRequest{DoguName: "cas", PageToken: token}, mockedDoguLogServer)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, "invalid page token: the token belongs to another query")
+	})
+
+	t.Run("should fail to query a page for an error of the log provider", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedLogProvider.EXPECT().queryLogsPage(newDoguQuery("cas", ""), mock.Anything, 10).Return(nil, assert.AnError)
+
+		sut := NewLoggingService(mockedLogProvider, nil, nil, nil, nil, nil)
+
+		// when
+		err := sut.QueryForDogu(&pb.DoguLogMessageQueryRequest{DoguName: "cas", PageSize: 10}, mockedDoguLogServer)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func Test_createLogCursorFromProto(t *testing.T) {
+	query := newDoguQuery("cas", "")
+
+	t.Run("should query the last 30 days backward by default", func(t *testing.T) {
+		// when
+		cursor, pageSize, err := createLogCursorFromProto(query, &pb.DoguLogMessageQueryRequest{PageSize: 50}, contractNow)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 50, pageSize)
+		assert.Equal(t, newLogCursor(query, contractNow.Add(-30*24*time.Hour), contractNow, false), cursor)
+	})
+	t.Run("should fail for a page size above the maximum", func(t *testing.T) {
+		// when
+		_, _, err := createLogCursorFromProto(query, &pb.DoguLogMessageQueryRequest{PageSize: 2501}, contractNow)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "page size 2501 must be between 0 and 2500")
+	})
+	t.Run("should fail for an end date before the start date", func(t *testing.T) {
+		// given
+		request := &pb.DoguLogMessageQueryRequest{PageSize: 50, StartDate: timestamppb.New(contractNow), EndDate: timestamppb.New(contractNow.Add(-time.Minute))}
+
+		// when
+		_, _, err := createLogCursorFromProto(query, request, contractNow)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "end date 2026-03-04T11:59:00Z must be after start date 2026-03-04T12:00:00Z")
+	})
+}
+
+func Test_GetLogStatistics(t *testing.T) {
+	newSut := func(t *testing.T, provider logProvider, doguGetter doguGetter) *loggingService {
+		return NewLoggingService(provider, newMockDoguConfigRepository(t), newMockDoguRestarter(t), newMockDoguDescriptionGetter(t), doguGetter, newMockAuditLogger(t))
+	}
+
+	t.Run("should return the series of the dogus", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		start := time.Unix(1772622000, 0).UTC()
+		end := time.Unix(1772625600, 0).UTC()
+		expectedQuery := logStatisticsQuery{
+			logQuery:  logQuery{doguNames: []string{"cas", "ldap"}, lineFilters: []logFilter{{value: "failed"}}},
+			function:  logStatisticsRate,
+			startDate: start,
+			endDate:   end,
+			step:      5 * time.Minute,
+			byLevel:   true,
+		}
+		mockedLogProvider.EXPECT().queryStatistics(expectedQuery).Return([]logSeries{
+			{dogu: "ldap", level: "error", samples: []logSample{{timestamp: start, value: 0.5}, {timestamp: end, value: 2}}},
+		}, nil)
+
+		sut := newSut(t, mockedLogProvider, newMockDoguGetter(t))
+		filter := "failed"
+
+		// when
+		actual, err := sut.GetLogStatistics(context.Background(), &pb.LogStatisticsRequest{
+			DoguNames:    []string{"ldap", "cas"},
+			StartDate:    timestamppb.New(start),
+			EndDate:      timestamppb.New(end),
+			Step:         durationpb.New(5 * time.Minute),
+			Function:     pb.LogStatisticsFunction_RATE,
+			GroupByLevel: true,
+			Filter:       &filter,
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &pb.LogStatisticsResponse{Series: []*pb.LogStatisticsSeries{{
+			DoguName: "ldap",
+			Level:    "error",
+			Samples: []*pb.LogStatisticsSample{
+				{Timestamp: timestamppb.New(start), Value: 0.5},
+				{Timestamp: timestamppb.New(end), Value: 2},
+			},
+		}}}, actual)
+	})
+
+	t.Run("should return no series without installed dogus", func(t *testing.T) {
+		// given
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedDoguGetter.EXPECT().List(context.Background(), metav1.ListOptions{}).Return(&v2.DoguList{}, nil)
+		sut := newSut(t, newMockLogProvider(t), mockedDoguGetter)
+
+		// when
+		actual, err := sut.GetLogStatistics(context.Background(), &pb.LogStatisticsRequest{AllDogus: true})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, actual.GetSeries())
+	})
+
+	t.Run("should fail for an invalid query", func(t *testing.T) {
+		// given
+		sut := newSut(t, newMockLogProvider(t), newMockDoguGetter(t))
+
+		// when
+		_, err := sut.GetLogStatistics(context.Background(), &pb.LogStatisticsRequest{DoguName: "cas", Step: durationpb.New(time.Millisecond)})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, "invalid log statistics query")
+	})
+
+	t.Run("should fail with unimplemented for a log backend without statistics", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedLogProvider.EXPECT().queryStatistics(mock.Anything).Return(nil, fmt.Errorf("log statistics: %w", errors.ErrUnsupported))
+		sut := newSut(t, mockedLogProvider, newMockDoguGetter(t))
+
+		// when
+		_, err := sut.GetLogStatistics(context.Background(), &pb.LogStatisticsRequest{DoguName: "cas"})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("should fail for an error of the log provider", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedLogProvider.EXPECT().queryStatistics(mock.Anything).Return(nil, assert.AnError)
+		sut := newSut(t, mockedLogProvider, newMockDoguGetter(t))
+
+		// when
+		_, err := sut.GetLogStatistics(context.Background(), &pb.LogStatisticsRequest{DoguName: "cas"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "rpc error: code = InvalidArgument desc = assert.AnError general error for testing")
+	})
+}
+
+func Test_createLogStatisticsQueryFromProto(t *testing.T) {
+	t.Run("should count the lines of the last hour per minute by default", func(t *testing.T) {
+		// when
+		actual, err := createLogStatisticsQueryFromProto([]string{"cas"}, &pb.LogStatisticsRequest{DoguName: "cas"}, contractNow)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, logStatisticsQuery{
+			logQuery:  logQuery{doguNames: []string{"cas"}},
+			function:  logStatisticsCount,
+			startDate: contractNow.Add(-time.Hour),
+			endDate:   contractNow,
+			step:      time.Minute,
+		}, actual)
+	})
+	t.Run("should use a step of at least one second", func(t *testing.T) {
+		// when
+		actual, err := createLogStatisticsQueryFromProto([]string{"cas"}, &pb.LogStatisticsRequest{
+			DoguName:  "cas",
+			StartDate: timestamppb.New(contractNow.Add(-10 * time.Second)),
+			EndDate:   timestamppb.New(contractNow),
+		}, contractNow)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Second, actual.step)
+	})
+	t.Run("should fail for an unknown function", func(t *testing.T) {
+		// when
+		_, err := createLogStatisticsQueryFromProto([]string{"cas"}, &pb.LogStatisticsRequest{DoguName: "cas", Function: pb.LogStatisticsFunction(42)}, contractNow)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown statistics function 42")
+	})
+}
+
+func TestLoggingService_GetLogVolumeReport(t *testing.T) {
+	windowOf := func(window time.Duration) (any, any) {
+		var startDate time.Time
+		start := mock.MatchedBy(func(date time.Time) bool {
+			startDate = date
+			return true
+		})
+		end := mock.MatchedBy(func(date time.Time) bool { return date.Sub(startDate) == window })
+		return start, end
+	}
+
+	t.Run("should return the volume of the dogus sorted by bytes with their log level", func(t *testing.T) {
 		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+
+		dayStart, dayEnd := windowOf(24 * time.Hour)
+		mockedLogProvider.EXPECT().queryVolume([]string{"cas", "ldap"}, dayStart, dayEnd).Return([]logVolume{
+			{dogu: "cas", bytes: 100, lines: 10},
+			{dogu: "ldap", bytes: 2000, lines: 20},
+		}, nil).Once()
+		hourStart, hourEnd := windowOf(time.Hour)
+		mockedLogProvider.EXPECT().queryVolume([]string{"cas", "ldap"}, hourStart, hourEnd).Return([]logVolume{
+			{dogu: "cas", bytes: 50, lines: 5},
+			{dogu: "ldap", bytes: 0, lines: 0},
+		}, nil).Once()
+		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("cas")).Return(config.CreateDoguConfig("cas", config.Entries{}), nil)
+		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "cas").Return(&core.Dogu{Name: "official/cas"}, nil)
+		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("ldap")).Return(config.CreateDoguConfig("ldap", config.Entries{"logging/root": "DEBUG"}), nil)
+		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "ldap").Return(&core.Dogu{Name: "official/ldap"}, nil)
+
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, newMockDoguRestarter(t), mockedDescriptionGetter, newMockDoguGetter(t), newMockAuditLogger(t))
 
 		// when
-		actualMyDoguLogZip, err := compressMessages("my-dogu", []logLine{})
+		actual, err := sut.GetLogVolumeReport(context.TODO(), &pb.LogVolumeReportRequest{
+			DoguNames: []string{"ldap", "cas"},
+			Windows:   []*durationpb.Duration{durationpb.New(24 * time.Hour), durationpb.New(time.Hour)},
+		})
 
 		// then
 		require.NoError(t, err)
-		assert.NotNil(t, actualMyDoguLogZip)
-		assert.Empty(t, actualMyDoguLogZip)
+		assert.Equal(t, &pb.LogVolumeReportResponse{Dogus: []*pb.DoguLogVolume{
+			{
+				DoguName:       "ldap",
+				LogLevel:       "DEBUG",
+				LogLevelSource: pb.LogLevelSource_CONFIG,
+				Windows: []*pb.LogVolumeWindow{
+					{Window: durationpb.New(24 * time.Hour), Bytes: 2000, Lines: 20},
+					{Window: durationpb.New(time.Hour)},
+				},
+			},
+			{
+				DoguName:       "cas",
+				LogLevelSource: pb.LogLevelSource_UNSET,
+				Windows: []*pb.LogVolumeWindow{
+					{Window: durationpb.New(24 * time.Hour), Bytes: 100, Lines: 10},
+					{Window: durationpb.New(time.Hour), Bytes: 50, Lines: 5},
+				},
+			},
+		}}, actual)
 	})
-}
-
-func Test_GetForDogu(t *testing.T) {
-	t.Run("should get logs for Dogu", func(t *testing.T) {
+	t.Run("should report the volume of a dogu whose log level cannot be read", func(t *testing.T) {
 		// given
 		mockedLogProvider := newMockLogProvider(t)
-		mockedDoguLogServer := newMockDoguLogMessagesServer(t)
 		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
-		mockedDoguRestarter := newMockDoguRestarter(t)
-		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
-		mockedDoguGetter := newMockDoguGetter(t)
 
-		logLines := []logLine{
-			{timestamp: time.Unix(0, 1655722130600667903), value: `{"log":"Mon Jun 20 10:48:50 UTC 2022 -- Logging1\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
-			{timestamp: time.Unix(0, 1655722130600667919), value: `{"log":"Mon Jun 20 10:48:51 UTC 2022 -- Logging2\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
-			{timestamp: time.Unix(0, 1655722130600667934), value: `{"log":"Mon Jun 20 10:48:52 UTC 2022 -- Logging3\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
-		}
-		mockedLogProvider.EXPECT().getLogs("my-dogu", 333).Return(logLines, nil)
+		start, end := windowOf(defaultVolumeWindow)
+		mockedLogProvider.EXPECT().queryVolume([]string{"cas"}, start, end).Return([]logVolume{{dogu: "cas", bytes: 100, lines: 10}}, nil)
+		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("cas")).Return(config.DoguConfig{}, assert.AnError)
 
-		mockedDoguLogServer.EXPECT().Send(mock.Anything).Return(nil)
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, newMockDoguRestarter(t), newMockDoguDescriptionGetter(t), newMockDoguGetter(t), newMockAuditLogger(t))
 
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+		// when
+		actual, err := sut.GetLogVolumeReport(context.TODO(), &pb.LogVolumeReportRequest{DoguName: "cas", SortBy: pb.LogVolumeSortField_LINES})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, actual.GetDogus(), 1)
+		assert.Empty(t, actual.GetDogus()[0].GetLogLevel())
+		assert.Equal(t, uint64(10), actual.GetDogus()[0].GetWindows()[0].GetLines())
+	})
+	t.Run("should return no dogus without installed dogus", func(t *testing.T) {
+		// given
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedDoguGetter.EXPECT().List(context.TODO(), metav1.ListOptions{}).Return(&v2.DoguList{}, nil)
+		sut := NewLoggingService(newMockLogProvider(t), newMockDoguConfigRepository(t), newMockDoguRestarter(t), newMockDoguDescriptionGetter(t), mockedDoguGetter, newMockAuditLogger(t))
 
 		// when
-		request := &pb.DoguLogMessageRequest{
-			DoguName:  "my-dogu",
-			LineCount: 333,
-		}
-		err := sut.GetForDogu(request, mockedDoguLogServer)
+		actual, err := sut.GetLogVolumeReport(context.TODO(), &pb.LogVolumeReportRequest{AllDogus: true})
 
 		// then
 		require.NoError(t, err)
+		assert.Empty(t, actual.GetDogus())
 	})
-}
+	t.Run("should fail with unimplemented for backends without volume", func(t *testing.T) {
+		// given
+		mockedLogProvider := newMockLogProvider(t)
+		mockedLogProvider.EXPECT().queryVolume([]string{"cas"}, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("no volume: %w", errors.ErrUnsupported))
+		sut := NewLoggingService(mockedLogProvider, newMockDoguConfigRepository(t), newMockDoguRestarter(t), newMockDoguDescriptionGetter(t), newMockDoguGetter(t), newMockAuditLogger(t))
 
-func Test_QueryForDogu(t *testing.T) {
-	t.Run("should get logs for Dogu", func(t *testing.T) {
+		// when
+		_, err := sut.GetLogVolumeReport(context.TODO(), &pb.LogVolumeReportRequest{DoguName: "cas"})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+	t.Run("should fail if the volume cannot be queried", func(t *testing.T) {
 		// given
 		mockedLogProvider := newMockLogProvider(t)
-		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
-		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
-		mockedDoguRestarter := newMockDoguRestarter(t)
-		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
-		mockedDoguGetter := newMockDoguGetter(t)
+		mockedLogProvider.EXPECT().queryVolume([]string{"cas"}, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+		sut := NewLoggingService(mockedLogProvider, newMockDoguConfigRepository(t), newMockDoguRestarter(t), newMockDoguDescriptionGetter(t), newMockDoguGetter(t), newMockAuditLogger(t))
 
-		start := time.Unix(1711616504, 0).UTC()
-		end := time.Unix(1712131304, 0).UTC()
-		filter := "foo=bar"
+		// when
+		_, err := sut.GetLogVolumeReport(context.TODO(), &pb.LogVolumeReportRequest{DoguName: "cas"})
 
-		logLines := []logLine{
-			{timestamp: time.Unix(0, 1655722130600667903), value: `{"log":"Mon Jun 20 10:48:50 UTC 2022 -- Logging1\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
-			{timestamp: time.Unix(0, 1655722130600667919), value: `{"log":"Mon Jun 20 10:48:51 UTC 2022 -- Logging2\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
-			{timestamp: time.Unix(0, 1655722130600667934), value: `{"log":"Mon Jun 20 10:48:52 UTC 2022 -- Logging3\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
-		}
-		mockedLogProvider.EXPECT().queryLogs("my-dogu", start, end, filter).Return(logLines, nil)
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+	t.Run("should fail for an invalid window", func(t *testing.T) {
+		// given
+		sut := NewLoggingService(newMockLogProvider(t), newMockDoguConfigRepository(t), newMockDoguRestarter(t), newMockDoguDescriptionGetter(t), newMockDoguGetter(t), newMockAuditLogger(t))
 
-		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[0].timestamp), Message: logLines[0].value}).Return(nil)
-		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[1].timestamp), Message: logLines[1].value}).Return(nil)
-		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[2].timestamp), Message: logLines[2].value}).Return(nil)
+		// when
+		_, err := sut.GetLogVolumeReport(context.TODO(), &pb.LogVolumeReportRequest{DoguName: "cas", Windows: []*durationpb.Duration{durationpb.New(time.Second)}})
 
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, "invalid log volume report: window 1s must be between 1m0s and 720h0m0s")
+	})
+}
 
+func Test_createLogVolumeReportFromProto(t *testing.T) {
+	t.Run("should report the last day by bytes by default", func(t *testing.T) {
 		// when
-		request := &pb.DoguLogMessageQueryRequest{
-			DoguName:  "my-dogu",
-			StartDate: timestamppb.New(start),
-			EndDate:   timestamppb.New(end),
-			Filter:    &filter,
-		}
-		err := sut.QueryForDogu(request, mockedDoguLogServer)
+		windows, sortBy, err := createLogVolumeReportFromProto(&pb.LogVolumeReportRequest{})
 
 		// then
 		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{24 * time.Hour}, windows)
+		assert.Equal(t, logVolumeByBytes, sortBy)
 	})
+	t.Run("should use the requested windows and sort field", func(t *testing.T) {
+		// when
+		windows, sortBy, err := createLogVolumeReportFromProto(&pb.LogVolumeReportRequest{
+			Windows: []*durationpb.Duration{durationpb.New(time.Hour), durationpb.New(7 * 24 * time.Hour)},
+			SortBy:  pb.LogVolumeSortField_LINES,
+		})
 
-	t.Run("should get logs for Dogu without optional parameters", func(t *testing.T) {
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{time.Hour, 7 * 24 * time.Hour}, windows)
+		assert.Equal(t, logVolumeByLines, sortBy)
+	})
+	t.Run("should fail for an unknown sort field", func(t *testing.T) {
+		// when
+		_, _, err := createLogVolumeReportFromProto(&pb.LogVolumeReportRequest{SortBy: pb.LogVolumeSortField(42)})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown sort field 42")
+	})
+}
+
+func Test_FollowForDogu(t *testing.T) {
+	t.Run("should send followed logs until the client cancels", func(t *testing.T) {
 		// given
 		mockedLogProvider := newMockLogProvider(t)
-		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguLogServer := newMockDoguLogMessagesFollowServer(t)
 		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
 		mockedDoguRestarter := newMockDoguRestarter(t)
 		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
 		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
 
+		ctx := context.Background()
+		filter := "foo=bar"
 		logLines := []logLine{
-			{timestamp: time.Unix(0, 1655722130600667903), value: `{"log":"Mon Jun 20 10:48:50 UTC 2022 -- Logging1\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
-			{timestamp: time.Unix(0, 1655722130600667919), value: `{"log":"Mon Jun 20 10:48:51 UTC 2022 -- Logging2\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
-			{timestamp: time.Unix(0, 1655722130600667934), value: `{"log":"Mon Jun 20 10:48:52 UTC 2022 -- Logging3\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
+			{timestamp: time.Unix(0, 1655722130600667903), value: "Logging1"},
+			{timestamp: time.Unix(0, 1655722130600667919), value: "Logging2"},
 		}
-		mockedLogProvider.EXPECT().queryLogs("my-dogu", time.Time{}, time.Time{}, "").Return(logLines, nil)
+		mockedDoguLogServer.EXPECT().Context().Return(ctx)
+		mockedLogProvider.EXPECT().followLogs(ctx, newDoguQuery("my-dogu", filter), mock.Anything).RunAndReturn(
+			func(_ context.Context, _ logQuery, send func(logLine) error) error {
+				for _, line := range logLines {
+					require.NoError(t, send(line))
+				}
+				return nil
+			})
 
 		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[0].timestamp), Message: logLines[0].value}).Return(nil)
 		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[1].timestamp), Message: logLines[1].value}).Return(nil)
-		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[2].timestamp), Message: logLines[2].value}).Return(nil)
 
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
 
 		// when
-		request := &pb.DoguLogMessageQueryRequest{
-			DoguName:  "my-dogu",
-			StartDate: nil,
-			EndDate:   nil,
-			Filter:    nil,
+		request := &pb.DoguLogMessageFollowRequest{
+			DoguName: "my-dogu",
+			Filter:   &filter,
 		}
-		err := sut.QueryForDogu(request, mockedDoguLogServer)
+		err := sut.FollowForDogu(request, mockedDoguLogServer)
 
 		// then
 		require.NoError(t, err)
 	})
 
-	t.Run("should fail to get logs for empty doguname", func(t *testing.T) {
+	t.Run("should fail to follow logs for empty doguname", func(t *testing.T) {
 		// given
 		mockedLogProvider := newMockLogProvider(t)
-		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguLogServer := newMockDoguLogMessagesFollowServer(t)
 		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
 		mockedDoguRestarter := newMockDoguRestarter(t)
 		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
 		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
 
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
 
 		// when
-		request := &pb.DoguLogMessageQueryRequest{
-			DoguName: "",
-		}
-		err := sut.QueryForDogu(request, mockedDoguLogServer)
+		err := sut.FollowForDogu(&pb.DoguLogMessageFollowRequest{DoguName: ""}, mockedDoguLogServer)
 
 		// then
 		require.Error(t, err)
 		assert.ErrorContains(t, err, "rpc error: code = InvalidArgument desc = dogu name should not be empty")
 	})
 
-	t.Run("should fail to get logs for error querying the log provider", func(t *testing.T) {
-		// given
-		mockedLogProvider := newMockLogProvider(t)
-		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
-		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
-		mockedDoguRestarter := newMockDoguRestarter(t)
-		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
-		mockedDoguGetter := newMockDoguGetter(t)
-
-		start := time.Unix(1711616504, 0).UTC()
-		end := time.Unix(1712131304, 0).UTC()
-		filter := "foo=bar"
-
-		mockedLogProvider.EXPECT().queryLogs("my-dogu", start, end, filter).Return(nil, assert.AnError)
-
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
-
-		// when
-		request := &pb.DoguLogMessageQueryRequest{
-			DoguName:  "my-dogu",
-			StartDate: timestamppb.New(start),
-			EndDate:   timestamppb.New(end),
-			Filter:    &filter,
-		}
-		err := sut.QueryForDogu(request, mockedDoguLogServer)
-
-		// then
-		require.Error(t, err)
-		assert.ErrorContains(t, err, assert.AnError.Error())
-	})
-
-	t.Run("should fail to get logs for error while sending", func(t *testing.T) {
+	t.Run("should fail if following the logs fails", func(t *testing.T) {
 		// given
 		mockedLogProvider := newMockLogProvider(t)
-		mockedDoguLogServer := newMockDoguLogMessagesQueryServer(t)
+		mockedDoguLogServer := newMockDoguLogMessagesFollowServer(t)
 		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
 		mockedDoguRestarter := newMockDoguRestarter(t)
 		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
 		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
 
-		start := time.Unix(1711616504, 0).UTC()
-		end := time.Unix(1712131304, 0).UTC()
-		filter := "foo=bar"
-
-		logLines := []logLine{
-			{timestamp: time.Unix(0, 1655722130600667903), value: `{"log":"Mon Jun 20 10:48:50 UTC 2022 -- Logging1\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
-			{timestamp: time.Unix(0, 1655722130600667919), value: `{"log":"Mon Jun 20 10:48:51 UTC 2022 -- Logging2\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
-			{timestamp: time.Unix(0, 1655722130600667934), value: `{"log":"Mon Jun 20 10:48:52 UTC 2022 -- Logging3\n","stream":"stdout","time":"2022-06-20T10:48:50.432098057Z"}`},
-		}
-		mockedLogProvider.EXPECT().queryLogs("my-dogu", start, end, filter).Return(logLines, nil)
-
-		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[0].timestamp), Message: logLines[0].value}).Return(nil)
-		mockedDoguLogServer.EXPECT().Send(&pb.DoguLogMessage{Timestamp: timestamppb.New(logLines[1].timestamp), Message: logLines[1].value}).Return(assert.AnError)
+		ctx := context.Background()
+		mockedDoguLogServer.EXPECT().Context().Return(ctx)
+		mockedLogProvider.EXPECT().followLogs(ctx, newDoguQuery("my-dogu", ""), mock.Anything).Return(assert.AnError)
 
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
 
 		// when
-		request := &pb.DoguLogMessageQueryRequest{
-			DoguName:  "my-dogu",
-			StartDate: timestamppb.New(start),
-			EndDate:   timestamppb.New(end),
-			Filter:    &filter,
-		}
-		err := sut.QueryForDogu(request, mockedDoguLogServer)
+		err := sut.FollowForDogu(&pb.DoguLogMessageFollowRequest{DoguName: "my-dogu"}, mockedDoguLogServer)
 
 		// then
 		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
 		assert.ErrorContains(t, err, assert.AnError.Error())
 	})
 }
@@ -374,6 +1139,15 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 			xResponse:     true,
 			xResponseCode: codes.OK,
 		},
+		{
+			name: "Set LogLevel TRACE",
+			req: &pb.LogLevelRequest{
+				DoguName: "test",
+				LogLevel: pb.LogLevel_TRACE,
+			},
+			xResponse:     true,
+			xResponseCode: codes.OK,
+		},
 		{
 			name: "Set LogLevel INFO",
 			req: &pb.LogLevelRequest{
@@ -406,7 +1180,7 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 			name: "Set wrong LogLevel",
 			req: &pb.LogLevelRequest{
 				DoguName: "test",
-				LogLevel: 4,
+				LogLevel: 100,
 			},
 			xResponse:     false,
 			xResponseCode: codes.InvalidArgument,
@@ -429,9 +1203,14 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 			mockedDoguRestarter := newMockDoguRestarter(t)
 			mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
 			mockedDoguGetter := newMockDoguGetter(t)
+			mockedAuditLogger := newMockAuditLogger(t)
 
 			if tc.xResponse {
+				mockedAuditLogger.EXPECT().Record(context.TODO(), mock.MatchedBy(func(entry audit.Entry) bool {
+					return entry.Action == "ApplyLogLevelWithRestart" && entry.Resource == audit.DoguResource("test") && entry.Parameters["logLevel"] == tc.req.LogLevel.String() && entry.Err == nil
+				})).Return()
 				mockedDoguConfigRepository.EXPECT().Get(context.TODO(), mock.Anything).Return(config.CreateDoguConfig(common.SimpleName(tc.req.DoguName), config.Entries{"logging/root": config.Value(tc.actualLogLevel.String())}), nil)
+				mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "test").Return(&core.Dogu{Name: "test"}, nil)
 				mockedDoguConfigRepository.EXPECT().Update(context.TODO(), mock.Anything).RunAndReturn(func(_a0 context.Context, doguConfig config.DoguConfig) (config.DoguConfig, error) {
 					get, b := doguConfig.Get("logging/root")
 					require.True(t, b)
@@ -449,7 +1228,7 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 				mockedDoguRestarter.EXPECT().RestartDogu(mock.Anything, mock.Anything).Return(nil)
 			}
 
-			sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+			sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
 
 			resp, err := sut.ApplyLogLevelWithRestart(context.TODO(), tc.req)
 
@@ -469,6 +1248,8 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 		mockedDoguRestarter := newMockDoguRestarter(t)
 		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
 		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.Anything).Return()
 
 		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), mock.Anything).Return(config.CreateDoguConfig("test", config.Entries{}), nil)
 		mockedDoguConfigRepository.EXPECT().Update(context.TODO(), mock.Anything).RunAndReturn(func(_a0 context.Context, doguConfig config.DoguConfig) (config.DoguConfig, error) {
@@ -496,7 +1277,7 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 
 		mockedDoguRestarter.EXPECT().RestartDogu(mock.Anything, mock.Anything).Return(nil)
 
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
 
 		resp, err := sut.ApplyLogLevelWithRestart(context.TODO(), &pb.LogLevelRequest{
 			DoguName: "test",
@@ -513,6 +1294,8 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 		mockedDoguRestarter := newMockDoguRestarter(t)
 		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
 		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.Anything).Return()
 
 		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), mock.Anything).Return(config.CreateDoguConfig("test", config.Entries{}), nil)
 		mockedDoguConfigRepository.EXPECT().Update(context.TODO(), mock.Anything).RunAndReturn(func(_a0 context.Context, doguConfig config.DoguConfig) (config.DoguConfig, error) {
@@ -539,7 +1322,7 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 
 		mockedDoguRestarter.EXPECT().RestartDogu(mock.Anything, mock.Anything).Return(nil)
 
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
 
 		resp, err := sut.ApplyLogLevelWithRestart(context.TODO(), &pb.LogLevelRequest{
 			DoguName: "test",
@@ -556,6 +1339,8 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 		mockedDoguRestarter := newMockDoguRestarter(t)
 		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
 		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.Anything).Return()
 
 		mockedDescriptionGetter.EXPECT().GetCurrent(mock.Anything, mock.Anything).Return(&core.Dogu{
 			Name: "test",
@@ -581,7 +1366,7 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 			},
 		}, nil)
 
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
 
 		resp, err := sut.ApplyLogLevelWithRestart(context.TODO(), &pb.LogLevelRequest{
 			DoguName: "test",
@@ -600,10 +1385,13 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 		mockedDoguRestarter := newMockDoguRestarter(t)
 		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
 		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.Anything).Return()
 
 		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), mock.Anything).Return(config.CreateDoguConfig("test", config.Entries{"logging/root": config.Value(LevelDebug.String())}), nil)
+		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "test").Return(&core.Dogu{Name: "test"}, nil)
 
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
 
 		resp, err := sut.ApplyLogLevelWithRestart(context.TODO(), &pb.LogLevelRequest{
 			DoguName: "test",
@@ -622,6 +1410,8 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 		mockedDoguRestarter := newMockDoguRestarter(t)
 		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
 		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.Anything).Return()
 
 		mockedDescriptionGetter.EXPECT().GetCurrent(mock.Anything, mock.Anything).Return(&core.Dogu{
 			Name: "test",
@@ -635,7 +1425,7 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), mock.Anything).Return(config.CreateDoguConfig("test", config.Entries{}), nil)
 		mockedDoguConfigRepository.EXPECT().Update(context.TODO(), mock.Anything).Return(config.DoguConfig{}, errors.New("testError"))
 
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
 
 		resp, err := sut.ApplyLogLevelWithRestart(context.TODO(), &pb.LogLevelRequest{
 			DoguName: "test",
@@ -655,6 +1445,8 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 		mockedDoguRestarter := newMockDoguRestarter(t)
 		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
 		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.MatchedBy(func(entry audit.Entry) bool { return entry.Err != nil })).Return()
 
 		mockedDescriptionGetter.EXPECT().GetCurrent(mock.Anything, mock.Anything).Return(&core.Dogu{
 			Name: "test",
@@ -683,7 +1475,7 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 
 		mockedDoguRestarter.EXPECT().RestartDogu(mock.Anything, mock.Anything).Return(errors.New("testError"))
 
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
 
 		resp, err := sut.ApplyLogLevelWithRestart(context.TODO(), &pb.LogLevelRequest{
 			DoguName: "test",
@@ -695,6 +1487,158 @@ func TestLoggingService_SetLogLevel(t *testing.T) {
 	})
 }
 
+func TestLoggingService_ApplyLogLevelWithRestart_validation(t *testing.T) {
+	t.Run("should reject a level not allowed by the dogu descriptor", func(t *testing.T) {
+		// given
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.MatchedBy(func(entry audit.Entry) bool { return entry.Err != nil })).Return()
+
+		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("test")).Return(config.CreateDoguConfig("test", config.Entries{}), nil)
+		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "test").Return(&core.Dogu{
+			Name: "test",
+			Configuration: []core.ConfigurationField{
+				{Name: "logging/root", Validation: core.ValidationDescriptor{Type: "ONE_OF", Values: []string{"ERROR", "WARN", "INFO", "DEBUG"}}},
+			},
+		}, nil)
+
+		sut := NewLoggingService(newMockLogProvider(t), mockedDoguConfigRepository, newMockDoguRestarter(t), mockedDescriptionGetter, newMockDoguGetter(t), mockedAuditLogger)
+
+		// when
+		resp, err := sut.ApplyLogLevelWithRestart(context.TODO(), &pb.LogLevelRequest{DoguName: "test", LogLevel: pb.LogLevel_TRACE})
+
+		// then
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, `invalid log level "TRACE" for logger root: allowed are ERROR, WARN, INFO, DEBUG`)
+	})
+}
+
+func TestLoggingService_GetLoggers(t *testing.T) {
+	t.Run("should list the loggers of the dogu with their levels and sources", func(t *testing.T) {
+		// given
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("redmine")).Return(config.CreateDoguConfig("redmine", config.Entries{"logging/root": "DEBUG"}), nil)
+		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "redmine").Return(loggerTestDescriptor(), nil)
+
+		sut := NewLoggingService(nil, mockedDoguConfigRepository, nil, mockedDescriptionGetter, nil, nil)
+
+		// when
+		actual, err := sut.GetLoggers(context.TODO(), &pb.DoguLoggersRequest{DoguName: "redmine"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []*pb.DoguLogger{
+			{Name: "root", Level: "DEBUG", Source: pb.LogLevelSource_CONFIG, AllowedLevels: []string{"ERROR", "WARN", "INFO", "DEBUG", "TRACE"}},
+			{Name: "mail", Level: "WARN", Source: pb.LogLevelSource_DESCRIPTOR_DEFAULT, AllowedLevels: []string{"OFF", "WARN"}},
+			{Name: "sql", Description: "Level of the SQL logger", Source: pb.LogLevelSource_UNSET},
+		}, actual.GetLoggers())
+	})
+	t.Run("should fail for an empty dogu name", func(t *testing.T) {
+		// given
+		sut := NewLoggingService(nil, nil, nil, nil, nil, nil)
+
+		// when
+		_, err := sut.GetLoggers(context.TODO(), &pb.DoguLoggersRequest{})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("should fail if the dogu descriptor cannot be read", func(t *testing.T) {
+		// given
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("redmine")).Return(config.CreateDoguConfig("redmine", config.Entries{}), nil)
+		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "redmine").Return(nil, assert.AnError)
+
+		sut := NewLoggingService(nil, mockedDoguConfigRepository, nil, mockedDescriptionGetter, nil, nil)
+
+		// when
+		_, err := sut.GetLoggers(context.TODO(), &pb.DoguLoggersRequest{DoguName: "redmine"})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.ErrorContains(t, err, "could not get dogu description for dogu redmine")
+	})
+}
+
+func TestLoggingService_SetLoggerLevel(t *testing.T) {
+	t.Run("should set the level of the logger and restart the dogu", func(t *testing.T) {
+		// given
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedDoguGetter := newMockDoguGetter(t)
+		mockedDoguRestarter := newMockDoguRestarter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("redmine")).Return(config.CreateDoguConfig("redmine", config.Entries{}), nil)
+		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "redmine").Return(loggerTestDescriptor(), nil)
+		mockedDoguConfigRepository.EXPECT().Update(context.TODO(), mock.Anything).RunAndReturn(func(_ context.Context, doguConfig config.DoguConfig) (config.DoguConfig, error) {
+			level, ok := doguConfig.Get("logging/mail")
+			require.True(t, ok)
+			assert.Equal(t, "OFF", level.String())
+
+			return doguConfig, nil
+		})
+		mockedDoguGetter.EXPECT().Get(context.TODO(), "redmine", metav1.GetOptions{}).Return(&v2.Dogu{}, nil)
+		mockedDoguRestarter.EXPECT().RestartDogu(mock.Anything, "redmine").Return(nil)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "SetLoggerLevel" && entry.Parameters["logger"] == "mail" && entry.Parameters["logLevel"] == "off" && entry.Err == nil
+		})).Return()
+
+		sut := NewLoggingService(nil, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
+
+		// when
+		resp, err := sut.SetLoggerLevel(context.TODO(), &pb.LoggerLevelRequest{DoguName: "redmine", Logger: "mail", Level: "off"})
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+	})
+	t.Run("should not write the level if it is the effective level", func(t *testing.T) {
+		// given
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("redmine")).Return(config.CreateDoguConfig("redmine", config.Entries{}), nil)
+		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "redmine").Return(loggerTestDescriptor(), nil)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.Anything).Return()
+
+		sut := NewLoggingService(nil, mockedDoguConfigRepository, newMockDoguRestarter(t), mockedDescriptionGetter, newMockDoguGetter(t), mockedAuditLogger)
+
+		// when
+		_, err := sut.SetLoggerLevel(context.TODO(), &pb.LoggerLevelRequest{DoguName: "redmine", Logger: "mail", Level: "WARN"})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail for an unknown logger", func(t *testing.T) {
+		// given
+		mockedDoguConfigRepository := newMockDoguConfigRepository(t)
+		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
+
+		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("redmine")).Return(config.CreateDoguConfig("redmine", config.Entries{}), nil)
+		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "redmine").Return(loggerTestDescriptor(), nil)
+		mockedAuditLogger.EXPECT().Record(context.TODO(), mock.MatchedBy(func(entry audit.Entry) bool { return entry.Err != nil })).Return()
+
+		sut := NewLoggingService(nil, mockedDoguConfigRepository, nil, mockedDescriptionGetter, nil, mockedAuditLogger)
+
+		// when
+		_, err := sut.SetLoggerLevel(context.TODO(), &pb.LoggerLevelRequest{DoguName: "redmine", Logger: "cache", Level: "DEBUG"})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, `unknown logger "cache"`)
+	})
+}
+
 func Test_loggingService_GetLogLevel(t *testing.T) {
 	t.Run("should return error on get config error", func(t *testing.T) {
 		// given
@@ -703,10 +1647,11 @@ func Test_loggingService_GetLogLevel(t *testing.T) {
 		mockedDoguRestarter := newMockDoguRestarter(t)
 		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
 		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
 
 		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("test")).Return(config.DoguConfig{}, assert.AnError)
 
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
 
 		// when
 		_, err := sut.GetLogLevel(context.TODO(), "test")
@@ -723,11 +1668,12 @@ func Test_loggingService_GetLogLevel(t *testing.T) {
 		mockedDoguRestarter := newMockDoguRestarter(t)
 		mockedDescriptionGetter := newMockDoguDescriptionGetter(t)
 		mockedDoguGetter := newMockDoguGetter(t)
+		mockedAuditLogger := newMockAuditLogger(t)
 
 		mockedDoguConfigRepository.EXPECT().Get(context.TODO(), common.SimpleName("test")).Return(config.DoguConfig{}, nil)
 		mockedDescriptionGetter.EXPECT().GetCurrent(context.TODO(), "test").Return(nil, assert.AnError)
 
-		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter)
+		sut := NewLoggingService(mockedLogProvider, mockedDoguConfigRepository, mockedDoguRestarter, mockedDescriptionGetter, mockedDoguGetter, mockedAuditLogger)
 
 		// when
 		_, err := sut.GetLogLevel(context.TODO(), "test")
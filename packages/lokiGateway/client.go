@@ -0,0 +1,36 @@
+package lokiGateway
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// NewHTTPClient creates the http client for requests to the loki gateway. If a CA file is given, the certificate of
+// the gateway is verified with this CA bundle instead of the system CAs. The client has no timeout because the
+// timeouts are set per request.
+func NewHTTPClient(caFile string) (*http.Client, error) {
+	if caFile == "" {
+		return &http.Client{}, nil
+	}
+
+	content, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read loki gateway CA file %s: %w", caFile, err)
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(content) {
+		return nil, fmt.Errorf("failed to parse loki gateway CA file %s: no valid certificates found", caFile)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    rootCAs,
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
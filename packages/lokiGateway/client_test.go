@@ -0,0 +1,67 @@
+package lokiGateway
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Run("should use system CAs without CA file", func(t *testing.T) {
+		// when
+		client, err := NewHTTPClient("")
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, client.Transport)
+		assert.Zero(t, client.Timeout)
+	})
+	t.Run("should trust the gateway certificate signed by the CA bundle", func(t *testing.T) {
+		// given
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		caFile := filepath.Join(t.TempDir(), "ca.crt")
+		caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		require.NoError(t, os.WriteFile(caFile, caPEM, 0600))
+
+		client, err := NewHTTPClient(caFile)
+		require.NoError(t, err)
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+	t.Run("should fail if CA file does not exist", func(t *testing.T) {
+		// when
+		_, err := NewHTTPClient(filepath.Join(t.TempDir(), "ca.crt"))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read loki gateway CA file")
+	})
+	t.Run("should fail for invalid CA file", func(t *testing.T) {
+		// given
+		caFile := filepath.Join(t.TempDir(), "ca.crt")
+		require.NoError(t, os.WriteFile(caFile, []byte("no certificate"), 0600))
+
+		// when
+		_, err := NewHTTPClient(caFile)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "no valid certificates found")
+	})
+}
@@ -0,0 +1,165 @@
+package lokiGateway
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var reloadInterval = time.Second * 30
+
+// CredentialsConfig contains the credentials for the loki gateway. The username and password are used for basic auth
+// unless a token file is set. A file takes precedence over the corresponding value.
+type CredentialsConfig struct {
+	Username     string
+	UsernameFile string
+	Password     string
+	PasswordFile string
+	// TokenFile contains a bearer token which is sent instead of the username and password.
+	TokenFile string
+}
+
+// Credentials authenticate the requests to the loki gateway. Credentials read from files, e.g. from a mounted secret,
+// are checked periodically and replaced when the files change so that rotated credentials are used without
+// restarting the pod.
+type Credentials struct {
+	config CredentialsConfig
+
+	mutex       sync.RWMutex
+	username    string
+	password    string
+	token       string
+	loadedFiles [][]byte
+}
+
+// NewCredentials creates new Credentials and reads the credential files initially.
+func NewCredentials(config CredentialsConfig) (*Credentials, error) {
+	c := &Credentials{config: config}
+
+	_, err := c.reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Authenticate adds the current credentials to the request.
+func (c *Credentials) Authenticate(req *http.Request) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		return
+	}
+
+	req.SetBasicAuth(c.username, c.password)
+}
+
+// Watch checks the credential files for changes until the given context is done. It returns immediately if no
+// credentials are read from files.
+func (c *Credentials) Watch(ctx context.Context) {
+	if len(c.files()) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := c.reload()
+			if err != nil {
+				logrus.Error(fmt.Errorf("failed to reload loki gateway credentials, keep using the previous ones: %w", err))
+				continue
+			}
+			if reloaded {
+				logrus.Info("Reloaded loki gateway credentials")
+			}
+		}
+	}
+}
+
+func (c *Credentials) files() []string {
+	var files []string
+	for _, file := range []string{c.config.UsernameFile, c.config.PasswordFile, c.config.TokenFile} {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
+
+// reload reads all credential files and replaces the current credentials if any file has changed.
+func (c *Credentials) reload() (bool, error) {
+	files := c.files()
+	contents := make([][]byte, 0, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return false, fmt.Errorf("failed to read file %s: %w", file, err)
+		}
+		contents = append(contents, content)
+	}
+
+	c.mutex.RLock()
+	unchanged := c.loadedFiles != nil && slices.EqualFunc(c.loadedFiles, contents, bytes.Equal)
+	c.mutex.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	// the contents read above are used so that all credentials belong to the same version of the files
+	credential := func(file string, value string) (string, error) {
+		if file == "" {
+			return value, nil
+		}
+
+		content := strings.TrimRight(string(contents[slices.Index(files, file)]), "\r\n")
+		if content == "" {
+			return "", fmt.Errorf("file %s is empty", file)
+		}
+
+		return content, nil
+	}
+
+	token, err := credential(c.config.TokenFile, "")
+	if err != nil {
+		return false, err
+	}
+
+	username, password := "", ""
+	if token == "" {
+		username, err = credential(c.config.UsernameFile, c.config.Username)
+		if err != nil {
+			return false, err
+		}
+
+		password, err = credential(c.config.PasswordFile, c.config.Password)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.username = username
+	c.password = password
+	c.token = token
+	c.loadedFiles = contents
+
+	return true, nil
+}
@@ -0,0 +1,183 @@
+package lokiGateway
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func authorizationOf(t *testing.T, credentials *Credentials) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, "http://loki", nil)
+	require.NoError(t, err)
+	credentials.Authenticate(req)
+
+	return req
+}
+
+func TestNewCredentials(t *testing.T) {
+	t.Run("should use basic auth with values", func(t *testing.T) {
+		// when
+		credentials, err := NewCredentials(CredentialsConfig{Username: "admin", Password: "secret"})
+
+		// then
+		require.NoError(t, err)
+		username, password, ok := authorizationOf(t, credentials).BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "admin", username)
+		assert.Equal(t, "secret", password)
+	})
+	t.Run("should read basic auth from files without trailing line breaks", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		config := CredentialsConfig{
+			Username:     "ignored",
+			UsernameFile: writeFile(t, dir, "username", "admin\n"),
+			PasswordFile: writeFile(t, dir, "password", "secret\r\n"),
+		}
+
+		// when
+		credentials, err := NewCredentials(config)
+
+		// then
+		require.NoError(t, err)
+		username, password, ok := authorizationOf(t, credentials).BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "admin", username)
+		assert.Equal(t, "secret", password)
+	})
+	t.Run("should use bearer token from file", func(t *testing.T) {
+		// given
+		config := CredentialsConfig{TokenFile: writeFile(t, t.TempDir(), "token", "my-token\n")}
+
+		// when
+		credentials, err := NewCredentials(config)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer my-token", authorizationOf(t, credentials).Header.Get("Authorization"))
+	})
+	t.Run("should fail if file does not exist", func(t *testing.T) {
+		// when
+		_, err := NewCredentials(CredentialsConfig{PasswordFile: filepath.Join(t.TempDir(), "password")})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read file")
+	})
+	t.Run("should fail for empty file", func(t *testing.T) {
+		// when
+		_, err := NewCredentials(CredentialsConfig{TokenFile: writeFile(t, t.TempDir(), "token", "\n")})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "is empty")
+	})
+}
+
+func TestCredentials_reload(t *testing.T) {
+	t.Run("should not reload unchanged files", func(t *testing.T) {
+		// given
+		credentials, err := NewCredentials(CredentialsConfig{Username: "admin", PasswordFile: writeFile(t, t.TempDir(), "password", "secret")})
+		require.NoError(t, err)
+
+		// when
+		reloaded, err := credentials.reload()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, reloaded)
+	})
+	t.Run("should reload rotated password", func(t *testing.T) {
+		// given
+		passwordFile := writeFile(t, t.TempDir(), "password", "secret")
+		credentials, err := NewCredentials(CredentialsConfig{Username: "admin", PasswordFile: passwordFile})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(passwordFile, []byte("rotated"), 0600))
+
+		// when
+		reloaded, err := credentials.reload()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, reloaded)
+		_, password, _ := authorizationOf(t, credentials).BasicAuth()
+		assert.Equal(t, "rotated", password)
+	})
+	t.Run("should keep previous credentials if rotated file is empty", func(t *testing.T) {
+		// given
+		tokenFile := writeFile(t, t.TempDir(), "token", "my-token")
+		credentials, err := NewCredentials(CredentialsConfig{TokenFile: tokenFile})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(tokenFile, nil, 0600))
+
+		// when
+		reloaded, err := credentials.reload()
+
+		// then
+		require.Error(t, err)
+		assert.False(t, reloaded)
+		assert.Equal(t, "Bearer my-token", authorizationOf(t, credentials).Header.Get("Authorization"))
+	})
+}
+
+func TestCredentials_Watch(t *testing.T) {
+	t.Run("should return immediately without files", func(t *testing.T) {
+		// given
+		credentials, err := NewCredentials(CredentialsConfig{Username: "admin", Password: "secret"})
+		require.NoError(t, err)
+
+		// when
+		credentials.Watch(context.Background())
+
+		// then
+		// Watch returned
+	})
+	t.Run("should reload credentials until context is cancelled", func(t *testing.T) {
+		// given
+		oldReloadInterval := reloadInterval
+		reloadInterval = time.Millisecond
+		defer func() { reloadInterval = oldReloadInterval }()
+
+		tokenFile := writeFile(t, t.TempDir(), "token", "my-token")
+		credentials, err := NewCredentials(CredentialsConfig{TokenFile: tokenFile})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			credentials.Watch(ctx)
+			close(done)
+		}()
+
+		// when
+		rotatedFile := writeFile(t, t.TempDir(), "token", "rotated-token")
+		require.NoError(t, os.Rename(rotatedFile, tokenFile))
+
+		// then
+		assert.Eventually(t, func() bool {
+			return authorizationOf(t, credentials).Header.Get("Authorization") == "Bearer rotated-token"
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("watch did not stop after the context was cancelled")
+		}
+	})
+}
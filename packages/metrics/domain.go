@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const unknownStatus = "unknown"
+
+var collectTimeout = 10 * time.Second
+
+var (
+	dogusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dogus"),
+		"Number of installed dogus per health state.",
+		[]string{"health"}, nil,
+	)
+	backupsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "backups"),
+		"Number of backups per status.",
+		[]string{"status"}, nil,
+	)
+	restoresDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "restores"),
+		"Number of restores per status.",
+		[]string{"status"}, nil,
+	)
+	debugModeActiveDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "debug_mode_active"),
+		"1 if the debug mode is active, otherwise 0.",
+		nil, nil,
+	)
+	debugModeRemainingDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "debug_mode_remaining_seconds"),
+		"Remaining time in seconds until the active debug mode is disabled automatically.",
+		nil, nil,
+	)
+)
+
+type realClock struct{}
+
+func (r *realClock) Now() time.Time {
+	return time.Now()
+}
+
+// DomainCollector reads the state of dogus, backups, restores and the debug mode from the cluster on every scrape.
+// Resources which cannot be read are logged and omitted from the scrape.
+type DomainCollector struct {
+	dogus     doguLister
+	backups   backupLister
+	restores  restoreLister
+	debugMode debugModeStatus
+	clock     nowClock
+}
+
+// NewDomainCollector creates a new DomainCollector.
+func NewDomainCollector(dogus doguLister, backups backupLister, restores restoreLister, debugMode debugModeStatus) *DomainCollector {
+	return &DomainCollector{
+		dogus:     dogus,
+		backups:   backups,
+		restores:  restores,
+		debugMode: debugMode,
+		clock:     &realClock{},
+	}
+}
+
+// Describe sends the descriptors of all metrics of the collector.
+func (c *DomainCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- dogusDesc
+	ch <- backupsDesc
+	ch <- restoresDesc
+	ch <- debugModeActiveDesc
+	ch <- debugModeRemainingDesc
+}
+
+// Collect reads the current state from the cluster and sends the metrics.
+func (c *DomainCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	c.collectDogus(ctx, ch)
+	c.collectBackups(ctx, ch)
+	c.collectRestores(ctx, ch)
+	c.collectDebugMode(ctx, ch)
+}
+
+func (c *DomainCollector) collectDogus(ctx context.Context, ch chan<- prometheus.Metric) {
+	list, err := c.dogus.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logrus.Errorf("failed to list dogus for metrics: %v", err)
+		return
+	}
+
+	counts := map[string]int{}
+	for _, dogu := range list.Items {
+		counts[statusOrUnknown(string(dogu.Status.Health))]++
+	}
+
+	sendCounts(ch, dogusDesc, counts)
+}
+
+func (c *DomainCollector) collectBackups(ctx context.Context, ch chan<- prometheus.Metric) {
+	list, err := c.backups.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logrus.Errorf("failed to list backups for metrics: %v", err)
+		return
+	}
+
+	counts := map[string]int{}
+	for _, backup := range list.Items {
+		counts[statusOrUnknown(backup.Status.Status)]++
+	}
+
+	sendCounts(ch, backupsDesc, counts)
+}
+
+func (c *DomainCollector) collectRestores(ctx context.Context, ch chan<- prometheus.Metric) {
+	list, err := c.restores.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logrus.Errorf("failed to list restores for metrics: %v", err)
+		return
+	}
+
+	counts := map[string]int{}
+	for _, restore := range list.Items {
+		counts[statusOrUnknown(restore.Status.Status)]++
+	}
+
+	sendCounts(ch, restoresDesc, counts)
+}
+
+func (c *DomainCollector) collectDebugMode(ctx context.Context, ch chan<- prometheus.Metric) {
+	active, disableAt, err := c.debugMode.IsActive(ctx)
+	if err != nil {
+		logrus.Errorf("failed to get debug mode for metrics: %v", err)
+		return
+	}
+
+	remaining := time.Duration(0)
+	if active {
+		remaining = max(disableAt.Sub(c.clock.Now()), 0)
+	}
+
+	ch <- prometheus.MustNewConstMetric(debugModeActiveDesc, prometheus.GaugeValue, boolToFloat(active))
+	ch <- prometheus.MustNewConstMetric(debugModeRemainingDesc, prometheus.GaugeValue, remaining.Seconds())
+}
+
+func sendCounts(ch chan<- prometheus.Metric, desc *prometheus.Desc, counts map[string]int) {
+	for status, count := range counts {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(count), status)
+	}
+}
+
+func statusOrUnknown(status string) string {
+	if status == "" {
+		return unknownStatus
+	}
+
+	return status
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
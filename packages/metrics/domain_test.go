@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	backupV1 "github.com/cloudogu/k8s-backup-lib/api/v1"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testTime = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+func TestNewDomainCollector(t *testing.T) {
+	// given
+	doguListerMock := newMockDoguLister(t)
+	backupListerMock := newMockBackupLister(t)
+	restoreListerMock := newMockRestoreLister(t)
+	debugModeStatusMock := newMockDebugModeStatus(t)
+
+	// when
+	sut := NewDomainCollector(doguListerMock, backupListerMock, restoreListerMock, debugModeStatusMock)
+
+	// then
+	assert.Equal(t, doguListerMock, sut.dogus)
+	assert.Equal(t, backupListerMock, sut.backups)
+	assert.Equal(t, restoreListerMock, sut.restores)
+	assert.Equal(t, debugModeStatusMock, sut.debugMode)
+	assert.NotNil(t, sut.clock)
+}
+
+func TestDomainCollector_Collect(t *testing.T) {
+	t.Run("should collect all domain metrics", func(t *testing.T) {
+		// given
+		doguListerMock := newMockDoguLister(t)
+		doguListerMock.EXPECT().List(mock.Anything, metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{
+			{Status: v2.DoguStatus{Health: v2.AvailableHealthStatus}},
+			{Status: v2.DoguStatus{Health: v2.AvailableHealthStatus}},
+			{Status: v2.DoguStatus{Health: v2.UnavailableHealthStatus}},
+			{},
+		}}, nil)
+		backupListerMock := newMockBackupLister(t)
+		backupListerMock.EXPECT().List(mock.Anything, metav1.ListOptions{}).Return(&backupV1.BackupList{Items: []backupV1.Backup{
+			{Status: backupV1.BackupStatus{Status: "completed"}},
+			{Status: backupV1.BackupStatus{Status: "failed"}},
+		}}, nil)
+		restoreListerMock := newMockRestoreLister(t)
+		restoreListerMock.EXPECT().List(mock.Anything, metav1.ListOptions{}).Return(&backupV1.RestoreList{Items: []backupV1.Restore{
+			{Status: backupV1.RestoreStatus{Status: "completed"}},
+		}}, nil)
+		debugModeStatusMock := newMockDebugModeStatus(t)
+		debugModeStatusMock.EXPECT().IsActive(mock.Anything).Return(true, testTime.Add(15*time.Minute), nil)
+		clockMock := newMockNowClock(t)
+		clockMock.EXPECT().Now().Return(testTime)
+
+		sut := &DomainCollector{dogus: doguListerMock, backups: backupListerMock, restores: restoreListerMock, debugMode: debugModeStatusMock, clock: clockMock}
+
+		// when
+		metrics := collect(t, sut)
+
+		// then
+		assert.Equal(t, map[string]float64{
+			`k8s_ces_control_dogus{health="available"}`:      2,
+			`k8s_ces_control_dogus{health="unavailable"}`:    1,
+			`k8s_ces_control_dogus{health="unknown"}`:        1,
+			`k8s_ces_control_backups{status="completed"}`:    1,
+			`k8s_ces_control_backups{status="failed"}`:       1,
+			`k8s_ces_control_restores{status="completed"}`:   1,
+			`k8s_ces_control_debug_mode_active{}`:            1,
+			`k8s_ces_control_debug_mode_remaining_seconds{}`: 900,
+		}, metrics)
+	})
+	t.Run("should report inactive debug mode if it does not exist and omit failed resources", func(t *testing.T) {
+		// given
+		doguListerMock := newMockDoguLister(t)
+		doguListerMock.EXPECT().List(mock.Anything, metav1.ListOptions{}).Return(nil, assert.AnError)
+		backupListerMock := newMockBackupLister(t)
+		backupListerMock.EXPECT().List(mock.Anything, metav1.ListOptions{}).Return(nil, assert.AnError)
+		restoreListerMock := newMockRestoreLister(t)
+		restoreListerMock.EXPECT().List(mock.Anything, metav1.ListOptions{}).Return(nil, assert.AnError)
+		debugModeStatusMock := newMockDebugModeStatus(t)
+		debugModeStatusMock.EXPECT().IsActive(mock.Anything).Return(false, time.Time{}, nil)
+
+		sut := &DomainCollector{dogus: doguListerMock, backups: backupListerMock, restores: restoreListerMock, debugMode: debugModeStatusMock, clock: newMockNowClock(t)}
+
+		// when
+		metrics := collect(t, sut)
+
+		// then
+		assert.Equal(t, map[string]float64{
+			`k8s_ces_control_debug_mode_active{}`:            0,
+			`k8s_ces_control_debug_mode_remaining_seconds{}`: 0,
+		}, metrics)
+	})
+	t.Run("should omit the debug mode if its state cannot be read", func(t *testing.T) {
+		// given
+		debugModeStatusMock := newMockDebugModeStatus(t)
+		debugModeStatusMock.EXPECT().IsActive(testCtx).Return(false, time.Time{}, assert.AnError)
+		ch := make(chan prometheus.Metric, 2)
+
+		sut := &DomainCollector{debugMode: debugModeStatusMock, clock: newMockNowClock(t)}
+
+		// when
+		sut.collectDebugMode(testCtx, ch)
+		close(ch)
+
+		// then
+		assert.Empty(t, toMap(t, ch))
+	})
+	t.Run("should not report negative remaining time", func(t *testing.T) {
+		// given
+		debugModeStatusMock := newMockDebugModeStatus(t)
+		debugModeStatusMock.EXPECT().IsActive(testCtx).Return(true, testTime.Add(-time.Minute), nil)
+		clockMock := newMockNowClock(t)
+		clockMock.EXPECT().Now().Return(testTime)
+		ch := make(chan prometheus.Metric, 2)
+
+		sut := &DomainCollector{debugMode: debugModeStatusMock, clock: clockMock}
+
+		// when
+		sut.collectDebugMode(testCtx, ch)
+		close(ch)
+
+		// then
+		metrics := toMap(t, ch)
+		assert.Equal(t, float64(1), metrics[`k8s_ces_control_debug_mode_active{}`])
+		assert.Equal(t, float64(0), metrics[`k8s_ces_control_debug_mode_remaining_seconds{}`])
+	})
+}
+
+func collect(t *testing.T, collector prometheus.Collector) map[string]float64 {
+	t.Helper()
+
+	ch := make(chan prometheus.Metric, 100)
+	collector.Collect(ch)
+	close(ch)
+
+	return toMap(t, ch)
+}
+
+// toMap converts the collected gauges into a map from the metric name with labels to the value.
+func toMap(t *testing.T, ch <-chan prometheus.Metric) map[string]float64 {
+	t.Helper()
+
+	names := map[*prometheus.Desc]string{
+		dogusDesc:              "k8s_ces_control_dogus",
+		backupsDesc:            "k8s_ces_control_backups",
+		restoresDesc:           "k8s_ces_control_restores",
+		debugModeActiveDesc:    "k8s_ces_control_debug_mode_active",
+		debugModeRemainingDesc: "k8s_ces_control_debug_mode_remaining_seconds",
+	}
+
+	result := map[string]float64{}
+	for metric := range ch {
+		m := &dto.Metric{}
+		require.NoError(t, metric.Write(m))
+
+		labels := ""
+		for _, label := range m.GetLabel() {
+			labels += label.GetName() + `="` + label.GetValue() + `"`
+		}
+
+		result[names[metric.Desc()]+"{"+labels+"}"] = m.GetGauge().GetValue()
+	}
+
+	return result
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	grpcTypeUnary        = "unary"
+	grpcTypeClientStream = "client_stream"
+	grpcTypeServerStream = "server_stream"
+	grpcTypeBidiStream   = "bidi_stream"
+)
+
+// the grpc metrics use the names of the go-grpc-prometheus middleware so that existing dashboards can be reused
+var (
+	grpcStartedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_started_total",
+		Help: "Total number of RPCs started on the server.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+	grpcHandledCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, regardless of success or failure.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+	grpcHandlingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Histogram of response latency (seconds) of RPCs that had been application-level handled by the server.",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300},
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+)
+
+// UnaryServerInterceptor counts and times every unary call.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		observe := startObservation(grpcTypeUnary, info.FullMethod)
+		resp, err := handler(ctx, req)
+		observe(err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor counts and times every streaming call. The duration of a stream covers the whole transfer.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		observe := startObservation(streamType(info), info.FullMethod)
+		err := handler(srv, ss)
+		observe(err)
+
+		return err
+	}
+}
+
+func startObservation(grpcType string, fullMethod string) func(err error) {
+	service, method := splitMethodName(fullMethod)
+	grpcStartedCounter.WithLabelValues(grpcType, service, method).Inc()
+	started := time.Now()
+
+	return func(err error) {
+		grpcHandledCounter.WithLabelValues(grpcType, service, method, status.Code(err).String()).Inc()
+		grpcHandlingSeconds.WithLabelValues(grpcType, service, method).Observe(time.Since(started).Seconds())
+	}
+}
+
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return grpcTypeBidiStream
+	case info.IsClientStream:
+		return grpcTypeClientStream
+	default:
+		return grpcTypeServerStream
+	}
+}
+
+// splitMethodName splits a fully qualified method like "/logging.DoguLogMessages/GetForDogu" into service and method.
+func splitMethodName(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+
+	return "unknown", fullMethod
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testCtx = context.Background()
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Run("should count successful call", func(t *testing.T) {
+		// given
+		info := &grpc.UnaryServerInfo{FullMethod: "/doguAdministration.DoguAdministration/StartDogu"}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return "response", nil
+		}
+		handledBefore := counterValue(t, grpcHandledCounter.WithLabelValues("unary", "doguAdministration.DoguAdministration", "StartDogu", "OK"))
+
+		// when
+		resp, err := UnaryServerInterceptor()(testCtx, "request", info, handler)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "response", resp)
+		assert.Equal(t, handledBefore+1, counterValue(t, grpcHandledCounter.WithLabelValues("unary", "doguAdministration.DoguAdministration", "StartDogu", "OK")))
+	})
+	t.Run("should count failed call with status code", func(t *testing.T) {
+		// given
+		info := &grpc.UnaryServerInfo{FullMethod: "/doguAdministration.DoguAdministration/StopDogu"}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return nil, status.Error(codes.InvalidArgument, "dogu name is empty")
+		}
+		handledBefore := counterValue(t, grpcHandledCounter.WithLabelValues("unary", "doguAdministration.DoguAdministration", "StopDogu", "InvalidArgument"))
+
+		// when
+		_, err := UnaryServerInterceptor()(testCtx, "request", info, handler)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, handledBefore+1, counterValue(t, grpcHandledCounter.WithLabelValues("unary", "doguAdministration.DoguAdministration", "StopDogu", "InvalidArgument")))
+	})
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	t.Run("should count server stream", func(t *testing.T) {
+		// given
+		info := &grpc.StreamServerInfo{FullMethod: "/logging.DoguLogMessages/GetForDogu", IsServerStream: true}
+		handler := func(srv any, stream grpc.ServerStream) error {
+			return assert.AnError
+		}
+		startedBefore := counterValue(t, grpcStartedCounter.WithLabelValues("server_stream", "logging.DoguLogMessages", "GetForDogu"))
+		handledBefore := counterValue(t, grpcHandledCounter.WithLabelValues("server_stream", "logging.DoguLogMessages", "GetForDogu", "Unknown"))
+
+		// when
+		err := StreamServerInterceptor()(nil, nil, info, handler)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, startedBefore+1, counterValue(t, grpcStartedCounter.WithLabelValues("server_stream", "logging.DoguLogMessages", "GetForDogu")))
+		assert.Equal(t, handledBefore+1, counterValue(t, grpcHandledCounter.WithLabelValues("server_stream", "logging.DoguLogMessages", "GetForDogu", "Unknown")))
+	})
+}
+
+func Test_streamType(t *testing.T) {
+	assert.Equal(t, "bidi_stream", streamType(&grpc.StreamServerInfo{IsClientStream: true, IsServerStream: true}))
+	assert.Equal(t, "client_stream", streamType(&grpc.StreamServerInfo{IsClientStream: true}))
+	assert.Equal(t, "server_stream", streamType(&grpc.StreamServerInfo{IsServerStream: true}))
+}
+
+func Test_splitMethodName(t *testing.T) {
+	tests := []struct {
+		fullMethod    string
+		wantedService string
+		wantedMethod  string
+	}{
+		{"/logging.DoguLogMessages/GetForDogu", "logging.DoguLogMessages", "GetForDogu"},
+		{"/grpc.health.v1.Health/Check", "grpc.health.v1.Health", "Check"},
+		{"invalid", "unknown", "invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fullMethod, func(t *testing.T) {
+			service, method := splitMethodName(tt.fullMethod)
+			assert.Equal(t, tt.wantedService, service)
+			assert.Equal(t, tt.wantedMethod, method)
+		})
+	}
+}
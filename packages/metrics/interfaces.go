@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	backupV1 "github.com/cloudogu/k8s-backup-lib/api/v1"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type doguLister interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*v2.DoguList, error)
+}
+
+type backupLister interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*backupV1.BackupList, error)
+}
+
+type restoreLister interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*backupV1.RestoreList, error)
+}
+
+type debugModeStatus interface {
+	// IsActive returns whether the debug mode is active and when it is disabled automatically.
+	IsActive(ctx context.Context) (bool, time.Time, error)
+}
+
+type nowClock interface {
+	Now() time.Time
+}
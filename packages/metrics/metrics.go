@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "k8s_ces_control"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	lokiQueryDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "loki_query_duration_seconds",
+		Help:      "Duration of queries against the loki gateway.",
+		Buckets:   prometheus.DefBuckets,
+	})
+	lokiQueryErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "loki_query_errors_total",
+		Help:      "Number of failed queries against the loki gateway.",
+	})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		grpcStartedCounter,
+		grpcHandledCounter,
+		grpcHandlingSeconds,
+		lokiQueryDuration,
+		lokiQueryErrors,
+	)
+}
+
+// Register adds the given collector to the registry served by the metrics endpoint.
+func Register(collector prometheus.Collector) error {
+	err := registry.Register(collector)
+	if err != nil {
+		return fmt.Errorf("failed to register metrics collector: %w", err)
+	}
+
+	return nil
+}
+
+// ObserveLokiQuery records the duration of a loki query started at the given time and counts it as error if err
+// is not nil.
+func ObserveLokiQuery(started time.Time, err error) {
+	lokiQueryDuration.Observe(time.Since(started).Seconds())
+	if err != nil {
+		lokiQueryErrors.Inc()
+	}
+}
+
+// NewServer creates a http server which serves the metrics on the path /metrics of the given address.
+func NewServer(address string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	return &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
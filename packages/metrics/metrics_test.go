@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserveLokiQuery(t *testing.T) {
+	t.Run("should count errors", func(t *testing.T) {
+		// given
+		errorsBefore := counterValue(t, lokiQueryErrors)
+
+		// when
+		ObserveLokiQuery(time.Now(), assert.AnError)
+		ObserveLokiQuery(time.Now(), nil)
+
+		// then
+		assert.Equal(t, errorsBefore+1, counterValue(t, lokiQueryErrors))
+	})
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("should fail to register collector twice", func(t *testing.T) {
+		// given
+		counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_register_total", Help: "test"})
+		require.NoError(t, Register(counter))
+		defer registry.Unregister(counter)
+
+		// when
+		err := Register(counter)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to register metrics collector")
+	})
+}
+
+func TestNewServer(t *testing.T) {
+	t.Run("should serve metrics", func(t *testing.T) {
+		// given
+		sut := NewServer(":9090")
+		ObserveLokiQuery(time.Now(), nil)
+		request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.Handler.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, ":9090", sut.Addr)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		body, err := io.ReadAll(recorder.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "k8s_ces_control_loki_query_duration_seconds")
+		assert.Contains(t, string(body), "go_goroutines")
+	})
+}
+
+func counterValue(t *testing.T, counter prometheus.Counter) float64 {
+	t.Helper()
+
+	metric := &dto.Metric{}
+	require.NoError(t, counter.Write(metric))
+
+	return metric.GetCounter().GetValue()
+}
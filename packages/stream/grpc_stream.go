@@ -57,3 +57,57 @@ func WriteReaderToStream(reader io.Reader, server GRPCStreamServer) error {
 	}
 	return nil
 }
+
+// ContentTypeHeader is the header key under which a stream announces the media type of its chunked data, e.g. for the
+// http gateway.
+const ContentTypeHeader = "x-content-type"
+
+// ChunkWriter is an io.WriteCloser which sends the written data to a stream server in chunks. The data is buffered
+// until a chunk is full, so that a chunk is sent for every 64 KiB independent of the size of the single writes.
+type ChunkWriter struct {
+	server GRPCStreamServer
+	buffer []byte
+}
+
+// NewChunkWriter creates a new ChunkWriter sending to the given stream server.
+func NewChunkWriter(server GRPCStreamServer) *ChunkWriter {
+	return &ChunkWriter{
+		server: server,
+		buffer: make([]byte, 0, chunkSize),
+	}
+}
+
+// Write buffers the data and sends every full chunk.
+func (w *ChunkWriter) Write(data []byte) (int, error) {
+	written := 0
+	for len(data) > 0 {
+		n := min(chunkSize-len(w.buffer), len(data))
+		w.buffer = append(w.buffer, data[:n]...)
+		data = data[n:]
+		written += n
+
+		if len(w.buffer) == chunkSize {
+			if err := w.flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+// Close sends the buffered data which does not fill a whole chunk.
+func (w *ChunkWriter) Close() error {
+	return w.flush()
+}
+
+func (w *ChunkWriter) flush() error {
+	if len(w.buffer) == 0 {
+		return nil
+	}
+
+	// the buffer can be reused because the message is serialized when it is sent
+	err := w.server.Send(&pbTypes.ChunkedDataResponse{Data: w.buffer})
+	w.buffer = w.buffer[:0]
+	return err
+}
@@ -201,6 +201,63 @@ func TestWriteReaderToStream(t *testing.T) {
 	})
 }
 
+func TestChunkWriter(t *testing.T) {
+	t.Run("should send full chunks and the rest on close", func(t *testing.T) {
+		mockServer := NewMockGRPCStreamServer(t)
+		var capturedChunks [][]byte
+		mockServer.EXPECT().Send(mock.AnythingOfType("*types.ChunkedDataResponse")).
+			Run(func(resp *pbTypes.ChunkedDataResponse) {
+				capturedChunks = append(capturedChunks, append([]byte(nil), resp.Data...))
+			}).
+			Return(nil).Times(3)
+
+		data := make([]byte, chunkSize*2+10)
+		for i := range data {
+			data[i] = byte(i % 256)
+		}
+		writer := NewChunkWriter(mockServer)
+
+		n, err := writer.Write(data[:100])
+		require.NoError(t, err)
+		assert.Equal(t, 100, n)
+		n, err = writer.Write(data[100:])
+		require.NoError(t, err)
+		assert.Equal(t, len(data)-100, n)
+		assert.Len(t, capturedChunks, 2)
+
+		err = writer.Close()
+
+		require.NoError(t, err)
+		require.Len(t, capturedChunks, 3)
+		assert.Len(t, capturedChunks[0], chunkSize)
+		assert.Len(t, capturedChunks[1], chunkSize)
+		assert.Len(t, capturedChunks[2], 10)
+		assert.Equal(t, data, append(append(capturedChunks[0], capturedChunks[1]...), capturedChunks[2]...))
+	})
+
+	t.Run("should send nothing without data", func(t *testing.T) {
+		mockServer := NewMockGRPCStreamServer(t)
+
+		err := NewChunkWriter(mockServer).Close()
+
+		require.NoError(t, err)
+		mockServer.AssertNotCalled(t, "Send")
+	})
+
+	t.Run("should return error when send fails", func(t *testing.T) {
+		mockServer := NewMockGRPCStreamServer(t)
+		mockServer.EXPECT().Send(mock.AnythingOfType("*types.ChunkedDataResponse")).Return(assert.AnError).Once()
+
+		writer := NewChunkWriter(mockServer)
+		_, err := writer.Write([]byte("test data"))
+		require.NoError(t, err)
+
+		err = writer.Close()
+
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
 // errorReader is a reader that always returns an error
 type errorReader struct {
 	err error
@@ -1,9 +1,11 @@
 package supportArchive
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/cloudogu/ces-control-api/generated/maintenance"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
 	"github.com/cloudogu/k8s-support-archive-lib/client/v1"
 )
 
@@ -20,3 +22,8 @@ type supportArchiveDownloadServer interface {
 type httpClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
+
+type auditLogger interface {
+	// Record writes the given entry to the audit log.
+	Record(ctx context.Context, entry audit.Entry)
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	pbMaintenance "github.com/cloudogu/ces-control-api/generated/maintenance"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
 	"github.com/cloudogu/k8s-ces-control/packages/stream"
 	v1 "github.com/cloudogu/k8s-support-archive-lib/api/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -19,13 +21,15 @@ type supportArchiveService struct {
 	supportArchiveClient supportArchiveClient
 	httpClient           httpClient
 	writeToStream        stream.Writer
+	auditLogger          auditLogger
 }
 
-func NewSupportArchiveService(client supportArchiveClient, http httpClient) *supportArchiveService {
+func NewSupportArchiveService(client supportArchiveClient, http httpClient, auditLogger auditLogger) *supportArchiveService {
 	return &supportArchiveService{
 		supportArchiveClient: client,
 		httpClient:           http,
 		writeToStream:        stream.WriteToStream,
+		auditLogger:          auditLogger,
 	}
 }
 
@@ -35,7 +39,9 @@ func (d *supportArchiveService) Create(ctx context.Context, req *pbMaintenance.C
 		return nil, fmt.Errorf("failed to map support archive settings: %q", err)
 	}
 
+	started := time.Now()
 	_, err = d.supportArchiveClient.Create(ctx, supportArchive, metav1.CreateOptions{})
+	d.auditLogger.Record(ctx, audit.Entry{Action: "CreateSupportArchive", Resource: supportArchiveResource(supportArchive.Name), Started: started, Err: err})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create support archive: %q", err)
 	}
@@ -62,7 +68,9 @@ func (d *supportArchiveService) AllSupportArchives(ctx context.Context, _ *pbMai
 }
 
 func (d *supportArchiveService) DeleteSupportArchive(ctx context.Context, req *pbMaintenance.DeleteSupportArchiveRequest) (*pbMaintenance.DeleteSupportArchiveResponse, error) {
+	started := time.Now()
 	err := d.supportArchiveClient.Delete(ctx, req.Name, metav1.DeleteOptions{})
+	d.auditLogger.Record(ctx, audit.Entry{Action: "DeleteSupportArchive", Resource: supportArchiveResource(req.Name), Started: started, Err: err})
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete support archive: %w", err)
 	}
@@ -102,6 +110,10 @@ func (d *supportArchiveService) DownloadSupportArchive(req *pbMaintenance.Downlo
 	return nil
 }
 
+func supportArchiveResource(name string) audit.Resource {
+	return audit.Resource{Kind: audit.KindSupportArchive, Name: name}
+}
+
 func getStatus(archive v1.SupportArchive) pbMaintenance.SupportArchiveStatus {
 	archiveStatus := archive.Status
 
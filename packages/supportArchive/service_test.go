@@ -2,6 +2,7 @@ package supportArchive
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	pbMaintenance "github.com/cloudogu/ces-control-api/generated/maintenance"
+	"github.com/cloudogu/k8s-ces-control/packages/audit"
 	v1 "github.com/cloudogu/k8s-support-archive-lib/api/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,7 +26,7 @@ func TestNewSupportArchiveService(t *testing.T) {
 		supportArchiveClientMock := newMockSupportArchiveClient(t)
 
 		//when
-		service := NewSupportArchiveService(supportArchiveClientMock, &http.Client{})
+		service := NewSupportArchiveService(supportArchiveClientMock, &http.Client{}, newMockAuditLogger(t))
 
 		require.NotNil(t, service)
 	})
@@ -108,7 +110,7 @@ func Test_defaultSupportArchive_mapRequestSettingsToSupportArchive(t *testing.T)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			supportArchiveClientMock := newMockSupportArchiveClient(t)
-			d := NewSupportArchiveService(supportArchiveClientMock, &http.Client{})
+			d := NewSupportArchiveService(supportArchiveClientMock, &http.Client{}, newMockAuditLogger(t))
 
 			beforeTime := metav1.Now()
 			got, err := d.mapRequestSettingsToSupportArchive(tt.reqFn(t))
@@ -133,7 +135,7 @@ func Test_defaultSupportArchive_mapRequestSettingsToSupportArchive(t *testing.T)
 		}
 
 		supportArchiveClientMock := newMockSupportArchiveClient(t)
-		d := NewSupportArchiveService(supportArchiveClientMock, &http.Client{})
+		d := NewSupportArchiveService(supportArchiveClientMock, &http.Client{}, newMockAuditLogger(t))
 
 		beforeTime := metav1.Now()
 		got, err := d.mapRequestSettingsToSupportArchive(request)
@@ -164,6 +166,7 @@ func Test_defaultSupportArchive_Create(t *testing.T) {
 		supportArchiveClientFn func(t *testing.T) supportArchiveClient
 		req                    *pbMaintenance.CreateSupportArchiveRequest
 		wantErrMessage         string
+		wantAuditRecord        bool
 	}{
 		{
 			name: "should fail to map request settings",
@@ -195,7 +198,8 @@ func Test_defaultSupportArchive_Create(t *testing.T) {
 					Return(nil, assert.AnError)
 				return clientMock
 			},
-			wantErrMessage: "failed to create support archive: ",
+			wantErrMessage:  "failed to create support archive: ",
+			wantAuditRecord: true,
 		},
 		{
 			name: "should succeed",
@@ -220,14 +224,22 @@ func Test_defaultSupportArchive_Create(t *testing.T) {
 
 				return clientMock
 			},
+			wantAuditRecord: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// given
 			clientmock := tt.supportArchiveClientFn(t)
+			auditLoggerMock := newMockAuditLogger(t)
+			if tt.wantAuditRecord {
+				auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+					return entry.Action == "CreateSupportArchive" && entry.Resource.Kind == audit.KindSupportArchive
+				})).Return()
+			}
 			service := &supportArchiveService{
 				supportArchiveClient: clientmock,
+				auditLogger:          auditLoggerMock,
 			}
 
 			// when
@@ -315,9 +327,14 @@ func Test_supportArchiveService_DeleteSupportArchive(t *testing.T) {
 	t.Run("should delete archive", func(t *testing.T) {
 		mSupportArchiveClient := newMockSupportArchiveClient(t)
 		mSupportArchiveClient.EXPECT().Delete(testCtx, "archive-1", metav1.DeleteOptions{}).Return(nil)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "DeleteSupportArchive" && entry.Resource == supportArchiveResource("archive-1") && entry.Err == nil
+		})).Return()
 
 		sut := &supportArchiveService{
 			supportArchiveClient: mSupportArchiveClient,
+			auditLogger:          auditLoggerMock,
 		}
 
 		resp, err := sut.DeleteSupportArchive(testCtx, &pbMaintenance.DeleteSupportArchiveRequest{Name: "archive-1"})
@@ -329,9 +346,14 @@ func Test_supportArchiveService_DeleteSupportArchive(t *testing.T) {
 	t.Run("should fail delete archive for error deleting", func(t *testing.T) {
 		mSupportArchiveClient := newMockSupportArchiveClient(t)
 		mSupportArchiveClient.EXPECT().Delete(testCtx, "archive-1", metav1.DeleteOptions{}).Return(assert.AnError)
+		auditLoggerMock := newMockAuditLogger(t)
+		auditLoggerMock.EXPECT().Record(testCtx, mock.MatchedBy(func(entry audit.Entry) bool {
+			return entry.Action == "DeleteSupportArchive" && entry.Resource == supportArchiveResource("archive-1") && errors.Is(entry.Err, assert.AnError)
+		})).Return()
 
 		sut := &supportArchiveService{
 			supportArchiveClient: mSupportArchiveClient,
+			auditLogger:          auditLoggerMock,
 		}
 
 		_, err := sut.DeleteSupportArchive(testCtx, &pbMaintenance.DeleteSupportArchiveRequest{Name: "archive-1"})